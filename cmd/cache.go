@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SAP/cloud-mta-build-tool/internal/artifacts"
+)
+
+var cacheListCmdSrc string
+var cacheListCmdMtaYamlFilename string
+var cacheListCmdTrg string
+
+var cacheCleanCmdSrc string
+var cacheCleanCmdMtaYamlFilename string
+var cacheCleanCmdTrg string
+var cacheCleanCmdModules []string
+
+func init() {
+	// set flags of cache list command
+	cacheListCmd.Flags().StringVarP(&cacheListCmdSrc, "source", "s", "",
+		"The path to the MTA project; the current path is set as default")
+	cacheListCmd.Flags().StringVarP(&cacheListCmdMtaYamlFilename, "filename", "f", "",
+		"The mta yaml filename of the MTA project; the mta.yaml is set as default")
+	cacheListCmd.Flags().StringVarP(&cacheListCmdTrg, "target", "t", "",
+		"The path to the folder in which the MTA project was built; the current path is set as default")
+	cacheListCmd.Flags().BoolP("help", "h", false, `Displays detailed information about the "cache ls" command`)
+
+	// set flags of cache clean command
+	cacheCleanCmd.Flags().StringVarP(&cacheCleanCmdSrc, "source", "s", "",
+		"The path to the MTA project; the current path is set as default")
+	cacheCleanCmd.Flags().StringVarP(&cacheCleanCmdMtaYamlFilename, "filename", "f", "",
+		"The mta yaml filename of the MTA project; the mta.yaml is set as default")
+	cacheCleanCmd.Flags().StringVarP(&cacheCleanCmdTrg, "target", "t", "",
+		"The path to the folder in which the MTA project was built; the current path is set as default")
+	cacheCleanCmd.Flags().StringSliceVarP(&cacheCleanCmdModules, "modules", "m", nil,
+		"The names of the modules whose cached build results are removed; all the cached build results are removed by default")
+	cacheCleanCmd.Flags().BoolP("help", "h", false, `Displays detailed information about the "cache clean" command`)
+}
+
+// cacheCmd - the parent command of the build cache commands
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manages the build cache",
+	Long:  "Manages the build cache, in which the packaged build results of the MTA modules are kept between builds",
+	Run:   nil,
+}
+
+// cacheListCmd - lists the modules build results stored in the build cache
+var cacheListCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "Lists the cached build results",
+	Long:  "Lists the modules build results stored in the build cache",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := artifacts.ExecuteCacheList(cacheListCmdSrc, cacheListCmdMtaYamlFilename, cacheListCmdTrg, os.Getwd)
+		logError(err)
+		return err
+	},
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+// cacheCleanCmd - removes the modules build results from the build cache
+var cacheCleanCmd = &cobra.Command{
+	Use:   "clean",
+	Short: "Removes the cached build results",
+	Long:  "Removes the build results of the specified modules, or of all the modules, from the build cache",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := artifacts.ExecuteCacheClean(cacheCleanCmdSrc, cacheCleanCmdMtaYamlFilename, cacheCleanCmdTrg, cacheCleanCmdModules, os.Getwd)
+		logError(err)
+		return err
+	},
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Cache commands", func() {
+	createCacheEntry := func(module string) {
+		Ω(os.MkdirAll(getTestPath("result", ".mta_mta_build_cache", module), os.ModePerm)).Should(Succeed())
+		Ω(ioutil.WriteFile(getTestPath("result", ".mta_mta_build_cache", module, "cache.json"),
+			[]byte(`{"module": "`+module+`"}`), 0644)).Should(Succeed())
+	}
+
+	BeforeEach(func() {
+		cacheListCmdSrc = getTestPath("mta")
+		cacheListCmdTrg = getTestPath("result")
+		cacheCleanCmdSrc = getTestPath("mta")
+		cacheCleanCmdTrg = getTestPath("result")
+		createCacheEntry("node-js")
+	})
+
+	AfterEach(func() {
+		cacheCleanCmdModules = nil
+		Ω(os.RemoveAll(getTestPath("result"))).Should(Succeed())
+	})
+
+	It("cache ls - Sanity", func() {
+		Ω(cacheListCmd.RunE(nil, []string{})).Should(Succeed())
+	})
+
+	It("cache clean - removes the specified modules", func() {
+		createCacheEntry("ui5app")
+		cacheCleanCmdModules = []string{"node-js"}
+		Ω(cacheCleanCmd.RunE(nil, []string{})).Should(Succeed())
+		Ω(getTestPath("result", ".mta_mta_build_cache", "node-js")).ShouldNot(BeADirectory())
+		Ω(getTestPath("result", ".mta_mta_build_cache", "ui5app")).Should(BeADirectory())
+	})
+
+	It("cache clean - fails when the module is not in the cache", func() {
+		cacheCleanCmdModules = []string{".."}
+		Ω(cacheCleanCmd.RunE(nil, []string{})).Should(HaveOccurred())
+		Ω(getTestPath("result", ".mta_mta_build_cache", "node-js")).Should(BeADirectory())
+	})
+
+	It("cache clean - removes the whole cache", func() {
+		Ω(cacheCleanCmd.RunE(nil, []string{})).Should(Succeed())
+		Ω(getTestPath("result", ".mta_mta_build_cache")).ShouldNot(BeADirectory())
+	})
+})
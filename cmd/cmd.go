@@ -29,15 +29,17 @@ func init() {
 
 	// Add command to the root
 	rootCmd.AddCommand(initCmd, buildCmd, validateCmd, cleanupCmd, provideCmd, generateCmd, moduleCmd, assembleCommand,
-		projectCmd, mergeCmd, executeCommand, copyCmd, mtadGenCmd, soloBuildModuleCmd, projectSBomGenCommand)
+		projectCmd, mergeCmd, executeCommand, copyCmd, mtadGenCmd, soloBuildModuleCmd, projectSBomGenCommand, cacheCmd, inspectCmd, verifyCmd, diffCmd, unpackCmd, repackCmd, graphCmd, watchCmd, resolveCmd)
 	// Build module
 	provideCmd.AddCommand(provideModuleCmd)
 	// generate immutable commands
 	generateCmd.AddCommand(metaCmd, mtarCmd)
 	// module commands
-	moduleCmd.AddCommand(buildModuleCmd, packModuleCmd)
+	moduleCmd.AddCommand(buildModuleCmd, packModuleCmd, restoreModuleCmd)
 	// project commands
 	projectCmd.AddCommand(projectBuildCmd)
+	// build cache commands
+	cacheCmd.AddCommand(cacheListCmd, cacheCleanCmd)
 
 	// set flags of cleanup command
 	rootCmd.Flags().BoolP("version", "v", false, "Displays the Cloud MTA Build Tool version")
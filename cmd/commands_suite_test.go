@@ -11,6 +11,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildconfig"
 	"github.com/SAP/cloud-mta-build-tool/internal/logs"
 )
 
@@ -23,6 +24,11 @@ var _ = BeforeSuite(func() {
 	logs.Logger = logs.NewLogger()
 })
 
+// the build configuration set by the specs is not kept for the next specs
+var _ = AfterEach(func() {
+	buildconfig.Reset()
+})
+
 func executeAndProvideOutput(execute func() error) (string, error) {
 	old := os.Stdout // keep backup of the real stdout
 	r, w, err := os.Pipe()
@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SAP/cloud-mta-build-tool/internal/artifacts"
+)
+
+var diffCmdOutput string
+
+// Compare two MTA archives
+var diffCmd = &cobra.Command{
+	Use:   "diff <old mtar> <new mtar>",
+	Short: "Displays the differences between two MTA archives",
+	Long:  "Displays the added, removed and changed modules and resources of two MTA archives, the changed deployment descriptor parameters and properties, the changed manifest content types, and the added, removed and changed files of the modules",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := artifacts.ExecuteDiff(args[0], args[1], diffCmdOutput, os.Stdout)
+		logError(err)
+		return err
+	},
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+func init() {
+	diffCmd.Flags().StringVarP(&diffCmdOutput, "output", "o", artifacts.OutputTable,
+		`The output format; supported formats: "table" (default), "json", "yaml"`)
+	diffCmd.Flags().BoolP("help", "h", false, `Displays detailed information about the "diff" command`)
+}
@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Diff", func() {
+
+	mtarPath := getTestPath("result", "com.sap.xs2.samples.javahelloworld_0.1.0.mtar")
+
+	BeforeEach(func() {
+		assembleCmdSrc = getTestPath("assembly-sample")
+		assembleCmdTrg = getTestPath("result")
+		Ω(assembleCommand.RunE(nil, []string{})).Should(Succeed())
+	})
+
+	AfterEach(func() {
+		assembleCmdSrc = ""
+		assembleCmdTrg = ""
+		diffCmdOutput = "table"
+		Ω(os.RemoveAll(getTestPath("result"))).Should(Succeed())
+	})
+
+	It("prints no differences for the same MTA archive", func() {
+		out, err := executeAndProvideOutput(func() error {
+			return diffCmd.RunE(nil, []string{mtarPath, mtarPath})
+		})
+		Ω(err).Should(Succeed())
+		Ω(out).Should(ContainSubstring("no differences found"))
+	})
+
+	It("prints the differences in the JSON format", func() {
+		diffCmdOutput = "json"
+		out, err := executeAndProvideOutput(func() error {
+			return diffCmd.RunE(nil, []string{mtarPath, mtarPath})
+		})
+		Ω(err).Should(Succeed())
+		Ω(out).Should(ContainSubstring(`"old": `))
+	})
+
+	It("fails when the MTA archive does not exist", func() {
+		Ω(diffCmd.RunE(nil, []string{mtarPath, getTestPath("result", "unknown.mtar")})).Should(HaveOccurred())
+	})
+})
@@ -4,15 +4,25 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/SAP/cloud-mta-build-tool/internal/archive"
-	"github.com/SAP/cloud-mta-build-tool/internal/exec"
+	"github.com/SAP/cloud-mta-build-tool/internal/artifacts"
+	"github.com/SAP/cloud-mta-build-tool/internal/commands"
 )
 
 var executeCmdCommands []string
 var executeCmdTimeout string
 var executeCmdDir string
+var executeCmdRetries []int
+var executeCmdRetryDelay string
+var executeCmdRetryOnExitCodes []int
+var executeCmdContinueOnError []bool
+var executeCmdModule string
+var executeCmdReportDir string
 var copyCmdSrc string
 var copyCmdTrg string
 var copyCmdPatterns []string
+var copyCmdExclude []string
+var copyCmdRename []string
+var copyCmdExtract bool
 
 // Execute commands in the current working directory with a timeout.
 // This is used in verbose make files for implementing a timeout on module builds.
@@ -22,7 +32,12 @@ var executeCommand = &cobra.Command{
 	Long:  "Execute commands with timeout",
 	Args:  cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		err := exec.ExecuteCommandsWithTimeout(executeCmdCommands, executeCmdTimeout, executeCmdDir, true)
+		policies, err := commands.NewCommandPolicies(len(executeCmdCommands), executeCmdRetries, executeCmdRetryDelay,
+			executeCmdRetryOnExitCodes, executeCmdContinueOnError)
+		if err == nil {
+			err = artifacts.ExecuteModuleCommands(executeCmdCommands, policies, executeCmdTimeout, executeCmdDir,
+				executeCmdModule, executeCmdReportDir)
+		}
 		logError(err)
 		return err
 	},
@@ -39,7 +54,10 @@ var copyCmd = &cobra.Command{
 	Long:  "Copy files by patterns",
 	Args:  cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		err := dir.CopyByPatterns(copyCmdSrc, copyCmdTrg, copyCmdPatterns)
+		rename, err := dir.ParseRenameEntries(copyCmdRename)
+		if err == nil {
+			err = dir.CopyByPatternsWithOptions(copyCmdSrc, copyCmdTrg, copyCmdPatterns, copyCmdExclude, rename, copyCmdExtract)
+		}
 		logError(err)
 		return err
 	},
@@ -56,6 +74,18 @@ func init() {
 		"timeout", "t", "", "The timeout after which the run stops, in the format [123h][123m][123s]; 10m is set as the default")
 	executeCommand.Flags().StringVarP(&executeCmdDir,
 		"dir", "d", "", "The path to the folder in which to execute the commands; the current path is set as the default")
+	executeCommand.Flags().IntSliceVar(&executeCmdRetries,
+		"retries", nil, "The number of retries of the failed commands, as a single value for all the commands or as a value per command")
+	executeCommand.Flags().StringVar(&executeCmdRetryDelay,
+		"retry-delay", "", "The delay before retrying a failed command, in the format [123h][123m][123s]")
+	executeCommand.Flags().IntSliceVar(&executeCmdRetryOnExitCodes,
+		"retry-on-exit-codes", nil, "The exit codes on which the failed commands are retried; the commands are retried on any failure by default")
+	executeCommand.Flags().BoolSliceVar(&executeCmdContinueOnError,
+		"continue-on-error", nil, "Continue with the next command when a command fails, as a single value for all the commands or as a value per command")
+	executeCommand.Flags().StringVarP(&executeCmdModule,
+		"module", "m", "", "The name of the module whose build commands are executed; used with the report folder")
+	executeCommand.Flags().StringVar(&executeCmdReportDir,
+		"report-dir", "", "The path to the folder in which the module report with the commands execution result is created")
 
 	// set flags of copy command
 	copyCmd.Flags().StringVarP(&copyCmdSrc, "source", "s", "",
@@ -64,4 +94,10 @@ func init() {
 		"The path to the target folder")
 	copyCmd.Flags().StringArrayVarP(&copyCmdPatterns,
 		"patterns", "p", nil, "Patterns for matching the files and folders to copy")
+	copyCmd.Flags().StringArrayVarP(&copyCmdExclude,
+		"exclude", "x", nil, "Patterns for matching the files and folders that are not copied")
+	copyCmd.Flags().StringArrayVar(&copyCmdRename,
+		"rename", nil, `The new path of a copied file or folder, in the format "<path>=<new path>" relative to the target folder`)
+	copyCmd.Flags().BoolVar(&copyCmdExtract,
+		"extract", false, "Extract the files and folders matching the patterns from the source archive")
 }
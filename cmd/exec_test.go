@@ -44,12 +44,60 @@ var _ = Describe("cp command", func() {
 		copyCmdPatterns = []string{"*"}
 		Ω(copyCmd.RunE(nil, []string{})).Should(HaveOccurred())
 	})
+	It("copy with excluded and renamed entries", func() {
+		copyCmdPatterns = []string{"mta.*", "ui5app2"}
+		copyCmdExclude = []string{"mta.sh"}
+		copyCmdRename = []string{"ui5app2/test.txt=test.txt"}
+		defer func() {
+			copyCmdExclude = nil
+			copyCmdRename = nil
+		}()
+		Ω(copyCmd.RunE(nil, []string{})).Should(Succeed())
+		validateFilesInDir(getTestPath("result"), []string{"mta.yaml", "test.txt", "ui5app2/"})
+	})
+	It("copy should return error when a rename entry is invalid", func() {
+		copyCmdPatterns = []string{"*"}
+		copyCmdRename = []string{"test.txt"}
+		defer func() {
+			copyCmdRename = nil
+		}()
+		Ω(copyCmd.RunE(nil, []string{})).Should(HaveOccurred())
+	})
 	It("copy should return error when a pattern is invalid", func() {
 		copyCmdPatterns = []string{"["}
 		Ω(copyCmd.RunE(nil, []string{})).Should(HaveOccurred())
 	})
 })
 
+var _ = Describe("execute command", func() {
+	AfterEach(func() {
+		executeCmdCommands = nil
+		executeCmdRetries = nil
+		executeCmdContinueOnError = nil
+		executeCmdModule = ""
+		executeCmdReportDir = ""
+	})
+
+	It("continues with the next command on error", func() {
+		executeCmdCommands = []string{"sh -c 'exit 1'", "sh -c 'exit 0'"}
+		executeCmdContinueOnError = []bool{true, false}
+		Ω(executeCommand.RunE(nil, []string{})).Should(Succeed())
+	})
+	It("fails when the number of the policy values does not match the number of the commands", func() {
+		executeCmdCommands = []string{"sh -c 'exit 0'"}
+		executeCmdRetries = []int{1, 2}
+		Ω(executeCommand.RunE(nil, []string{})).Should(HaveOccurred())
+	})
+	It("writes the module report of the failed commands", func() {
+		executeCmdCommands = []string{"sh -c 'exit 1'"}
+		executeCmdModule = "m1"
+		executeCmdReportDir = getTestPath("result")
+		defer os.RemoveAll(getTestPath("result"))
+		Ω(executeCommand.RunE(nil, []string{})).Should(HaveOccurred())
+		Ω(getTestPath("result", "modules", "m1.json")).Should(BeAnExistingFile())
+	})
+})
+
 // Check the folder exists and includes exactly the expected files
 func validateFilesInDir(src string, expectedFilesInDir []string) {
 	// List all files in the folder recursively
@@ -24,6 +24,7 @@ var mtarCmdDesc string
 var mtarCmdTrgProvided string
 var mtarCmdExtensions []string
 var mtarCmdMtarName string
+var mtarCmdReportDir string
 
 // init - inits flags of init command
 func init() {
@@ -40,7 +41,7 @@ func init() {
 	metaCmd.Flags().StringSliceVarP(&metaCmdExtensions, "extensions", "e", nil,
 		"The MTA extension descriptors")
 	metaCmd.Flags().StringVarP(&metaCmdPlatform, "platform", "p", "cf",
-		`The deployment platform; supported platforms: "cf", "xsa", "neo"`)
+		`The deployment platform; supported platforms: "cf", "xsa", "neo" and the platforms of the platforms configuration files`)
 	metaCmd.Flags().BoolP("help", "h", false, `Displays detailed information about the "meta" command`)
 
 	// set flags of mtar command
@@ -59,6 +60,9 @@ func init() {
 	mtarCmd.Flags().StringVarP(&mtarCmdTrgProvided, "target_provided", "", "",
 		"The MTA target provided indicator; supported values: true, false")
 	_ = mtarCmd.Flags().MarkHidden("target_provided")
+	mtarCmd.Flags().StringVarP(&mtarCmdReportDir, "report-dir", "", "",
+		"The path to the folder in which the MTA archive report is created")
+	_ = mtarCmd.Flags().MarkHidden("report-dir")
 	mtarCmd.Flags().BoolP("help", "h", false, `Displays detailed information about the "mtar" command`)
 
 }
@@ -86,7 +90,7 @@ var mtarCmd = &cobra.Command{
 	Long:  "Generates MTA archive from the folder with all artifacts",
 	Args:  cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		err := artifacts.ExecuteGenMtar(mtarCmdSrc, mtarCmdMtaYamlFilename, mtarCmdTrg, mtarCmdTrgProvided, mtarCmdDesc, mtarCmdExtensions, mtarCmdMtarName, os.Getwd)
+		err := artifacts.ExecuteGenMtar(mtarCmdSrc, mtarCmdMtaYamlFilename, mtarCmdTrg, mtarCmdTrgProvided, mtarCmdDesc, mtarCmdExtensions, mtarCmdMtarName, mtarCmdReportDir, os.Getwd)
 		logError(err)
 		return err
 	},
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SAP/cloud-mta-build-tool/internal/buildops"
+)
+
+var graphCmdSrc string
+var graphCmdMtaYamlFilename string
+var graphCmdExtensions []string
+var graphCmdFormat string
+
+// Print the build dependency graph of the modules
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Displays the build dependency graph of the modules",
+	Long:  "Displays the build dependency graph of the MTA project modules with the builders, paths and supported platforms of the modules and the artifacts and target paths of the dependencies; the circular dependencies are highlighted",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := buildops.ExecuteGraph(graphCmdSrc, graphCmdMtaYamlFilename, graphCmdExtensions, graphCmdFormat, os.Stdout, os.Getwd)
+		logError(err)
+		return err
+	},
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+func init() {
+	graphCmd.Flags().StringVarP(&graphCmdSrc, "source", "s", "",
+		"The path to the MTA project; the current path is set as the default")
+	graphCmd.Flags().StringVarP(&graphCmdMtaYamlFilename, "filename", "f", "",
+		"The mta yaml filename of the MTA project; the mta.yaml is set as default")
+	graphCmd.Flags().StringSliceVarP(&graphCmdExtensions, "extensions", "e", nil,
+		"The MTA extension descriptors")
+	graphCmd.Flags().StringVarP(&graphCmdFormat, "output", "o", buildops.GraphDOT,
+		`The output format of the graph; supported formats: "dot" (default), "mermaid", "json"`)
+	graphCmd.Flags().BoolP("help", "h", false, `Displays detailed information about the "graph" command`)
+}
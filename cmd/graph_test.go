@@ -0,0 +1,39 @@
+package commands
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Graph", func() {
+
+	AfterEach(func() {
+		graphCmdSrc = ""
+		graphCmdFormat = "dot"
+	})
+
+	It("prints the build dependency graph of the modules", func() {
+		graphCmdSrc = getTestPath("mtahtml5")
+		out, err := executeAndProvideOutput(func() error {
+			return graphCmd.RunE(nil, []string{})
+		})
+		Ω(err).Should(Succeed())
+		Ω(out).Should(ContainSubstring("digraph modules {"))
+		Ω(out).Should(ContainSubstring(`"ui5app2"`))
+	})
+
+	It("prints the graph in the Mermaid format", func() {
+		graphCmdSrc = getTestPath("mtahtml5")
+		graphCmdFormat = "mermaid"
+		out, err := executeAndProvideOutput(func() error {
+			return graphCmd.RunE(nil, []string{})
+		})
+		Ω(err).Should(Succeed())
+		Ω(out).Should(ContainSubstring("flowchart TD"))
+	})
+
+	It("fails when the MTA project does not exist", func() {
+		graphCmdSrc = getTestPath("unknown")
+		Ω(graphCmd.RunE(nil, []string{})).Should(HaveOccurred())
+	})
+})
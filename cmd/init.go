@@ -37,6 +37,9 @@ var buildCmdJobs int
 var buildCmdOutputSync bool
 var buildCmdKeepMakefile bool
 var buildCmdSBomFilePath string
+var buildCmdEngine string
+var buildCmdReport string
+var buildCmdDryRun bool
 
 func init() {
 	// set flags for init command
@@ -54,13 +57,16 @@ func init() {
 	buildCmd.Flags().StringVarP(&buildCmdTrg, "target", "t", "", `The path to the folder in which the MTAR file is created; the path to the "mta_archives" subfolder of the current folder is set as default`)
 	buildCmd.Flags().StringSliceVarP(&buildCmdExtensions, "extensions", "e", nil, "The MTA extension descriptors")
 	buildCmd.Flags().StringVarP(&buildCmdMtar, "mtar", "", "", "The file name of the generated archive file")
-	buildCmd.Flags().StringVarP(&buildCmdPlatform, "platform", "p", "cf", `The deployment platform; supported platforms: "cf", "xsa", "neo"`)
+	buildCmd.Flags().StringVarP(&buildCmdPlatform, "platform", "p", "cf", `The deployment platform; supported platforms: "cf", "xsa", "neo" and the platforms of the platforms configuration files`)
 	buildCmd.Flags().BoolVarP(&buildCmdStrict, "strict", "", true, `If set to true, duplicated fields and fields not defined in the "mta.yaml" schema are reported as errors; if set to false, they are reported as warnings`)
 	buildCmd.Flags().StringVarP(&buildCmdMode, "mode", "m", "", `(beta) If set to "verbose", Make can run build jobs simultaneously.`)
 	buildCmd.Flags().IntVarP(&buildCmdJobs, "jobs", "j", 0, fmt.Sprintf(`(beta) The number of Make jobs to be executed simultaneously. The default value is the number of available CPUs (maximum %d). Used only in "verbose" mode.`, artifacts.MaxMakeParallel))
 	buildCmd.Flags().BoolVarP(&buildCmdOutputSync, "output-sync", "o", false, `(beta) Groups the output of each Make job and prints it when the job is complete. Used only in "verbose" mode.`)
 	buildCmd.Flags().BoolVarP(&buildCmdKeepMakefile, "keep-makefile", "k", false, `Don't remove the generated Makefile after the build ends.`)
 	buildCmd.Flags().StringVarP(&buildCmdSBomFilePath, "sbom-file-path", "b", "", `(beta) The path of SBOM file, relative or absoluted; if relative path, it is relative to MTA project root; if value is empty, SBOM file will not be generated.`)
+	buildCmd.Flags().StringVarP(&buildCmdEngine, "engine", "", artifacts.MakeEngine, `(beta) The build engine; supported values: "make" (generates a Makefile and runs GNU Make, default value) and "native" (runs the build steps without GNU Make)`)
+	buildCmd.Flags().StringVarP(&buildCmdReport, "report", "", "", `The path to the JSON build report file, relative or absolute; if relative path, it is relative to MTA project root; if value is empty, the report is not generated.`)
+	buildCmd.Flags().BoolVarP(&buildCmdDryRun, "dry-run", "", false, `Prints the resolved build plan without executing it: the modules build order, their working directories, commands, timeouts, build results, artifacts and ignored files, the artifacts copied from the required modules, and the MTA archive path`)
 	_ = buildCmd.Flags().MarkHidden("keep-makefile")
 	// _ = buildCmd.Flags().MarkHidden("sbom-file-path")
 	buildCmd.Flags().BoolP("help", "h", false, `Displays detailed information about the "build" command`)
@@ -85,6 +91,11 @@ var buildCmd = &cobra.Command{
 	Long:  "Builds the project modules and generates an MTA archive according to the MTA development descriptor (mta.yaml)",
 	Args:  cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if buildCmdDryRun {
+			err := artifacts.ExecuteBuildDryRun(buildCmdSrc, buildCmdMtaYamlFilename, buildCmdTrg, buildCmdExtensions, buildCmdMtar, buildCmdPlatform, os.Stdout, os.Getwd)
+			logError(err)
+			return err
+		}
 		// Generate temp Makefile with unique id
 		makefileTmp := "Makefile_" + time.Now().Format("20060102150405") + ".mta"
 		// Generate build script
@@ -93,7 +104,7 @@ var buildCmd = &cobra.Command{
 		// However, in some environments we might want to always use the default mbt from the path. This can be set by using environment variable MBT_USE_DEFAULT.
 		useDefaultMbt := os.Getenv("MBT_USE_DEFAULT") == "true"
 		// Note: we can only use the non-default mbt (i.e. the current executable name) from inside the command itself because if this function runs from other places like tests it won't point to the MBT
-		err := artifacts.ExecBuild(makefileTmp, buildCmdSrc, buildCmdMtaYamlFilename, buildCmdTrg, buildCmdExtensions, buildCmdMode, buildCmdMtar, buildCmdPlatform, buildCmdStrict, buildCmdJobs, buildCmdOutputSync, os.Getwd, exec.Execute, useDefaultMbt, buildCmdKeepMakefile, buildCmdSBomFilePath)
+		err := artifacts.ExecBuild(makefileTmp, buildCmdSrc, buildCmdMtaYamlFilename, buildCmdTrg, buildCmdExtensions, buildCmdMode, buildCmdMtar, buildCmdPlatform, buildCmdStrict, buildCmdJobs, buildCmdOutputSync, os.Getwd, exec.Execute, useDefaultMbt, buildCmdKeepMakefile, buildCmdSBomFilePath, buildCmdEngine, buildCmdReport)
 		// output err info to stdout
 		logError(err)
 		return err
@@ -32,6 +32,7 @@ var _ = Describe("Build", func() {
 	})
 	AfterEach(func() {
 		mbtCmdCLI = ""
+		Ω(os.RemoveAll(getTestPath("mta", ".mta"+dir.CacheFolderSuffix))).Should(Succeed())
 	})
 	It("Success - build with abs source parameter", func() {
 		source := "\"" + getTestPath("mta") + "\""
@@ -194,3 +195,23 @@ var _ = Describe("Build", func() {
 		Ω(cmd.Run()).Should(HaveOccurred())
 	})
 })
+
+var _ = Describe("Build dry run", func() {
+	AfterEach(func() {
+		buildCmdSrc = ""
+		buildCmdPlatform = "cf"
+		buildCmdDryRun = false
+	})
+
+	It("prints the build plan without building the project", func() {
+		buildCmdSrc = getTestPath("mta")
+		buildCmdDryRun = true
+		out, err := executeAndProvideOutput(func() error {
+			return buildCmd.RunE(nil, []string{})
+		})
+		Ω(err).Should(Succeed())
+		Ω(out).Should(ContainSubstring("1. node-js"))
+		Ω(out).Should(ContainSubstring("MTA archive: " + getTestPath("mta", dir.MtarFolder)))
+		Ω(getTestPath("mta", dir.MtarFolder)).ShouldNot(BeADirectory())
+	})
+})
@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SAP/cloud-mta-build-tool/internal/artifacts"
+)
+
+var inspectCmdOutput string
+
+// Inspect the content of an existing MTA archive
+var inspectCmd = &cobra.Command{
+	Use:   "inspect <mtar>",
+	Short: "Displays the content of an MTA archive",
+	Long:  "Displays the modules and resources of an MTA archive according to its manifest and deployment descriptor, and the archive entries that the manifest does not reference",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := artifacts.ExecuteInspect(args[0], inspectCmdOutput, os.Stdout)
+		logError(err)
+		return err
+	},
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+func init() {
+	inspectCmd.Flags().StringVarP(&inspectCmdOutput, "output", "o", artifacts.OutputTable,
+		`The output format; supported formats: "table" (default), "json", "yaml"`)
+	inspectCmd.Flags().BoolP("help", "h", false, `Displays detailed information about the "inspect" command`)
+}
@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Inspect", func() {
+
+	mtarPath := getTestPath("result", "com.sap.xs2.samples.javahelloworld_0.1.0.mtar")
+
+	BeforeEach(func() {
+		assembleCmdSrc = getTestPath("assembly-sample")
+		assembleCmdTrg = getTestPath("result")
+		Ω(assembleCommand.RunE(nil, []string{})).Should(Succeed())
+	})
+
+	AfterEach(func() {
+		assembleCmdSrc = ""
+		assembleCmdTrg = ""
+		inspectCmdOutput = "table"
+		Ω(os.RemoveAll(getTestPath("result"))).Should(Succeed())
+	})
+
+	It("prints the content of the MTA archive", func() {
+		out, err := executeAndProvideOutput(func() error {
+			return inspectCmd.RunE(nil, []string{mtarPath})
+		})
+		Ω(err).Should(Succeed())
+		Ω(out).Should(ContainSubstring("MTA-Module"))
+	})
+
+	It("prints the content of the MTA archive in the JSON format", func() {
+		inspectCmdOutput = "json"
+		out, err := executeAndProvideOutput(func() error {
+			return inspectCmd.RunE(nil, []string{mtarPath})
+		})
+		Ω(err).Should(Succeed())
+		Ω(out).Should(ContainSubstring(`"modules": [`))
+	})
+
+	It("fails when the MTA archive does not exist", func() {
+		Ω(inspectCmd.RunE(nil, []string{getTestPath("result", "unknown.mtar")})).Should(HaveOccurred())
+	})
+})
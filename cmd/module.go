@@ -3,6 +3,7 @@ package commands
 import (
 	"os"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 
 	"github.com/SAP/cloud-mta-build-tool/internal/artifacts"
@@ -15,6 +16,16 @@ var packCmdTrg string
 var packCmdExtensions []string
 var packCmdModule string
 var packCmdPlatform string
+var packCmdReportDir string
+
+// flags of restore command
+var restoreCmdSrc string
+var restoreCmdMtaYamlFilename string
+var restoreCmdTrg string
+var restoreCmdExtensions []string
+var restoreCmdModule string
+var restoreCmdPlatform string
+var restoreCmdReportDir string
 
 // flags of Makefile build command
 var buildModuleCmdSrc string
@@ -23,6 +34,7 @@ var buildModuleCmdTrg string
 var buildModuleCmdExtensions []string
 var buildModuleCmdModule string
 var buildModuleCmdPlatform string
+var buildModuleCmdReportDir string
 
 // flags of stand alone build command
 var soloBuildModuleCmdSrc string
@@ -33,6 +45,8 @@ var soloBuildModuleCmdModules []string
 var soloBuildModuleCmdAllDependencies bool
 var soloBuildModuleCmdMtadGen bool
 var soloBuildModuleCmdPlatform string
+var soloBuildModuleCmdReport string
+var soloBuildModuleCmdDryRun bool
 
 func init() {
 
@@ -48,7 +62,25 @@ func init() {
 	packModuleCmd.Flags().StringVarP(&packCmdModule, "module", "m", "",
 		"The name of the module")
 	packModuleCmd.Flags().StringVarP(&packCmdPlatform, "platform", "p", "cf",
-		`The deployment platform; supported platforms: "cf", "xsa", "neo"`)
+		`The deployment platform; supported platforms: "cf", "xsa", "neo" and the platforms of the platforms configuration files`)
+	packModuleCmd.Flags().StringVarP(&packCmdReportDir, "report-dir", "", "",
+		"The path to the folder in which the module report is created")
+
+	// sets the flags of the command restore module
+	restoreModuleCmd.Flags().StringVarP(&restoreCmdSrc, "source", "s", "",
+		"The path to the MTA project; the current path is set as default")
+	restoreModuleCmd.Flags().StringVarP(&restoreCmdMtaYamlFilename, "filename", "f", "",
+		"The mta yaml filename of MTA project; the mta.yaml is set as default")
+	restoreModuleCmd.Flags().StringVarP(&restoreCmdTrg, "target", "t", "",
+		"The path to the folder in which the build cache and the temporary artifacts of the module pack are created; the current path is set as default")
+	restoreModuleCmd.Flags().StringSliceVarP(&restoreCmdExtensions, "extensions", "e", nil,
+		"The MTA extension descriptors")
+	restoreModuleCmd.Flags().StringVarP(&restoreCmdModule, "module", "m", "",
+		"The name of the module")
+	restoreModuleCmd.Flags().StringVarP(&restoreCmdPlatform, "platform", "p", "cf",
+		`The deployment platform; supported platforms: "cf", "xsa", "neo" and the platforms of the platforms configuration files`)
+	restoreModuleCmd.Flags().StringVarP(&restoreCmdReportDir, "report-dir", "", "",
+		"The path to the folder in which the module report is created")
 
 	// sets the flags of the Makefile command build module
 	buildModuleCmd.Flags().StringVarP(&buildModuleCmdSrc, "source", "s", "",
@@ -62,7 +94,9 @@ func init() {
 	buildModuleCmd.Flags().StringVarP(&buildModuleCmdModule, "module", "m", "",
 		"The name of the module")
 	buildModuleCmd.Flags().StringVarP(&buildModuleCmdPlatform, "platform", "p", "cf",
-		`The deployment platform; supported platforms: "cf", "xsa", "neo"`)
+		`The deployment platform; supported platforms: "cf", "xsa", "neo" and the platforms of the platforms configuration files`)
+	buildModuleCmd.Flags().StringVarP(&buildModuleCmdReportDir, "report-dir", "", "",
+		"The path to the folder in which the module report is created")
 
 	// sets the flags of the solo Makefile command build module
 	soloBuildModuleCmd.Flags().StringVarP(&soloBuildModuleCmdSrc, "source", "s", "",
@@ -80,7 +114,11 @@ func init() {
 	soloBuildModuleCmd.Flags().BoolVarP(&soloBuildModuleCmdMtadGen, "mtad-gen", "g", false,
 		`Generate "mtad.yaml" file`)
 	soloBuildModuleCmd.Flags().StringVarP(&soloBuildModuleCmdPlatform, "platform", "p", "cf",
-		`The deployment platform; supported platforms: "cf", "xsa", "neo"`)
+		`The deployment platform; supported platforms: "cf", "xsa", "neo" and the platforms of the platforms configuration files`)
+	soloBuildModuleCmd.Flags().StringVarP(&soloBuildModuleCmdReport, "report", "", "",
+		"The path to the JSON build report file, relative or absolute; if relative path, it is relative to MTA project root; if value is empty, the report is not generated")
+	soloBuildModuleCmd.Flags().BoolVarP(&soloBuildModuleCmdDryRun, "dry-run", "", false,
+		"Prints the resolved build plan of the modules without executing it")
 }
 
 // soloBuildModuleCmd - Build module command used stand alone
@@ -90,9 +128,15 @@ var soloBuildModuleCmd = &cobra.Command{
 	Long:  "Builds specified modules according to configurations in the MTA development descriptor (mta.yaml)",
 	Args:  cobra.MaximumNArgs(4),
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if soloBuildModuleCmdDryRun {
+			err := artifacts.ExecuteSoloBuildDryRun(soloBuildModuleCmdSrc, soloBuildModuleCmdMtaYamlFilename, soloBuildModuleCmdTrg, soloBuildModuleCmdExtensions,
+				soloBuildModuleCmdModules, soloBuildModuleCmdAllDependencies, os.Stdout, os.Getwd)
+			logError(err)
+			return err
+		}
 		err := artifacts.ExecuteSoloBuild(soloBuildModuleCmdSrc, soloBuildModuleCmdMtaYamlFilename, soloBuildModuleCmdTrg, soloBuildModuleCmdExtensions,
 			soloBuildModuleCmdModules, soloBuildModuleCmdAllDependencies, soloBuildModuleCmdMtadGen, soloBuildModuleCmdPlatform,
-			os.Getwd)
+			soloBuildModuleCmdReport, os.Getwd)
 		logError(err)
 		return err
 	},
@@ -107,7 +151,7 @@ var buildModuleCmd = &cobra.Command{
 	Long:  "Builds module according to configurations in the MTA development descriptor (mta.yaml) and archives its artifacts",
 	Args:  cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		err := artifacts.ExecuteBuild(buildModuleCmdSrc, buildModuleCmdMtaYamlFilename, buildModuleCmdTrg, buildModuleCmdExtensions, buildModuleCmdModule, buildModuleCmdPlatform, os.Getwd)
+		err := artifacts.ExecuteBuild(buildModuleCmdSrc, buildModuleCmdMtaYamlFilename, buildModuleCmdTrg, buildModuleCmdExtensions, buildModuleCmdModule, buildModuleCmdPlatform, buildModuleCmdReportDir, os.Getwd)
 		logError(err)
 		return err
 	},
@@ -126,8 +170,29 @@ var packModuleCmd = &cobra.Command{
 	Long:  "Packs the module artifacts after the build process",
 	Args:  cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		err := artifacts.ExecutePack(packCmdSrc, packCmdMtaYamlFilename, packCmdTrg, packCmdExtensions, packCmdModule, packCmdPlatform, os.Getwd)
+		err := artifacts.ExecutePack(packCmdSrc, packCmdMtaYamlFilename, packCmdTrg, packCmdExtensions, packCmdModule, packCmdPlatform, packCmdReportDir, os.Getwd)
+		logError(err)
+		return err
+	},
+	Hidden:        true,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+// restoreModuleCmd - restores the packed module artifacts from the build cache before the module build in the verbose Makefile;
+// the command fails if the module is not restored, so the Makefile builds and packs the module
+var restoreModuleCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Restores module artifacts from build cache",
+	Long:  "Restores the packed module artifacts from the build cache if the module sources and build configuration are not changed",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		restored, err := artifacts.ExecuteModuleRestore(restoreCmdSrc, restoreCmdMtaYamlFilename, restoreCmdTrg, restoreCmdExtensions,
+			restoreCmdModule, restoreCmdPlatform, restoreCmdReportDir, os.Getwd)
 		logError(err)
+		if err == nil && !restored {
+			return errors.Errorf(`the "%s" module is not restored from the build cache`, restoreCmdModule)
+		}
 		return err
 	},
 	Hidden:        true,
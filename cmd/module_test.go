@@ -67,6 +67,24 @@ var _ = Describe("Commands", func() {
 		)
 	})
 
+	var _ = Describe("Restore", func() {
+		BeforeEach(func() {
+			restoreCmdSrc = getTestPath("mtahtml5")
+			restoreCmdTrg = getTestPath("result")
+			restoreCmdModule = "ui5app"
+			restoreCmdPlatform = "cf"
+		})
+		It("restores the module packed before", func() {
+			packCmdSrc = getTestPath("mtahtml5")
+			packCmdModule = "ui5app"
+			Ω(packModuleCmd.RunE(nil, []string{})).Should(Succeed())
+			Ω(restoreModuleCmd.RunE(nil, []string{})).Should(Succeed())
+		})
+		It("fails when the module is not in the build cache", func() {
+			Ω(restoreModuleCmd.RunE(nil, []string{})).Should(HaveOccurred())
+		})
+	})
+
 	var _ = Describe("Build", func() {
 		var config []byte
 
@@ -110,5 +128,21 @@ builders:
 			Ω(soloBuildModuleCmd.RunE(nil, []string{})).Should(Succeed())
 			Ω(getTestPath("result", "data.zip")).Should(BeAnExistingFile())
 		})
+
+		It("stand alone build Command in the dry-run mode", func() {
+			soloBuildModuleCmdModules = []string{"node-js"}
+			soloBuildModuleCmdSrc = getTestPath("mta")
+			soloBuildModuleCmdTrg = getTestPath("result")
+			soloBuildModuleCmdDryRun = true
+			defer func() {
+				soloBuildModuleCmdDryRun = false
+			}()
+			out, err := executeAndProvideOutput(func() error {
+				return soloBuildModuleCmd.RunE(nil, []string{})
+			})
+			Ω(err).Should(Succeed())
+			Ω(out).Should(ContainSubstring("1. node-js"))
+			Ω(getTestPath("result", "data.zip")).ShouldNot(BeAnExistingFile())
+		})
 	})
 })
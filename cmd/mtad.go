@@ -41,6 +41,6 @@ func init() {
 	mtadGenCmd.Flags().StringSliceVarP(&mtadGenCmdExtensions, "extensions", "e", nil,
 		"The MTA extension descriptors")
 	mtadGenCmd.Flags().StringVarP(&mtadGenCmdPlatform, "platform", "p", "cf",
-		`The deployment platform; supported platforms: "cf", "xsa", "neo"`)
+		`The deployment platform; supported platforms: "cf", "xsa", "neo" and the platforms of the platforms configuration files`)
 	mtadGenCmd.Flags().BoolP("help", "h", false, `Displays detailed information about the 'mtad gen' command`)
 }
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SAP/cloud-mta-build-tool/internal/artifacts"
+)
+
+var unpackCmdTrg string
+var repackCmdTrg string
+var repackCmdMtarName string
+
+// Unpack an existing MTA archive for editing
+var unpackCmd = &cobra.Command{
+	Use:   "unpack <mtar>",
+	Short: "Extracts an MTA archive to a folder that can be packed back",
+	Long:  "Extracts an MTA archive to a folder with the layout of the temporary build folder, so that the deployment descriptor or the module content can be changed and the folder packed back by the repack command",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := artifacts.ExecuteUnpack(args[0], unpackCmdTrg, os.Getwd)
+		logError(err)
+		return err
+	},
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+// Pack the unpacked MTA archive
+var repackCmd = &cobra.Command{
+	Use:   "repack <folder>",
+	Short: "Generates an MTA archive from a folder extracted by the unpack command",
+	Long:  "Validates the layout of a folder extracted by the unpack command, regenerates its manifest according to the deployment descriptor and generates a new MTA archive",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := artifacts.ExecuteRepack(args[0], repackCmdTrg, repackCmdMtarName, os.Getwd)
+		logError(err)
+		return err
+	},
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+func init() {
+	unpackCmd.Flags().StringVarP(&unpackCmdTrg,
+		"target", "t", "", "The path to the folder to which the MTA archive is extracted; the folder must be empty or not exist; the subfolder of the current folder named as the MTA archive is set as default")
+	unpackCmd.Flags().BoolP("help", "h", false, `Displays detailed information about the "unpack" command`)
+
+	repackCmd.Flags().StringVarP(&repackCmdTrg,
+		"target", "t", "", `The path to the folder in which the MTAR file is created; the path to the "mta_archives" subfolder of the current folder is set as default`)
+	repackCmd.Flags().StringVarP(&repackCmdMtarName,
+		"mtar", "m", "", "The archive name")
+	repackCmd.Flags().BoolP("help", "h", false, `Displays detailed information about the "repack" command`)
+}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Unpack and repack", func() {
+
+	mtarPath := getTestPath("result", "com.sap.xs2.samples.javahelloworld_0.1.0.mtar")
+
+	BeforeEach(func() {
+		assembleCmdSrc = getTestPath("assembly-sample")
+		assembleCmdTrg = getTestPath("result")
+		Ω(assembleCommand.RunE(nil, []string{})).Should(Succeed())
+	})
+
+	AfterEach(func() {
+		assembleCmdSrc = ""
+		assembleCmdTrg = ""
+		unpackCmdTrg = ""
+		repackCmdTrg = ""
+		repackCmdMtarName = ""
+		Ω(os.RemoveAll(getTestPath("result"))).Should(Succeed())
+	})
+
+	It("unpacks and repacks the MTA archive", func() {
+		unpackCmdTrg = getTestPath("result", "unpacked")
+		Ω(unpackCmd.RunE(nil, []string{mtarPath})).Should(Succeed())
+		Ω(getTestPath("result", "unpacked", "META-INF", "mtad.yaml")).Should(BeAnExistingFile())
+
+		repackCmdTrg = getTestPath("result", "repacked")
+		repackCmdMtarName = "repacked"
+		Ω(repackCmd.RunE(nil, []string{getTestPath("result", "unpacked")})).Should(Succeed())
+		Ω(getTestPath("result", "repacked", "repacked.mtar")).Should(BeAnExistingFile())
+		Ω(verifyCmd.RunE(nil, []string{getTestPath("result", "repacked", "repacked.mtar")})).Should(Succeed())
+	})
+
+	It("fails to unpack the MTA archive that does not exist", func() {
+		unpackCmdTrg = getTestPath("result", "unpacked")
+		Ω(unpackCmd.RunE(nil, []string{getTestPath("result", "unknown.mtar")})).Should(HaveOccurred())
+	})
+
+	It("fails to repack the folder that does not exist", func() {
+		Ω(repackCmd.RunE(nil, []string{getTestPath("result", "unknown")})).Should(HaveOccurred())
+	})
+})
@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SAP/cloud-mta-build-tool/internal/artifacts"
+)
+
+var resolveCmdSrc string
+var resolveCmdMtaYamlFilename string
+var resolveCmdExtensions []string
+var resolveCmdPlatform string
+var resolveCmdParams []string
+var resolveCmdEnvFile string
+var resolveCmdOutput string
+
+// Print the deployment view of the MTA project with the resolved placeholders
+var resolveCmd = &cobra.Command{
+	Use:   "resolve",
+	Short: "Displays the deployment view of the MTA project with the resolved placeholders",
+	Long:  "Merges the \"mta.yaml\" file with the MTA extension descriptors, adapts it to the deployment platform as the \"mtad-gen\" command does, and displays the result in which the \"${<parameter>}\" placeholders and the \"~{<property>}\" references to the provided properties are resolved; the placeholders that are not defined in the descriptors are resolved by the provided values, and the unresolved placeholders and references are reported as errors",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := artifacts.ExecuteResolve(resolveCmdSrc, resolveCmdMtaYamlFilename, resolveCmdExtensions, resolveCmdPlatform,
+			resolveCmdParams, resolveCmdEnvFile, resolveCmdOutput, os.Stdout, os.Getwd)
+		logError(err)
+		return err
+	},
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+func init() {
+	resolveCmd.Flags().StringVarP(&resolveCmdSrc, "source", "s", "",
+		"The path to the MTA project; the current path is set as default")
+	resolveCmd.Flags().StringVarP(&resolveCmdMtaYamlFilename, "filename", "f", "",
+		"The mta yaml filename of the MTA project; the mta.yaml is set as default")
+	resolveCmd.Flags().StringSliceVarP(&resolveCmdExtensions, "extensions", "e", nil,
+		"The MTA extension descriptors")
+	resolveCmd.Flags().StringVarP(&resolveCmdPlatform, "platform", "p", "cf",
+		`The deployment platform; supported platforms: "cf", "xsa", "neo" and the platforms of the platforms configuration files`)
+	resolveCmd.Flags().StringArrayVarP(&resolveCmdParams, "param", "", nil,
+		`The value of a placeholder in the "<name>=<value>" format; the "<module or resource>/<name>=<value>" format provides the value only for the module or resource`)
+	resolveCmd.Flags().StringVarP(&resolveCmdEnvFile, "env-file", "", "",
+		`The path to a file with the "<name>=<value>" values of the placeholders; the values of the "param" flags override them`)
+	resolveCmd.Flags().StringVarP(&resolveCmdOutput, "output", "o", artifacts.OutputYAML,
+		`The output format; supported formats: "yaml" (default), "json"`)
+	resolveCmd.Flags().BoolP("help", "h", false, `Displays detailed information about the "resolve" command`)
+}
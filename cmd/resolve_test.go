@@ -0,0 +1,44 @@
+package commands
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Resolve", func() {
+
+	AfterEach(func() {
+		resolveCmdSrc = ""
+		resolveCmdParams = nil
+		resolveCmdOutput = "yaml"
+	})
+
+	It("prints the resolved deployment view", func() {
+		resolveCmdSrc = getTestPath("mta_resolve")
+		resolveCmdParams = []string{"default-domain=cfapps.example.com"}
+		out, err := executeAndProvideOutput(func() error {
+			return resolveCmd.RunE(nil, []string{})
+		})
+		Ω(err).Should(Succeed())
+		Ω(out).Should(ContainSubstring("url: https://srv.cfapps.example.com"))
+		Ω(out).ShouldNot(ContainSubstring("~{url}"))
+	})
+
+	It("prints the resolved deployment view in the JSON format", func() {
+		resolveCmdSrc = getTestPath("mta_resolve")
+		resolveCmdParams = []string{"default-domain=cfapps.example.com"}
+		resolveCmdOutput = "json"
+		out, err := executeAndProvideOutput(func() error {
+			return resolveCmd.RunE(nil, []string{})
+		})
+		Ω(err).Should(Succeed())
+		Ω(out).Should(ContainSubstring(`"url": "https://srv.cfapps.example.com"`))
+	})
+
+	It("fails on the unresolved placeholders", func() {
+		resolveCmdSrc = getTestPath("mta_resolve")
+		err := resolveCmd.RunE(nil, []string{})
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring(`"${default-domain}"`))
+	})
+})
@@ -1,15 +1,47 @@
 package commands
 
 import (
+	"os"
+
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/x-cray/logrus-prefixed-formatter"
 
+	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/artifacts"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildconfig"
+	"github.com/SAP/cloud-mta-build-tool/internal/commands"
 	"github.com/SAP/cloud-mta-build-tool/internal/logs"
+	"github.com/SAP/cloud-mta-build-tool/internal/platform"
 )
 
 var cfgFile string
 
+// flags of the external builders and module types configuration
+var buildersConfig string
+var moduleTypesConfig string
+
+// flag of the external platforms configuration
+var platformsConfig string
+
+// flag of the reproducible archives
+var reproducible bool
+
+// flag of the MTA archive compression
+var mtarCompression string
+
+// flag of the number of workers that compress the archive files in parallel
+var archiveWorkers int
+
+// flag of the key that signs the MTA archive
+var signKey string
+
+// flag of the build profile
+var profile string
+
+// flag that disables the build cache of the modules
+var noCache bool
+
 func init() {
 	logs.Logger = logs.NewLogger()
 	formatter, ok := logs.Logger.Formatter.(*prefixed.TextFormatter)
@@ -17,6 +49,25 @@ func init() {
 		formatter.DisableColors = true
 	}
 	cobra.OnInitialize(initConfig)
+
+	rootCmd.PersistentFlags().StringVarP(&buildersConfig, "builders-config", "", "",
+		"The path to the builders configuration file that is merged over the default builders")
+	rootCmd.PersistentFlags().StringVarP(&moduleTypesConfig, "module-types-config", "", "",
+		"The path to the module types configuration file that is merged over the default module types")
+	rootCmd.PersistentFlags().StringVarP(&platformsConfig, "platform-config", "", "",
+		"The path to the platforms configuration file that defines additional deployment platforms and their module types mappings")
+	rootCmd.PersistentFlags().BoolVarP(&reproducible, "reproducible", "", false,
+		"Create reproducible module archives and MTA archive; the SOURCE_DATE_EPOCH environment variable, if set, defines the timestamp of the archive entries")
+	rootCmd.PersistentFlags().StringVarP(&mtarCompression, "compression", "", "",
+		`The compression of the MTA archive entries: "store", "fast", "default" or "best"; the default value is "default"`)
+	rootCmd.PersistentFlags().IntVarP(&archiveWorkers, "archive-workers", "", 1,
+		"The number of workers that read and compress the files of the module archives and MTA archive in parallel; the default value 1 means serial archiving, 0 means the number of CPUs; the reproducible archives are always created serially")
+	rootCmd.PersistentFlags().StringVarP(&signKey, "sign-key", "", "",
+		"The path to the PKCS#8 PEM private key file that signs the MTA archive; the MBT_SIGN_KEY environment variable is used if the flag is not provided")
+	rootCmd.PersistentFlags().StringVarP(&profile, "profile", "", "",
+		`The build profile; the modules, resources and extension files tied to other profiles by the "profiles" parameter are excluded from the build`)
+	rootCmd.PersistentFlags().BoolVarP(&noCache, "no-cache", "", false,
+		"Build all the modules without using the build cache and without storing their build results in it")
 }
 
 // rootCmd represents the base command
@@ -26,6 +77,27 @@ var rootCmd = &cobra.Command{
 	Long:    "Cloud MTA Build Tool",
 	Version: cliVersion(),
 	Args:    cobra.MaximumNArgs(1),
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		err := loadExternalConfig(cmd)
+		if err == nil && reproducible {
+			err = dir.SetReproducible()
+		}
+		if err == nil {
+			err = dir.SetMtarCompression(mtarCompression)
+		}
+		if err == nil {
+			err = dir.SetArchiveWorkers(archiveWorkers)
+		}
+		if err == nil {
+			err = artifacts.SetSigningKey(signKey)
+		}
+		if err == nil {
+			err = buildconfig.SetProfile(profile)
+		}
+		buildconfig.SetNoCache(noCache)
+		logError(err)
+		return err
+	},
 }
 
 // Execute command adds all child commands to the root command and sets flags appropriately
@@ -33,6 +105,27 @@ func Execute() error {
 	return rootCmd.Execute()
 }
 
+// loadExternalConfig - loads the external builders, module types and platforms configuration;
+// the project configuration folder is searched in the folder provided by the "source" flag of the command
+func loadExternalConfig(cmd *cobra.Command) error {
+	projectDir := ""
+	if source := cmd.Flags().Lookup("source"); source != nil {
+		projectDir = source.Value.String()
+	}
+	if projectDir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		projectDir = wd
+	}
+	err := commands.LoadExternalConfig(projectDir, buildersConfig, moduleTypesConfig)
+	if err != nil {
+		return err
+	}
+	return platform.LoadExternalConfig(projectDir, platformsConfig)
+}
+
 func initConfig() {
 	viper.SetConfigFile(cfgFile)
 	viper.AutomaticEnv() // reads in the environment variables that match
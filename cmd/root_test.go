@@ -9,6 +9,12 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/types"
 	"github.com/spf13/viper"
+
+	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/artifacts"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildconfig"
+	"github.com/SAP/cloud-mta-build-tool/internal/commands"
+	"github.com/SAP/cloud-mta-build-tool/internal/platform"
 )
 
 var _ = Describe("Root", func() {
@@ -35,6 +41,144 @@ var _ = Describe("Root", func() {
 		)
 	})
 
+	Describe("loadExternalConfig", func() {
+		AfterEach(func() {
+			buildersConfig = ""
+			platformsConfig = ""
+			Ω(commands.LoadExternalConfig("", "", "")).Should(Succeed())
+			Ω(platform.LoadExternalConfig("", "")).Should(Succeed())
+		})
+
+		It("loads the provided builders configuration file", func() {
+			buildersConfig = getTestPath("builders.yaml")
+			Ω(loadExternalConfig(buildCmd)).Should(Succeed())
+			loaded, _ := commands.GetExternalConfigPaths()
+			Ω(loaded).Should(Equal(buildersConfig))
+		})
+
+		It("fails when the provided builders configuration file does not exist", func() {
+			buildersConfig = getTestPath("unknown.yaml")
+			Ω(loadExternalConfig(buildCmd)).Should(HaveOccurred())
+		})
+
+		It("loads the provided platforms configuration file", func() {
+			platformsConfig = getTestPath("platforms.yaml")
+			Ω(loadExternalConfig(buildCmd)).Should(Succeed())
+			Ω(platform.GetExternalConfigPath()).Should(Equal(platformsConfig))
+			Ω(platform.GetPlatformNames()).Should(ContainElement("kyma"))
+		})
+
+		It("fails when the provided platforms configuration file does not exist", func() {
+			platformsConfig = getTestPath("unknown.yaml")
+			Ω(loadExternalConfig(buildCmd)).Should(HaveOccurred())
+		})
+	})
+
+	Describe("reproducible flag", func() {
+		AfterEach(func() {
+			reproducible = false
+			Ω(os.Unsetenv(dir.SourceDateEpochEnv)).Should(Succeed())
+		})
+
+		It("makes the archives reproducible", func() {
+			reproducible = true
+			Ω(rootCmd.PersistentPreRunE(buildCmd, []string{})).Should(Succeed())
+			Ω(os.Getenv(dir.SourceDateEpochEnv)).ShouldNot(BeEmpty())
+		})
+
+		It("fails on the invalid SOURCE_DATE_EPOCH", func() {
+			reproducible = true
+			Ω(os.Setenv(dir.SourceDateEpochEnv, "abc")).Should(Succeed())
+			Ω(rootCmd.PersistentPreRunE(buildCmd, []string{})).Should(HaveOccurred())
+		})
+	})
+
+	Describe("compression flag", func() {
+		AfterEach(func() {
+			mtarCompression = ""
+			Ω(dir.SetMtarCompression("")).Should(Succeed())
+		})
+
+		It("sets the compression of the MTA archive", func() {
+			mtarCompression = dir.CompressionStore
+			Ω(rootCmd.PersistentPreRunE(buildCmd, []string{})).Should(Succeed())
+			Ω(dir.GetMtarCompression()).Should(Equal(dir.CompressionStore))
+		})
+
+		It("fails on the invalid compression", func() {
+			mtarCompression = "fastest"
+			Ω(rootCmd.PersistentPreRunE(buildCmd, []string{})).Should(HaveOccurred())
+		})
+	})
+
+	Describe("archive workers flag", func() {
+		AfterEach(func() {
+			archiveWorkers = 1
+			Ω(dir.SetArchiveWorkers(1)).Should(Succeed())
+		})
+
+		It("sets the number of archive workers", func() {
+			archiveWorkers = 2
+			Ω(rootCmd.PersistentPreRunE(buildCmd, []string{})).Should(Succeed())
+			Ω(dir.GetArchiveWorkers()).Should(Equal(2))
+		})
+
+		It("fails on the negative number of archive workers", func() {
+			archiveWorkers = -1
+			Ω(rootCmd.PersistentPreRunE(buildCmd, []string{})).Should(HaveOccurred())
+		})
+	})
+
+	Describe("sign key flag", func() {
+		AfterEach(func() {
+			signKey = ""
+			Ω(artifacts.SetSigningKey("")).Should(Succeed())
+			Ω(os.RemoveAll(getTestPath("result"))).Should(Succeed())
+		})
+
+		It("sets the signing key of the MTA archive without exporting it to the environment", func() {
+			signKey, _ = generateSigningKey()
+			Ω(rootCmd.PersistentPreRunE(buildCmd, []string{})).Should(Succeed())
+			Ω(buildconfig.SignKeyPath()).Should(Equal(signKey))
+			_, ok := os.LookupEnv(artifacts.SignKeyEnv)
+			Ω(ok).Should(BeFalse())
+		})
+
+		It("fails on the invalid signing key", func() {
+			signKey = getTestPath("unknown.pem")
+			Ω(rootCmd.PersistentPreRunE(buildCmd, []string{})).Should(HaveOccurred())
+		})
+	})
+
+	Describe("profile flag", func() {
+		AfterEach(func() {
+			profile = ""
+		})
+
+		It("sets the build profile", func() {
+			profile = "trial"
+			Ω(rootCmd.PersistentPreRunE(buildCmd, []string{})).Should(Succeed())
+			Ω(buildconfig.Profile()).Should(Equal("trial"))
+		})
+
+		It("fails on the invalid profile", func() {
+			profile = "trial profile"
+			Ω(rootCmd.PersistentPreRunE(buildCmd, []string{})).Should(HaveOccurred())
+		})
+	})
+
+	Describe("no cache flag", func() {
+		AfterEach(func() {
+			noCache = false
+		})
+
+		It("disables the build cache", func() {
+			noCache = true
+			Ω(rootCmd.PersistentPreRunE(buildCmd, []string{})).Should(Succeed())
+			Ω(buildconfig.NoCache()).Should(BeTrue())
+		})
+	})
+
 	Describe("Execute", func() {
 		It("Sanity", func() {
 			out, err := executeAndProvideOutput(func() error {
@@ -22,6 +22,7 @@ var _ = Describe("mbt cli build and sbom gen", func() {
 		buildCmdTrg = ""
 		buildCmdPlatform = ""
 		buildCmdKeepMakefile = false
+		Ω(os.RemoveAll(getTestPath("mta", ".mta"+dir.CacheFolderSuffix))).Should(Succeed())
 	})
 	It("Success - build and gen sbom with relatvie source and relative sbom-file-path parameter", func() {
 		source := "\"" + "testdata/mta" + "\""
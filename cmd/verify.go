@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SAP/cloud-mta-build-tool/internal/artifacts"
+)
+
+var verifyCmdOutput string
+var verifyCmdSignature bool
+var verifyCmdPublicKey string
+
+// Verify the internal consistency of an existing MTA archive
+var verifyCmd = &cobra.Command{
+	Use:   "verify <mtar>",
+	Short: "Verifies that an MTA archive is internally consistent",
+	Long:  "Verifies that the manifest entries of an MTA archive reference existing archive entries with the expected content types, that the deployment descriptor modules have manifest entries and that the deployment descriptor is valid; optionally verifies the signature of the archive",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := artifacts.ExecuteVerify(args[0], verifyCmdOutput, verifyCmdSignature, verifyCmdPublicKey, os.Stdout)
+		logError(err)
+		return err
+	},
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+func init() {
+	verifyCmd.Flags().StringVarP(&verifyCmdOutput, "output", "o", artifacts.OutputTable,
+		`The output format of the findings; supported formats: "table" (default), "json", "yaml"`)
+	verifyCmd.Flags().BoolVarP(&verifyCmdSignature, "signature", "", false,
+		"Verifies the signature of the archive and the digests of its entries")
+	verifyCmd.Flags().StringVarP(&verifyCmdPublicKey, "public-key", "", "",
+		"The path to the PKIX PEM public key, or the key itself, that is expected to sign the archive; implies the signature verification")
+	verifyCmd.Flags().BoolP("help", "h", false, `Displays detailed information about the "verify" command`)
+}
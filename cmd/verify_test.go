@@ -0,0 +1,94 @@
+package commands
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/SAP/cloud-mta-build-tool/internal/artifacts"
+)
+
+// generateSigningKey - writes the generated PKCS#8 PEM private key to the result folder and returns the path of its file
+// and the PEM public key
+func generateSigningKey() (privateKeyPath, publicKey string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Ω(err).Should(Succeed())
+	privateKeyBytes, err := x509.MarshalPKCS8PrivateKey(key)
+	Ω(err).Should(Succeed())
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(key.Public())
+	Ω(err).Should(Succeed())
+	Ω(os.MkdirAll(getTestPath("result"), os.ModePerm)).Should(Succeed())
+	privateKeyPath = getTestPath("result", "signing-key.pem")
+	Ω(ioutil.WriteFile(privateKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateKeyBytes}), os.ModePerm)).Should(Succeed())
+	return privateKeyPath, string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyBytes}))
+}
+
+var _ = Describe("Verify", func() {
+
+	mtarPath := getTestPath("result", "com.sap.xs2.samples.javahelloworld_0.1.0.mtar")
+
+	BeforeEach(func() {
+		assembleCmdSrc = getTestPath("assembly-sample")
+		assembleCmdTrg = getTestPath("result")
+		Ω(assembleCommand.RunE(nil, []string{})).Should(Succeed())
+	})
+
+	AfterEach(func() {
+		assembleCmdSrc = ""
+		assembleCmdTrg = ""
+		verifyCmdOutput = "table"
+		verifyCmdSignature = false
+		verifyCmdPublicKey = ""
+		Ω(artifacts.SetSigningKey("")).Should(Succeed())
+		Ω(os.RemoveAll(getTestPath("result"))).Should(Succeed())
+	})
+
+	It("verifies the assembled MTA archive", func() {
+		out, err := executeAndProvideOutput(func() error {
+			return verifyCmd.RunE(nil, []string{mtarPath})
+		})
+		Ω(err).Should(Succeed())
+		Ω(out).Should(MatchRegexp(`VALID:\s+true`))
+	})
+
+	It("prints the findings in the JSON format", func() {
+		verifyCmdOutput = "json"
+		out, err := executeAndProvideOutput(func() error {
+			return verifyCmd.RunE(nil, []string{mtarPath})
+		})
+		Ω(err).Should(Succeed())
+		Ω(out).Should(ContainSubstring(`"valid": true`))
+	})
+
+	It("fails when the MTA archive does not exist", func() {
+		Ω(verifyCmd.RunE(nil, []string{getTestPath("result", "unknown.mtar")})).Should(HaveOccurred())
+	})
+
+	It("fails the signature verification of the unsigned archive", func() {
+		verifyCmdSignature = true
+		out, err := executeAndProvideOutput(func() error {
+			return verifyCmd.RunE(nil, []string{mtarPath})
+		})
+		Ω(err).Should(HaveOccurred())
+		Ω(out).Should(ContainSubstring("the archive is not signed"))
+	})
+
+	It("verifies the signature of the signed archive", func() {
+		privateKey, publicKey := generateSigningKey()
+		Ω(artifacts.SetSigningKey(privateKey)).Should(Succeed())
+		Ω(assembleCommand.RunE(nil, []string{})).Should(Succeed())
+		verifyCmdPublicKey = publicKey
+		out, err := executeAndProvideOutput(func() error {
+			return verifyCmd.RunE(nil, []string{mtarPath})
+		})
+		Ω(err).Should(Succeed())
+		Ω(out).Should(MatchRegexp(`VALID:\s+true`))
+	})
+})
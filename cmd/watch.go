@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/SAP/cloud-mta-build-tool/internal/artifacts"
+)
+
+var watchCmdSrc string
+var watchCmdMtaYamlFilename string
+var watchCmdTrg string
+var watchCmdExtensions []string
+var watchCmdModules []string
+var watchCmdMtadGen bool
+var watchCmdPlatform string
+var watchCmdInterval time.Duration
+var watchCmdDebounce time.Duration
+
+// Rebuild the modules on changes of their sources
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Rebuilds the modules when their sources change",
+	Long:  "Watches the source folders of the modules and rebuilds the changed modules and the modules that depend on them, as the \"module-build\" command does, until the command is interrupted; the files matching the \"ignore\" build parameter of the module are not watched",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stop := make(chan struct{})
+		interrupt := make(chan os.Signal, 1)
+		signal.Notify(interrupt, os.Interrupt)
+		defer signal.Stop(interrupt)
+		go func() {
+			<-interrupt
+			close(stop)
+		}()
+		err := artifacts.ExecuteWatch(watchCmdSrc, watchCmdMtaYamlFilename, watchCmdTrg, watchCmdExtensions, watchCmdModules,
+			watchCmdMtadGen, watchCmdPlatform, watchCmdInterval, watchCmdDebounce, stop, os.Getwd)
+		logError(err)
+		return err
+	},
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+func init() {
+	watchCmd.Flags().StringVarP(&watchCmdSrc, "source", "s", "",
+		"The path to the MTA project; the current path is set as default")
+	watchCmd.Flags().StringVarP(&watchCmdMtaYamlFilename, "filename", "f", "",
+		"The mta yaml filename of the MTA project; the mta.yaml is set as default")
+	watchCmd.Flags().StringVarP(&watchCmdTrg, "target", "t", "",
+		"The path to the folder in which the module build results are created; the <current folder>/.<project name>_mta_build_tmp/<module name> path is set as default")
+	watchCmd.Flags().StringSliceVarP(&watchCmdExtensions, "extensions", "e", nil,
+		"The MTA extension descriptors")
+	watchCmd.Flags().StringSliceVarP(&watchCmdModules, "modules", "m", nil,
+		"The names of the watched modules; all the modules are watched by default")
+	watchCmd.Flags().BoolVarP(&watchCmdMtadGen, "mtad-gen", "g", false,
+		`Generate "mtad.yaml" file after each build`)
+	watchCmd.Flags().StringVarP(&watchCmdPlatform, "platform", "p", "cf",
+		`The deployment platform; supported platforms: "cf", "xsa", "neo" and the platforms of the platforms configuration files`)
+	watchCmd.Flags().DurationVarP(&watchCmdInterval, "interval", "", artifacts.DefaultWatchInterval,
+		"The interval of checking the module folders for changes")
+	watchCmd.Flags().DurationVarP(&watchCmdDebounce, "debounce", "", artifacts.DefaultWatchDebounce,
+		"The time without changes after which the changed modules are rebuilt")
+	watchCmd.Flags().BoolP("help", "h", false, `Displays detailed information about the "watch" command`)
+}
@@ -0,0 +1,25 @@
+package commands
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Watch", func() {
+
+	AfterEach(func() {
+		watchCmdSrc = ""
+		watchCmdModules = nil
+	})
+
+	It("fails when the watched module is not defined", func() {
+		watchCmdSrc = getTestPath("mtahtml5")
+		watchCmdModules = []string{"unknown"}
+		Ω(watchCmd.RunE(nil, []string{})).Should(HaveOccurred())
+	})
+
+	It("fails when the MTA project does not exist", func() {
+		watchCmdSrc = getTestPath("unknown")
+		Ω(watchCmd.RunE(nil, []string{})).Should(HaveOccurred())
+	})
+})
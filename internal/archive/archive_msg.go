@@ -12,6 +12,9 @@ const (
 	copyByPatternFailedOnTargetMsg   = `could not copy files matching the patterns [%s,...] from the "%s" folder to the "%s" folder: "%s" is not a folder`
 	copyByPatternFailedOnMatchMsg    = `could not copy files matching the "%s" pattern from the "%s" folder to the "%s": could not get list of files matching the "%s" pattern`
 	wrongPathMsg                     = `could not find the "%s" path`
+	wrongRenameEntryMsg              = `the "%s" rename entry is invalid; expected the "<path>=<new path>" format`
+	extractFailedOnOpenMsg           = `could not open the "%s" archive for extraction`
+	copyFailedOnTargetPathMsg        = `could not copy the "%s" entry from "%s" to the "%s" folder: the target path is outside of the target folder`
 
 	// InitLocFailedOnWorkDirMsg - message raised on getting working directory when initializing location
 	InitLocFailedOnWorkDirMsg = `could not get working directory`
@@ -28,4 +31,14 @@ const (
 
 	recursiveSymLinkMsg = `the "%s" symbolic path is recursive`
 	badSymLink          = `could not read the "%s" symbolic link`
+
+	wrongSourceDateEpochMsg   = `the "%s" value of the %s environment variable is invalid; expected the number of seconds since the Unix epoch`
+	normalizeArchiveFailedMsg = `could not make the "%s" archive reproducible`
+
+	wrongCompressionMsg = `the "%s" compression is invalid; expected one of the following values: store, fast, default, best`
+
+	wrongArchiveWorkersMsg = `the "%d" number of archive workers is invalid; expected 0 for the number of CPUs or a positive number`
+
+	hashFailedMsg      = `could not calculate the hash of the "%s" folder`
+	treeStateFailedMsg = `could not read the state of the "%s" folder`
 )
@@ -0,0 +1,101 @@
+package dir
+
+import (
+	"archive/zip"
+	"compress/flate"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// CompressionStore - the archive entries are stored without compression
+	CompressionStore = "store"
+	// CompressionFast - the archive entries are compressed with the best speed
+	CompressionFast = "fast"
+	// CompressionDefault - the archive entries are compressed with the default compression level
+	CompressionDefault = "default"
+	// CompressionBest - the archive entries are compressed with the best compression
+	CompressionBest = "best"
+)
+
+// mtarCompression - the compression of the MTA archive entries
+var mtarCompression = CompressionDefault
+
+// archiveWriter - the zip writer with the compression method of the archive entries
+type archiveWriter struct {
+	*zip.Writer
+	// store - all the entries are stored without compression
+	store bool
+	// storedExtensions - the extensions of the files that are stored without compression, e.g. the nested archives
+	storedExtensions map[string]bool
+	// level - the compression level of the deflated entries
+	level int
+	// pipeline - reads and compresses the files in parallel; nil when the files are archived serially
+	pipeline *archivePipeline
+}
+
+// newArchiveWriter - creates the archive writer; when there is more than one worker,
+// the files are read and compressed by the workers in parallel
+func newArchiveWriter(w io.Writer, compression string, storedExtensions []string, workers int) (*archiveWriter, error) {
+	err := ValidateCompression(compression)
+	if err != nil {
+		return nil, err
+	}
+	writer := &archiveWriter{Writer: zip.NewWriter(w), store: compression == CompressionStore, storedExtensions: make(map[string]bool)}
+	for _, ext := range storedExtensions {
+		writer.storedExtensions[strings.ToLower(ext)] = true
+	}
+	level := flate.DefaultCompression
+	switch compression {
+	case CompressionFast:
+		level = flate.BestSpeed
+	case CompressionBest:
+		level = flate.BestCompression
+	}
+	writer.level = level
+	writer.RegisterCompressor(zip.Deflate, func(out io.Writer) (io.WriteCloser, error) {
+		return flate.NewWriter(out, level)
+	})
+	if workers > 1 {
+		writer.startPipeline(workers)
+	}
+	return writer, nil
+}
+
+// getMethod - gets the compression method of the file entry
+func (w *archiveWriter) getMethod(path string) uint16 {
+	if w.store || w.storedExtensions[strings.ToLower(filepath.Ext(path))] {
+		return zip.Store
+	}
+	return zip.Deflate
+}
+
+// ValidateCompression - checks that the compression is one of "store", "fast", "default" and "best"; the empty value means "default"
+func ValidateCompression(compression string) error {
+	switch compression {
+	case "", CompressionStore, CompressionFast, CompressionDefault, CompressionBest:
+		return nil
+	}
+	return errors.Errorf(wrongCompressionMsg, compression)
+}
+
+// SetMtarCompression - sets the compression of the MTA archive entries
+func SetMtarCompression(compression string) error {
+	err := ValidateCompression(compression)
+	if err != nil {
+		return err
+	}
+	if compression == "" {
+		compression = CompressionDefault
+	}
+	mtarCompression = compression
+	return nil
+}
+
+// GetMtarCompression - gets the compression of the MTA archive entries
+func GetMtarCompression() string {
+	return mtarCompression
+}
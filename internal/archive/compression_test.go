@@ -0,0 +1,70 @@
+package dir
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Compression", func() {
+
+	sourcePath := getFullPath("testdata", "result", "source")
+	archivePath := getFullPath("testdata", "result", "source.zip")
+
+	BeforeEach(func() {
+		Ω(os.MkdirAll(sourcePath, os.ModePerm)).Should(Succeed())
+		Ω(ioutil.WriteFile(getFullPath("testdata", "result", "source", "a.txt"), []byte("aaaaaaaaaaaaaaaaaaaaaaaaaaaa"), 0644)).Should(Succeed())
+		Ω(ioutil.WriteFile(getFullPath("testdata", "result", "source", "b.ZIP"), []byte("bbbbbbbbbbbbbbbbbbbbbbbbbbbb"), 0644)).Should(Succeed())
+	})
+
+	AfterEach(func() {
+		Ω(os.RemoveAll(getFullPath("testdata", "result"))).Should(Succeed())
+	})
+
+	getMethods := func() map[string]uint16 {
+		reader, err := zip.OpenReader(archivePath)
+		Ω(err).Should(Succeed())
+		defer reader.Close()
+		methods := make(map[string]uint16)
+		for _, file := range reader.File {
+			methods[file.Name] = file.Method
+		}
+		return methods
+	}
+
+	var _ = DescribeTable("ArchiveWithCompression", func(compression string, storedExtensions []string, expected map[string]uint16) {
+		Ω(ArchiveWithCompression(sourcePath, archivePath, nil, compression, storedExtensions)).Should(Succeed())
+		Ω(getMethods()).Should(Equal(expected))
+	},
+		Entry("default compression", "", nil, map[string]uint16{"a.txt": zip.Deflate, "b.ZIP": zip.Deflate}),
+		Entry("fast compression", CompressionFast, nil, map[string]uint16{"a.txt": zip.Deflate, "b.ZIP": zip.Deflate}),
+		Entry("best compression", CompressionBest, nil, map[string]uint16{"a.txt": zip.Deflate, "b.ZIP": zip.Deflate}),
+		Entry("stored entries", CompressionStore, nil, map[string]uint16{"a.txt": zip.Store, "b.ZIP": zip.Store}),
+		Entry("stored extensions", CompressionBest, []string{".zip"}, map[string]uint16{"a.txt": zip.Deflate, "b.ZIP": zip.Store}),
+	)
+
+	It("ArchiveWithCompression fails on the invalid compression", func() {
+		err := ArchiveWithCompression(sourcePath, archivePath, nil, "fastest", nil)
+		Ω(err).Should(MatchError(`the "fastest" compression is invalid; expected one of the following values: store, fast, default, best`))
+	})
+
+	Describe("SetMtarCompression", func() {
+		AfterEach(func() {
+			Ω(SetMtarCompression("")).Should(Succeed())
+		})
+		It("sets the compression", func() {
+			Ω(SetMtarCompression(CompressionStore)).Should(Succeed())
+			Ω(GetMtarCompression()).Should(Equal(CompressionStore))
+			Ω(SetMtarCompression("")).Should(Succeed())
+			Ω(GetMtarCompression()).Should(Equal(CompressionDefault))
+		})
+		It("fails on the invalid compression", func() {
+			Ω(SetMtarCompression("none")).Should(HaveOccurred())
+			Ω(GetMtarCompression()).Should(Equal(CompressionDefault))
+		})
+	})
+})
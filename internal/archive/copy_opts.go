@@ -0,0 +1,282 @@
+package dir
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/SAP/cloud-mta-build-tool/internal/logs"
+)
+
+// copyMapping - a file or folder to create in the target folder
+type copyMapping struct {
+	// sourceRel - the slash-separated path of the entry relative to the source folder or to the archive root
+	sourceRel string
+	// targetRel - the slash-separated path of the entry relative to the target folder
+	targetRel string
+	isDir     bool
+	mode      os.FileMode
+	open      func() (io.ReadCloser, error)
+}
+
+// CopyByPatternsWithOptions - copy files/directories according to patterns, skipping the entries that match the exclude patterns
+// and renaming the copied entries; the keys of the rename map are the paths of the copied entries relative to the target folder
+// and the values are their new paths. If extract is true, the source is a zip archive (zip, jar or war) and the entries
+// matching the patterns are unpacked to the target folder; all the archive entries are unpacked if there are no patterns.
+func CopyByPatternsWithOptions(source, target string, patterns []string, exclude []string, rename map[string]string, extract bool) error {
+	if !extract && len(exclude) == 0 && len(rename) == 0 {
+		return CopyByPatterns(source, target, patterns)
+	}
+	if extract && len(patterns) == 0 {
+		patterns = []string{"*"}
+	}
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	logs.Logger.Infof(copyByPatternMsg, patterns[0], source, target)
+	source, err := FindPath(source)
+	if err != nil {
+		return err
+	}
+	if extract {
+		var reader *zip.ReadCloser
+		reader, err = zip.OpenReader(source)
+		if err != nil {
+			return errors.Wrapf(err, extractFailedOnOpenMsg, source)
+		}
+		defer func() {
+			_ = reader.Close()
+		}()
+		return copyMappings(source, target, getArchiveMappings(reader, patterns), patterns, exclude, rename)
+	}
+	mappings, err := getFolderMappings(source, patterns)
+	if err != nil {
+		return errors.Wrapf(err, copyByPatternFailedOnMatchMsg, patterns[0], source, target, patterns[0])
+	}
+	return copyMappings(source, target, mappings, patterns, exclude, rename)
+}
+
+// getFolderMappings - gets the files and folders that match the patterns in the source folder;
+// the entry that matches the pattern is copied to the target folder by its name, as CopyByPatterns does
+func getFolderMappings(source string, patterns []string) ([]copyMapping, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, err
+	}
+	var mappings []copyMapping
+	for _, pattern := range patterns {
+		var entries []string
+		if !info.IsDir() && pattern == "*" {
+			entries = []string{source}
+		} else {
+			entries, err = filepath.Glob(filepath.Join(source, strings.Replace(pattern, "./", "", -1)))
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, entry := range entries {
+			baseDir := filepath.Dir(entry)
+			err = filepath.Walk(entry, func(entryPath string, entryInfo os.FileInfo, err error) error {
+				if err != nil {
+					return err
+				}
+				if entryInfo.Mode()&os.ModeSymlink != 0 {
+					logs.Logger.Infof(skipSymbolicLinkMsg, source, baseDir, entryPath)
+					return nil
+				}
+				targetRel, err := filepath.Rel(baseDir, entryPath)
+				if err != nil {
+					return err
+				}
+				sourceRel := filepath.Base(entryPath)
+				if info.IsDir() {
+					sourceRel, err = filepath.Rel(source, entryPath)
+					if err != nil {
+						return err
+					}
+				}
+				filePath := entryPath
+				mappings = append(mappings, copyMapping{
+					sourceRel: filepath.ToSlash(sourceRel),
+					targetRel: filepath.ToSlash(targetRel),
+					isDir:     entryInfo.IsDir(),
+					mode:      entryInfo.Mode(),
+					open: func() (io.ReadCloser, error) {
+						return os.Open(filePath)
+					},
+				})
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return mappings, nil
+}
+
+// getArchiveMappings - gets the archive entries that match the patterns; the patterns are matched against the archive paths
+// the same way they are matched against the folder paths: the matching entry is unpacked to the target folder by its name
+func getArchiveMappings(reader *zip.ReadCloser, patterns []string) []copyMapping {
+	var mappings []copyMapping
+	for _, file := range reader.File {
+		sourceRel := strings.TrimSuffix(path.Clean("/" + file.Name)[1:], "/")
+		if sourceRel == "" {
+			continue
+		}
+		targetRel, ok := matchArchiveEntry(sourceRel, patterns)
+		if !ok {
+			continue
+		}
+		zipFile := file
+		mappings = append(mappings, copyMapping{
+			sourceRel: sourceRel,
+			targetRel: targetRel,
+			isDir:     file.FileInfo().IsDir(),
+			mode:      file.Mode(),
+			open:      zipFile.Open,
+		})
+	}
+	return mappings
+}
+
+// matchArchiveEntry - checks if the archive entry or one of its parent folders matches one of the patterns
+// and returns the path of the entry relative to the target folder
+func matchArchiveEntry(sourceRel string, patterns []string) (string, bool) {
+	parts := strings.Split(sourceRel, "/")
+	for _, pattern := range patterns {
+		pattern = strings.Trim(strings.Replace(filepath.ToSlash(pattern), "./", "", -1), "/")
+		for i := range parts {
+			prefix := strings.Join(parts[:i+1], "/")
+			if matched, _ := path.Match(pattern, prefix); matched {
+				return strings.Join(parts[i:], "/"), true
+			}
+		}
+	}
+	return "", false
+}
+
+// copyMappings - creates the files and folders in the target folder, skipping the excluded entries
+func copyMappings(source, target string, mappings []copyMapping, patterns []string, exclude []string, rename map[string]string) error {
+	err := CreateDirIfNotExist(target)
+	if err != nil {
+		return errors.Wrapf(err, copyByPatternFailedOnCreateMsg, patterns[0], source, target, target)
+	}
+	renames := getRenames(rename)
+	for _, mapping := range mappings {
+		if isExcluded(mapping.sourceRel, exclude) {
+			continue
+		}
+		targetRel := applyRename(mapping.targetRel, renames)
+		targetPath := filepath.Join(target, filepath.FromSlash(targetRel))
+		if rel, err := filepath.Rel(target, targetPath); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return errors.Errorf(copyFailedOnTargetPathMsg, mapping.sourceRel, source, target)
+		}
+		err = copyMappingEntry(mapping, targetPath)
+		if err != nil {
+			return errors.Wrapf(err, copyFailedMsg, patterns[0], source, target, mapping.sourceRel, targetPath)
+		}
+	}
+	return nil
+}
+
+func copyMappingEntry(mapping copyMapping, targetPath string) (rerr error) {
+	if mapping.isDir {
+		return CreateDirIfNotExist(targetPath)
+	}
+	err := CreateDirIfNotExist(filepath.Dir(targetPath))
+	if err != nil {
+		return err
+	}
+	in, err := mapping.open()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		rerr = CloseFile(in, rerr)
+	}()
+	mode := mapping.mode.Perm()
+	if mode == 0 {
+		mode = 0644
+	}
+	out, err := os.OpenFile(targetPath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		rerr = CloseFile(out, rerr)
+	}()
+	_, err = io.Copy(out, in)
+	return err
+}
+
+// isExcluded - checks if the entry or one of its parent folders matches one of the exclude patterns;
+// the pattern is matched against the path relative to the source and against the entry name
+func isExcluded(sourceRel string, exclude []string) bool {
+	parts := strings.Split(sourceRel, "/")
+	for _, pattern := range exclude {
+		pattern = strings.Trim(strings.Replace(filepath.ToSlash(pattern), "./", "", -1), "/")
+		for i := range parts {
+			if matched, _ := path.Match(pattern, strings.Join(parts[:i+1], "/")); matched {
+				return true
+			}
+			if matched, _ := path.Match(pattern, parts[i]); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ParseRenameEntries - parses the "<path>=<new path>" rename entries to the rename map of CopyByPatternsWithOptions
+func ParseRenameEntries(entries []string) (map[string]string, error) {
+	rename := make(map[string]string)
+	for _, entry := range entries {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, errors.Errorf(wrongRenameEntryMsg, entry)
+		}
+		rename[parts[0]] = parts[1]
+	}
+	return rename, nil
+}
+
+type renameEntry struct {
+	from string
+	to   string
+}
+
+// getRenames - gets the rename entries sorted from the longest source path, so the most specific entry is applied first
+func getRenames(rename map[string]string) []renameEntry {
+	renames := make([]renameEntry, 0, len(rename))
+	for from, to := range rename {
+		renames = append(renames, renameEntry{
+			from: strings.Trim(path.Clean(filepath.ToSlash(from)), "/"),
+			to:   strings.Trim(path.Clean(filepath.ToSlash(to)), "/"),
+		})
+	}
+	sort.Slice(renames, func(i, j int) bool {
+		return len(renames[i].from) > len(renames[j].from)
+	})
+	return renames
+}
+
+// applyRename - replaces the renamed entry or parent folder in the target path
+func applyRename(targetRel string, renames []renameEntry) string {
+	for _, r := range renames {
+		if targetRel == r.from {
+			return r.to
+		}
+		if strings.HasPrefix(targetRel, r.from+"/") {
+			return r.to + strings.TrimPrefix(targetRel, r.from)
+		}
+	}
+	return targetRel
+}
@@ -0,0 +1,78 @@
+package dir
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Copy By Patterns With Options", func() {
+
+	AfterEach(func() {
+		Ω(os.RemoveAll(getFullPath("testdata", "result"))).Should(Succeed())
+		Ω(os.RemoveAll(getFullPath("testdata", "archive"))).Should(Succeed())
+	})
+
+	var _ = DescribeTable("Copy", func(patterns, exclude []string, rename map[string]string, expectedFiles []string) {
+		sourcePath := getFullPath("testdata", "testbuildparams", "ui2")
+		targetPath := getFullPath("testdata", "result")
+		Ω(CopyByPatternsWithOptions(sourcePath, targetPath, patterns, exclude, rename, false)).Should(Succeed())
+		validateFilesInDir(targetPath, expectedFiles)
+	},
+		Entry("without options", []string{"deep/*/inui2/another*"}, nil, nil,
+			[]string{"anotherfile.txt", "anotherfile2.txt"}),
+		Entry("exclude file by name", []string{"deep/*"}, []string{"anotherfile2.txt"}, nil,
+			[]string{"folder/", "folder/inui2/", "folder/inui2/anotherfile.txt"}),
+		Entry("exclude folder by path", []string{"webapp"}, []string{"webapp/*/*.js", "webapp/i18n", "webapp/css"}, nil,
+			[]string{"webapp/", "webapp/Component.js", "webapp/controller/", "webapp/index.html", "webapp/model/", "webapp/view/",
+				"webapp/view/View1.view.xml"}),
+		Entry("rename file", []string{"deep/*/inui2/another*"}, nil, map[string]string{"anotherfile.txt": "renamed/file.txt"},
+			[]string{"anotherfile2.txt", "renamed/", "renamed/file.txt"}),
+		Entry("rename folder", []string{"deep/folder"}, []string{"anotherfile2.txt"}, map[string]string{"folder/inui2": "ui2"},
+			[]string{"folder/", "ui2/", "ui2/anotherfile.txt"}),
+	)
+
+	var _ = DescribeTable("Extract", func(patterns, exclude []string, rename map[string]string, expectedFiles []string) {
+		archivePath := getFullPath("testdata", "archive", "ui2.zip")
+		Ω(Archive(getFullPath("testdata", "testbuildparams", "ui2", "deep"), archivePath, nil)).Should(Succeed())
+		targetPath := getFullPath("testdata", "result")
+		Ω(CopyByPatternsWithOptions(getFullPath("testdata", "archive", "*.zip"), targetPath, patterns, exclude, rename, true)).Should(Succeed())
+		validateFilesInDir(targetPath, expectedFiles)
+	},
+		Entry("all entries", nil, nil, nil,
+			[]string{"folder/", "folder/inui2/", "folder/inui2/anotherfile.txt", "folder/inui2/anotherfile2.txt"}),
+		Entry("entries matching the pattern", []string{"folder/*"}, nil, nil,
+			[]string{"inui2/", "inui2/anotherfile.txt", "inui2/anotherfile2.txt"}),
+		Entry("excluded and renamed entries", []string{"*/inui2/*"}, []string{"*2.txt"}, map[string]string{"anotherfile.txt": "file.txt"},
+			[]string{"file.txt"}),
+	)
+
+	It("fails when the archive to extract is a folder", func() {
+		sourcePath := getFullPath("testdata", "testbuildparams", "ui2")
+		Ω(CopyByPatternsWithOptions(sourcePath, getFullPath("testdata", "result"), nil, nil, nil, true)).Should(HaveOccurred())
+	})
+
+	It("fails when the renamed path is outside of the target folder", func() {
+		sourcePath := getFullPath("testdata", "testbuildparams", "ui2")
+		err := CopyByPatternsWithOptions(sourcePath, getFullPath("testdata", "result"), []string{"webapp/index.html"}, nil,
+			map[string]string{"index.html": "../index.html"}, false)
+		Ω(err).Should(HaveOccurred())
+	})
+
+	var _ = DescribeTable("ParseRenameEntries", func(entries []string, expected map[string]string, isError bool) {
+		rename, err := ParseRenameEntries(entries)
+		if isError {
+			Ω(err).Should(HaveOccurred())
+		} else {
+			Ω(err).Should(Succeed())
+			Ω(rename).Should(Equal(expected))
+		}
+	},
+		Entry("valid entries", []string{"a.txt=b.txt", "c=d=e"}, map[string]string{"a.txt": "b.txt", "c": "d=e"}, false),
+		Entry("no entries", nil, map[string]string{}, false),
+		Entry("entry without new path", []string{"a.txt="}, nil, true),
+		Entry("entry without separator", []string{"a.txt"}, nil, true),
+	)
+})
@@ -2,6 +2,8 @@ package dir
 
 import (
 	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -69,7 +71,26 @@ func RemoveIfExist(dir string) error {
 // to support the spec requirements
 // Source Path to be zipped
 // Target artifact
-func Archive(sourcePath, targetArchivePath string, ignore []string) (e error) {
+// The archive is reproducible when the SOURCE_DATE_EPOCH environment variable is set
+func Archive(sourcePath, targetArchivePath string, ignore []string) error {
+	return ArchiveWithCompression(sourcePath, targetArchivePath, ignore, CompressionDefault, nil)
+}
+
+// ArchiveWithCompression - archives the source path with the compression ("store", "fast", "default" or "best");
+// the files with the stored extensions, e.g. the nested archives, are stored without compression
+func ArchiveWithCompression(sourcePath, targetArchivePath string, ignore []string, compression string, storedExtensions []string) error {
+	modTime, reproducible, err := GetSourceDateEpoch()
+	if err != nil {
+		return err
+	}
+	err = createArchive(sourcePath, targetArchivePath, ignore, compression, storedExtensions)
+	if err != nil || !reproducible {
+		return err
+	}
+	return normalizeArchive(targetArchivePath, modTime)
+}
+
+func createArchive(sourcePath, targetArchivePath string, ignore []string, compression string, storedExtensions []string) (e error) {
 
 	// check that folder to be packed exist
 	info, err := fileInfoProvider.stat(sourcePath)
@@ -93,7 +114,10 @@ func Archive(sourcePath, targetArchivePath string, ignore []string) (e error) {
 	}()
 
 	// create archive writer
-	archive := zip.NewWriter(zipfile)
+	archive, err := newArchiveWriter(zipfile, compression, storedExtensions, getArchiveWorkers())
+	if err != nil {
+		return err
+	}
 	defer func() {
 		e = CloseFile(archive, e)
 	}()
@@ -116,6 +140,103 @@ func Archive(sourcePath, targetArchivePath string, ignore []string) (e error) {
 	return err
 }
 
+// HashTree - calculates the SHA-256 hash of the folder content: the relative paths, modes and contents of its files,
+// and the targets of its symbolic links. The entries matching the ignore patterns (relative to the folder)
+// and the excluded absolute paths are not part of the hash.
+func HashTree(sourcePath string, ignore []string, excluded []string) (string, error) {
+	ignoreMap, err := getIgnoredEntries(ignore, sourcePath)
+	if err != nil {
+		return "", err
+	}
+	for _, path := range excluded {
+		ignoreMap[path] = nil
+	}
+
+	hash := sha256.New()
+	err = filepath.Walk(sourcePath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if _, ok := ignoreMap[path]; ok {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		relPath := filepath.ToSlash(getRelativePath(path, sourcePath))
+		if fileInfoProvider.isSymbolicLink(info) {
+			linkedPath, e := fileInfoProvider.readlink(path)
+			if e != nil {
+				return errors.Wrapf(e, badSymLink, path)
+			}
+			_, e = fmt.Fprintf(hash, "link %s %s\n", relPath, filepath.ToSlash(linkedPath))
+			return e
+		}
+		if info.IsDir() {
+			_, e := fmt.Fprintf(hash, "dir %s\n", relPath)
+			return e
+		}
+		return hashFile(hash, path, relPath, info)
+	})
+	if err != nil {
+		return "", errors.Wrapf(err, hashFailedMsg, sourcePath)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// GetTreeState - gets the state of the folder content that is compared to detect changes by polling:
+// the relative paths of the files with their sizes, modes and modification times. The entries matching the ignore patterns
+// (relative to the folder) and the excluded absolute paths are skipped, and so are the entries removed while the folder is read.
+func GetTreeState(sourcePath string, ignore []string, excluded []string) (map[string]string, error) {
+	ignoreMap, err := getIgnoredEntries(ignore, sourcePath)
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range excluded {
+		ignoreMap[path] = nil
+	}
+
+	state := make(map[string]string)
+	err = filepath.Walk(sourcePath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path != sourcePath {
+				return nil
+			}
+			return err
+		}
+		if _, ok := ignoreMap[path]; ok {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.IsDir() {
+			state[filepath.ToSlash(getRelativePath(path, sourcePath))] = fmt.Sprintf("%o %d %d", info.Mode(), info.Size(), info.ModTime().UnixNano())
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, treeStateFailedMsg, sourcePath)
+	}
+	return state, nil
+}
+
+func hashFile(hash io.Writer, path, relPath string, info os.FileInfo) (e error) {
+	_, e = fmt.Fprintf(hash, "file %s %o %d\n", relPath, info.Mode().Perm(), info.Size())
+	if e != nil {
+		return e
+	}
+	file, e := os.Open(path)
+	if e != nil {
+		return e
+	}
+	defer func() {
+		e = CloseFile(file, e)
+	}()
+	_, e = io.Copy(hash, file)
+	return e
+}
+
 func getBaseDir(path string, info os.FileInfo) (string, error) {
 	var err error
 	regularInfo := info
@@ -173,7 +294,7 @@ func CloseFile(file io.Closer, err error) error {
 	return err
 }
 
-func walk(sourcePath string, baseDir, symLinkPathInZip, linkedPath string, archive *zip.Writer,
+func walk(sourcePath string, baseDir, symLinkPathInZip, linkedPath string, archive *archiveWriter,
 	symlinks map[string]bool,
 	ignore map[string]interface{}) error {
 
@@ -273,7 +394,7 @@ func dereferenceSymlink(path string, predecessors map[string]bool) (string, os.F
 	return linkedPath, linkedInfo, paths, nil
 }
 
-func addSymbolicLinkToArchive(path string, baseDir, parentSymLinkPath, parentLinkedPath string, archive *zip.Writer,
+func addSymbolicLinkToArchive(path string, baseDir, parentSymLinkPath, parentLinkedPath string, archive *archiveWriter,
 	predecessors map[string]bool, ignore map[string]interface{}) (e error) {
 
 	if symlinkReferencesPredecessor(path, predecessors) {
@@ -318,7 +439,7 @@ func deleteAddedPredecessors(predecessors map[string]bool, paths []string) {
 	}
 }
 
-func addToArchive(path string, pathInZip string, info os.FileInfo, archive *zip.Writer) (e error) {
+func addToArchive(path string, pathInZip string, info os.FileInfo, archive *archiveWriter) error {
 	header, err := zip.FileInfoHeader(info)
 	if err != nil {
 		return err
@@ -326,16 +447,24 @@ func addToArchive(path string, pathInZip string, info os.FileInfo, archive *zip.
 
 	header.Name = pathInZip
 	if !info.IsDir() {
-		header.Method = zip.Deflate
+		header.Method = archive.getMethod(pathInZip)
 	}
 
+	if archive.pipeline != nil {
+		return archive.addEntry(path, header, info.Size())
+	}
+	return archive.writeToArchive(path, header)
+}
+
+// writeToArchive - adds the header to the archive and compresses the file while writing it
+func (w *archiveWriter) writeToArchive(path string, header *zip.FileHeader) (e error) {
 	// add new header and file to archive
-	writer, err := archive.CreateHeader(header)
+	writer, err := w.CreateHeader(header)
 	if err != nil {
 		return err
 	}
 
-	if info.IsDir() {
+	if header.FileInfo().IsDir() {
 		return nil
 	}
 
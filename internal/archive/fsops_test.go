@@ -118,6 +118,97 @@ var _ = Describe("FSOPS", func() {
 		)
 	})
 
+	var _ = Describe("HashTree", func() {
+		var hashDir = getFullPath("testdata", "hashtree")
+
+		BeforeEach(func() {
+			Ω(os.MkdirAll(filepath.Join(hashDir, "sub"), os.ModePerm)).Should(Succeed())
+			Ω(ioutil.WriteFile(filepath.Join(hashDir, "a.txt"), []byte("a"), 0644)).Should(Succeed())
+			Ω(ioutil.WriteFile(filepath.Join(hashDir, "sub", "b.txt"), []byte("b"), 0644)).Should(Succeed())
+		})
+
+		AfterEach(func() {
+			Ω(os.RemoveAll(hashDir)).Should(Succeed())
+		})
+
+		It("returns the same hash for the same content", func() {
+			hash1, err := HashTree(hashDir, nil, nil)
+			Ω(err).Should(Succeed())
+			hash2, err := HashTree(hashDir, nil, nil)
+			Ω(err).Should(Succeed())
+			Ω(hash1).Should(Equal(hash2))
+			Ω(hash1).Should(HaveLen(64))
+		})
+		It("returns another hash when a file content is changed", func() {
+			hash1, err := HashTree(hashDir, nil, nil)
+			Ω(err).Should(Succeed())
+			Ω(ioutil.WriteFile(filepath.Join(hashDir, "sub", "b.txt"), []byte("c"), 0644)).Should(Succeed())
+			hash2, err := HashTree(hashDir, nil, nil)
+			Ω(err).Should(Succeed())
+			Ω(hash1).ShouldNot(Equal(hash2))
+		})
+		It("returns another hash when a file is renamed", func() {
+			hash1, err := HashTree(hashDir, nil, nil)
+			Ω(err).Should(Succeed())
+			Ω(os.Rename(filepath.Join(hashDir, "a.txt"), filepath.Join(hashDir, "c.txt"))).Should(Succeed())
+			hash2, err := HashTree(hashDir, nil, nil)
+			Ω(err).Should(Succeed())
+			Ω(hash1).ShouldNot(Equal(hash2))
+		})
+		It("does not hash ignored and excluded entries", func() {
+			hash1, err := HashTree(hashDir, []string{"*.log"}, []string{filepath.Join(hashDir, "sub")})
+			Ω(err).Should(Succeed())
+			Ω(ioutil.WriteFile(filepath.Join(hashDir, "x.log"), []byte("x"), 0644)).Should(Succeed())
+			Ω(ioutil.WriteFile(filepath.Join(hashDir, "sub", "b.txt"), []byte("c"), 0644)).Should(Succeed())
+			hash2, err := HashTree(hashDir, []string{"*.log"}, []string{filepath.Join(hashDir, "sub")})
+			Ω(err).Should(Succeed())
+			Ω(hash1).Should(Equal(hash2))
+		})
+		It("fails when the folder does not exist", func() {
+			_, err := HashTree(getFullPath("testdata", "notexists"), nil, nil)
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	var _ = Describe("GetTreeState", func() {
+		var stateDir = getFullPath("testdata", "treestate")
+
+		BeforeEach(func() {
+			Ω(os.MkdirAll(filepath.Join(stateDir, "node_modules", "lib"), os.ModePerm)).Should(Succeed())
+			Ω(ioutil.WriteFile(filepath.Join(stateDir, "a.txt"), []byte("a"), 0644)).Should(Succeed())
+			Ω(ioutil.WriteFile(filepath.Join(stateDir, "node_modules", "lib", "b.js"), []byte("b"), 0644)).Should(Succeed())
+		})
+
+		AfterEach(func() {
+			Ω(os.RemoveAll(stateDir)).Should(Succeed())
+		})
+
+		It("gets the states of the files", func() {
+			state, err := GetTreeState(stateDir, nil, nil)
+			Ω(err).Should(Succeed())
+			Ω(state).Should(HaveLen(2))
+			Ω(state).Should(HaveKey("a.txt"))
+			Ω(state).Should(HaveKey("node_modules/lib/b.js"))
+		})
+		It("gets another state when a file is changed", func() {
+			state1, err := GetTreeState(stateDir, nil, nil)
+			Ω(err).Should(Succeed())
+			Ω(ioutil.WriteFile(filepath.Join(stateDir, "a.txt"), []byte("changed"), 0644)).Should(Succeed())
+			state2, err := GetTreeState(stateDir, nil, nil)
+			Ω(err).Should(Succeed())
+			Ω(state1).ShouldNot(Equal(state2))
+		})
+		It("skips the ignored and excluded entries", func() {
+			state, err := GetTreeState(stateDir, []string{"node_modules"}, []string{filepath.Join(stateDir, "a.txt")})
+			Ω(err).Should(Succeed())
+			Ω(state).Should(BeEmpty())
+		})
+		It("fails when the folder does not exist", func() {
+			_, err := GetTreeState(getFullPath("testdata", "notexists"), nil, nil)
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
 	var _ = Describe("FindPath", func() {
 		It("returns file path for existing file", func() {
 			path := getFullPath("testdata", "findpath", "folder1", "file1.txt")
@@ -258,7 +349,7 @@ var _ = Describe("FSOPS", func() {
 				path = filepath.Join(path, pathElement)
 			}
 
-			err := addSymbolicLinkToArchive(path, getFullPath("testdata", "testsymlink"), "", "", archive,
+			err := addSymbolicLinkToArchive(path, getFullPath("testdata", "testsymlink"), "", "", &archiveWriter{Writer: archive},
 				make(map[string]bool), nil)
 			Ω(err).Should(HaveOccurred())
 			Ω(err.Error()).Should(Equal(fmt.Sprintf(recursiveSymLinkMsg, path)))
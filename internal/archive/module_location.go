@@ -25,6 +25,11 @@ func (ep *ModuleLoc) GetTargetTmpRoot() string {
 	return filepath.Dir(ep.loc.GetTarget())
 }
 
+// GetTargetCacheDir - gets the build cache path in the module target folder
+func (ep *ModuleLoc) GetTargetCacheDir() string {
+	return ep.loc.GetTargetCacheDir()
+}
+
 // GetSourceModuleDir - gets the absolute path to the module
 func (ep *ModuleLoc) GetSourceModuleDir(modulePath string) string {
 	return ep.loc.GetSourceModuleDir(modulePath)
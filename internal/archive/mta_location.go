@@ -24,6 +24,8 @@ const (
 	MtarFolder = "mta_archives"
 	// SBomTempFolderSuffix - sbom temporary folder suffix
 	SBomTempFolderSuffix = "_mta_sbom_tmp"
+	// CacheFolderSuffix - build cache folder suffix
+	CacheFolderSuffix = "_mta_build_cache"
 )
 
 // IMtaParser - MTA Parser interface
@@ -64,6 +66,7 @@ type ITargetPath interface {
 type ITargetModule interface {
 	GetTargetModuleDir(moduleName string) string
 	GetTargetTmpRoot() string
+	GetTargetCacheDir() string
 }
 
 // IModule - module interface
@@ -138,6 +141,14 @@ func (ep *Loc) GetTargetTmpDir() string {
 	return filepath.Join(target, file)
 }
 
+// GetTargetCacheDir gets the build cache directory path.
+// The subdirectory in the target folder is named as the source project folder suffixed with "_mta_build_cache".
+// It is a sibling of the temporary target directory, so it is not packed into the MTA archive and it is kept after the cleanup.
+func (ep *Loc) GetTargetCacheDir() string {
+	_, file := filepath.Split(ep.GetSource())
+	return filepath.Join(ep.GetTarget(), "."+file+CacheFolderSuffix)
+}
+
 // GetTargetTmpRoot gets the build results directory root path.
 func (ep *Loc) GetTargetTmpRoot() string {
 	return ep.GetTargetTmpDir()
@@ -241,11 +252,15 @@ func (ep *Loc) ParseFile() (*mta.MTA, error) {
 	return mtaFile, err
 }
 
-// GetExtensionFilePaths returns the MTA extension descriptor full paths
+// GetExtensionFilePaths returns the MTA extension descriptor full paths;
+// the extension descriptors tied to other build profiles are skipped
 func (ep *Loc) GetExtensionFilePaths() []string {
-	paths := make([]string, len(ep.ExtensionFileNames))
-	for i, fileName := range ep.ExtensionFileNames {
-		paths[i] = ep.GetMtaExtYamlPath(fileName)
+	paths := make([]string, 0, len(ep.ExtensionFileNames))
+	for _, fileName := range ep.ExtensionFileNames {
+		path := ep.GetMtaExtYamlPath(fileName)
+		if extensionInProfile(path) {
+			paths = append(paths, path)
+		}
 	}
 	return paths
 }
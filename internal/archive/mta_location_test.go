@@ -30,6 +30,10 @@ var _ = Describe("Path", func() {
 		location := Loc{SourcePath: getPath("xyz"), TargetPath: getPath("abc")}
 		Ω(location.GetTargetTmpDir()).Should(Equal(getPath("abc", ".xyz_mta_build_tmp")))
 	})
+	It("GetTargetCacheDir", func() {
+		location := Loc{SourcePath: getPath("xyz"), TargetPath: getPath("abc")}
+		Ω(location.GetTargetCacheDir()).Should(Equal(getPath("abc", ".xyz_mta_build_cache")))
+	})
 	It("GetTargetModuleDir", func() {
 		location := Loc{SourcePath: getPath("xyz"), TargetPath: getPath("abc")}
 		Ω(location.GetTargetModuleDir("mmm")).Should(
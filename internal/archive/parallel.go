@@ -0,0 +1,237 @@
+package dir
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"os"
+	"runtime"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// streamedEntrySize - the files of this size or bigger are compressed while writing them to the archive
+	// instead of compressing them in memory by the workers
+	streamedEntrySize = 32 * 1024 * 1024
+
+	zipVersion20      = 20
+	zipUTF8Flag       = 0x800
+	extTimeExtraID    = 0x5455
+	extTimeExtraSize  = 5
+	extTimeExtraFlags = 1
+)
+
+// archiveWorkers - the number of workers that read and compress the archive files in parallel;
+// 1, the default, means that the files are archived serially and 0 means the number of CPUs
+var archiveWorkers = 1
+
+// archiveEntry - a file or folder of the archive; the entries are written to the archive in the order they are added
+type archiveEntry struct {
+	path   string
+	header *zip.FileHeader
+	// data - the compressed content of the file; nil when the entry is written without the workers
+	data *bytes.Buffer
+	err  error
+	// done - closed when the entry is ready to be written
+	done chan struct{}
+}
+
+// archivePipeline - the workers compress the files of the queued entries, while the entries are written to the archive
+// in the queue order
+type archivePipeline struct {
+	jobs    chan *archiveEntry
+	entries chan *archiveEntry
+	written chan error
+	mutex   sync.Mutex
+	err     error
+}
+
+// SetArchiveWorkers - sets the number of workers that read and compress the archive files in parallel;
+// 0 means the number of CPUs and 1 means that the files are archived serially
+func SetArchiveWorkers(workers int) error {
+	if workers < 0 {
+		return errors.Errorf(wrongArchiveWorkersMsg, workers)
+	}
+	archiveWorkers = workers
+	return nil
+}
+
+// GetArchiveWorkers - gets the number of workers that read and compress the archive files in parallel;
+// 0 means the number of CPUs
+func GetArchiveWorkers() int {
+	return archiveWorkers
+}
+
+// getArchiveWorkers - gets the number of workers that archive the files; the reproducible archives are always created serially
+func getArchiveWorkers() int {
+	if _, reproducible, _ := GetSourceDateEpoch(); reproducible {
+		return 1
+	}
+	if archiveWorkers == 0 {
+		return runtime.NumCPU()
+	}
+	return archiveWorkers
+}
+
+func (p *archivePipeline) getErr() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.err
+}
+
+func (p *archivePipeline) setErr(err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.err = err
+}
+
+func (w *archiveWriter) startPipeline(workers int) {
+	pipeline := &archivePipeline{
+		jobs:    make(chan *archiveEntry, workers),
+		entries: make(chan *archiveEntry, 2*workers),
+		written: make(chan error, 1),
+	}
+	for i := 0; i < workers; i++ {
+		go compressEntries(pipeline.jobs, w.level)
+	}
+	go w.writeEntries(pipeline)
+	w.pipeline = pipeline
+}
+
+// addEntry - queues the entry for writing; the folders and the big files are written without the workers
+func (w *archiveWriter) addEntry(path string, header *zip.FileHeader, size int64) error {
+	err := w.pipeline.getErr()
+	if err != nil {
+		return err
+	}
+	entry := &archiveEntry{path: path, header: header, done: make(chan struct{})}
+	w.pipeline.entries <- entry
+	if header.FileInfo().IsDir() || size >= streamedEntrySize {
+		close(entry.done)
+		return nil
+	}
+	w.pipeline.jobs <- entry
+	return nil
+}
+
+// compressEntries - the worker reads and compresses the files of the queued entries
+func compressEntries(jobs <-chan *archiveEntry, level int) {
+	// the level is validated when the archive writer is created
+	compressor, _ := flate.NewWriter(nil, level)
+	for entry := range jobs {
+		entry.err = compressEntry(entry, compressor)
+		close(entry.done)
+	}
+}
+
+func compressEntry(entry *archiveEntry, compressor *flate.Writer) (e error) {
+	file, err := os.Open(entry.path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		e = CloseFile(file, e)
+	}()
+
+	data := &bytes.Buffer{}
+	checksum := crc32.NewIEEE()
+	var size int64
+	if entry.header.Method == zip.Deflate {
+		compressor.Reset(data)
+		size, err = io.Copy(io.MultiWriter(compressor, checksum), file)
+		if err == nil {
+			err = compressor.Close()
+		}
+	} else {
+		size, err = io.Copy(io.MultiWriter(data, checksum), file)
+	}
+	if err != nil {
+		return err
+	}
+	prepareRawHeader(entry.header, checksum.Sum32(), size, data.Len())
+	entry.data = data
+	return nil
+}
+
+// writeEntries - writes the queued entries to the archive in the queue order;
+// after the first failure the rest of the entries are skipped
+func (w *archiveWriter) writeEntries(pipeline *archivePipeline) {
+	var err error
+	for entry := range pipeline.entries {
+		<-entry.done
+		if err == nil {
+			err = entry.err
+			if err == nil {
+				err = w.writeEntry(entry)
+			}
+			if err != nil {
+				pipeline.setErr(err)
+			}
+		}
+		entry.data = nil
+	}
+	pipeline.written <- err
+}
+
+func (w *archiveWriter) writeEntry(entry *archiveEntry) error {
+	if entry.data == nil {
+		return w.writeToArchive(entry.path, entry.header)
+	}
+	writer, err := w.CreateRaw(entry.header)
+	if err != nil {
+		return err
+	}
+	_, err = entry.data.WriteTo(writer)
+	return err
+}
+
+// prepareRawHeader - sets the header fields of the compressed file, including the fields
+// that zip.Writer.CreateHeader sets and zip.Writer.CreateRaw does not
+func prepareRawHeader(header *zip.FileHeader, checksum uint32, size int64, compressedSize int) {
+	header.CRC32 = checksum
+	header.UncompressedSize64 = uint64(size)
+	header.CompressedSize64 = uint64(compressedSize)
+	header.CreatorVersion = header.CreatorVersion&0xff00 | zipVersion20
+	header.ReaderVersion = zipVersion20
+	if !isASCII(header.Name) && utf8.ValidString(header.Name) {
+		header.Flags |= zipUTF8Flag
+	}
+	if !header.Modified.IsZero() {
+		header.ModifiedDate, header.ModifiedTime = toMsDosTime(header.Modified)
+		extra := make([]byte, 9)
+		binary.LittleEndian.PutUint16(extra, extTimeExtraID)
+		binary.LittleEndian.PutUint16(extra[2:], extTimeExtraSize)
+		extra[4] = extTimeExtraFlags
+		binary.LittleEndian.PutUint32(extra[5:], uint32(header.Modified.Unix()))
+		header.Extra = append(header.Extra, extra...)
+	}
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// Close - waits for the queued entries to be written and writes the central directory of the archive
+func (w *archiveWriter) Close() error {
+	if w.pipeline != nil {
+		close(w.pipeline.jobs)
+		close(w.pipeline.entries)
+		err := <-w.pipeline.written
+		w.pipeline = nil
+		if err != nil {
+			return err
+		}
+	}
+	return w.Writer.Close()
+}
@@ -0,0 +1,198 @@
+package dir
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// archiveContent - the names, modes, modification times and contents of the archive entries in the archive order
+type archiveContent struct {
+	names    []string
+	modes    map[string]os.FileMode
+	modified map[string]time.Time
+	contents map[string]string
+}
+
+func readArchiveContent(archivePath string) archiveContent {
+	reader, err := zip.OpenReader(archivePath)
+	Ω(err).Should(Succeed())
+	defer reader.Close()
+	content := archiveContent{modes: make(map[string]os.FileMode), modified: make(map[string]time.Time), contents: make(map[string]string)}
+	for _, file := range reader.File {
+		content.names = append(content.names, file.Name)
+		content.modes[file.Name] = file.Mode()
+		content.modified[file.Name] = file.Modified.UTC()
+		r, err := file.Open()
+		Ω(err).Should(Succeed())
+		// reading the whole entry verifies its checksum
+		data, err := ioutil.ReadAll(r)
+		Ω(err).Should(Succeed())
+		Ω(r.Close()).Should(Succeed())
+		content.contents[file.Name] = string(data)
+	}
+	return content
+}
+
+var _ = Describe("Parallel archive", func() {
+
+	resultPath := getFullPath("testdata", "result")
+
+	AfterEach(func() {
+		Ω(SetArchiveWorkers(1)).Should(Succeed())
+		Ω(os.RemoveAll(resultPath)).Should(Succeed())
+	})
+
+	archiveWithWorkers := func(sourcePath string, workers int, compression string) archiveContent {
+		Ω(SetArchiveWorkers(workers)).Should(Succeed())
+		archivePath := filepath.Join(resultPath, fmt.Sprintf("%s_%d.zip", compression, workers))
+		Ω(ArchiveWithCompression(sourcePath, archivePath, nil, compression, []string{".zip"})).Should(Succeed())
+		return readArchiveContent(archivePath)
+	}
+
+	It("creates the same archive as the serial archiving", func() {
+		sourcePath := getFullPath("testdata", "mtahtml5")
+		serial := archiveWithWorkers(sourcePath, 1, CompressionDefault)
+		Ω(serial.names).ShouldNot(BeEmpty())
+		Ω(archiveWithWorkers(sourcePath, 4, CompressionDefault)).Should(Equal(serial))
+		Ω(archiveWithWorkers(sourcePath, 4, CompressionStore)).Should(Equal(serial))
+	})
+
+	It("archives the stored extensions and the non-ASCII names", func() {
+		sourcePath := filepath.Join(resultPath, "source")
+		Ω(os.MkdirAll(filepath.Join(sourcePath, "földer"), os.ModePerm)).Should(Succeed())
+		Ω(ioutil.WriteFile(filepath.Join(sourcePath, "földer", "ä.txt"), []byte("aaaaaaaaaaaaaaaa"), 0644)).Should(Succeed())
+		Ω(ioutil.WriteFile(filepath.Join(sourcePath, "b.zip"), []byte("bbbbbbbbbbbbbbbb"), 0755)).Should(Succeed())
+		Ω(ioutil.WriteFile(filepath.Join(sourcePath, "empty.txt"), []byte{}, 0644)).Should(Succeed())
+		serial := archiveWithWorkers(sourcePath, 1, CompressionBest)
+		Ω(archiveWithWorkers(sourcePath, 3, CompressionBest)).Should(Equal(serial))
+
+		reader, err := zip.OpenReader(filepath.Join(resultPath, "best_3.zip"))
+		Ω(err).Should(Succeed())
+		defer reader.Close()
+		methods := make(map[string]uint16)
+		for _, file := range reader.File {
+			methods[file.Name] = file.Method
+			if file.Name == "földer/ä.txt" {
+				Ω(file.Flags & zipUTF8Flag).ShouldNot(BeZero())
+			}
+		}
+		Ω(methods).Should(Equal(map[string]uint16{
+			"b.zip": zip.Store, "empty.txt": zip.Deflate, "földer/": zip.Store, "földer/ä.txt": zip.Deflate}))
+	})
+
+	It("fails when a queued file cannot be read", func() {
+		archive, err := newArchiveWriter(&bytes.Buffer{}, CompressionDefault, nil, 2)
+		Ω(err).Should(Succeed())
+		header := &zip.FileHeader{Name: "unknown.txt", Method: zip.Deflate}
+		Ω(archive.addEntry(getFullPath("testdata", "unknown.txt"), header, 0)).Should(Succeed())
+		Ω(archive.Close()).Should(HaveOccurred())
+	})
+
+	It("stops adding the entries after a failure", func() {
+		archive, err := newArchiveWriter(&bytes.Buffer{}, CompressionDefault, nil, 2)
+		Ω(err).Should(Succeed())
+		header := &zip.FileHeader{Name: "unknown.txt", Method: zip.Deflate}
+		Ω(archive.addEntry(getFullPath("testdata", "unknown.txt"), header, 0)).Should(Succeed())
+		Eventually(func() error {
+			return archive.addEntry(getFullPath("testdata", "mta.yaml"), &zip.FileHeader{Name: "mta.yaml", Method: zip.Deflate}, 0)
+		}).Should(HaveOccurred())
+		Ω(archive.Close()).Should(HaveOccurred())
+	})
+
+	Describe("SetArchiveWorkers", func() {
+		It("sets the number of workers", func() {
+			Ω(SetArchiveWorkers(3)).Should(Succeed())
+			Ω(GetArchiveWorkers()).Should(Equal(3))
+			Ω(getArchiveWorkers()).Should(Equal(3))
+		})
+		It("archives the files serially by default", func() {
+			Ω(GetArchiveWorkers()).Should(Equal(1))
+			Ω(getArchiveWorkers()).Should(Equal(1))
+		})
+		It("uses the number of CPUs when 0 workers are set", func() {
+			Ω(SetArchiveWorkers(0)).Should(Succeed())
+			Ω(GetArchiveWorkers()).Should(Equal(0))
+			Ω(getArchiveWorkers()).Should(BeNumerically(">", 0))
+		})
+		It("archives the files serially when the archives are reproducible", func() {
+			Ω(SetArchiveWorkers(4)).Should(Succeed())
+			Ω(os.Setenv(SourceDateEpochEnv, "1600000000")).Should(Succeed())
+			defer os.Unsetenv(SourceDateEpochEnv)
+			Ω(GetArchiveWorkers()).Should(Equal(4))
+			Ω(getArchiveWorkers()).Should(Equal(1))
+		})
+		It("fails on the negative number of workers", func() {
+			Ω(SetArchiveWorkers(-1)).Should(MatchError(`the "-1" number of archive workers is invalid; expected 0 for the number of CPUs or a positive number`))
+			Ω(GetArchiveWorkers()).Should(Equal(1))
+		})
+	})
+})
+
+// createBenchmarkSource - creates a folder with compressible files, similar to the build results of a big module
+func createBenchmarkSource(b *testing.B) string {
+	sourcePath, err := ioutil.TempDir("", "mbt_archive_benchmark")
+	if err != nil {
+		b.Fatal(err)
+	}
+	random := rand.New(rand.NewSource(1))
+	words := []string{"module", "build", "archive", "require", "function", "return", "const", "import", "export"}
+	for i := 0; i < 200; i++ {
+		folder := filepath.Join(sourcePath, fmt.Sprintf("folder%d", i%10))
+		err = os.MkdirAll(folder, os.ModePerm)
+		if err != nil {
+			b.Fatal(err)
+		}
+		var content bytes.Buffer
+		for content.Len() < 256*1024 {
+			content.WriteString(words[random.Intn(len(words))])
+			content.WriteByte(byte(' ' + random.Intn(16)))
+		}
+		err = ioutil.WriteFile(filepath.Join(folder, fmt.Sprintf("file%d.js", i)), content.Bytes(), 0644)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+	return sourcePath
+}
+
+func benchmarkArchive(b *testing.B, workers int) {
+	sourcePath := createBenchmarkSource(b)
+	defer os.RemoveAll(sourcePath)
+	archivePath := sourcePath + ".zip"
+	defer os.Remove(archivePath)
+	err := SetArchiveWorkers(workers)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer SetArchiveWorkers(1)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err = Archive(sourcePath, archivePath, nil)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkArchiveSerial(b *testing.B) {
+	benchmarkArchive(b, 1)
+}
+
+func BenchmarkArchiveParallel(b *testing.B) {
+	benchmarkArchive(b, 4)
+}
+
+func BenchmarkArchiveParallelCPUs(b *testing.B) {
+	benchmarkArchive(b, 0)
+}
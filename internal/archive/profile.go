@@ -0,0 +1,23 @@
+package dir
+
+import (
+	"io/ioutil"
+
+	"github.com/SAP/cloud-mta-build-tool/internal/buildconfig"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
+	"github.com/SAP/cloud-mta/mta"
+)
+
+// extensionInProfile - checks if the MTA extension file is included in the build by its "profiles" parameter;
+// the file that cannot be parsed is included, so its error is reported when the extensions are merged
+func extensionInProfile(path string) bool {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return true
+	}
+	ext, err := mta.UnmarshalExt(content)
+	if err != nil || ext.Parameters == nil {
+		return true
+	}
+	return buildconfig.InProfile(ext.Parameters[buildparams.ProfilesParam])
+}
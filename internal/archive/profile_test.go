@@ -0,0 +1,51 @@
+package dir
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/SAP/cloud-mta-build-tool/internal/buildconfig"
+)
+
+var _ = Describe("Profile", func() {
+
+	AfterEach(func() {
+		buildconfig.Reset()
+	})
+
+	Describe("extensions", func() {
+		wd, _ := os.Getwd()
+		ep := Loc{SourcePath: filepath.Join(wd, "testdata", "testext"), ExtensionFileNames: []string{"cf-mtaext.yaml", "trial.mtaext"}}
+
+		It("skips the extension file tied to another profile", func() {
+			Ω(buildconfig.SetProfile("prod")).Should(Succeed())
+			Ω(ep.GetExtensionFilePaths()).Should(Equal([]string{filepath.Join(wd, "testdata", "testext", "cf-mtaext.yaml")}))
+			mta, err := ep.ParseFile()
+			Ω(err).Should(Succeed())
+			module, err := mta.GetModuleByName("ui5app2")
+			Ω(err).Should(Succeed())
+			Ω(module.Parameters["memory"]).Should(Equal("512M"))
+		})
+
+		It("merges the extension file tied to the build profile", func() {
+			Ω(buildconfig.SetProfile("trial")).Should(Succeed())
+			Ω(ep.GetExtensionFilePaths()).Should(HaveLen(2))
+			mta, err := ep.ParseFile()
+			Ω(err).Should(Succeed())
+			module, err := mta.GetModuleByName("ui5app2")
+			Ω(err).Should(Succeed())
+			Ω(module.Parameters["memory"]).Should(Equal("128M"))
+		})
+
+		It("keeps the extension file that cannot be read, so its error is reported", func() {
+			Ω(buildconfig.SetProfile("trial")).Should(Succeed())
+			loc := Loc{SourcePath: filepath.Join(wd, "testdata", "testext"), ExtensionFileNames: []string{"unknown.mtaext"}}
+			Ω(loc.GetExtensionFilePaths()).Should(HaveLen(1))
+			_, err := loc.ParseFile()
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,155 @@
+package dir
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// SourceDateEpochEnv - the environment variable with the timestamp (seconds since the Unix epoch) of the entries
+	// of the reproducible archives; the archives are reproducible when the variable is set
+	SourceDateEpochEnv = "SOURCE_DATE_EPOCH"
+	// DefaultSourceDateEpoch - the timestamp of the entries of the reproducible archives when SOURCE_DATE_EPOCH is not set:
+	// 1980-01-01T00:00:00Z, the earliest time supported by the zip format
+	DefaultSourceDateEpoch = 315532800
+
+	metaInfPrefix = "META-INF/"
+	// utf8Flag - the general purpose flag of the zip entries with UTF-8 names
+	utf8Flag = 0x800
+)
+
+// SetReproducible - makes the archives created by the current process and its child processes reproducible;
+// the provided SOURCE_DATE_EPOCH environment variable is kept
+func SetReproducible() error {
+	if _, ok := os.LookupEnv(SourceDateEpochEnv); ok {
+		_, _, err := GetSourceDateEpoch()
+		return err
+	}
+	return os.Setenv(SourceDateEpochEnv, strconv.Itoa(DefaultSourceDateEpoch))
+}
+
+// GetSourceDateEpoch - gets the timestamp of the entries of the reproducible archives and indicates if the archives are reproducible
+func GetSourceDateEpoch() (time.Time, bool, error) {
+	value, ok := os.LookupEnv(SourceDateEpochEnv)
+	if !ok || strings.TrimSpace(value) == "" {
+		return time.Time{}, false, nil
+	}
+	seconds, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return time.Time{}, false, errors.Errorf(wrongSourceDateEpochMsg, value, SourceDateEpochEnv)
+	}
+	if seconds < DefaultSourceDateEpoch {
+		seconds = DefaultSourceDateEpoch
+	}
+	return time.Unix(seconds, 0).UTC(), true, nil
+}
+
+// normalizeArchive - rewrites the archive so that its content does not depend on the file system:
+// the entries are sorted by name with the META-INF folder first, the timestamps are set to modTime
+// and the permissions are normalized; the owner of the files is not stored in the archives.
+// The compressed content of the entries is copied as is.
+func normalizeArchive(archivePath string, modTime time.Time) (e error) {
+	reader, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return errors.Wrapf(err, normalizeArchiveFailedMsg, archivePath)
+	}
+	defer func() {
+		e = CloseFile(reader, e)
+	}()
+
+	files := append([]*zip.File{}, reader.File...)
+	sort.SliceStable(files, func(i, j int) bool {
+		return archiveEntryLess(files[i].Name, files[j].Name)
+	})
+
+	tmpPath := archivePath + ".tmp"
+	err = writeNormalizedArchive(tmpPath, files, modTime)
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return errors.Wrapf(err, normalizeArchiveFailedMsg, archivePath)
+	}
+	return os.Rename(tmpPath, archivePath)
+}
+
+func writeNormalizedArchive(path string, files []*zip.File, modTime time.Time) (e error) {
+	out, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		e = CloseFile(out, e)
+	}()
+	writer := zip.NewWriter(out)
+	defer func() {
+		e = CloseFile(writer, e)
+	}()
+
+	date, clock := toMsDosTime(modTime)
+	for _, file := range files {
+		header := &zip.FileHeader{
+			Name:               file.Name,
+			Method:             file.Method,
+			Flags:              file.Flags & utf8Flag,
+			CRC32:              file.CRC32,
+			CompressedSize64:   file.CompressedSize64,
+			UncompressedSize64: file.UncompressedSize64,
+			ReaderVersion:      20,
+			ModifiedDate:       date,
+			ModifiedTime:       clock,
+		}
+		header.SetMode(normalizeMode(file.Mode()))
+		w, err := writer.CreateRaw(header)
+		if err != nil {
+			return err
+		}
+		r, err := file.OpenRaw()
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(w, r)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// archiveEntryLess - the order of the reproducible archive entries: the META-INF folder entries,
+// required by the JAR specification, come first, followed by the rest of the entries sorted by name
+func archiveEntryLess(a, b string) bool {
+	aMeta := strings.HasPrefix(a, metaInfPrefix)
+	bMeta := strings.HasPrefix(b, metaInfPrefix)
+	if aMeta != bMeta {
+		return aMeta
+	}
+	return a < b
+}
+
+// normalizeMode - folders and executable files get the 0755 permissions and the rest of the files get the 0644 permissions
+func normalizeMode(mode os.FileMode) os.FileMode {
+	if mode.IsDir() {
+		return os.ModeDir | 0755
+	}
+	if mode&0111 != 0 {
+		return 0755
+	}
+	return 0644
+}
+
+// toMsDosTime - converts the time to the MS-DOS date and time format of the zip entries;
+// the times before 1980, which the format does not support, are converted to 1980-01-01
+func toMsDosTime(t time.Time) (uint16, uint16) {
+	if t.Year() < 1980 {
+		t = time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	date := uint16(t.Day() + int(t.Month())<<5 + (t.Year()-1980)<<9)
+	clock := uint16(t.Second()/2 + t.Minute()<<5 + t.Hour()<<11)
+	return date, clock
+}
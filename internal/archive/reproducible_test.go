@@ -0,0 +1,123 @@
+package dir
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Reproducible archives", func() {
+
+	sourcePath := getFullPath("testdata", "result", "source")
+
+	createSource := func(modTime time.Time, mode os.FileMode) {
+		Ω(os.MkdirAll(getFullPath("testdata", "result", "source", "META-INF"), os.ModePerm)).Should(Succeed())
+		Ω(os.MkdirAll(getFullPath("testdata", "result", "source", "a"), os.ModePerm)).Should(Succeed())
+		files := []string{
+			getFullPath("testdata", "result", "source", "META-INF", "MANIFEST.MF"),
+			getFullPath("testdata", "result", "source", "META-INF", "mtad.yaml"),
+			getFullPath("testdata", "result", "source", "a", "b.txt"),
+			getFullPath("testdata", "result", "source", "a.txt"),
+			getFullPath("testdata", "result", "source", "B.txt"),
+		}
+		for _, file := range files {
+			Ω(ioutil.WriteFile(file, []byte(file[len(sourcePath):]), mode)).Should(Succeed())
+			Ω(os.Chmod(file, mode)).Should(Succeed())
+			Ω(os.Chtimes(file, modTime, modTime)).Should(Succeed())
+		}
+	}
+
+	readEntries := func(path string) []*zip.File {
+		reader, err := zip.OpenReader(path)
+		Ω(err).Should(Succeed())
+		defer reader.Close()
+		return reader.File
+	}
+
+	AfterEach(func() {
+		Ω(os.Unsetenv(SourceDateEpochEnv)).Should(Succeed())
+		Ω(os.RemoveAll(getFullPath("testdata", "result"))).Should(Succeed())
+	})
+
+	It("creates identical archives from the sources with different timestamps and permissions", func() {
+		Ω(os.Setenv(SourceDateEpochEnv, "1600000000")).Should(Succeed())
+		createSource(time.Now(), 0600)
+		Ω(Archive(sourcePath, getFullPath("testdata", "result", "first.zip"), nil)).Should(Succeed())
+		createSource(time.Now().Add(-time.Hour), 0644)
+		Ω(Archive(sourcePath, getFullPath("testdata", "result", "second.zip"), nil)).Should(Succeed())
+
+		first, err := ioutil.ReadFile(getFullPath("testdata", "result", "first.zip"))
+		Ω(err).Should(Succeed())
+		second, err := ioutil.ReadFile(getFullPath("testdata", "result", "second.zip"))
+		Ω(err).Should(Succeed())
+		Ω(first).Should(Equal(second))
+
+		entries := readEntries(getFullPath("testdata", "result", "first.zip"))
+		names := make([]string, len(entries))
+		for i, entry := range entries {
+			names[i] = entry.Name
+			Ω(entry.Modified.Equal(time.Unix(1600000000, 0))).Should(BeTrue())
+		}
+		Ω(names).Should(Equal([]string{"META-INF/", "META-INF/MANIFEST.MF", "META-INF/mtad.yaml", "B.txt", "a.txt", "a/", "a/b.txt"}))
+		Ω(entries[1].Mode().Perm()).Should(Equal(os.FileMode(0644)))
+		Ω(entries[0].Mode().IsDir()).Should(BeTrue())
+	})
+
+	It("keeps the content of the archive entries", func() {
+		Ω(os.Setenv(SourceDateEpochEnv, "1600000000")).Should(Succeed())
+		createSource(time.Now(), 0755)
+		Ω(Archive(sourcePath, getFullPath("testdata", "result", "first.zip"), nil)).Should(Succeed())
+		archive, err := zip.OpenReader(getFullPath("testdata", "result", "first.zip"))
+		Ω(err).Should(Succeed())
+		defer archive.Close()
+		for _, entry := range archive.File {
+			if entry.Name == "a/b.txt" {
+				Ω(entry.Mode().Perm()).Should(Equal(os.FileMode(0755)))
+				reader, err := entry.Open()
+				Ω(err).Should(Succeed())
+				content, err := ioutil.ReadAll(reader)
+				Ω(err).Should(Succeed())
+				Ω(reader.Close()).Should(Succeed())
+				Ω(string(content)).Should(Equal(string(os.PathSeparator) + "a" + string(os.PathSeparator) + "b.txt"))
+			}
+		}
+	})
+
+	It("fails on the invalid SOURCE_DATE_EPOCH", func() {
+		Ω(os.Setenv(SourceDateEpochEnv, "yesterday")).Should(Succeed())
+		createSource(time.Now(), 0644)
+		Ω(Archive(sourcePath, getFullPath("testdata", "result", "first.zip"), nil)).Should(HaveOccurred())
+		Ω(SetReproducible()).Should(HaveOccurred())
+	})
+
+	Describe("GetSourceDateEpoch", func() {
+		It("is not reproducible when SOURCE_DATE_EPOCH is not set", func() {
+			_, reproducible, err := GetSourceDateEpoch()
+			Ω(err).Should(Succeed())
+			Ω(reproducible).Should(BeFalse())
+		})
+		It("uses the earliest zip time for the earlier timestamps", func() {
+			Ω(os.Setenv(SourceDateEpochEnv, "0")).Should(Succeed())
+			modTime, reproducible, err := GetSourceDateEpoch()
+			Ω(err).Should(Succeed())
+			Ω(reproducible).Should(BeTrue())
+			Ω(modTime).Should(Equal(time.Date(1980, 1, 1, 0, 0, 0, 0, time.UTC)))
+		})
+	})
+
+	Describe("SetReproducible", func() {
+		It("sets the default SOURCE_DATE_EPOCH", func() {
+			Ω(SetReproducible()).Should(Succeed())
+			Ω(os.Getenv(SourceDateEpochEnv)).Should(Equal("315532800"))
+		})
+		It("keeps the provided SOURCE_DATE_EPOCH", func() {
+			Ω(os.Setenv(SourceDateEpochEnv, "1600000000")).Should(Succeed())
+			Ω(SetReproducible()).Should(Succeed())
+			Ω(os.Getenv(SourceDateEpochEnv)).Should(Equal("1600000000"))
+		})
+	})
+})
@@ -28,8 +28,10 @@ const (
 
 	genMTADMsg            = `generating the "%s" file...`
 	genMTADTypeTypeCnvMsg = `could not generate the MTAD file when converting types according to the "%s" platform`
+	genMTADParamsMsg      = `could not generate the MTAD file when setting the parameters according to the "%s" platform`
 	genMTADMarshMsg       = `could not generate the MTAD file when marshalling the MTAD object`
 	genMTADWriteMsg       = `could not generate the MTAD file when writing`
+	genMTADRequiresMsg    = `the "%s" module requires the "%s" resource, which is excluded from the "%s" build profile; the requirement is removed from the MTAD file`
 
 	genMTARParsingMsg = `could not generate the MTA archive`
 	genMTARArchMsg    = `could not generate the MTA archive when archiving`
@@ -43,12 +45,33 @@ const (
 	multiBuildWithPathsConflictMsg = `could not save the build results of modules "%s" and "%s" in the specified target folder (%s) because of conflicting naming (%s); use the "build-artifact-name" build parameter to create a unique name for each module's build result or use the default target folder`
 	multiBuildFailedMsg            = `could not build the selected modules`
 	buildFailedOnCommandsMsg       = `could not get commands for the "%s" module`
+	buildFailedOnEnvMsg            = `could not get the environment variables for the "%s" module`
 	buildFailedOnDepsMsg           = `could not process dependencies for the "%s" module`
 	buildResultMsg                 = `the build results of the "%s" module will be packaged and saved in the "%s" folder`
 	buildSkippedMsg                = `the "%s" module was not built because the "no-source" build parameter is set to "true"`
+	buildSkippedOnProfileMsg       = `the "%s" module was not built because it is not included in the "%s" build profile`
 	buildFailedOnEmptyPathMsg      = `could not build the "%s" module because the mandatory "path" property is missing or empty`
 	buildFailedOnEmptyModuleMsg    = `the mandatory "module" flag is missing or empty`
 	buildFailedOnEmptyModulesMsg   = `the mandatory "modules" flag is missing or empty`
+	execHookMsg                    = `running the "%s" hook of the "%s" module...`
+	execHookFailedMsg              = `the "%s" hook of the "%s" module failed`
+	buildFailedOnCacheKeyMsg       = `could not calculate the build cache key of the "%s" module`
+	buildRestoredFromCacheMsg      = `the "%s" module was not built because its sources and build configuration are not changed; the cached build result is used`
+
+	reportFailedMsg            = `could not generate the build report`
+	reportGeneratedMsg         = `the build report generated at: %s`
+	reportWriteFailedMsg       = `could not write the "%s" build report file`
+	reportReadFailedMsg        = `could not read the "%s" build report file`
+	reportDirCreationFailedMsg = `could not create the temporary folder of the build report`
+
+	cacheStoreFailedMsg    = `could not store the build result of the "%s" module in the build cache: %s`
+	cacheFailedOnLocMsg    = `could not access the build cache when initializing the location`
+	cacheReadFailedMsg     = `could not read the "%s" build cache folder`
+	cacheEmptyMsg          = `the "%s" build cache is empty`
+	cacheCleanMsg          = `removing the "%s" build cache folder...`
+	cacheCleanFailedMsg    = `could not remove the "%s" build cache folder`
+	cacheModuleNotFoundMsg = `the "%s" module is not found in the "%s" build cache`
+	restoreFailedOnLocMsg  = `could not restore the "%s" module from the build cache when initializing the location`
 
 	packMsg                       = `packaging the "%s" module...`
 	packFailedOnLocMsg            = `could not package the "%s" module when initializing the location`
@@ -57,7 +80,9 @@ const (
 	packFailedOnTargetArtifactMsg = `could not package the "%s" module while getting the build artifact target path`
 	packFailedOnFolderCreationMsg = `could not package the "%s" module when creating the "%s" folder`
 	packFailedOnCopyMsg           = `could not package the "%s" module when copying the "%s" path to the "%s" path`
+	packSkippedOnProfileMsg       = `the "%s" module was not packaged because it is not included in the "%s" build profile`
 	packSkippedMsg                = `the "%s" module was not packaged because the "no-source" build parameter is set to "true"`
+	packFailedOnReportMsg         = `could not package the "%s" module when reporting the build artifact`
 	packFailedOnEmptyPathMsg      = `could not package the "%s" module because the mandatory "path" property is missing or empty`
 	// PackFailedOnArchMsg - message raised when packaging fails during archiving the module
 	PackFailedOnArchMsg = `could not package the "%s" module when archiving`
@@ -70,13 +95,20 @@ const (
 	copyDoneMsg               = `copied "%s"`
 	cleanupFailedMsg          = `could not clean up`
 
-	invalidPlatformMsg = `invalid target platform "%s"; supported platforms are: "cf", "neo", "xsa"`
+	invalidPlatformMsg = `invalid target platform "%s"; supported platforms are: %s`
 	adaptationMsg      = `could not adapt the "%s" module path property`
 
 	// UnsupportedPhaseMsg - message raised when phase of mta project build is wrong
-	UnsupportedPhaseMsg     = `the "%s" phase of MTA project build is invalid; supported phases: "pre", "post"`
-	execFailedMsg           = `could not build the MTA project`
-	removeFailedMsg         = `could not remove the "%s" file`
+	UnsupportedPhaseMsg = `the "%s" phase of MTA project build is invalid; supported phases: "pre", "post"`
+	execFailedMsg       = `could not build the MTA project`
+	removeFailedMsg     = `could not remove the "%s" file`
+	invalidEngineMsg    = `the "%s" build engine is invalid; supported engines: "make", "native"`
+
+	nativeModeNotSupportedMsg  = `the "%s" mode is not supported; supported values: "default" and "verbose"`
+	nativeBuildFailedOnLocMsg  = `could not build the MTA project when initializing the location`
+	nativeBuildNotScheduledMsg = `could not schedule the build of the "%s" module because its dependencies are not built`
+	nativeBuildStartMsg        = `scheduling the build of the "%s" module`
+
 	commandsMissingMsg      = `the "commands" property is missing in the "custom" builder`
 	commandsNotSupportedMsg = `the "commands" property is not supported by the "%s" builder`
 
@@ -85,6 +117,53 @@ const (
 	validationFailedOnLocMsg  = `could not validate when initializing the location`
 	validationFailedOnModeMsg = `could not validate when analyzing the validation mode`
 
+	wrongOutputMsg            = `the "%s" output format is invalid; supported formats: "table", "json", "yaml"`
+	mtarOpenFailedMsg         = `could not open the "%s" MTA archive`
+	mtarManifestReadFailedMsg = `could not read the manifest file of the "%s" MTA archive`
+	mtarMtadReadFailedMsg     = `could not read the deployment descriptor of the "%s" MTA archive`
+	mtarEntryNotFoundMsg      = `the "%s" entry does not exist in the archive`
+	mtarEntryReadFailedMsg    = `could not read the "%s" entry of the "%s" MTA archive`
+
+	signFailedMsg            = `could not sign the MTA archive`
+	signKeyReadFailedMsg     = `could not read the signing key`
+	signKeyNotPKCS8Msg       = `the signing key is not a PKCS#8 PEM private key of the RSA, ECDSA or Ed25519 type`
+	publicKeyReadFailedMsg   = `could not read the public key`
+	publicKeyNotPKIXMsg      = `the public key is not a PEM public key in the PKIX format`
+	signatureBlockInvalidMsg = `the signature block is not valid`
+
+	verifyNotSignedMsg             = `the archive is not signed; the "%s" entry does not exist`
+	verifySignatureInvalidMsg      = `the signature of the "%s" signature file does not match the public key of the signature block`
+	verifySignerUnknownMsg         = `the signer is not checked because the public key is not provided`
+	verifySignerMismatchMsg        = `the archive is signed by a different key than the provided public key`
+	verifyManifestDigestInvalidMsg = `the digest of the manifest does not match the "%s" signature file; the manifest was changed after signing`
+	verifyDigestMissingMsg         = `the entry has no digest in the manifest; the entry was added after signing`
+	verifyDigestMismatchMsg        = `the digest of the entry does not match the manifest; the entry was changed after signing`
+	verifyDigestEntryMissingMsg    = `the entry signed in the manifest does not exist in the archive`
+
+	unpackMsg               = `unpacking the "%s" MTA archive to the "%s" folder...`
+	unpackFailedMsg         = `could not unpack the "%s" MTA archive`
+	unpackFailedOnLocMsg    = `could not unpack the MTA archive when initializing the location`
+	unpackFailedOnTargetMsg = `could not unpack the MTA archive when reading the "%s" target folder`
+	unpackTargetNotEmptyMsg = `could not unpack the MTA archive because the "%s" target folder is not empty`
+
+	repackMsg               = `packing the "%s" folder to the MTA archive...`
+	repackFinishedMsg       = `the MTA archive generated at: %s`
+	repackFailedOnLocMsg    = `could not pack the MTA archive when initializing the location`
+	repackFailedOnMtadMsg   = `could not pack the MTA archive when reading the "%s" deployment descriptor`
+	repackInvalidLayoutMsg  = `could not pack the MTA archive because the layout of the "%s" folder is not valid`
+	repackPathNotFoundMsg   = `the "%s" path of the %s "%s" does not exist`
+	repackTargetInSourceMsg = `the "%s" target folder of the MTA archive is inside the packed folder`
+	repackWrongPathMsg      = `the "%v" path of the %s "%s" is not a string`
+
+	verifyFailedMsg              = `the "%s" MTA archive is not valid; %d errors found`
+	verifySucceededMsg           = `the "%s" MTA archive is valid`
+	verifyTmpDirFailedMsg        = `could not write the deployment descriptor to a temporary folder for the validation`
+	verifyEntryMissingMsg        = `the "%s" path referenced by the manifest does not exist in the archive`
+	verifyContentTypeMismatchMsg = `the "%s" content type of the "%s" path does not match the expected "%s" content type`
+	verifyContentTypeUnknownMsg  = `the content type of the "%s" path cannot be checked because its extension is unknown`
+	verifyModuleNotInManifestMsg = `the "%s" module of the deployment descriptor has no entry in the manifest`
+	verifyMtadParseFailedMsg     = `could not parse the deployment descriptor: %s`
+
 	mergeInfoMsg                 = `merging the "mta.yaml" file with the MTA extension descriptors...`
 	mergeNameRequiredMsg         = `could not find the mandatory parameter "target-file-name"`
 	mergeFailedOnFileCreationMsg = `the "%s" file already exists`
@@ -100,4 +179,34 @@ const (
 	createSBomTargetDirFailedMsg   = `create sbom file target path "%s" failed`
 	mvSBomToTargetDirFailedMsg     = `mv sbom file from "%s" to "%s" failed`
 	genSBomNotSupportedFileTypeMsg = `sbom file type %s is not supported at present`
+
+	// dry-run messages
+	dryRunFailedOnLocMsg = `could not provide the build plan when initializing the location`
+	planHeaderMsg        = `the build plan (dry run, nothing is executed):`
+	planNoSourceMsg      = `the "no-source" build parameter is set to "true"`
+	planProfileMsg       = `the module is not included in the "%s" build profile`
+	planNotSelectedMsg   = `the module is built only as a dependency of the selected modules`
+	planPlatformMsg      = `the module does not support the "%s" platform`
+
+	// watch messages
+	watchFailedOnLocMsg    = `could not watch the modules when initializing the location`
+	watchFailedOnModuleMsg = `could not read the changes of the "%s" module`
+	watchStartedMsg        = `watching the "%s" modules for changes; press Ctrl+C to stop...`
+	watchRebuildMsg        = `rebuilding the "%s" modules after the changes...`
+	watchWaitingMsg        = `waiting for changes...`
+	watchChangesSkippedMsg = `the changes written by the build of the "%s" modules are skipped`
+
+	// resolve messages
+	resolveFailedOnLocMsg      = `could not resolve the MTA project when initializing the location`
+	resolveFailedOnParseMsg    = `could not resolve the MTA project when parsing the MTA file and the extension descriptors`
+	resolveFailedOnPlatformMsg = `could not resolve the MTA project for the "%s" platform`
+	resolveWrongOutputMsg      = `the "%s" output format is invalid; supported formats: "yaml", "json"`
+	resolveInvalidParamMsg     = `the "%s" value is invalid; the "<name>=<value>" format is expected`
+	resolveEnvFileReadMsg      = `could not read the "%s" environment file`
+	resolveEnvFileLineMsg      = `the line %d of the "%s" environment file is invalid; the "<name>=<value>" format is expected`
+	unresolvedReferencesMsg    = "could not resolve the references of the MTA project:\n%s"
+	unresolvedParameterMsg     = `the "${%s}" placeholder of %s`
+	unresolvedPropertyMsg      = `the "~{%s}" reference of %s`
+	unresolvedNoProviderMsg    = `the "~{%s}" reference of %s has no provider; use the "~{<provider>/<property>}" form`
+	circularReferenceMsg       = `the "%s" reference of %s is circular`
 )
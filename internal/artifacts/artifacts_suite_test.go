@@ -13,7 +13,10 @@ import (
 	. "github.com/onsi/gomega"
 
 	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildconfig"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
 	"github.com/SAP/cloud-mta-build-tool/internal/logs"
+	"github.com/SAP/cloud-mta/mta"
 )
 
 func TestArtifacts(t *testing.T) {
@@ -25,6 +28,11 @@ var _ = BeforeSuite(func() {
 	logs.Logger = logs.NewLogger()
 })
 
+// the build configuration set by the specs is not kept for the next specs
+var _ = AfterEach(func() {
+	buildconfig.Reset()
+})
+
 func getTestPath(relPath ...string) string {
 	wd, _ := os.Getwd()
 	return filepath.Join(wd, "testdata", filepath.Join(relPath...))
@@ -77,3 +85,15 @@ func checkError(err error, msg string, args ...interface{}) {
 		Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(msg, args...)))
 	}
 }
+
+func decode(module *mta.Module) *buildparams.Module {
+	params, err := buildparams.Decode(module)
+	Ω(err).Should(Succeed())
+	return params
+}
+
+func decodeAll(m *mta.MTA) buildparams.Modules {
+	params, err := buildparams.DecodeAll(m)
+	Ω(err).Should(Succeed())
+	return params
+}
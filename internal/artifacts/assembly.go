@@ -29,7 +29,7 @@ func Assembly(source, mtaYamlFilename, target string, extensions []string, platf
 		return errors.Wrap(err, assemblyFailedOnMetaMsg)
 	}
 	// generate mtar
-	err = ExecuteGenMtar(source, mtaYamlFilename, target, strconv.FormatBool(target != ""), dir.Dep, extensions, mtarName, getWd)
+	err = ExecuteGenMtar(source, mtaYamlFilename, target, strconv.FormatBool(target != ""), dir.Dep, extensions, mtarName, "", getWd)
 	if err != nil {
 		return errors.Wrap(err, assemblyFailedOnMtarMsg)
 	}
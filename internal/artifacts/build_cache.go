@@ -0,0 +1,346 @@
+package artifacts
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildconfig"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildops"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
+	"github.com/SAP/cloud-mta-build-tool/internal/commands"
+	"github.com/SAP/cloud-mta-build-tool/internal/logs"
+	"github.com/SAP/cloud-mta-build-tool/internal/version"
+	"github.com/SAP/cloud-mta/mta"
+)
+
+const (
+	cacheEntryFileName   = "cache.json"
+	cacheArtifactsFolder = "artifact"
+	// cacheKeyDisplayLength - the number of the cache key characters shown by the cache list command
+	cacheKeyDisplayLength = 12
+)
+
+// cacheEntry - the build cache record of a module
+type cacheEntry struct {
+	Module string `json:"module"`
+	Key    string `json:"key"`
+	// Artifact - the path of the packed build result relative to the module folder in the temporary target folder
+	Artifact string    `json:"artifact"`
+	Created  time.Time `json:"created"`
+}
+
+// buildCache - the cache of the packed build results of the modules.
+// Each module result is stored with a key calculated from the module sources, build commands, build parameters,
+// build requires results and the tool version; the module build is skipped while the key is not changed.
+type buildCache struct {
+	dir string
+	// excluded - the absolute paths of the build outputs that are not part of the modules sources
+	excluded []string
+}
+
+// newBuildCache - creates the build cache in the target folder; returns nil if the build cache is disabled
+func newBuildCache(loc *dir.Loc) *buildCache {
+	if buildconfig.NoCache() {
+		return nil
+	}
+	excluded := []string{loc.GetTargetTmpDir(), loc.GetTargetCacheDir(), filepath.Join(loc.GetTarget(), dir.MtarFolder)}
+	if loc.GetTarget() != loc.GetSource() {
+		excluded = append(excluded, loc.GetTarget())
+	}
+	return &buildCache{dir: loc.GetTargetCacheDir(), excluded: excluded}
+}
+
+// newModuleBuildCache - creates the build cache of the stand alone module build in the module target folder;
+// the build results of the other modules in the default target folder are not part of the module sources
+func newModuleBuildCache(loc *dir.Loc, moduleLoc *dir.ModuleLoc) *buildCache {
+	cache := newBuildCache(loc)
+	if cache != nil && moduleLoc.GetTargetTmpRoot() != loc.GetSource() {
+		cache.excluded = append(cache.excluded, moduleLoc.GetTargetTmpRoot())
+	}
+	return cache
+}
+
+// getModuleKey - calculates the cache key of the module; the build requires of the module must be processed before
+func (c *buildCache) getModuleKey(mtaObj *mta.MTA, modulesParams buildparams.Modules, moduleLoc dir.IModule, module *mta.Module,
+	cmds []string, defaultBuildResult, platform string) (string, error) {
+
+	v, err := version.GetVersion()
+	if err != nil {
+		return "", err
+	}
+	params, err := yaml.Marshal(module.BuildParams)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "mbt %s\n", v.CliVersion)
+	fmt.Fprintf(hash, "module %s %s %s %s\n", module.Name, module.Type, module.Path, platform)
+	fmt.Fprintf(hash, "build-result %s\n", defaultBuildResult)
+	// the stand alone module build and the MTA build put the packed build result in different target folders
+	fmt.Fprintf(hash, "target %s\n", moduleLoc.GetTargetModuleDir(module.Name))
+	for _, command := range cmds {
+		fmt.Fprintf(hash, "command %s\n", command)
+	}
+	fmt.Fprintf(hash, "build-parameters %s\n", params)
+	// the packed build results of the reproducible builds are normalized with the SOURCE_DATE_EPOCH timestamp
+	epoch, reproducible, err := dir.GetSourceDateEpoch()
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(hash, "reproducible %t %d\n", reproducible, epoch.Unix())
+	// the resolved environment variables can refer to the variables of the build process
+	buildParams := modulesParams[module.Name]
+	env, err := commands.GetModuleExecEnv(mtaObj, module, buildParams)
+	if err != nil {
+		return "", err
+	}
+	for _, v := range env {
+		fmt.Fprintf(hash, "env %s\n", v)
+	}
+
+	// the results of the required modules are copied to the module target path before the build
+	for _, req := range buildParams.Requires {
+		_, targetPath, _, err := buildops.GetRequiresArtifacts(moduleLoc, mtaObj, modulesParams, &req, module.Name, true)
+		if err != nil {
+			return "", err
+		}
+		reqHash := "-"
+		if _, err = os.Stat(targetPath); err == nil {
+			reqHash, err = dir.HashTree(targetPath, nil, c.excluded)
+			if err != nil {
+				return "", err
+			}
+		}
+		fmt.Fprintf(hash, "requires %s %s\n", req.Name, reqHash)
+	}
+
+	sourceHash, err := dir.HashTree(moduleLoc.GetSourceModuleDir(module.Path), buildParams.Ignore, c.excluded)
+	if err != nil {
+		return "", err
+	}
+	fmt.Fprintf(hash, "source %s\n", sourceHash)
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// get - returns the cached build result of the module if it was stored with the same key
+func (c *buildCache) get(moduleName, key string) (*cacheEntry, string, bool) {
+	entry, err := readCacheEntry(filepath.Join(c.dir, moduleName))
+	if err != nil || entry.Key != key {
+		return nil, "", false
+	}
+	artifact := filepath.Join(c.dir, moduleName, cacheArtifactsFolder, entry.Artifact)
+	if _, err = os.Stat(artifact); err != nil {
+		return nil, "", false
+	}
+	return entry, artifact, true
+}
+
+// put - stores the packed build result of the module with the key, replacing the previous result of the module
+func (c *buildCache) put(moduleName, key, moduleTargetDir, artifact string) error {
+	relArtifact, err := filepath.Rel(moduleTargetDir, artifact)
+	if err != nil {
+		return err
+	}
+	moduleCacheDir := filepath.Join(c.dir, moduleName)
+	err = os.RemoveAll(moduleCacheDir)
+	if err != nil {
+		return err
+	}
+	cachedArtifact := filepath.Join(moduleCacheDir, cacheArtifactsFolder, relArtifact)
+	err = dir.CreateDirIfNotExist(filepath.Dir(cachedArtifact))
+	if err != nil {
+		return err
+	}
+	err = dir.CopyFile(artifact, cachedArtifact)
+	if err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(cacheEntry{Module: moduleName, Key: key, Artifact: relArtifact, Created: time.Now()}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(moduleCacheDir, cacheEntryFileName), content, 0644)
+}
+
+func readCacheEntry(moduleCacheDir string) (*cacheEntry, error) {
+	content, err := ioutil.ReadFile(filepath.Join(moduleCacheDir, cacheEntryFileName))
+	if err != nil {
+		return nil, err
+	}
+	entry := &cacheEntry{}
+	err = json.Unmarshal(content, entry)
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// getCacheEntries - gets the build cache records of all the modules sorted by module name;
+// folders without a valid record are skipped
+func getCacheEntries(cacheDir string) ([]*cacheEntry, error) {
+	files, err := ioutil.ReadDir(cacheDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []*cacheEntry
+	for _, file := range files {
+		if !file.IsDir() {
+			continue
+		}
+		entry, err := readCacheEntry(filepath.Join(cacheDir, file.Name()))
+		if err == nil {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Module < entries[j].Module
+	})
+	return entries, nil
+}
+
+// restoreModuleFromCache - copies the cached build result of the module to the temporary target folder
+// if the module cache key has not changed since the module was built; returns the path of the restored build result
+func restoreModuleFromCache(cache *buildCache, moduleLoc dir.IModule, moduleName, key string, buildResults map[string]string) (string, bool, error) {
+	entry, cachedArtifact, ok := cache.get(moduleName, key)
+	if !ok {
+		return "", false, nil
+	}
+	targetArtifact := filepath.Join(moduleLoc.GetTargetModuleDir(moduleName), entry.Artifact)
+	conflictingModule, ok := buildResults[targetArtifact]
+	if ok {
+		return "", false, fmt.Errorf(multiBuildWithPathsConflictMsg, conflictingModule, moduleName, filepath.Dir(targetArtifact), filepath.Base(targetArtifact))
+	}
+	buildResults[targetArtifact] = moduleName
+
+	err := copyModuleArchiveToResultDir(cachedArtifact, targetArtifact, moduleName)
+	if err != nil {
+		return "", false, err
+	}
+	return targetArtifact, true, nil
+}
+
+// storeModuleInCache - stores the packed build result of the module in the cache;
+// the key is calculated after the build, so the next build finds the module sources in the same state
+func storeModuleInCache(cache *buildCache, mtaObj *mta.MTA, modulesParams buildparams.Modules, moduleLoc dir.IModule, module *mta.Module,
+	commands []string, defaultBuildResult, platform string) {
+
+	if !isModulePacked(modulesParams[module.Name], platform) {
+		return
+	}
+	targetArtifact, _, err := buildops.GetModuleTargetArtifactPath(moduleLoc, false, module, modulesParams[module.Name], defaultBuildResult, true)
+	if err == nil {
+		var key string
+		key, err = cache.getModuleKey(mtaObj, modulesParams, moduleLoc, module, commands, defaultBuildResult, platform)
+		if err == nil {
+			err = cache.put(module.Name, key, moduleLoc.GetTargetModuleDir(module.Name), targetArtifact)
+		}
+	}
+	if err != nil {
+		// the build result is packed, so a failure to cache it must not fail the build
+		logs.Logger.Warnf(cacheStoreFailedMsg, module.Name, err.Error())
+	}
+}
+
+// ExecuteCacheList - lists the modules build results stored in the build cache
+func ExecuteCacheList(source, mtaYamlFilename, target string, wdGetter func() (string, error)) error {
+	loc, err := dir.Location(source, mtaYamlFilename, target, dir.Dev, nil, wdGetter)
+	if err != nil {
+		return errors.Wrap(err, cacheFailedOnLocMsg)
+	}
+	cacheDir := loc.GetTargetCacheDir()
+	entries, err := getCacheEntries(cacheDir)
+	if err != nil {
+		return errors.Wrapf(err, cacheReadFailedMsg, cacheDir)
+	}
+	if len(entries) == 0 {
+		logs.Logger.Infof(cacheEmptyMsg, cacheDir)
+		return nil
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "MODULE\tKEY\tARTIFACT\tCREATED")
+	for _, entry := range entries {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", entry.Module, getDisplayedCacheKey(entry.Key), filepath.ToSlash(entry.Artifact),
+			entry.Created.Format(time.RFC3339))
+	}
+	return writer.Flush()
+}
+
+// getDisplayedCacheKey - gets the beginning of the cache key shown by the cache list command;
+// the key of an edited or corrupted cache record can be shorter
+func getDisplayedCacheKey(key string) string {
+	if len(key) > cacheKeyDisplayLength {
+		return key[:cacheKeyDisplayLength]
+	}
+	return key
+}
+
+// ExecuteCacheClean - removes the build results of the specified modules from the build cache;
+// the whole cache is removed if no modules are specified
+func ExecuteCacheClean(source, mtaYamlFilename, target string, modules []string, wdGetter func() (string, error)) error {
+	loc, err := dir.Location(source, mtaYamlFilename, target, dir.Dev, nil, wdGetter)
+	if err != nil {
+		return errors.Wrap(err, cacheFailedOnLocMsg)
+	}
+	cacheDir := loc.GetTargetCacheDir()
+	paths := []string{cacheDir}
+	if len(modules) > 0 {
+		paths, err = getModulesCacheDirs(cacheDir, modules)
+		if err != nil {
+			return err
+		}
+	}
+	for _, path := range paths {
+		logs.Logger.Infof(cacheCleanMsg, path)
+		err = os.RemoveAll(path)
+		if err != nil {
+			return errors.Wrapf(err, cacheCleanFailedMsg, path)
+		}
+	}
+	return nil
+}
+
+// getModulesCacheDirs - gets the build cache folders of the modules; only the modules stored in the build cache are accepted,
+// so the module names cannot point outside of the build cache folder
+func getModulesCacheDirs(cacheDir string, modules []string) ([]string, error) {
+	entries, err := getCacheEntries(cacheDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, cacheReadFailedMsg, cacheDir)
+	}
+	cached := make(map[string]bool)
+	for _, entry := range entries {
+		cached[entry.Module] = true
+	}
+	var paths []string
+	for _, module := range modules {
+		if !isCacheModuleName(module) || !cached[module] {
+			return nil, errors.Errorf(cacheModuleNotFoundMsg, module, cacheDir)
+		}
+		paths = append(paths, filepath.Join(cacheDir, module))
+	}
+	return paths, nil
+}
+
+// isCacheModuleName - checks that the module name can be used as the name of a build cache folder
+func isCacheModuleName(module string) bool {
+	return module != "" && module != "." && module != ".." && !filepath.IsAbs(module) &&
+		!strings.ContainsAny(module, `/\`) && filepath.Base(module) == module
+}
@@ -0,0 +1,230 @@
+package artifacts
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildconfig"
+	"github.com/SAP/cloud-mta-build-tool/internal/commands"
+)
+
+var _ = Describe("BuildCache", func() {
+
+	getBuilds := func() []string {
+		content, err := ioutil.ReadFile(getTestPath("mta_build_cache", "builds.log"))
+		Ω(err).Should(Succeed())
+		return strings.Fields(string(content))
+	}
+	build := func(modules ...string) {
+		for _, module := range modules {
+			Ω(ExecuteBuild(getTestPath("mta_build_cache"), "", getResultPath(), nil, module, "cf", "", os.Getwd)).Should(Succeed())
+		}
+	}
+	// buildVerbose - executes the module build steps of the verbose Makefile
+	buildVerbose := func(modules ...string) {
+		for _, module := range modules {
+			restored, err := ExecuteModuleRestore(getTestPath("mta_build_cache"), "", getResultPath(), nil, module, "cf", "", os.Getwd)
+			Ω(err).Should(Succeed())
+			if restored {
+				continue
+			}
+			loc, err := dir.Location(getTestPath("mta_build_cache"), "", getResultPath(), dir.Dev, nil, os.Getwd)
+			Ω(err).Should(Succeed())
+			m, _, mCmd, _, err := commands.GetModuleAndCommands(loc, module)
+			Ω(err).Should(Succeed())
+			Ω(ExecuteModuleCommands(mCmd, nil, "", loc.GetSourceModuleDir(m.Path), module, "")).Should(Succeed())
+			Ω(ExecutePack(getTestPath("mta_build_cache"), "", getResultPath(), nil, module, "cf", "", os.Getwd)).Should(Succeed())
+		}
+	}
+	buildSolo := func(modules ...string) {
+		Ω(ExecuteSoloBuild(getTestPath("mta_build_cache"), "", getResultPath(), nil, modules, false, false, "cf", "", os.Getwd)).Should(Succeed())
+	}
+	cleanup := func() {
+		Ω(ExecuteCleanup(getTestPath("mta_build_cache"), "", getResultPath(), dir.Dev, os.Getwd)).Should(Succeed())
+	}
+	cacheDir := getTestPath("result", ".mta_build_cache_mta_build_cache")
+
+	BeforeEach(func() {
+		Ω(os.Mkdir(getResultPath(), os.ModePerm)).Should(Succeed())
+		Ω(ioutil.WriteFile(getTestPath("mta_build_cache", "m2", "src.txt"), []byte("v1"), 0644)).Should(Succeed())
+	})
+	AfterEach(func() {
+		Ω(os.RemoveAll(getResultPath())).Should(Succeed())
+		Ω(os.RemoveAll(getTestPath("mta_build_cache", "builds.log"))).Should(Succeed())
+		Ω(os.RemoveAll(getTestPath("mta_build_cache", "m1", "from_m2"))).Should(Succeed())
+		Ω(os.RemoveAll(getTestPath("mta_build_cache", "m2", "m2.txt"))).Should(Succeed())
+		Ω(os.RemoveAll(getTestPath("mta_build_cache", "m2", "src.txt"))).Should(Succeed())
+	})
+
+	It("skips the build of a module which is not changed", func() {
+		build("m2")
+		cleanup()
+		build("m2")
+		Ω(getBuilds()).Should(Equal([]string{"m2"}))
+		Ω(getFullPathInTmpFolder("mta_build_cache", "m2", "data.zip")).Should(BeAnExistingFile())
+		Ω(cacheDir).Should(BeADirectory())
+	})
+
+	It("builds a module when its sources are changed", func() {
+		build("m2")
+		Ω(ioutil.WriteFile(getTestPath("mta_build_cache", "m2", "src.txt"), []byte("v2"), 0644)).Should(Succeed())
+		build("m2")
+		Ω(getBuilds()).Should(Equal([]string{"m2", "m2"}))
+	})
+
+	It("ignores the files matching the ignore build parameter", func() {
+		build("m2", "m1")
+		Ω(ioutil.WriteFile(getTestPath("mta_build_cache", "m1", "debug.log"), []byte("x"), 0644)).Should(Succeed())
+		defer os.Remove(getTestPath("mta_build_cache", "m1", "debug.log"))
+		build("m1")
+		Ω(getBuilds()).Should(Equal([]string{"m2", "m1"}))
+	})
+
+	It("builds a module when the results of its build requires are changed", func() {
+		build("m2", "m1")
+		Ω(ioutil.WriteFile(getTestPath("mta_build_cache", "m2", "src.txt"), []byte("v2"), 0644)).Should(Succeed())
+		build("m2", "m1")
+		Ω(getBuilds()).Should(Equal([]string{"m2", "m1", "m2", "m1"}))
+	})
+
+	It("builds a module after its cache is cleaned", func() {
+		build("m2", "m1")
+		Ω(ExecuteCacheClean(getTestPath("mta_build_cache"), "", getResultPath(), []string{"m1"}, os.Getwd)).Should(Succeed())
+		build("m2", "m1")
+		Ω(getBuilds()).Should(Equal([]string{"m2", "m1", "m1"}))
+	})
+
+	It("builds a module which is not changed when the reproducible mode is toggled", func() {
+		build("m2")
+		Ω(os.Setenv(dir.SourceDateEpochEnv, "1600000000")).Should(Succeed())
+		defer os.Unsetenv(dir.SourceDateEpochEnv)
+		cleanup()
+		build("m2")
+		Ω(os.Unsetenv(dir.SourceDateEpochEnv)).Should(Succeed())
+		cleanup()
+		build("m2")
+		Ω(getBuilds()).Should(Equal([]string{"m2", "m2", "m2"}))
+	})
+
+	It("builds a module which is not changed when the build cache is disabled", func() {
+		build("m2")
+		buildconfig.SetNoCache(true)
+		cleanup()
+		build("m2")
+		Ω(getBuilds()).Should(Equal([]string{"m2", "m2"}))
+	})
+
+	It("skips the execution of a module which is not changed in the verbose Makefile", func() {
+		buildVerbose("m2")
+		cleanup()
+		buildVerbose("m2")
+		Ω(getBuilds()).Should(Equal([]string{"m2"}))
+		Ω(getFullPathInTmpFolder("mta_build_cache", "m2", "data.zip")).Should(BeAnExistingFile())
+	})
+
+	It("executes a module whose sources are changed in the verbose Makefile", func() {
+		buildVerbose("m2")
+		Ω(ioutil.WriteFile(getTestPath("mta_build_cache", "m2", "src.txt"), []byte("v2"), 0644)).Should(Succeed())
+		buildVerbose("m2")
+		Ω(getBuilds()).Should(Equal([]string{"m2", "m2"}))
+	})
+
+	It("restores a module built by the default Makefile in the verbose Makefile", func() {
+		build("m2")
+		cleanup()
+		buildVerbose("m2")
+		Ω(getBuilds()).Should(Equal([]string{"m2"}))
+	})
+
+	It("skips the execution of a module which is not changed in the stand alone module build", func() {
+		buildSolo("m2")
+		Ω(os.Remove(getTestPath("result", "data.zip"))).Should(Succeed())
+		buildSolo("m2")
+		Ω(getBuilds()).Should(Equal([]string{"m2"}))
+		Ω(getTestPath("result", "data.zip")).Should(BeAnExistingFile())
+		Ω(getTestPath("result", ".mta_build_cache_mta_build_cache", "m2", cacheEntryFileName)).Should(BeAnExistingFile())
+	})
+
+	It("does not restore the module build result of the MTA build in the stand alone module build", func() {
+		build("m2")
+		buildSolo("m2")
+		Ω(getBuilds()).Should(Equal([]string{"m2", "m2"}))
+	})
+
+	Describe("ExecuteModuleRestore", func() {
+		It("does not restore a module which is not in the build cache", func() {
+			Ω(ExecuteModuleRestore(getTestPath("mta_build_cache"), "", getResultPath(), nil, "m2", "cf", "", os.Getwd)).Should(BeFalse())
+		})
+		It("does not restore a module when the build cache is disabled", func() {
+			build("m2")
+			buildconfig.SetNoCache(true)
+			Ω(ExecuteModuleRestore(getTestPath("mta_build_cache"), "", getResultPath(), nil, "m2", "cf", "", os.Getwd)).Should(BeFalse())
+		})
+		It("writes the restored module to the module report", func() {
+			build("m2")
+			cleanup()
+			reportDir := getTestPath("result", "reports")
+			Ω(ExecuteModuleRestore(getTestPath("mta_build_cache"), "", getResultPath(), nil, "m2", "cf", reportDir, os.Getwd)).Should(BeTrue())
+			Ω(readModuleReport(reportDir, "m2").Cached).Should(BeTrue())
+		})
+		It("fails when the location cannot be initialized", func() {
+			_, err := ExecuteModuleRestore("", "", "", nil, "m2", "cf", "", failingGetWd)
+			checkError(err, restoreFailedOnLocMsg, "m2")
+		})
+	})
+
+	Describe("ExecuteCacheList", func() {
+		It("lists the cached modules", func() {
+			build("m2", "m1")
+			Ω(ExecuteCacheList(getTestPath("mta_build_cache"), "", getResultPath(), os.Getwd)).Should(Succeed())
+			entries, err := getCacheEntries(cacheDir)
+			Ω(err).Should(Succeed())
+			Ω(entries).Should(HaveLen(2))
+			Ω(entries[0].Module).Should(Equal("m1"))
+			Ω(entries[0].Artifact).Should(Equal("data.zip"))
+			Ω(entries[1].Module).Should(Equal("m2"))
+		})
+		It("lists the cache record with a short key", func() {
+			Ω(os.MkdirAll(filepath.Join(cacheDir, "m2"), os.ModePerm)).Should(Succeed())
+			Ω(ioutil.WriteFile(filepath.Join(cacheDir, "m2", cacheEntryFileName), []byte(`{"module": "m2", "key": "abc"}`), 0644)).Should(Succeed())
+			Ω(ExecuteCacheList(getTestPath("mta_build_cache"), "", getResultPath(), os.Getwd)).Should(Succeed())
+		})
+		It("succeeds when the cache is empty", func() {
+			Ω(ExecuteCacheList(getTestPath("mta_build_cache"), "", getResultPath(), os.Getwd)).Should(Succeed())
+		})
+		It("fails when the location cannot be initialized", func() {
+			checkError(ExecuteCacheList("", "", "", failingGetWd), cacheFailedOnLocMsg)
+		})
+	})
+
+	Describe("ExecuteCacheClean", func() {
+		It("removes the whole cache", func() {
+			build("m2")
+			Ω(ExecuteCacheClean(getTestPath("mta_build_cache"), "", getResultPath(), nil, os.Getwd)).Should(Succeed())
+			Ω(cacheDir).ShouldNot(BeADirectory())
+		})
+		It("fails when the module is not in the cache", func() {
+			build("m2")
+			checkError(ExecuteCacheClean(getTestPath("mta_build_cache"), "", getResultPath(), []string{"m1"}, os.Getwd),
+				cacheModuleNotFoundMsg, "m1", cacheDir)
+			Ω(filepath.Join(cacheDir, "m2")).Should(BeADirectory())
+		})
+		It("fails when the module name points outside of the cache", func() {
+			build("m2")
+			for _, module := range []string{"..", ".", filepath.Join("..", ".."), filepath.Join("m2", ".."), cacheDir} {
+				checkError(ExecuteCacheClean(getTestPath("mta_build_cache"), "", getResultPath(), []string{module}, os.Getwd),
+					cacheModuleNotFoundMsg, module, cacheDir)
+			}
+			Ω(filepath.Join(cacheDir, "m2")).Should(BeADirectory())
+		})
+		It("fails when the location cannot be initialized", func() {
+			checkError(ExecuteCacheClean("", "", "", nil, failingGetWd), cacheFailedOnLocMsg)
+		})
+	})
+})
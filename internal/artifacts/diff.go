@@ -0,0 +1,388 @@
+package artifacts
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/SAP/cloud-mta/mta"
+
+	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+)
+
+const (
+	diffAdded   = "added"
+	diffRemoved = "removed"
+	diffChanged = "changed"
+)
+
+// mtarDiff - the differences between two MTA archives
+type mtarDiff struct {
+	Old       string           `json:"old" yaml:"old"`
+	New       string           `json:"new" yaml:"new"`
+	Changes   []*mtarValueDiff `json:"changes,omitempty" yaml:"changes,omitempty"`
+	Modules   []*mtarItemDiff  `json:"modules,omitempty" yaml:"modules,omitempty"`
+	Resources []*mtarItemDiff  `json:"resources,omitempty" yaml:"resources,omitempty"`
+	Requires  []*mtarItemDiff  `json:"requires,omitempty" yaml:"requires,omitempty"`
+}
+
+// mtarItemDiff - the difference of a module, resource or required dependency
+type mtarItemDiff struct {
+	Name    string           `json:"name" yaml:"name"`
+	Change  string           `json:"change" yaml:"change"`
+	Changes []*mtarValueDiff `json:"changes,omitempty" yaml:"changes,omitempty"`
+	Files   []*mtarFileDiff  `json:"files,omitempty" yaml:"files,omitempty"`
+}
+
+// mtarValueDiff - the difference of a field of the deployment descriptor or of the manifest;
+// the parameters and properties fields are prefixed with "parameters." and "properties."
+type mtarValueDiff struct {
+	Field string `json:"field" yaml:"field"`
+	Old   string `json:"old,omitempty" yaml:"old,omitempty"`
+	New   string `json:"new,omitempty" yaml:"new,omitempty"`
+}
+
+// mtarFileDiff - the difference of a file of the module content
+type mtarFileDiff struct {
+	Path    string `json:"path" yaml:"path"`
+	Change  string `json:"change" yaml:"change"`
+	OldSize int64  `json:"old-size,omitempty" yaml:"old-size,omitempty"`
+	NewSize int64  `json:"new-size,omitempty" yaml:"new-size,omitempty"`
+	OldHash string `json:"old-hash,omitempty" yaml:"old-hash,omitempty"`
+	NewHash string `json:"new-hash,omitempty" yaml:"new-hash,omitempty"`
+}
+
+// mtarFileInfo - the size and the SHA-256 hash of a file of the module content
+type mtarFileInfo struct {
+	size int64
+	hash string
+}
+
+// mtarSnapshot - the opened MTA archive with its content
+type mtarSnapshot struct {
+	reader  *zip.ReadCloser
+	content *mtarContent
+}
+
+func (d *mtarDiff) empty() bool {
+	return len(d.Changes) == 0 && len(d.Modules) == 0 && len(d.Resources) == 0 && len(d.Requires) == 0
+}
+
+// ExecuteDiff - prints the differences between two MTA archives: the added, removed and changed modules, resources
+// and required dependencies with their changed deployment descriptor fields, manifest content types and module files
+func ExecuteDiff(oldMtarPath, newMtarPath, output string, out io.Writer) error {
+	err := validateOutput(output)
+	if err != nil {
+		return err
+	}
+	diff, err := diffMtars(oldMtarPath, newMtarPath)
+	if err != nil {
+		return err
+	}
+	return printOutput(diff, output, out, func(out io.Writer) error {
+		return printMtarDiff(diff, out)
+	})
+}
+
+func diffMtars(oldMtarPath, newMtarPath string) (diff *mtarDiff, e error) {
+	oldMtar, err := openMtarSnapshot(oldMtarPath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		e = dir.CloseFile(oldMtar.reader, e)
+	}()
+	newMtar, err := openMtarSnapshot(newMtarPath)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		e = dir.CloseFile(newMtar.reader, e)
+	}()
+
+	diff = &mtarDiff{Old: oldMtarPath, New: newMtarPath}
+	diff.Changes = appendValueDiff(diff.Changes, "version", oldMtar.content.Version, newMtar.content.Version)
+	diff.Changes = appendMapDiff(diff.Changes, "parameters", oldMtar.content.Mtad.Parameters, newMtar.content.Mtad.Parameters)
+
+	diff.Modules, err = diffMtarEntries(oldMtar, newMtar, oldMtar.content.Modules, newMtar.content.Modules, true)
+	if err != nil {
+		return nil, err
+	}
+	diff.Resources, err = diffMtarEntries(oldMtar, newMtar, oldMtar.content.Resources, newMtar.content.Resources, false)
+	if err != nil {
+		return nil, err
+	}
+	diff.Requires, err = diffMtarEntries(oldMtar, newMtar, oldMtar.content.Requires, newMtar.content.Requires, false)
+	if err != nil {
+		return nil, err
+	}
+	return diff, nil
+}
+
+func openMtarSnapshot(mtarPath string) (*mtarSnapshot, error) {
+	reader, err := zip.OpenReader(mtarPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, mtarOpenFailedMsg, mtarPath)
+	}
+	content, err := inspectMtarReader(&reader.Reader, mtarPath)
+	if err != nil {
+		return nil, dir.CloseFile(reader, err)
+	}
+	return &mtarSnapshot{reader: reader, content: content}, nil
+}
+
+// diffMtarEntries - gets the differences of the manifest entries with the same name, in the order of the new archive
+// followed by the removed entries
+func diffMtarEntries(oldMtar, newMtar *mtarSnapshot, oldEntries, newEntries []*mtarEntry, compareFiles bool) ([]*mtarItemDiff, error) {
+	var result []*mtarItemDiff
+	for _, newEntry := range newEntries {
+		oldEntry := findMtarEntry(oldEntries, newEntry.EntryName)
+		if oldEntry == nil {
+			result = append(result, &mtarItemDiff{Name: newEntry.EntryName, Change: diffAdded})
+			continue
+		}
+
+		item := &mtarItemDiff{Name: newEntry.EntryName, Change: diffChanged}
+		item.Changes = appendValueDiff(item.Changes, "type", oldEntry.Type, newEntry.Type)
+		item.Changes = appendValueDiff(item.Changes, "path", oldEntry.EntryPath, newEntry.EntryPath)
+		item.Changes = appendValueDiff(item.Changes, "content-type", oldEntry.ContentType, newEntry.ContentType)
+		oldParameters, oldProperties := getMtadParametersAndProperties(oldMtar.content.Mtad, oldEntry)
+		newParameters, newProperties := getMtadParametersAndProperties(newMtar.content.Mtad, newEntry)
+		item.Changes = appendMapDiff(item.Changes, "parameters", oldParameters, newParameters)
+		item.Changes = appendMapDiff(item.Changes, "properties", oldProperties, newProperties)
+
+		if compareFiles {
+			oldFiles, err := getMtarEntryFiles(oldMtar, oldEntry)
+			if err != nil {
+				return nil, err
+			}
+			newFiles, err := getMtarEntryFiles(newMtar, newEntry)
+			if err != nil {
+				return nil, err
+			}
+			item.Files = diffMtarFiles(oldFiles, newFiles)
+		}
+
+		if len(item.Changes) > 0 || len(item.Files) > 0 {
+			result = append(result, item)
+		}
+	}
+	for _, oldEntry := range oldEntries {
+		if findMtarEntry(newEntries, oldEntry.EntryName) == nil {
+			result = append(result, &mtarItemDiff{Name: oldEntry.EntryName, Change: diffRemoved})
+		}
+	}
+	return result, nil
+}
+
+func findMtarEntry(entries []*mtarEntry, name string) *mtarEntry {
+	for _, e := range entries {
+		if e.EntryName == name {
+			return e
+		}
+	}
+	return nil
+}
+
+// getMtadParametersAndProperties - gets the parameters and properties of the deployment descriptor module or resource
+func getMtadParametersAndProperties(mtad *mta.MTA, e *mtarEntry) (map[string]interface{}, map[string]interface{}) {
+	switch e.EntryType {
+	case moduleEntry:
+		module, err := mtad.GetModuleByName(e.EntryName)
+		if err == nil {
+			return module.Parameters, module.Properties
+		}
+	case resourceEntry:
+		resource := mtad.GetResourceByName(e.EntryName)
+		if resource != nil {
+			return resource.Parameters, resource.Properties
+		}
+	}
+	return nil, nil
+}
+
+func appendValueDiff(changes []*mtarValueDiff, field string, oldValue, newValue string) []*mtarValueDiff {
+	if oldValue == newValue {
+		return changes
+	}
+	return append(changes, &mtarValueDiff{Field: field, Old: oldValue, New: newValue})
+}
+
+// appendMapDiff - appends the differences of the map values, sorted by the key
+func appendMapDiff(changes []*mtarValueDiff, field string, oldMap, newMap map[string]interface{}) []*mtarValueDiff {
+	var keys []string
+	for key := range oldMap {
+		keys = append(keys, key)
+	}
+	for key := range newMap {
+		if _, ok := oldMap[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		oldValue, newValue := oldMap[key], newMap[key]
+		if !reflect.DeepEqual(oldValue, newValue) {
+			changes = append(changes, &mtarValueDiff{Field: field + "." + key, Old: formatDiffValue(oldValue), New: formatDiffValue(newValue)})
+		}
+	}
+	return changes
+}
+
+func formatDiffValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// getMtarEntryFiles - gets the files of the module content: the files of the folder, the files of the nested archive,
+// or the file itself
+func getMtarEntryFiles(snapshot *mtarSnapshot, e *mtarEntry) (map[string]*mtarFileInfo, error) {
+	files := make(map[string]*mtarFileInfo)
+	if e.EntryPath == "" || e.Missing {
+		return files, nil
+	}
+	if isMtarFolder(snapshot.reader.File, e.EntryPath) {
+		folderPrefix := strings.TrimSuffix(e.EntryPath, "/") + "/"
+		for _, file := range snapshot.reader.File {
+			if strings.HasPrefix(file.Name, folderPrefix) && !strings.HasSuffix(file.Name, "/") {
+				content, err := readMtarFile(file, file.Name)
+				if err != nil {
+					return nil, errors.Wrapf(err, mtarEntryReadFailedMsg, file.Name, snapshot.content.Archive)
+				}
+				files[strings.TrimPrefix(file.Name, folderPrefix)] = getMtarFileInfo(content)
+			}
+		}
+		return files, nil
+	}
+
+	for _, file := range snapshot.reader.File {
+		if file.Name != e.EntryPath {
+			continue
+		}
+		content, err := readMtarFile(file, file.Name)
+		if err != nil {
+			return nil, errors.Wrapf(err, mtarEntryReadFailedMsg, file.Name, snapshot.content.Archive)
+		}
+		nestedReader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+		if err != nil {
+			// the content is not an archive, it is compared as a single file
+			files[path.Base(file.Name)] = getMtarFileInfo(content)
+			return files, nil
+		}
+		for _, nestedFile := range nestedReader.File {
+			if strings.HasSuffix(nestedFile.Name, "/") {
+				continue
+			}
+			nestedContent, err := readMtarFile(nestedFile, nestedFile.Name)
+			if err != nil {
+				return nil, errors.Wrapf(err, mtarEntryReadFailedMsg, file.Name+"/"+nestedFile.Name, snapshot.content.Archive)
+			}
+			files[nestedFile.Name] = getMtarFileInfo(nestedContent)
+		}
+	}
+	return files, nil
+}
+
+func getMtarFileInfo(content []byte) *mtarFileInfo {
+	hash := sha256.Sum256(content)
+	return &mtarFileInfo{size: int64(len(content)), hash: hex.EncodeToString(hash[:])}
+}
+
+// diffMtarFiles - gets the differences of the module files, sorted by the path
+func diffMtarFiles(oldFiles, newFiles map[string]*mtarFileInfo) []*mtarFileDiff {
+	var result []*mtarFileDiff
+	for filePath, newFile := range newFiles {
+		oldFile, ok := oldFiles[filePath]
+		if !ok {
+			result = append(result, &mtarFileDiff{Path: filePath, Change: diffAdded, NewSize: newFile.size, NewHash: newFile.hash})
+		} else if oldFile.hash != newFile.hash {
+			result = append(result, &mtarFileDiff{Path: filePath, Change: diffChanged,
+				OldSize: oldFile.size, NewSize: newFile.size, OldHash: oldFile.hash, NewHash: newFile.hash})
+		}
+	}
+	for filePath, oldFile := range oldFiles {
+		if _, ok := newFiles[filePath]; !ok {
+			result = append(result, &mtarFileDiff{Path: filePath, Change: diffRemoved, OldSize: oldFile.size, OldHash: oldFile.hash})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Path < result[j].Path
+	})
+	return result
+}
+
+func printMtarDiff(diff *mtarDiff, out io.Writer) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "OLD: %s\n", diff.Old)
+	fmt.Fprintf(&buf, "NEW: %s\n", diff.New)
+	if diff.empty() {
+		fmt.Fprintln(&buf)
+		fmt.Fprintln(&buf, "no differences found")
+	}
+	if len(diff.Changes) > 0 {
+		fmt.Fprintln(&buf)
+		printValueDiffs(&buf, diff.Changes, "")
+	}
+	printItemDiffs(&buf, "MODULES", diff.Modules)
+	printItemDiffs(&buf, "RESOURCES", diff.Resources)
+	printItemDiffs(&buf, "REQUIRES", diff.Requires)
+	_, err := out.Write(buf.Bytes())
+	return err
+}
+
+func printValueDiffs(out io.Writer, changes []*mtarValueDiff, indent string) {
+	for _, change := range changes {
+		fmt.Fprintf(out, "%s%s: %s -> %s\n", indent, change.Field, valueOrDash(change.Old), valueOrDash(change.New))
+	}
+}
+
+func printItemDiffs(out io.Writer, title string, items []*mtarItemDiff) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintf(out, "%s:\n", title)
+	for _, item := range items {
+		fmt.Fprintf(out, "  %s %s\n", getDiffMarker(item.Change), item.Name)
+		printValueDiffs(out, item.Changes, "      ")
+		for _, file := range item.Files {
+			switch file.Change {
+			case diffAdded:
+				fmt.Fprintf(out, "      %s %s (%d bytes)\n", getDiffMarker(file.Change), file.Path, file.NewSize)
+			case diffRemoved:
+				fmt.Fprintf(out, "      %s %s (%d bytes)\n", getDiffMarker(file.Change), file.Path, file.OldSize)
+			default:
+				fmt.Fprintf(out, "      %s %s (%d -> %d bytes)\n", getDiffMarker(file.Change), file.Path, file.OldSize, file.NewSize)
+			}
+		}
+	}
+}
+
+func getDiffMarker(change string) string {
+	switch change {
+	case diffAdded:
+		return "+"
+	case diffRemoved:
+		return "-"
+	}
+	return "~"
+}
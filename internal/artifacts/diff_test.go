@@ -0,0 +1,132 @@
+package artifacts
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+// zipContent - gets the content of the archive with the files
+func zipContent(files map[string]string) string {
+	var buf bytes.Buffer
+	writer := zip.NewWriter(&buf)
+	for path, content := range files {
+		w, err := writer.Create(path)
+		Ω(err).Should(Succeed())
+		_, err = w.Write([]byte(content))
+		Ω(err).Should(Succeed())
+	}
+	Ω(writer.Close()).Should(Succeed())
+	return buf.String()
+}
+
+var _ = Describe("Diff", func() {
+
+	oldMtarPath := filepath.Join(getResultPath(), "old.mtar")
+	newMtarPath := filepath.Join(getResultPath(), "new.mtar")
+
+	BeforeEach(func() {
+		createTestMtar(getTestPath("mtar_verify"), oldMtarPath, map[string]string{
+			"ui/data.zip": zipContent(map[string]string{"index.html": "old", "app.js": "js"}),
+		})
+
+		mtad, err := ioutil.ReadFile(getTestPath("mtar_verify", "META-INF", "mtad.yaml"))
+		Ω(err).Should(Succeed())
+		mtadContent := strings.Replace(string(mtad), "version: 1.0.0", "version: 1.1.0", 1)
+		mtadContent = strings.Replace(mtadContent, "    path: srv\n", "    path: srv\n    parameters:\n      memory: 512M\n", 1)
+		mtadContent += "  - name: uaa\n    type: org.cloudfoundry.managed-service\n"
+		manifest, err := ioutil.ReadFile(getTestPath("mtar_verify", "META-INF", "MANIFEST.MF"))
+		Ω(err).Should(Succeed())
+		manifestContent := strings.Replace(string(manifest), "Content-Type: application/json", "Content-Type: text/plain", 1)
+		createTestMtar(getTestPath("mtar_verify"), newMtarPath, map[string]string{
+			"META-INF/mtad.yaml":   mtadContent,
+			"META-INF/MANIFEST.MF": manifestContent,
+			"ui/data.zip":          zipContent(map[string]string{"index.html": "new content", "style.css": "css"}),
+			"srv/index.js":         "console.log(\"new srv\");\n",
+		})
+	})
+
+	AfterEach(func() {
+		Ω(os.RemoveAll(getResultPath())).Should(Succeed())
+	})
+
+	It("finds the differences of the deployment descriptor, the manifest and the module files", func() {
+		diff, err := diffMtars(oldMtarPath, newMtarPath)
+		Ω(err).Should(Succeed())
+		Ω(diff.Changes).Should(Equal([]*mtarValueDiff{{Field: "version", Old: "1.0.0", New: "1.1.0"}}))
+
+		Ω(diff.Modules).Should(HaveLen(2))
+		Ω(diff.Modules[0].Name).Should(Equal("ui"))
+		Ω(diff.Modules[0].Change).Should(Equal(diffChanged))
+		Ω(diff.Modules[0].Changes).Should(BeEmpty())
+		Ω(diff.Modules[0].Files).Should(HaveLen(3))
+		Ω(*diff.Modules[0].Files[0]).Should(Equal(mtarFileDiff{Path: "app.js", Change: diffRemoved, OldSize: 2,
+			OldHash: getMtarFileInfo([]byte("js")).hash}))
+		Ω(diff.Modules[0].Files[1].Path).Should(Equal("index.html"))
+		Ω(diff.Modules[0].Files[1].Change).Should(Equal(diffChanged))
+		Ω(diff.Modules[0].Files[1].OldSize).Should(Equal(int64(3)))
+		Ω(diff.Modules[0].Files[1].NewSize).Should(Equal(int64(11)))
+		Ω(diff.Modules[0].Files[1].OldHash).ShouldNot(Equal(diff.Modules[0].Files[1].NewHash))
+		Ω(diff.Modules[0].Files[2].Path).Should(Equal("style.css"))
+		Ω(diff.Modules[0].Files[2].Change).Should(Equal(diffAdded))
+
+		Ω(diff.Modules[1].Name).Should(Equal("srv"))
+		Ω(diff.Modules[1].Changes).Should(Equal([]*mtarValueDiff{{Field: "parameters.memory", New: "512M"}}))
+		Ω(diff.Modules[1].Files).Should(HaveLen(1))
+		Ω(diff.Modules[1].Files[0].Path).Should(Equal("index.js"))
+		Ω(diff.Modules[1].Files[0].Change).Should(Equal(diffChanged))
+
+		Ω(diff.Resources).Should(Equal([]*mtarItemDiff{
+			{Name: "config", Change: diffChanged, Changes: []*mtarValueDiff{{Field: "content-type", Old: "application/json", New: "text/plain"}}},
+			{Name: "uaa", Change: diffAdded},
+		}))
+		Ω(diff.Requires).Should(BeEmpty())
+	})
+
+	It("finds the removed resources", func() {
+		diff, err := diffMtars(newMtarPath, oldMtarPath)
+		Ω(err).Should(Succeed())
+		Ω(diff.Resources).Should(ContainElement(&mtarItemDiff{Name: "uaa", Change: diffRemoved}))
+	})
+
+	It("finds no differences between the same archives", func() {
+		var out bytes.Buffer
+		Ω(ExecuteDiff(oldMtarPath, oldMtarPath, "", &out)).Should(Succeed())
+		Ω(out.String()).Should(ContainSubstring("no differences found"))
+	})
+
+	It("prints the differences in the human-readable format", func() {
+		var out bytes.Buffer
+		Ω(ExecuteDiff(oldMtarPath, newMtarPath, OutputTable, &out)).Should(Succeed())
+		Ω(out.String()).Should(ContainSubstring("version: 1.0.0 -> 1.1.0"))
+		Ω(out.String()).Should(ContainSubstring("  ~ srv\n      parameters.memory: - -> 512M\n      ~ index.js (20 -> 24 bytes)\n"))
+		Ω(out.String()).Should(ContainSubstring("      - app.js (2 bytes)\n"))
+		Ω(out.String()).Should(ContainSubstring("      + style.css (3 bytes)\n"))
+		Ω(out.String()).Should(ContainSubstring("  + uaa\n"))
+		Ω(out.String()).ShouldNot(ContainSubstring("no differences found"))
+	})
+
+	It("prints the differences in the JSON format", func() {
+		var out bytes.Buffer
+		Ω(ExecuteDiff(oldMtarPath, newMtarPath, OutputJSON, &out)).Should(Succeed())
+		var diff map[string]interface{}
+		Ω(json.Unmarshal(out.Bytes(), &diff)).Should(Succeed())
+		Ω(diff["modules"]).Should(ContainElement(HaveKeyWithValue("name", "srv")))
+		Ω(diff["resources"]).Should(ContainElement(HaveKeyWithValue("change", diffAdded)))
+	})
+
+	It("fails on the wrong output format", func() {
+		checkError(ExecuteDiff(oldMtarPath, newMtarPath, "xml", &bytes.Buffer{}), wrongOutputMsg, "xml")
+	})
+
+	It("fails when the archive does not exist", func() {
+		checkError(ExecuteDiff(oldMtarPath, getTestPath("unknown.mtar"), "", &bytes.Buffer{}), mtarOpenFailedMsg, getTestPath("unknown.mtar"))
+	})
+})
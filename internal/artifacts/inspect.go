@@ -0,0 +1,270 @@
+package artifacts
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/SAP/cloud-mta/mta"
+
+	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+)
+
+const (
+	// OutputTable - the output of the command is printed as a table
+	OutputTable = "table"
+	// OutputJSON - the output of the command is printed in the JSON format
+	OutputJSON = "json"
+	// OutputYAML - the output of the command is printed in the YAML format
+	OutputYAML = "yaml"
+
+	manifestPathInMtar = "META-INF/MANIFEST.MF"
+	mtadPathInMtar     = "META-INF/mtad.yaml"
+)
+
+// mtarContent - the content of the MTA archive
+type mtarContent struct {
+	Archive   string             `json:"archive" yaml:"archive"`
+	ID        string             `json:"id" yaml:"id"`
+	Version   string             `json:"version,omitempty" yaml:"version,omitempty"`
+	Modules   []*mtarEntry       `json:"modules" yaml:"modules"`
+	Resources []*mtarEntry       `json:"resources" yaml:"resources"`
+	Requires  []*mtarEntry       `json:"requires,omitempty" yaml:"requires,omitempty"`
+	Unknown   []*mtarArchiveFile `json:"unreferenced-entries" yaml:"unreferenced-entries"`
+	Mtad      *mta.MTA           `json:"mtad" yaml:"mtad"`
+}
+
+// mtarEntry - the manifest entry of a module, resource or required dependency with the content it references in the archive;
+// the modules and resources without content in the archive have an empty path
+type mtarEntry struct {
+	entry `yaml:",inline"`
+	// Type - the module or resource type in the deployment descriptor
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+	// Size - the uncompressed size of the content
+	Size int64 `json:"size" yaml:"size"`
+	// Missing - the manifest entry references content that does not exist in the archive
+	Missing bool `json:"missing,omitempty" yaml:"missing,omitempty"`
+}
+
+// mtarArchiveFile - the file of the archive
+type mtarArchiveFile struct {
+	Path string `json:"path" yaml:"path"`
+	Size int64  `json:"size" yaml:"size"`
+}
+
+// ExecuteInspect - prints the content of the MTA archive: the modules and resources of the manifest and the deployment descriptor,
+// and the archive entries the manifest does not reference
+func ExecuteInspect(mtarPath, output string, out io.Writer) error {
+	err := validateOutput(output)
+	if err != nil {
+		return err
+	}
+	content, err := inspectMtar(mtarPath)
+	if err != nil {
+		return err
+	}
+	return printOutput(content, output, out, func(out io.Writer) error {
+		return printMtarContentTable(content, out)
+	})
+}
+
+func validateOutput(output string) error {
+	switch output {
+	case "", OutputTable, OutputJSON, OutputYAML:
+		return nil
+	}
+	return errors.Errorf(wrongOutputMsg, output)
+}
+
+func inspectMtar(mtarPath string) (content *mtarContent, e error) {
+	reader, err := zip.OpenReader(mtarPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, mtarOpenFailedMsg, mtarPath)
+	}
+	defer func() {
+		e = dir.CloseFile(reader, e)
+	}()
+	return inspectMtarReader(&reader.Reader, mtarPath)
+}
+
+// inspectMtarReader - gets the content of the opened MTA archive
+func inspectMtarReader(reader *zip.Reader, mtarPath string) (*mtarContent, error) {
+	manifestContent, mtadContent, err := readMtarDescriptors(reader, mtarPath)
+	if err != nil {
+		return nil, err
+	}
+	mtad, err := mta.Unmarshal(mtadContent)
+	if err != nil {
+		return nil, errors.Wrapf(err, mtarMtadReadFailedMsg, mtarPath)
+	}
+
+	content := &mtarContent{Archive: mtarPath, ID: mtad.ID, Version: mtad.Version, Mtad: mtad,
+		Modules: []*mtarEntry{}, Resources: []*mtarEntry{}, Unknown: []*mtarArchiveFile{}}
+	entries := parseManifest(manifestContent)
+	referenced := map[string]bool{manifestPathInMtar: true, mtadPathInMtar: true, signatureFilePath: true, signatureBlockPath: true}
+	for _, manifestEntry := range entries {
+		contentEntry := &mtarEntry{entry: manifestEntry}
+		contentEntry.Size, contentEntry.Missing = getMtarEntrySize(reader.File, manifestEntry.EntryPath, referenced)
+		switch manifestEntry.EntryType {
+		case moduleEntry:
+			content.Modules = append(content.Modules, contentEntry)
+		case resourceEntry:
+			content.Resources = append(content.Resources, contentEntry)
+		default:
+			content.Requires = append(content.Requires, contentEntry)
+		}
+	}
+	content.Modules = addMtadModules(content.Modules, mtad.Modules)
+	content.Resources = addMtadResources(content.Resources, mtad.Resources)
+
+	for _, file := range reader.File {
+		if !referenced[file.Name] && !strings.HasSuffix(file.Name, "/") {
+			content.Unknown = append(content.Unknown, &mtarArchiveFile{Path: file.Name, Size: int64(file.UncompressedSize64)})
+		}
+	}
+	return content, nil
+}
+
+// readMtarDescriptors - reads the manifest and the deployment descriptor of the MTA archive
+func readMtarDescriptors(reader *zip.Reader, mtarPath string) (manifestContent, mtadContent []byte, err error) {
+	files := make(map[string]*zip.File)
+	for _, file := range reader.File {
+		files[file.Name] = file
+	}
+	manifestContent, err = readMtarFile(files[manifestPathInMtar], manifestPathInMtar)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, mtarManifestReadFailedMsg, mtarPath)
+	}
+	mtadContent, err = readMtarFile(files[mtadPathInMtar], mtadPathInMtar)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, mtarMtadReadFailedMsg, mtarPath)
+	}
+	return manifestContent, mtadContent, nil
+}
+
+func readMtarFile(file *zip.File, path string) (content []byte, e error) {
+	if file == nil {
+		return nil, errors.Errorf(mtarEntryNotFoundMsg, path)
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		e = dir.CloseFile(reader, e)
+	}()
+	return ioutil.ReadAll(reader)
+}
+
+// getMtarEntrySize - gets the size of the file or of the files of the folder referenced by the manifest entry
+// and marks these files as referenced
+func getMtarEntrySize(files []*zip.File, path string, referenced map[string]bool) (int64, bool) {
+	folderPrefix := strings.TrimSuffix(path, "/") + "/"
+	var size int64
+	found := false
+	for _, file := range files {
+		if file.Name == path || strings.HasPrefix(file.Name, folderPrefix) {
+			size += int64(file.UncompressedSize64)
+			referenced[file.Name] = true
+			found = true
+		}
+	}
+	return size, !found
+}
+
+// addMtadModules - sets the deployment descriptor types of the modules, adding the modules without content in the archive
+func addMtadModules(entries []*mtarEntry, modules []*mta.Module) []*mtarEntry {
+	for _, module := range modules {
+		found := false
+		for _, e := range entries {
+			if e.EntryName == module.Name {
+				e.Type = module.Type
+				found = true
+			}
+		}
+		if !found {
+			entries = append(entries, &mtarEntry{entry: entry{EntryName: module.Name, EntryType: moduleEntry}, Type: module.Type})
+		}
+	}
+	return entries
+}
+
+// addMtadResources - sets the deployment descriptor types of the resources, adding the resources without content in the archive
+func addMtadResources(entries []*mtarEntry, resources []*mta.Resource) []*mtarEntry {
+	for _, resource := range resources {
+		found := false
+		for _, e := range entries {
+			if e.EntryName == resource.Name {
+				e.Type = resource.Type
+				found = true
+			}
+		}
+		if !found {
+			entries = append(entries, &mtarEntry{entry: entry{EntryName: resource.Name, EntryType: resourceEntry}, Type: resource.Type})
+		}
+	}
+	return entries
+}
+
+// printOutput - prints the value in the JSON or YAML format, or as a table using the table printer
+func printOutput(value interface{}, output string, out io.Writer, printTable func(io.Writer) error) error {
+	switch output {
+	case OutputJSON:
+		data, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(data))
+		return err
+	case OutputYAML:
+		data, err := yaml.Marshal(value)
+		if err != nil {
+			return err
+		}
+		_, err = out.Write(data)
+		return err
+	}
+	return printTable(out)
+}
+
+func printMtarContentTable(content *mtarContent, out io.Writer) error {
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "ARCHIVE:\t%s\n", content.Archive)
+	fmt.Fprintf(writer, "ID:\t%s\n", content.ID)
+	fmt.Fprintf(writer, "VERSION:\t%s\n", content.Version)
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "KIND\tNAME\tTYPE\tPATH\tCONTENT-TYPE\tSIZE")
+	entries := append(append(append([]*mtarEntry{}, content.Modules...), content.Resources...), content.Requires...)
+	for _, e := range entries {
+		size := fmt.Sprint(e.Size)
+		if e.Missing {
+			size = "missing"
+		} else if e.EntryPath == "" {
+			size = "-"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n", e.EntryType, e.EntryName, valueOrDash(e.Type), valueOrDash(e.EntryPath),
+			valueOrDash(e.ContentType), size)
+	}
+	if len(content.Unknown) > 0 {
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "UNREFERENCED ENTRY\tSIZE")
+		for _, file := range content.Unknown {
+			fmt.Fprintf(writer, "%s\t%d\n", file.Path, file.Size)
+		}
+	}
+	return writer.Flush()
+}
+
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
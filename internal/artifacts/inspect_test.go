@@ -0,0 +1,103 @@
+package artifacts
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+
+	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+)
+
+var _ = Describe("Inspect", func() {
+
+	mtarPath := filepath.Join(getResultPath(), "inspect.mtar")
+
+	BeforeEach(func() {
+		Ω(dir.Archive(getTestPath("mtar_inspect"), mtarPath, nil)).Should(Succeed())
+	})
+
+	AfterEach(func() {
+		Ω(os.RemoveAll(getResultPath())).Should(Succeed())
+	})
+
+	It("parseManifest splits the merged entries", func() {
+		entries := parseManifest([]byte("manifest-Version: 1.0\r\n\r\nName: a/data.zip\r\nMTA-Module: m1, m2\r\n , m3\r\nContent-Type: application/zip\r\n"))
+		Ω(entries).Should(Equal([]entry{
+			{EntryName: "m1", EntryType: moduleEntry, ContentType: "application/zip", EntryPath: "a/data.zip"},
+			{EntryName: "m2", EntryType: moduleEntry, ContentType: "application/zip", EntryPath: "a/data.zip"},
+			{EntryName: "m3", EntryType: moduleEntry, ContentType: "application/zip", EntryPath: "a/data.zip"},
+		}))
+	})
+
+	It("inspects the modules, resources and unreferenced entries of the MTA archive", func() {
+		content, err := inspectMtar(mtarPath)
+		Ω(err).Should(Succeed())
+		Ω(content.ID).Should(Equal("inspect"))
+		Ω(content.Version).Should(Equal("1.0.0"))
+		Ω(content.Mtad.Modules).Should(HaveLen(5))
+		Ω(content.Modules).Should(Equal([]*mtarEntry{
+			{entry: entry{EntryName: "ui", EntryType: moduleEntry, ContentType: "application/zip", EntryPath: "ui/data.zip"}, Type: "html5", Size: 10},
+			{entry: entry{EntryName: "ui-copy", EntryType: moduleEntry, ContentType: "application/zip", EntryPath: "ui/data.zip"}, Type: "html5", Size: 10},
+			{entry: entry{EntryName: "srv", EntryType: moduleEntry, ContentType: dirContentType, EntryPath: "srv/"}, Type: "nodejs", Size: 21},
+			{entry: entry{EntryName: "missing", EntryType: moduleEntry, ContentType: "application/zip", EntryPath: "missing/data.zip"}, Type: "nodejs", Missing: true},
+			{entry: entry{EntryName: "db", EntryType: moduleEntry}, Type: "com.sap.xs.hdi"},
+		}))
+		Ω(content.Resources).Should(Equal([]*mtarEntry{
+			{entry: entry{EntryName: "config", EntryType: resourceEntry, ContentType: "application/json", EntryPath: "cfg/config.json"}, Type: "org.cloudfoundry.managed-service", Size: 2},
+			{entry: entry{EntryName: "uaa", EntryType: resourceEntry}, Type: "org.cloudfoundry.managed-service"},
+		}))
+		Ω(content.Requires).Should(Equal([]*mtarEntry{
+			{entry: entry{EntryName: "srv/libs", EntryType: requiredEntry, ContentType: dirContentType, EntryPath: "srv/lib"}, Size: 3},
+		}))
+		Ω(content.Unknown).Should(Equal([]*mtarArchiveFile{{Path: "extra.txt", Size: 5}}))
+	})
+
+	It("prints the content as a table", func() {
+		var out bytes.Buffer
+		Ω(ExecuteInspect(mtarPath, "", &out)).Should(Succeed())
+		Ω(out.String()).Should(ContainSubstring("ID:       inspect"))
+		Ω(out.String()).Should(MatchRegexp(`MTA-Module\s+srv\s+nodejs\s+srv/\s+text/directory\s+21`))
+		Ω(out.String()).Should(MatchRegexp(`MTA-Module\s+missing\s+nodejs\s+missing/data.zip\s+application/zip\s+missing`))
+		Ω(out.String()).Should(MatchRegexp(`MTA-Resource\s+uaa\s+org.cloudfoundry.managed-service\s+-\s+-\s+-`))
+		Ω(out.String()).Should(MatchRegexp(`extra.txt\s+5`))
+	})
+
+	It("prints the content in the JSON format", func() {
+		var out bytes.Buffer
+		Ω(ExecuteInspect(mtarPath, OutputJSON, &out)).Should(Succeed())
+		var content map[string]interface{}
+		Ω(json.Unmarshal(out.Bytes(), &content)).Should(Succeed())
+		Ω(content["id"]).Should(Equal("inspect"))
+		Ω(content["modules"]).Should(ContainElement(HaveKeyWithValue("name", "srv")))
+		Ω(content["mtad"]).Should(HaveKeyWithValue("ID", "inspect"))
+	})
+
+	It("prints the content in the YAML format", func() {
+		var out bytes.Buffer
+		Ω(ExecuteInspect(mtarPath, OutputYAML, &out)).Should(Succeed())
+		var content map[string]interface{}
+		Ω(yaml.Unmarshal(out.Bytes(), &content)).Should(Succeed())
+		Ω(content["id"]).Should(Equal("inspect"))
+		Ω(out.String()).Should(ContainSubstring("path: srv/"))
+		Ω(out.String()).Should(ContainSubstring("- path: extra.txt"))
+	})
+
+	It("fails on the wrong output format", func() {
+		checkError(ExecuteInspect(mtarPath, "xml", &bytes.Buffer{}), wrongOutputMsg, "xml")
+	})
+
+	It("fails when the archive does not exist", func() {
+		checkError(ExecuteInspect(getTestPath("unknown.mtar"), "", &bytes.Buffer{}), mtarOpenFailedMsg, getTestPath("unknown.mtar"))
+	})
+
+	It("fails when the archive has no deployment descriptor", func() {
+		archivePath := filepath.Join(getResultPath(), "nomtad.mtar")
+		Ω(dir.Archive(getTestPath("mtar_inspect"), archivePath, []string{"META-INF/mtad.yaml"})).Should(Succeed())
+		checkError(ExecuteInspect(archivePath, "", &bytes.Buffer{}), mtarMtadReadFailedMsg, archivePath)
+	})
+})
@@ -14,6 +14,7 @@ import (
 
 	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
 	"github.com/SAP/cloud-mta-build-tool/internal/buildops"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
 	"github.com/SAP/cloud-mta-build-tool/internal/commands"
 	"github.com/SAP/cloud-mta-build-tool/internal/conttype"
 	"github.com/SAP/cloud-mta-build-tool/internal/tpl"
@@ -37,22 +38,22 @@ const (
 )
 
 type entry struct {
-	EntryName   string
-	EntryType   string
-	ContentType string
-	EntryPath   string
+	EntryName   string `json:"name" yaml:"name"`
+	EntryType   string `json:"entry-type" yaml:"entry-type"`
+	ContentType string `json:"content-type" yaml:"content-type"`
+	EntryPath   string `json:"path" yaml:"path"`
 }
 
 // setManifestDesc - Set the MANIFEST.MF file
 func setManifestDesc(source dir.IModule, ep dir.ITargetArtifacts, targetPathGetter dir.ITargetPath, depDesc bool, mtaStr []*mta.Module,
-	mtaResources []*mta.Resource, platform string) error {
+	params buildparams.Modules, mtaResources []*mta.Resource, platform string) error {
 
 	contentTypes, err := conttype.GetContentTypes()
 	if err != nil {
 		return errors.Wrap(err, contentTypeCfgMsg)
 	}
 
-	entries, err := getModulesEntries(source, targetPathGetter, depDesc, mtaStr, contentTypes, platform)
+	entries, err := getModulesEntries(source, targetPathGetter, depDesc, mtaStr, params, contentTypes, platform)
 	if err != nil {
 		return err
 	}
@@ -156,16 +157,20 @@ func addModuleEntry(entries []entry, module *mta.Module, contentType, modulePath
 }
 
 func getModulesEntries(source dir.IModule, targetPathGetter dir.ITargetPath, depDesc bool, moduleList []*mta.Module,
-	contentTypes *conttype.ContentTypes, platform string) ([]entry, error) {
+	params buildparams.Modules, contentTypes *conttype.ContentTypes, platform string) ([]entry, error) {
 
 	var entries []entry
 	for _, mod := range moduleList {
-		if !buildops.IfNoSource(mod) && buildops.PlatformDefined(mod, platform) {
-			_, defaultBuildResult, err := commands.CommandProvider(*mod)
+		modParams := params[mod.Name]
+		if !buildops.ProfileDefined(modParams) {
+			continue
+		}
+		if !modParams.NoSource && buildops.PlatformDefined(modParams, platform) {
+			_, defaultBuildResult, err := commands.CommandProvider(*mod, modParams)
 			if err != nil {
 				return nil, err
 			}
-			modulePath, _, err := buildops.GetModuleTargetArtifactPath(source, depDesc, mod, defaultBuildResult, true)
+			modulePath, _, err := buildops.GetModuleTargetArtifactPath(source, depDesc, mod, modParams, defaultBuildResult, true)
 			if modulePath != "" && err == nil {
 				_, err = os.Stat(modulePath)
 			}
@@ -199,7 +204,7 @@ func getModulesEntries(source dir.IModule, targetPathGetter dir.ITargetPath, dep
 func getResourcesEntries(target dir.ITargetPath, resources []*mta.Resource, contentTypes *conttype.ContentTypes) ([]entry, error) {
 	var entries []entry
 	for _, resource := range resources {
-		if resource.Name == "" || resource.Parameters["path"] == nil {
+		if resource.Name == "" || resource.Parameters["path"] == nil || !buildops.ResourceProfileDefined(resource) {
 			continue
 		}
 		resourceRelativePath := getResourcePath(resource)
@@ -308,3 +313,60 @@ func moduleDefined(module string, modules []string) bool {
 	}
 	return false
 }
+
+// parseManifest - parses the MANIFEST.MF content into the entries of its name sections;
+// the merged entries, whose names are comma-separated, are split into an entry per name
+func parseManifest(content []byte) []entry {
+	var entries []entry
+	for _, section := range parseManifestSections(content) {
+		for _, entryType := range []string{moduleEntry, requiredEntry, resourceEntry} {
+			names, ok := section[entryType]
+			if !ok {
+				continue
+			}
+			for _, name := range strings.Split(names, ",") {
+				entries = append(entries, entry{
+					EntryName:   strings.TrimSpace(name),
+					EntryType:   entryType,
+					ContentType: section["Content-Type"],
+					EntryPath:   section["Name"],
+				})
+			}
+		}
+	}
+	return entries
+}
+
+// parseManifestSections - parses the MANIFEST.MF content into the attributes of its sections
+func parseManifestSections(content []byte) []map[string]string {
+	var sections []map[string]string
+	var section map[string]string
+	lastKey := ""
+	for _, line := range strings.Split(strings.Replace(string(content), "\r\n", "\n", -1), "\n") {
+		if strings.TrimSpace(line) == "" {
+			if section != nil {
+				sections = append(sections, section)
+			}
+			section = nil
+			continue
+		}
+		if section == nil {
+			section = make(map[string]string)
+		}
+		// a line starting with a space continues the value of the previous line
+		if strings.HasPrefix(line, " ") {
+			section[lastKey] += line[1:]
+			continue
+		}
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		lastKey = strings.TrimSpace(parts[0])
+		section[lastKey] = strings.TrimSpace(parts[1])
+	}
+	if section != nil {
+		sections = append(sections, section)
+	}
+	return sections
+}
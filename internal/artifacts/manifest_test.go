@@ -11,7 +11,8 @@ import (
 	"github.com/pkg/errors"
 
 	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
-	"github.com/SAP/cloud-mta-build-tool/internal/buildops"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildconfig"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
 	"github.com/SAP/cloud-mta-build-tool/internal/commands"
 	"github.com/SAP/cloud-mta-build-tool/internal/conttype"
 	"github.com/SAP/cloud-mta-build-tool/internal/version"
@@ -36,7 +37,7 @@ var _ = Describe("manifest", func() {
 			loc := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath()}
 			mtaObj, err := loc.ParseFile()
 			Ω(err).Should(Succeed())
-			Ω(setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, []*mta.Resource{}, "cf")).Should(Succeed())
+			Ω(setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, decodeAll(mtaObj), []*mta.Resource{}, "cf")).Should(Succeed())
 			actual := getFileContent(getFullPathInTmpFolder("mta", "META-INF", "MANIFEST.MF"))
 			golden := getFileContent(getTestPath("golden_manifest.mf"))
 			v, _ := version.GetVersion()
@@ -49,7 +50,7 @@ var _ = Describe("manifest", func() {
 			loc := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath(), Descriptor: dir.Dep}
 			mtaObj, err := loc.ParseFile()
 			Ω(err).Should(Succeed())
-			err = setManifestDesc(&loc, &loc, &loc, true, mtaObj.Modules, []*mta.Resource{}, "cf")
+			err = setManifestDesc(&loc, &loc, &loc, true, mtaObj.Modules, decodeAll(mtaObj), []*mta.Resource{}, "cf")
 			checkError(err, conttype.ContentTypeUndefinedMsg, ".js")
 		})
 		It("Sanity - with configuration provided", func() {
@@ -62,7 +63,7 @@ var _ = Describe("manifest", func() {
 			loc := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath(), MtaFilename: "mta_cfg.yaml"}
 			mtaObj, err := loc.ParseFile()
 			Ω(err).Should(Succeed())
-			Ω(setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, []*mta.Resource{}, "cf")).Should(Succeed())
+			Ω(setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, decodeAll(mtaObj), []*mta.Resource{}, "cf")).Should(Succeed())
 			actual := getFileContent(getFullPathInTmpFolder("mta", "META-INF", "MANIFEST.MF"))
 			golden := getFileContent(getTestPath("golden_manifest_cfg.mf"))
 			v, _ := version.GetVersion()
@@ -77,7 +78,7 @@ var _ = Describe("manifest", func() {
 			Ω(err).Should(Succeed())
 			moduleConf := commands.ModuleTypeConfig
 			commands.ModuleTypeConfig = []byte("bad module conf")
-			Ω(setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, []*mta.Resource{}, "cf")).Should(HaveOccurred())
+			Ω(setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, decodeAll(mtaObj), []*mta.Resource{}, "cf")).Should(HaveOccurred())
 			commands.ModuleTypeConfig = moduleConf
 		})
 		It("module with defined build-result fails when build-result file does not exist in source directory", func() {
@@ -86,14 +87,14 @@ var _ = Describe("manifest", func() {
 			loc := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath(), MtaFilename: "mtaWrongBuildResult.yaml"}
 			mtaObj, err := loc.ParseFile()
 			Ω(err).Should(Succeed())
-			Ω(setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, []*mta.Resource{}, "cf")).Should(HaveOccurred())
+			Ω(setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, decodeAll(mtaObj), []*mta.Resource{}, "cf")).Should(HaveOccurred())
 		})
 		It("module with defined build-result fails when build-result file does not exist in target temp directory", func() {
 			createDirInTmpFolder("mta", "node-js")
 			loc := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath(), MtaFilename: "mtaWrongBuildResult2.yaml"}
 			mtaObj, err := loc.ParseFile()
 			Ω(err).Should(Succeed())
-			Ω(setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, []*mta.Resource{}, "cf")).Should(HaveOccurred())
+			Ω(setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, decodeAll(mtaObj), []*mta.Resource{}, "cf")).Should(HaveOccurred())
 		})
 		It("entry for module with defined build-result has the build-result file", func() {
 			createDirInTmpFolder("mta", "node-js")
@@ -101,7 +102,7 @@ var _ = Describe("manifest", func() {
 			loc := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath(), MtaFilename: "mtaBuildResult.yaml"}
 			mtaObj, err := loc.ParseFile()
 			Ω(err).Should(Succeed())
-			Ω(setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, []*mta.Resource{}, "cf")).Should(Succeed())
+			Ω(setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, decodeAll(mtaObj), []*mta.Resource{}, "cf")).Should(Succeed())
 			actual := getFileContent(getFullPathInTmpFolder("mta", "META-INF", "MANIFEST.MF"))
 			golden := getFileContent(getTestPath("golden_manifestBuildResult.mf"))
 			v, _ := version.GetVersion()
@@ -116,7 +117,7 @@ var _ = Describe("manifest", func() {
 			Ω(err).Should(Succeed())
 			contentTypesOrig := conttype.ContentTypeConfig
 			conttype.ContentTypeConfig = []byte(`wrong configuraion`)
-			Ω(setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, []*mta.Resource{}, "cf")).Should(HaveOccurred())
+			Ω(setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, decodeAll(mtaObj), []*mta.Resource{}, "cf")).Should(HaveOccurred())
 			conttype.ContentTypeConfig = contentTypesOrig
 
 		})
@@ -125,7 +126,7 @@ var _ = Describe("manifest", func() {
 			loc := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath(), MtaFilename: "mta_no_paths.yaml"}
 			mtaObj, err := loc.ParseFile()
 			Ω(err).Should(Succeed())
-			Ω(setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, []*mta.Resource{}, "cf")).Should(Succeed())
+			Ω(setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, decodeAll(mtaObj), []*mta.Resource{}, "cf")).Should(Succeed())
 			actual := getFileContent(getFullPathInTmpFolder("mta", "META-INF", "MANIFEST.MF"))
 			golden := getFileContent(getTestPath("golden_assembly_manifest_no_paths.mf"))
 			v, _ := version.GetVersion()
@@ -143,19 +144,30 @@ var _ = Describe("manifest", func() {
 			loc := dir.Loc{SourcePath: getTestPath("assembly-sample"), TargetPath: getResultPath(), Descriptor: "dep"}
 			mtaObj, err := loc.ParseFile()
 			Ω(err).Should(Succeed())
-			Ω(setManifestDesc(&loc, &loc, &loc, true, mtaObj.Modules, mtaObj.Resources, "cf")).Should(Succeed())
+			Ω(setManifestDesc(&loc, &loc, &loc, true, mtaObj.Modules, decodeAll(mtaObj), mtaObj.Resources, "cf")).Should(Succeed())
 			actual := getFileContent(getFullPathInTmpFolder("assembly-sample", "META-INF", "MANIFEST.MF"))
 			golden := getFileContent(getTestPath("golden_assembly_manifest.mf"))
 			v, _ := version.GetVersion()
 			golden = strings.Replace(golden, "{{cli_version}}", v.CliVersion, -1)
 			Ω(actual).Should(Equal(golden))
 		})
+		It("skips the modules and resources of other build profiles", func() {
+			Ω(buildconfig.SetProfile("prod")).Should(Succeed())
+			loc := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath()}
+			modules := []*mta.Module{{Name: "trial", Type: "nodejs", Path: "trial",
+				BuildParams: map[string]interface{}{buildparams.ProfilesParam: []interface{}{"trial"}}}}
+			resources := []*mta.Resource{{Name: "trial-config",
+				Parameters: map[string]interface{}{"path": "trial.json", buildparams.ProfilesParam: []interface{}{"trial"}}}}
+			Ω(setManifestDesc(&loc, &loc, &loc, false, modules, decodeAll(&mta.MTA{Modules: modules}), resources, "cf")).Should(Succeed())
+			actual := getFileContent(getFullPathInTmpFolder("mta", "META-INF", "MANIFEST.MF"))
+			Ω(actual).ShouldNot(ContainSubstring("trial"))
+		})
 		It("With missing module path", func() {
 			createDirInTmpFolder("assembly-sample", "META-INF")
 			loc := dir.Loc{SourcePath: getTestPath("assembly-sample"), TargetPath: getResultPath(), Descriptor: "dep"}
 			mtaObj, err := loc.ParseFile()
 			Ω(err).Should(Succeed())
-			err = setManifestDesc(&loc, &loc, &loc, true, mtaObj.Modules, mtaObj.Resources, "cf")
+			err = setManifestDesc(&loc, &loc, &loc, true, mtaObj.Modules, decodeAll(mtaObj), mtaObj.Resources, "cf")
 			checkError(err, wrongArtifactPathMsg, "java-hello-world")
 		})
 		It("With missing resource", func() {
@@ -168,7 +180,7 @@ var _ = Describe("manifest", func() {
 			loc := dir.Loc{SourcePath: getTestPath("assembly-sample"), TargetPath: getTestPath("result"), Descriptor: "dep"}
 			mtaObj, err := loc.ParseFile()
 			Ω(err).Should(Succeed())
-			err = setManifestDesc(&loc, &loc, &loc, true, mtaObj.Modules, mtaObj.Resources, "cf")
+			err = setManifestDesc(&loc, &loc, &loc, true, mtaObj.Modules, decodeAll(mtaObj), mtaObj.Resources, "cf")
 			checkError(err, unknownResourceContentTypeMsg, "java-uaa")
 
 		})
@@ -179,7 +191,7 @@ var _ = Describe("manifest", func() {
 			loc := dir.Loc{SourcePath: getTestPath("assembly-sample"), TargetPath: getTestPath("result"), Descriptor: "dep"}
 			mtaObj, err := loc.ParseFile()
 			Ω(err).Should(Succeed())
-			err = setManifestDesc(&loc, &loc, &loc, true, mtaObj.Modules, mtaObj.Resources, "cf")
+			err = setManifestDesc(&loc, &loc, &loc, true, mtaObj.Modules, decodeAll(mtaObj), mtaObj.Resources, "cf")
 			// This fails because the config-site-host.json file (from the path of the required java-site-host) doesn't exist
 			checkError(err, requiredEntriesProblemMsg, "java-hello-world-backend")
 		})
@@ -190,7 +202,7 @@ var _ = Describe("manifest", func() {
 				loc := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath(), MtaFilename: "mtaBuildArtifact.yaml"}
 				mtaObj, err := loc.ParseFile()
 				Ω(err).Should(Succeed())
-				Ω(setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, []*mta.Resource{}, "cf")).Should(Succeed())
+				Ω(setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, decodeAll(mtaObj), []*mta.Resource{}, "cf")).Should(Succeed())
 				actual := getFileContent(getFullPathInTmpFolder("mta", "META-INF", "MANIFEST.MF"))
 				golden := getFileContentWithCliVersion(getTestPath("golden_manifestBuildArtifact.mf"))
 				Ω(actual).Should(Equal(golden))
@@ -201,7 +213,7 @@ var _ = Describe("manifest", func() {
 				loc := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath(), MtaFilename: "mtaBuildArtifact.yaml"}
 				mtaObj, err := loc.ParseFile()
 				Ω(err).Should(Succeed())
-				Ω(setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, []*mta.Resource{}, "cf")).Should(Succeed())
+				Ω(setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, decodeAll(mtaObj), []*mta.Resource{}, "cf")).Should(Succeed())
 				actual := getFileContent(getFullPathInTmpFolder("mta", "META-INF", "MANIFEST.MF"))
 				golden := getFileContentWithCliVersion(getTestPath("golden_manifestBuildArtifact.mf"))
 				Ω(actual).Should(Equal(golden))
@@ -211,7 +223,7 @@ var _ = Describe("manifest", func() {
 				loc := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath(), MtaFilename: "mtaBuildArtifactNoPath.yaml"}
 				mtaObj, err := loc.ParseFile()
 				Ω(err).Should(Succeed())
-				Ω(setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, []*mta.Resource{}, "cf")).Should(Succeed())
+				Ω(setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, decodeAll(mtaObj), []*mta.Resource{}, "cf")).Should(Succeed())
 				actual := getFileContent(getFullPathInTmpFolder("mta", "META-INF", "MANIFEST.MF"))
 				golden := getFileContentWithCliVersion(getTestPath("golden_assembly_manifest_no_paths.mf"))
 				Ω(actual).Should(Equal(golden))
@@ -222,7 +234,7 @@ var _ = Describe("manifest", func() {
 				loc := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath(), MtaFilename: "mtaBuildResultAndArtifact.yaml"}
 				mtaObj, err := loc.ParseFile()
 				Ω(err).Should(Succeed())
-				Ω(setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, []*mta.Resource{}, "cf")).Should(Succeed())
+				Ω(setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, decodeAll(mtaObj), []*mta.Resource{}, "cf")).Should(Succeed())
 				actual := getFileContent(getFullPathInTmpFolder("mta", "META-INF", "MANIFEST.MF"))
 				golden := getFileContentWithCliVersion(getTestPath("golden_manifestBuildResultAndArtifact.mf"))
 				Ω(actual).Should(Equal(golden))
@@ -233,8 +245,8 @@ var _ = Describe("manifest", func() {
 				loc := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath(), MtaFilename: "mtaBuildArtifactBad.yaml"}
 				mtaObj, err := loc.ParseFile()
 				Ω(err).Should(Succeed())
-				err = setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, []*mta.Resource{}, "cf")
-				checkError(err, buildops.WrongBuildArtifactNameMsg, "1", "node-js")
+				_, err = buildparams.DecodeAll(mtaObj)
+				checkError(err, buildparams.WrongParamMsg, "build-artifact-name", "node-js", "a string")
 			})
 			It("should fail when data.zip exists instead of the build artifact name", func() {
 				createDirInTmpFolder("mta", "node-js")
@@ -242,7 +254,7 @@ var _ = Describe("manifest", func() {
 				loc := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath(), MtaFilename: "mtaBuildArtifact.yaml"}
 				mtaObj, err := loc.ParseFile()
 				Ω(err).Should(Succeed())
-				err = setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, []*mta.Resource{}, "cf")
+				err = setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, decodeAll(mtaObj), []*mta.Resource{}, "cf")
 				checkError(err, wrongArtifactPathMsg, "node-js")
 			})
 			It("should fail when the build artifact doesn't exist in the module folder", func() {
@@ -250,14 +262,14 @@ var _ = Describe("manifest", func() {
 				loc := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath(), MtaFilename: "mtaBuildArtifact.yaml"}
 				mtaObj, err := loc.ParseFile()
 				Ω(err).Should(Succeed())
-				err = setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, []*mta.Resource{}, "cf")
+				err = setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, decodeAll(mtaObj), []*mta.Resource{}, "cf")
 				checkError(err, wrongArtifactPathMsg, "node-js")
 			})
 			It("should fail when the module folder doesn't exist", func() {
 				loc := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath(), MtaFilename: "mtaBuildArtifact.yaml"}
 				mtaObj, err := loc.ParseFile()
 				Ω(err).Should(Succeed())
-				err = setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, []*mta.Resource{}, "cf")
+				err = setManifestDesc(&loc, &loc, &loc, false, mtaObj.Modules, decodeAll(mtaObj), []*mta.Resource{}, "cf")
 				checkError(err, wrongArtifactPathMsg, "node-js")
 			})
 		})
@@ -11,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 
 	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
 	"github.com/SAP/cloud-mta-build-tool/internal/logs"
 	"github.com/SAP/cloud-mta-build-tool/internal/platform"
 	"github.com/SAP/cloud-mta/mta"
@@ -51,24 +52,29 @@ func generateMeta(loc *dir.Loc, targetArtifacts dir.ITargetArtifacts, deployment
 		return errors.Wrapf(err, genMetaMsg)
 	}
 
+	params, err := buildparams.DecodeAll(m)
+	if err != nil {
+		return errors.Wrapf(err, genMetaMsg)
+	}
+
 	// Generate meta info dir with required content
-	err = genMetaInfo(loc, targetArtifacts, loc, deploymentDescriptor, platform, m, createMetaInf, validatePaths)
+	err = genMetaInfo(loc, targetArtifacts, loc, deploymentDescriptor, platform, m, params, createMetaInf, validatePaths)
 	return err
 }
 
 // genMetaInfo generates a MANIFEST.MF file and updates the build artifacts paths for deployment purposes.
 func genMetaInfo(source dir.IModule, ep dir.ITargetArtifacts, targetPathGetter dir.ITargetPath, deploymentDesc bool,
-	platform string, mtaStr *mta.MTA, createMetaInf bool, validatePaths bool) (rerr error) {
+	platform string, mtaStr *mta.MTA, params buildparams.Modules, createMetaInf bool, validatePaths bool) (rerr error) {
 
 	if createMetaInf {
 		// Set the MANIFEST.MF file
-		err := setManifestDesc(source, ep, targetPathGetter, deploymentDesc, mtaStr.Modules, mtaStr.Resources, platform)
+		err := setManifestDesc(source, ep, targetPathGetter, deploymentDesc, mtaStr.Modules, params, mtaStr.Resources, platform)
 		if err != nil {
 			return errors.Wrap(err, genMetaPopulatingMsg)
 		}
 	}
 
-	err := genMtad(mtaStr, ep, targetPathGetter, deploymentDesc, platform, validatePaths, nil, yaml.Marshal)
+	err := genMtad(mtaStr, params, ep, targetPathGetter, deploymentDesc, platform, validatePaths, nil, yaml.Marshal)
 	if err != nil {
 		return errors.Wrap(err, genMetaMTADMsg)
 	}
@@ -78,8 +84,8 @@ func genMetaInfo(source dir.IModule, ep dir.ITargetArtifacts, targetPathGetter d
 
 // ConvertTypes - convert types to appropriate target platform types
 func ConvertTypes(mtaStr mta.MTA, platformName string) error {
-	// Load platform configuration file
-	platformCfg, err := platform.Unmarshal(platform.PlatformConfig)
+	// Load the embedded platform configuration merged with the external one
+	platformCfg, err := platform.GetPlatforms()
 	if err == nil {
 		// Modify MTAD object according to platform types
 		platform.ConvertTypes(mtaStr, platformCfg, platformName)
@@ -48,7 +48,7 @@ var _ = Describe("Meta", func() {
 			createDirInTmpFolder("mtahtml5", "ui5app2")
 			createDirInTmpFolder("mtahtml5", "testapp")
 			err := ExecuteGenMeta(getTestPath("mtahtml5"), "", getResultPath(), "dev", nil, "xx", os.Getwd)
-			checkError(err, invalidPlatformMsg, "xx")
+			checkError(err, invalidPlatformMsg, "xx", `"cf", "neo", "xsa"`)
 		})
 		It("generateMeta fails on wrong source path - parse mta fails", func() {
 			err := ExecuteGenMeta(getTestPath("mtahtml6"), "", getResultPath(), "dev", nil, "cf", os.Getwd)
@@ -75,7 +75,7 @@ modules:
 			createDirInTmpFolder("testproject", "htmlapp")
 			createFileInTmpFolder("testproject", "htmlapp", "data.zip")
 			createDirInTmpFolder("testproject", "META-INF")
-			Ω(genMetaInfo(&ep, &ep, &ep, ep.IsDeploymentDescriptor(), "cf", m, true, true)).Should(Succeed())
+			Ω(genMetaInfo(&ep, &ep, &ep, ep.IsDeploymentDescriptor(), "cf", m, decodeAll(m), true, true)).Should(Succeed())
 			Ω(ep.GetManifestPath()).Should(BeAnExistingFile())
 			Ω(ep.GetMtadPath()).Should(BeAnExistingFile())
 		})
@@ -87,7 +87,7 @@ modules:
 			createDirInTmpFolder("testproject", "META-INF")
 			cfg := platform.PlatformConfig
 			platform.PlatformConfig = []byte(`very bad config`)
-			Ω(genMetaInfo(&ep, &ep, &ep, ep.IsDeploymentDescriptor(), "cf", m, true, true)).Should(HaveOccurred())
+			Ω(genMetaInfo(&ep, &ep, &ep, ep.IsDeploymentDescriptor(), "cf", m, decodeAll(m), true, true)).Should(HaveOccurred())
 			platform.PlatformConfig = cfg
 		})
 
@@ -95,7 +95,7 @@ modules:
 			loc := testLoc{ep}
 			m, err := mta.Unmarshal(mtaSingleModule)
 			Ω(err).Should(Succeed())
-			Ω(genMetaInfo(&loc, &ep, &ep, ep.IsDeploymentDescriptor(), "cf", m, true, true)).Should(HaveOccurred())
+			Ω(genMetaInfo(&loc, &ep, &ep, ep.IsDeploymentDescriptor(), "cf", m, decodeAll(m), true, true)).Should(HaveOccurred())
 		})
 
 		var _ = Describe("Fails on setManifestDesc", func() {
@@ -119,7 +119,7 @@ cli_version:["x"]
 			It("Fails on get version", func() {
 				m, err := mta.Unmarshal(mtaSingleModule)
 				Ω(err).Should(Succeed())
-				Ω(genMetaInfo(&ep, &ep, &ep, ep.IsDeploymentDescriptor(), "cf", m, true, true)).Should(HaveOccurred())
+				Ω(genMetaInfo(&ep, &ep, &ep, ep.IsDeploymentDescriptor(), "cf", m, decodeAll(m), true, true)).Should(HaveOccurred())
 			})
 		})
 	})
@@ -182,7 +182,7 @@ cli_version:["x"]
 				ep := dir.Loc{SourcePath: getTestPath("mtahtml5"), TargetPath: getResultPath()}
 				err := generateMeta(&ep, &ep, false, "cf", true, true)
 				Ω(err).Should(HaveOccurred())
-				Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(genMTADTypeTypeCnvMsg, "cf")))
+				Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(genMTADParamsMsg, "cf")))
 				Ω(err.Error()).Should(ContainSubstring(platform.UnmarshalFailedMsg))
 			})
 		})
@@ -280,6 +280,10 @@ func (loc *testLoc) GetTargetTmpRoot() string {
 	return loc.loc.GetTargetTmpRoot()
 }
 
+func (loc *testLoc) GetTargetCacheDir() string {
+	return loc.loc.GetTargetCacheDir()
+}
+
 func createMtahtml5TmpFolder() {
 	createDirInTmpFolder("mtahtml5", "ui5app2")
 	createDirInTmpFolder("mtahtml5", "ui5app")
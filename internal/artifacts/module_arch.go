@@ -5,25 +5,27 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v2"
 
 	"github.com/pkg/errors"
 
 	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildconfig"
 	"github.com/SAP/cloud-mta-build-tool/internal/buildops"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
 	"github.com/SAP/cloud-mta-build-tool/internal/commands"
+	"github.com/SAP/cloud-mta-build-tool/internal/conttype"
 	"github.com/SAP/cloud-mta-build-tool/internal/exec"
 	"github.com/SAP/cloud-mta-build-tool/internal/logs"
 	"github.com/SAP/cloud-mta/mta"
 )
 
-const (
-	ignore = "ignore"
-)
-
-// ExecuteBuild - executes build of module from Makefile
-func ExecuteBuild(source, mtaYamlFilename, target string, extensions []string, moduleName, platform string, wdGetter func() (string, error)) error {
+// ExecuteBuild - executes build of module from Makefile;
+// if the report folder is provided, the module build report is written to it
+func ExecuteBuild(source, mtaYamlFilename, target string, extensions []string, moduleName, platform, reportDir string,
+	wdGetter func() (string, error)) error {
 	if moduleName == "" {
 		return errors.New(buildFailedOnEmptyModuleMsg)
 	}
@@ -34,17 +36,44 @@ func ExecuteBuild(source, mtaYamlFilename, target string, extensions []string, m
 		return errors.Wrapf(err, buildFailedMsg, moduleName)
 	}
 
-	err = buildModule(loc, loc, moduleName, platform, true, true, map[string]string{})
+	report := newModuleReport(reportDir, moduleName)
+	err = buildModule(loc, loc, moduleName, platform, true, true, map[string]string{}, newBuildCache(loc), report)
+	report.setError(err)
+	reportErr := writeModuleReport(reportDir, report)
 	if err != nil {
 		return err
 	}
+	if reportErr != nil {
+		return reportErr
+	}
 	logs.Logger.Infof(buildFinishedMsg, moduleName)
 	return nil
 }
 
-// ExecuteSoloBuild - executes build of module from stand alone command
+// ExecuteSoloBuild - executes build of module from stand alone command;
+// if the report file is provided, the build report is written to it
 func ExecuteSoloBuild(source, mtaYamlFilename, target string, extensions []string, modulesNames []string, allDependencies bool,
-	generateMtadFlag bool, platform string,
+	generateMtadFlag bool, platform, report string,
+	wdGetter func() (string, error)) error {
+
+	reportDir, err := createReportDir(report)
+	if err != nil {
+		return err
+	}
+	err = executeSoloBuild(source, mtaYamlFilename, target, extensions, modulesNames, allDependencies, generateMtadFlag, platform,
+		reportDir, wdGetter)
+	reportErr := writeBuildReport(report, reportDir, source, mtaYamlFilename, extensions, platform, "", err, wdGetter)
+	if err != nil {
+		if reportErr != nil {
+			logs.Logger.Error(reportErr)
+		}
+		return err
+	}
+	return reportErr
+}
+
+func executeSoloBuild(source, mtaYamlFilename, target string, extensions []string, modulesNames []string, allDependencies bool,
+	generateMtadFlag bool, platform, reportDir string,
 	wdGetter func() (string, error)) error {
 
 	if len(modulesNames) == 0 {
@@ -65,16 +94,20 @@ func ExecuteSoloBuild(source, mtaYamlFilename, target string, extensions []strin
 	if err != nil {
 		return err
 	}
+	params, err := buildparams.DecodeAll(mtaObj)
+	if err != nil {
+		return wrapBuildError(err, modulesNames)
+	}
 
 	// Fail-fast check on modules whose build results are resolved (not glob patterns),
 	// so we can give the error before building the modules.
 	// After the build we perform another check on the actual build result paths (including glob patterns)
-	err = checkResolvedBuildResultsConflicts(mtaObj, sourceDir, mtaYamlFilename, target, extensions, modulesNames, wdGetter)
+	err = checkResolvedBuildResultsConflicts(mtaObj, params, sourceDir, mtaYamlFilename, target, extensions, modulesNames, wdGetter)
 	if err != nil {
 		return wrapBuildError(err, modulesNames)
 	}
 
-	allModulesSorted, err := buildops.GetModulesNames(mtaObj)
+	allModulesSorted, err := buildops.GetModulesNames(mtaObj, params)
 	if err != nil {
 		return wrapBuildError(err, modulesNames)
 	}
@@ -89,7 +122,7 @@ func ExecuteSoloBuild(source, mtaYamlFilename, target string, extensions []strin
 	if allDependencies {
 		selectedModulesWithDependenciesMap = make(map[string]bool)
 		for module := range selectedModulesMap {
-			err = collectSelectedModulesAndDependencies(mtaObj, selectedModulesWithDependenciesMap, module)
+			err = collectSelectedModulesAndDependencies(mtaObj, params, selectedModulesWithDependenciesMap, module)
 			if err != nil {
 				return wrapBuildError(err, modulesNames)
 			}
@@ -106,13 +139,13 @@ func ExecuteSoloBuild(source, mtaYamlFilename, target string, extensions []strin
 		logs.Logger.Infof(multiBuildMsg, `"`+strings.Join(sortedModules, `", "`)+`"`)
 	}
 
-	packedModulePaths, err := buildModules(sourceDir, mtaYamlFilename, target, extensions, sortedModules, selectedModulesMap, wdGetter)
+	packedModulePaths, err := buildModules(sourceDir, mtaYamlFilename, target, extensions, sortedModules, selectedModulesMap, reportDir, wdGetter)
 	if err != nil {
 		return wrapBuildError(err, modulesNames)
 	}
 
 	if generateMtadFlag {
-		err = generateMtad(mtaObj, loc, target, platform, packedModulePaths, wdGetter)
+		err = generateMtad(mtaObj, params, loc, target, platform, packedModulePaths, wdGetter)
 		if err != nil {
 			return wrapBuildError(err, modulesNames)
 		}
@@ -125,7 +158,8 @@ func ExecuteSoloBuild(source, mtaYamlFilename, target string, extensions []strin
 	return nil
 }
 
-func checkResolvedBuildResultsConflicts(mtaObj *mta.MTA, source, mtaYamlFilename, target string, extensions []string, modulesNames []string, wdGetter func() (string, error)) error {
+func checkResolvedBuildResultsConflicts(mtaObj *mta.MTA, params buildparams.Modules, source, mtaYamlFilename, target string, extensions []string,
+	modulesNames []string, wdGetter func() (string, error)) error {
 
 	resultPathModuleNameMap := make(map[string]string)
 
@@ -140,11 +174,11 @@ func checkResolvedBuildResultsConflicts(mtaObj *mta.MTA, source, mtaYamlFilename
 			return err
 		}
 
-		_, defaultBuildResult, err := commands.CommandProvider(*module)
+		_, defaultBuildResult, err := commands.CommandProvider(*module, params[module.Name])
 		if err != nil {
 			return err
 		}
-		targetArtifact, _, err := buildops.GetModuleTargetArtifactPath(moduleLoc, false, module, defaultBuildResult, false)
+		targetArtifact, _, err := buildops.GetModuleTargetArtifactPath(moduleLoc, false, module, params[module.Name], defaultBuildResult, false)
 		if err != nil {
 			return err
 		}
@@ -163,7 +197,7 @@ func checkResolvedBuildResultsConflicts(mtaObj *mta.MTA, source, mtaYamlFilename
 	return nil
 }
 
-func generateMtad(mtaObj *mta.MTA, loc dir.ITargetPath, target string,
+func generateMtad(mtaObj *mta.MTA, params buildparams.Modules, loc dir.ITargetPath, target string,
 	platform string, packedModulePaths map[string]string, wdGetter func() (string, error)) error {
 
 	platform, err := validatePlatform(platform)
@@ -177,7 +211,7 @@ func generateMtad(mtaObj *mta.MTA, loc dir.ITargetPath, target string,
 	}
 	mtadLocation := mtadLoc{path: mtadTargetPath}
 
-	return genMtad(mtaObj, &mtadLocation, loc, false, platform, false, packedModulePaths, yaml.Marshal)
+	return genMtad(mtaObj, params, &mtadLocation, loc, false, platform, false, packedModulePaths, yaml.Marshal)
 }
 
 func getMtadPath(target string, wdGetter func() (string, error)) (string, error) {
@@ -194,7 +228,7 @@ func wrapBuildError(err error, modules []string) error {
 	return errors.Wrapf(err, multiBuildFailedMsg)
 }
 
-func collectSelectedModulesAndDependencies(mtaObj *mta.MTA, modulesWithDependencies map[string]bool, moduleName string) error {
+func collectSelectedModulesAndDependencies(mtaObj *mta.MTA, params buildparams.Modules, modulesWithDependencies map[string]bool, moduleName string) error {
 
 	if modulesWithDependencies[moduleName] {
 		return nil
@@ -205,13 +239,13 @@ func collectSelectedModulesAndDependencies(mtaObj *mta.MTA, modulesWithDependenc
 	if err != nil {
 		return err
 	}
-	for _, requires := range buildops.GetBuildRequires(module) {
+	for _, requires := range params[module.Name].Requires {
 		requiredModule, err := mtaObj.GetModuleByName(requires.Name)
 		if err != nil {
 			return err
 		}
 
-		err = collectSelectedModulesAndDependencies(mtaObj, modulesWithDependencies, requiredModule.Name)
+		err = collectSelectedModulesAndDependencies(mtaObj, params, modulesWithDependencies, requiredModule.Name)
 		if err != nil {
 			return err
 		}
@@ -220,11 +254,11 @@ func collectSelectedModulesAndDependencies(mtaObj *mta.MTA, modulesWithDependenc
 }
 
 func buildModules(source, mtaYamlFilename, target string, extensions []string, modulesToBuild []string,
-	modulesToPack map[string]bool, wdGetter func() (string, error)) (packedModulePaths map[string]string, err error) {
+	modulesToPack map[string]bool, reportDir string, wdGetter func() (string, error)) (packedModulePaths map[string]string, err error) {
 
 	buildResults := make(map[string]string)
 	for _, module := range modulesToBuild {
-		err := buildSelectedModule(source, mtaYamlFilename, target, extensions, module, modulesToPack[module], buildResults, wdGetter)
+		err := buildSelectedModule(source, mtaYamlFilename, target, extensions, module, modulesToPack[module], buildResults, reportDir, wdGetter)
 
 		if err != nil {
 			return nil, err
@@ -239,19 +273,26 @@ func buildModules(source, mtaYamlFilename, target string, extensions []string, m
 }
 
 func buildSelectedModule(source, mtaYamlFilename, target string, extensions []string, module string,
-	toPack bool, buildResults map[string]string, wdGetter func() (string, error)) error {
+	toPack bool, buildResults map[string]string, reportDir string, wdGetter func() (string, error)) error {
 
 	logs.Logger.Infof(buildMsg, module)
 
-	moduleLoc, err := getModuleLocation(source, mtaYamlFilename, target, module, extensions, wdGetter)
+	loc, err := getModuleTargetLocation(source, mtaYamlFilename, target, module, extensions, wdGetter)
 	if err != nil {
 		return err
 	}
+	moduleLoc := dir.ModuleLocation(loc, target != "")
 
-	err = buildModule(moduleLoc, moduleLoc, module, "", false, toPack, buildResults)
+	report := newModuleReport(reportDir, module)
+	err = buildModule(moduleLoc, moduleLoc, module, "", false, toPack, buildResults, newModuleBuildCache(loc, moduleLoc), report)
+	report.setError(err)
+	reportErr := writeModuleReport(reportDir, report)
 	if err != nil {
 		return err
 	}
+	if reportErr != nil {
+		return reportErr
+	}
 
 	logs.Logger.Infof(buildFinishedMsg, module)
 	return nil
@@ -269,17 +310,22 @@ func sortModules(allModulesSorted []string, selectedModulesMap map[string]bool)
 }
 
 func getModuleLocation(source, mtaYamlFilename, target, moduleName string, extensions []string, wdGetter func() (string, error)) (*dir.ModuleLoc, error) {
-	targetDir, err := getSoloModuleBuildAbsTarget(source, target, moduleName, wdGetter)
+	loc, err := getModuleTargetLocation(source, mtaYamlFilename, target, moduleName, extensions, wdGetter)
 	if err != nil {
 		return nil, err
 	}
 
-	loc, err := dir.Location(source, mtaYamlFilename, targetDir, dir.Dev, extensions, wdGetter)
+	return dir.ModuleLocation(loc, target != ""), nil
+}
+
+// getModuleTargetLocation - gets the location whose target is the folder of the stand alone module build results
+func getModuleTargetLocation(source, mtaYamlFilename, target, moduleName string, extensions []string, wdGetter func() (string, error)) (*dir.Loc, error) {
+	targetDir, err := getSoloModuleBuildAbsTarget(source, target, moduleName, wdGetter)
 	if err != nil {
 		return nil, err
 	}
 
-	return dir.ModuleLocation(loc, target != ""), nil
+	return dir.Location(source, mtaYamlFilename, targetDir, dir.Dev, extensions, wdGetter)
 }
 
 func getSoloModuleBuildAbsSource(source string, wdGetter func() (string, error)) (string, error) {
@@ -305,8 +351,10 @@ func getSoloModuleBuildAbsTarget(absSource, target, moduleName string, wdGetter
 	return filepath.Join(target, tmpFolderName, moduleName), nil
 }
 
-// ExecutePack - executes packing of module
-func ExecutePack(source, mtaYamlFilename, target string, extensions []string, moduleName, platform string, wdGetter func() (string, error)) error {
+// ExecutePack - executes packing of module;
+// if the report folder is provided, the module build report is written to it
+func ExecutePack(source, mtaYamlFilename, target string, extensions []string, moduleName, platform, reportDir string,
+	wdGetter func() (string, error)) error {
 	logs.Logger.Infof(packMsg, moduleName)
 
 	loc, err := dir.Location(source, mtaYamlFilename, target, dir.Dev, extensions, wdGetter)
@@ -319,31 +367,124 @@ func ExecutePack(source, mtaYamlFilename, target string, extensions []string, mo
 		return err
 	}
 
-	module, _, defaultBuildResult, err := commands.GetModuleAndCommands(loc, moduleName)
+	mtaObj, err := loc.ParseFile()
+	if err != nil {
+		return errors.Wrapf(err, packFailedOnCommandsMsg, moduleName)
+	}
+	modulesParams, err := buildparams.DecodeAll(mtaObj)
+	if err != nil {
+		return errors.Wrapf(err, packFailedOnCommandsMsg, moduleName)
+	}
+	module, params, mCmd, defaultBuildResult, err := commands.GetParsedModuleAndCommands(mtaObj, modulesParams, moduleName)
 	if err != nil {
 		return errors.Wrapf(err, packFailedOnCommandsMsg, moduleName)
 	}
 
-	if buildops.IfNoSource(module) {
+	if params.NoSource {
 		logs.Logger.Infof(packSkippedMsg, module.Name)
 		return nil
 	}
 
+	if !buildops.ProfileDefined(params) {
+		logs.Logger.Infof(packSkippedOnProfileMsg, module.Name, buildconfig.Profile())
+		return nil
+	}
+
 	if module.Path == "" {
 		return fmt.Errorf(packFailedOnEmptyPathMsg, moduleName)
 	}
 
-	err = packModule(loc, module, moduleName, platform, defaultBuildResult, true, map[string]string{})
+	// the module commands are executed before packing by a separate command, which writes their result to the module report
+	report := readModuleReport(reportDir, moduleName)
+	report.setCommands(module, params, loc.GetSourceModuleDir(module.Path), mCmd)
+	err = packModule(loc, module, params, moduleName, platform, defaultBuildResult, true, map[string]string{}, report)
+	report.setError(err)
+	reportErr := writeModuleReport(reportDir, report)
 	if err != nil {
 		return err
 	}
+	// the module pack is the last step of the module build in the verbose Makefile
+	if cache := newBuildCache(loc); cache != nil {
+		storeModuleInCache(cache, mtaObj, modulesParams, loc, module, mCmd, defaultBuildResult, platform)
+	}
+	return reportErr
+}
 
-	return nil
+// ExecuteModuleRestore - restores the packed build result of the module from the build cache
+// before the module build in the verbose Makefile; returns false if the module has to be built;
+// if the report folder is provided, the module restored from the build cache is written to the module report
+func ExecuteModuleRestore(source, mtaYamlFilename, target string, extensions []string, moduleName, platform, reportDir string,
+	wdGetter func() (string, error)) (bool, error) {
+
+	loc, err := dir.Location(source, mtaYamlFilename, target, dir.Dev, extensions, wdGetter)
+	if err != nil {
+		return false, errors.Wrapf(err, restoreFailedOnLocMsg, moduleName)
+	}
+	cache := newBuildCache(loc)
+	if cache == nil {
+		return false, nil
+	}
+	platform, err = validatePlatform(platform)
+	if err != nil {
+		return false, err
+	}
+
+	mtaObj, err := loc.ParseFile()
+	if err != nil {
+		return false, errors.Wrapf(err, buildFailedOnCommandsMsg, moduleName)
+	}
+	modulesParams, err := buildparams.DecodeAll(mtaObj)
+	if err != nil {
+		return false, errors.Wrapf(err, buildFailedOnCommandsMsg, moduleName)
+	}
+	module, params, mCmd, defaultBuildResult, err := commands.GetParsedModuleAndCommands(mtaObj, modulesParams, moduleName)
+	if err != nil {
+		return false, errors.Wrapf(err, buildFailedOnCommandsMsg, moduleName)
+	}
+	if params.NoSource || module.Path == "" {
+		return false, nil
+	}
+
+	report := newModuleReport(reportDir, moduleName)
+	restored, err := restoreModule(mtaObj, modulesParams, loc, module, mCmd, defaultBuildResult, platform, map[string]string{}, cache, report)
+	if err != nil || !restored {
+		return false, err
+	}
+	return true, writeModuleReport(reportDir, report)
+}
+
+// ExecuteModuleCommands - executes the build commands of the module in the working folder;
+// if the module and the report folder are provided, the exit code and the duration of the commands execution
+// are written to the module report, so the module whose commands fail is reported too
+func ExecuteModuleCommands(cmds []string, policies []commands.CommandPolicy, timeout, workingDir, moduleName, reportDir string) error {
+	if moduleName == "" {
+		reportDir = ""
+	}
+	report := newModuleReport(reportDir, moduleName)
+	if report != nil {
+		absWorkingDir, err := filepath.Abs(workingDir)
+		if err != nil {
+			return err
+		}
+		report.WorkingDir = absWorkingDir
+		report.Commands = cmds
+	}
+	start := time.Now()
+	err := exec.ExecuteCommandsWithPolicies(cmds, policies, timeout, workingDir, true)
+	report.setExecResult(start, err)
+	report.setError(err)
+	reportErr := writeModuleReport(reportDir, report)
+	if err != nil {
+		return err
+	}
+	return reportErr
 }
 
-// buildModule - builds module
+// buildModule - builds module;
+// when the build cache is provided, the module build is skipped if its cached build result is up to date;
+// the build details are collected in the module report
 func buildModule(mtaParser dir.IMtaParser, moduleLoc dir.IModule, moduleName, platform string,
-	checkPlatform bool, toPack bool, buildResults map[string]string) error {
+	checkPlatform bool, toPack bool, buildResults map[string]string, cache *buildCache, report *moduleReport) error {
 
 	var err error
 	if checkPlatform {
@@ -354,30 +495,58 @@ func buildModule(mtaParser dir.IMtaParser, moduleLoc dir.IModule, moduleName, pl
 		}
 	}
 
+	// the MTA file is parsed and the build parameters of its modules are decoded once for the whole module build
+	mtaObj, err := mtaParser.ParseFile()
+	if err != nil {
+		return errors.Wrapf(err, buildFailedOnCommandsMsg, moduleName)
+	}
+	modulesParams, err := buildparams.DecodeAll(mtaObj)
+	if err != nil {
+		return errors.Wrapf(err, buildFailedOnCommandsMsg, moduleName)
+	}
 	// Get module respective command's to execute
-	module, mCmd, defaultBuildResults, err := commands.GetModuleAndCommands(mtaParser, moduleName)
+	module, params, mCmd, defaultBuildResults, err := commands.GetParsedModuleAndCommands(mtaObj, modulesParams, moduleName)
 	if err != nil {
 		return errors.Wrapf(err, buildFailedOnCommandsMsg, moduleName)
 	}
 
-	if buildops.IfNoSource(module) {
+	if params.NoSource {
 		logs.Logger.Infof(buildSkippedMsg, module.Name)
 		return nil
 	}
 
+	// the modules of other build profiles are built only when the modules of the build profile require them
+	profileBuilt, err := isProfileBuilt(mtaObj, modulesParams, module)
+	if err != nil {
+		return errors.Wrapf(err, buildFailedMsg, moduleName)
+	}
+	if !profileBuilt {
+		logs.Logger.Infof(buildSkippedOnProfileMsg, module.Name, buildconfig.Profile())
+		return nil
+	}
+
 	if module.Path == "" {
 		return fmt.Errorf(buildFailedOnEmptyPathMsg, moduleName)
 	}
 
 	// Development descriptor - build includes:
 	// 1. module dependencies processing
-	e := buildops.ProcessDependencies(mtaParser, moduleLoc, moduleName)
+	e := buildops.ProcessDependencies(mtaObj, modulesParams, moduleLoc, moduleName)
 	if e != nil {
 		return errors.Wrapf(e, buildFailedOnDepsMsg, moduleName)
 	}
 
+	useCache := cache != nil && toPack
+	if useCache {
+		restored, e := restoreModule(mtaObj, modulesParams, moduleLoc, module, mCmd, defaultBuildResults, platform, buildResults, cache, report)
+		if e != nil || restored {
+			return e
+		}
+	}
+
 	// 2. module type dependent commands execution
 	modulePath := moduleLoc.GetSourceModuleDir(module.Path)
+	report.setCommands(module, params, modulePath, mCmd)
 
 	// Get module commands
 	commandList, e := commands.CmdConverter(modulePath, mCmd)
@@ -386,43 +555,127 @@ func buildModule(mtaParser dir.IMtaParser, moduleLoc dir.IModule, moduleName, pl
 	}
 
 	// Execute child-process with module respective commands
-	var timeout string
-	if module.BuildParams != nil && module.BuildParams["timeout"] != nil {
-		var ok bool
-		timeout, ok = module.BuildParams["timeout"].(string)
-		if !ok {
-			return errors.Errorf(exec.ExecInvalidTimeoutMsg, fmt.Sprint(module.BuildParams["timeout"]))
-		}
+	env, e := commands.GetModuleExecEnv(mtaObj, module, params)
+	if e != nil {
+		return errors.Wrapf(e, buildFailedOnEnvMsg, moduleName)
 	}
-	e = exec.ExecuteWithTimeout(commandList, timeout, true)
+	policies, e := commands.GetCommandPolicies(module, params, len(commandList))
+	if e != nil {
+		return errors.Wrapf(e, buildFailedOnCommandsMsg, moduleName)
+	}
+	e = execModuleHook(modulePath, module, params, commands.BeforeBuildHook, env)
+	if e != nil {
+		return e
+	}
+	start := time.Now()
+	e = exec.ExecuteWithPolicies(commandList, policies, params.Timeout, env, true)
+	report.setExecResult(start, e)
 	if e != nil {
 		return errors.Wrapf(e, buildFailedMsg, moduleName)
 	}
+	e = execModuleHook(modulePath, module, params, commands.AfterBuildHook, env)
+	if e != nil {
+		return e
+	}
 
 	if toPack {
 		// 3. Packing the modules build artifacts (include node modules)
 		// into the artifactsPath dir as data zip
-		return packModule(moduleLoc, module, moduleName, platform, defaultBuildResults, checkPlatform, buildResults)
+		e = execModuleHook(modulePath, module, params, commands.BeforePackHook, env)
+		if e != nil {
+			return e
+		}
+		e = packModule(moduleLoc, module, params, moduleName, platform, defaultBuildResults, checkPlatform, buildResults, report)
+		if e != nil {
+			return e
+		}
 	}
 
+	if useCache {
+		storeModuleInCache(cache, mtaObj, modulesParams, moduleLoc, module, mCmd, defaultBuildResults, platform)
+	}
+
+	return nil
+}
+
+// execModuleHook - executes the commands of the module build hook, if it is defined, with the module build environment;
+// the working directory of the hook is relative to the module folder
+func execModuleHook(modulePath string, module *mta.Module, params *buildparams.Module, phase string, env []string) error {
+	hook := commands.GetModuleHook(params, phase)
+	if hook == nil {
+		return nil
+	}
+	logs.Logger.Infof(execHookMsg, phase, module.Name)
+	commandList, err := commands.CmdConverter(filepath.Join(modulePath, hook.WorkingDir), hook.Commands)
+	if err != nil {
+		return errors.Wrapf(err, execHookFailedMsg, phase, module.Name)
+	}
+	err = exec.ExecuteWithTimeoutAndEnv(commandList, hook.Timeout, env, true)
+	if err != nil {
+		return errors.Wrapf(err, execHookFailedMsg, phase, module.Name)
+	}
 	return nil
 }
 
+// restoreModule - restores the packed build result of the module from the build cache if the cache key of the module matches
+func restoreModule(mtaObj *mta.MTA, modulesParams buildparams.Modules, moduleLoc dir.IModule, module *mta.Module, commands []string,
+	defaultBuildResult, platform string, buildResults map[string]string, cache *buildCache, report *moduleReport) (bool, error) {
+
+	if !isModulePacked(modulesParams[module.Name], platform) {
+		return false, nil
+	}
+	key, err := cache.getModuleKey(mtaObj, modulesParams, moduleLoc, module, commands, defaultBuildResult, platform)
+	if err != nil {
+		return false, errors.Wrapf(err, buildFailedOnCacheKeyMsg, module.Name)
+	}
+	targetArtifact, restored, err := restoreModuleFromCache(cache, moduleLoc, module.Name, key, buildResults)
+	if err != nil || !restored {
+		return false, err
+	}
+	logs.Logger.Infof(buildRestoredFromCacheMsg, module.Name)
+	if report != nil {
+		report.Cached = true
+		err = report.setArtifact("", targetArtifact)
+		if err != nil {
+			return false, errors.Wrapf(err, buildFailedMsg, module.Name)
+		}
+	}
+	return true, nil
+}
+
+// isProfileBuilt - checks if the module is built with the build profile
+func isProfileBuilt(mtaObj *mta.MTA, modulesParams buildparams.Modules, module *mta.Module) (bool, error) {
+	if buildops.ProfileDefined(modulesParams[module.Name]) {
+		return true, nil
+	}
+	return buildops.ProfileBuilt(mtaObj, modulesParams, module.Name)
+}
+
+// isModulePacked - checks if the build result of the module is packed: the module supports the platform
+// and is included in the build profile
+func isModulePacked(params *buildparams.Module, platform string) bool {
+	return buildops.PlatformDefined(params, platform) && buildops.ProfileDefined(params)
+}
+
 // packModule - pack build module artifacts
-func packModule(moduleLoc dir.IModule, module *mta.Module, moduleName, platform, defaultBuildResult string,
-	checkPlatform bool, buildResults map[string]string) error {
+func packModule(moduleLoc dir.IModule, module *mta.Module, params *buildparams.Module, moduleName, platform, defaultBuildResult string,
+	checkPlatform bool, buildResults map[string]string, report *moduleReport) error {
 
-	if checkPlatform && !buildops.PlatformDefined(module, platform) {
+	if checkPlatform && !buildops.PlatformDefined(params, platform) {
+		return nil
+	}
+	if !buildops.ProfileDefined(params) {
+		logs.Logger.Infof(packSkippedOnProfileMsg, module.Name, buildconfig.Profile())
 		return nil
 	}
 
 	logs.Logger.Info(fmt.Sprintf(buildResultMsg, moduleName, moduleLoc.GetTargetModuleDir(moduleName)))
 
-	sourceArtifact, err := buildops.GetModuleSourceArtifactPath(moduleLoc, false, module, defaultBuildResult, true)
+	sourceArtifact, err := buildops.GetModuleSourceArtifactPath(moduleLoc, false, module, params, defaultBuildResult, true)
 	if err != nil {
 		return errors.Wrapf(err, packFailedOnBuildArtifactMsg, moduleName)
 	}
-	targetArtifact, toArchive, err := buildops.GetModuleTargetArtifactPath(moduleLoc, false, module, defaultBuildResult, true)
+	targetArtifact, toArchive, err := buildops.GetModuleTargetArtifactPath(moduleLoc, false, module, params, defaultBuildResult, true)
 	if err != nil {
 		return errors.Wrapf(err, packFailedOnTargetArtifactMsg, moduleName)
 	}
@@ -434,10 +687,19 @@ func packModule(moduleLoc dir.IModule, module *mta.Module, moduleName, platform,
 	buildResults[targetArtifact] = moduleName
 
 	if !toArchive {
-		return copyModuleArchiveToResultDir(sourceArtifact, targetArtifact, moduleName)
+		err = copyModuleArchiveToResultDir(sourceArtifact, targetArtifact, moduleName)
+	} else {
+		err = archiveModuleToResultDir(sourceArtifact, targetArtifact, getIgnores(moduleLoc, params.Ignore, sourceArtifact), getCompression(params), moduleName)
+	}
+	if err != nil {
+		return err
 	}
 
-	return archiveModuleToResultDir(sourceArtifact, targetArtifact, getIgnores(moduleLoc, module, sourceArtifact), moduleName)
+	err = report.setArtifact(sourceArtifact, targetArtifact)
+	if err != nil {
+		return errors.Wrapf(err, packFailedOnReportMsg, moduleName)
+	}
+	return nil
 }
 
 func copyModuleArchiveToResultDir(source, target, moduleName string) error {
@@ -456,41 +718,70 @@ func copyModuleArchiveToResultDir(source, target, moduleName string) error {
 	return nil
 }
 
-func archiveModuleToResultDir(buildResult string, requestedResultFileName string, ignore []string, moduleName string) error {
+func archiveModuleToResultDir(buildResult string, requestedResultFileName string, ignore []string, compression, moduleName string) error {
+	storedExtensions, err := getStoredExtensions()
+	if err != nil {
+		return errors.Wrapf(err, PackFailedOnArchMsg, moduleName)
+	}
 	// Archive the folder without the ignored files and/or subfolders, which are excluded from the package.
-	err := dir.Archive(buildResult, requestedResultFileName, ignore)
+	err = dir.ArchiveWithCompression(buildResult, requestedResultFileName, ignore, compression, storedExtensions)
 	if err != nil {
 		return errors.Wrapf(err, PackFailedOnArchMsg, moduleName)
 	}
 	return nil
 }
 
-// getIgnores - get files and/or subfolders to exclude from the package.
-func getIgnores(moduleLoc dir.IModule, module *mta.Module, moduleResultPath string) []string {
-	var ignoreList []string
-	// ignore defined in build params is declared
-	if module.BuildParams != nil && module.BuildParams[ignore] != nil {
-		ignoreList = convert(module.BuildParams[ignore].([]interface{}))
+// getCompression - gets the compression of the module archive defined in the build parameters
+func getCompression(params *buildparams.Module) string {
+	if params.Compression != "" {
+		return params.Compression
 	}
+	return dir.CompressionDefault
+}
+
+// getStoredExtensions - gets the extensions of the binary archive content types, which are stored without compression
+func getStoredExtensions() ([]string, error) {
+	contentTypes, err := conttype.GetContentTypes()
+	if err != nil {
+		return nil, errors.Wrap(err, contentTypeCfgMsg)
+	}
+	return conttype.GetBinaryArchiveExtensions(contentTypes), nil
+}
+
+// getIgnores - get files and/or subfolders to exclude from the package, starting with the ones defined in the build params.
+func getIgnores(moduleLoc dir.IModule, ignore []string, moduleResultPath string) []string {
+	var ignoreList []string
+	ignoreList = append(ignoreList, ignore...)
 	// we add target folder to the list of ignores to avoid it's packaging
 	// it can be the case only when target folder is subfolder (on any level) of the archived folder path
 	// the ignored folder is the root where all the build results are created, even if we are building more than one module
 	targetFolder := moduleLoc.GetTargetTmpRoot()
-	relativeTarget, err := filepath.Rel(moduleResultPath, targetFolder)
-	if err == nil && !(relativeTarget == ".." || strings.HasPrefix(relativeTarget, ".."+string(os.PathSeparator))) {
+	relativeTarget, ok := getRelativeSubPath(moduleResultPath, targetFolder)
+	if ok {
 		ignoreList = append(ignoreList, relativeTarget)
 	}
+	// the build cache folder is next to the temporary folder in the target folder of the MTA build
+	cacheFolder := moduleLoc.GetTargetCacheDir()
+	if _, inTarget := getRelativeSubPath(targetFolder, cacheFolder); !inTarget {
+		relativeCache, ok := getRelativeSubPath(moduleResultPath, cacheFolder)
+		if ok {
+			ignoreList = append(ignoreList, relativeCache)
+		}
+	}
 
 	return ignoreList
 }
 
-// Convert slice []interface{} to slice []string
-func convert(data []interface{}) []string {
-	aString := make([]string, len(data))
-	for i, v := range data {
-		aString[i] = v.(string)
+// getRelativeSubPath - gets the path relative to the folder if the path is the folder or its subfolder (on any level)
+func getRelativeSubPath(folder, path string) (string, bool) {
+	if path == "" {
+		return "", false
 	}
-	return aString
+	relativePath, err := filepath.Rel(folder, path)
+	if err != nil || relativePath == ".." || strings.HasPrefix(relativePath, ".."+string(os.PathSeparator)) {
+		return "", false
+	}
+	return relativePath, true
 }
 
 // CopyMtaContent copies the content of all modules and resources which are presented in the deployment descriptor,
@@ -511,7 +802,11 @@ func CopyMtaContent(source, mtaYamlFilename, target string, extensions []string,
 		return err
 	}
 
-	err = copyRequiredDependencyContent(loc.GetSource(), loc.GetTargetTmpDir(), mtaObj, copyInParallel)
+	params, err := buildparams.DecodeAll(mtaObj)
+	if err != nil {
+		return errors.Wrapf(err, copyContentFailedMsg)
+	}
+	err = copyRequiredDependencyContent(loc.GetSource(), loc.GetTargetTmpDir(), mtaObj, params, copyInParallel)
 	if err != nil {
 		return err
 	}
@@ -527,13 +822,16 @@ func copyResourceContent(source, target string, mta *mta.MTA, copyInParallel boo
 	return copyMtaContent(source, target, getResourcesPaths(mta.Resources), copyInParallel)
 }
 
-func copyRequiredDependencyContent(source, target string, mta *mta.MTA, copyInParallel bool) error {
-	return copyMtaContent(source, target, getRequiredDependencyPaths(mta.Modules), copyInParallel)
+func copyRequiredDependencyContent(source, target string, mta *mta.MTA, params buildparams.Modules, copyInParallel bool) error {
+	return copyMtaContent(source, target, getRequiredDependencyPaths(mta.Modules, params), copyInParallel)
 }
 
-func getRequiredDependencyPaths(mtaModules []*mta.Module) []string {
+func getRequiredDependencyPaths(mtaModules []*mta.Module, params buildparams.Modules) []string {
 	result := make([]string, 0)
 	for _, module := range mtaModules {
+		if !buildops.ProfileDefined(params[module.Name]) {
+			continue
+		}
 		requiredDependenciesWithPaths := getRequiredDependenciesWithPathsForModule(module)
 		result = append(result, requiredDependenciesWithPaths...)
 	}
@@ -622,7 +920,7 @@ func getModulesWithPaths(mtaModules []*mta.Module) []string {
 func getResourcesPaths(resources []*mta.Resource) []string {
 	result := make([]string, 0)
 	for _, resource := range resources {
-		if resource.Parameters["path"] != nil {
+		if resource.Parameters["path"] != nil && buildops.ResourceProfileDefined(resource) {
 			result = append(result, resource.Parameters["path"].(string))
 		}
 	}
@@ -17,7 +17,9 @@ import (
 	"github.com/onsi/gomega/types"
 
 	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildconfig"
 	"github.com/SAP/cloud-mta-build-tool/internal/buildops"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
 	"github.com/SAP/cloud-mta-build-tool/internal/commands"
 	"github.com/SAP/cloud-mta-build-tool/internal/exec"
 	"github.com/SAP/cloud-mta-build-tool/internal/platform"
@@ -60,27 +62,27 @@ builders:
 	Describe("ExecuteBuild", func() {
 
 		It("Sanity", func() {
-			Ω(ExecuteBuild(getTestPath("mta"), "", getResultPath(), nil, "node-js", "cf", os.Getwd)).Should(Succeed())
+			Ω(ExecuteBuild(getTestPath("mta"), "", getResultPath(), nil, "node-js", "cf", "", os.Getwd)).Should(Succeed())
 			Ω(getFullPathInTmpFolder("mta", "node-js", "data.zip")).Should(BeAnExistingFile())
 
 		})
 
 		It("Fails on empty module", func() {
-			Ω(ExecuteBuild(getTestPath("mta"), "", getResultPath(), nil, "", "cf", os.Getwd)).Should(HaveOccurred())
+			Ω(ExecuteBuild(getTestPath("mta"), "", getResultPath(), nil, "", "cf", "", os.Getwd)).Should(HaveOccurred())
 
 		})
 
 		It("Fails on platform validation", func() {
-			Ω(ExecuteBuild(getTestPath("mta"), "", getResultPath(), nil, "node-js", "xx", os.Getwd)).Should(HaveOccurred())
+			Ω(ExecuteBuild(getTestPath("mta"), "", getResultPath(), nil, "node-js", "xx", "", os.Getwd)).Should(HaveOccurred())
 
 		})
 
 		It("Fails on location initialization", func() {
-			Ω(ExecuteBuild("", "", "", nil, "ui5app", "cf", failingGetWd)).Should(HaveOccurred())
+			Ω(ExecuteBuild("", "", "", nil, "ui5app", "cf", "", failingGetWd)).Should(HaveOccurred())
 		})
 
 		It("Fails on wrong module", func() {
-			Ω(ExecuteBuild(getTestPath("mta"), "", getResultPath(), nil, "ui5app", "cf", os.Getwd)).Should(HaveOccurred())
+			Ω(ExecuteBuild(getTestPath("mta"), "", getResultPath(), nil, "ui5app", "cf", "", os.Getwd)).Should(HaveOccurred())
 		})
 	})
 
@@ -94,7 +96,7 @@ builders:
 			})
 
 			It("module m3 with no supported platform is mot presented in the generated mtad.yaml", func() {
-				Ω(ExecuteSoloBuild(getTestPath("mtaModelsBuild"), "", getResultPath(), []string{"mtaext.yaml"}, []string{"m1", "m3"}, true, true, "cf", os.Getwd)).Should(Succeed())
+				Ω(ExecuteSoloBuild(getTestPath("mtaModelsBuild"), "", getResultPath(), []string{"mtaext.yaml"}, []string{"m1", "m3"}, true, true, "cf", "", os.Getwd)).Should(Succeed())
 				Ω(getTestPath("result", "data.zip")).Should(BeAnExistingFile())
 				Ω(getTestPath("result", "m3.zip")).Should(BeAnExistingFile())
 				validateArchiveContents([]string{"test.txt", "test2.txt", "test2_copy.txt"}, getTestPath("result", "data.zip"))
@@ -110,7 +112,7 @@ builders:
 			})
 
 			It("path in mtad.yaml refers to the temporary folder in the current folder when no target provided (current folder is provided by the mock function)", func() {
-				Ω(ExecuteSoloBuild(getTestPath("mtaModelsBuild"), "", "", []string{"mtaext.yaml"}, []string{"m1"}, true, true, "cf", func() (string, error) {
+				Ω(ExecuteSoloBuild(getTestPath("mtaModelsBuild"), "", "", []string{"mtaext.yaml"}, []string{"m1"}, true, true, "cf", "", func() (string, error) {
 					return getResultPath(), nil
 				})).Should(Succeed())
 				Ω(getTestPath("result", ".mtaModelsBuild_mta_build_tmp", "m1", "data.zip")).Should(BeAnExistingFile())
@@ -138,12 +140,12 @@ builders:
 				})
 
 				It("mtad.yaml generation fails", func() {
-					Ω(ExecuteSoloBuild(getTestPath("mtaModelsBuild"), "", getResultPath(), nil, []string{"m1", "m3"}, true, true, "cf", os.Getwd)).Should(HaveOccurred())
+					Ω(ExecuteSoloBuild(getTestPath("mtaModelsBuild"), "", getResultPath(), nil, []string{"m1", "m3"}, true, true, "cf", "", os.Getwd)).Should(HaveOccurred())
 				})
 			})
 
 			It("required module m2 has ready artifact 'test2.txt' and creates a new one 'test2_copy.txt', mtad.yaml not generated", func() {
-				Ω(ExecuteSoloBuild(getTestPath("mtaModelsBuild"), "", getResultPath(), nil, []string{"m1"}, true, false, "cf", os.Getwd)).Should(Succeed())
+				Ω(ExecuteSoloBuild(getTestPath("mtaModelsBuild"), "", getResultPath(), nil, []string{"m1"}, true, false, "cf", "", os.Getwd)).Should(Succeed())
 				Ω(getTestPath("result", "data.zip")).Should(BeAnExistingFile())
 				Ω(getTestPath("result", "m3.zip")).ShouldNot(BeAnExistingFile())
 				Ω(getTestPath("result", "mtad.yaml")).ShouldNot(BeAnExistingFile())
@@ -151,27 +153,33 @@ builders:
 			})
 
 			It("fails on platform validation when mtad.yaml should be generated", func() {
-				Ω(ExecuteSoloBuild(getTestPath("mtaModelsBuild"), "", getResultPath(), nil, []string{"m1"}, true, true, "xx", os.Getwd)).Should(HaveOccurred())
+				Ω(ExecuteSoloBuild(getTestPath("mtaModelsBuild"), "", getResultPath(), nil, []string{"m1"}, true, true, "xx", "", os.Getwd)).Should(HaveOccurred())
 			})
 
 		})
 
+		It("fails on wrong build parameters before the module build", func() {
+			err := ExecuteSoloBuild(getTestPath("mta_native_build"), "mtaWrongParams.yaml", getResultPath(), nil, []string{"m1"}, false, false, "cf", "", os.Getwd)
+			checkError(err, buildFailedMsg, "m1")
+			Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(buildparams.WrongParamMsg, buildparams.SupportedPlatformsParam, "m1", "a sequence of strings")))
+		})
+
 		It("modules m1 and m2 have conflicting build results detected on checkResolvedBuildResultsConflicts", func() {
-			err := ExecuteSoloBuild(getTestPath("mtaModelsBuild"), "", getResultPath(), nil, []string{"m1", "m2"}, true, false, "", os.Getwd)
+			err := ExecuteSoloBuild(getTestPath("mtaModelsBuild"), "", getResultPath(), nil, []string{"m1", "m2"}, true, false, "", "", os.Getwd)
 			Ω(err).Should(HaveOccurred())
 			Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(multiBuildWithPathsConflictMsg, "m2", "m1", getResultPath(), "data.zip")))
 			Ω(getTestPath("result", "test.zip")).ShouldNot(BeAnExistingFile())
 		})
 
 		It("modules ui5app1 and ui5app2 have conflicting build results not detected on checkResolvedBuildResultsConflicts (patterns build results)", func() {
-			err := ExecuteSoloBuild(getTestPath("mtaWithPatternBuildResults"), "", getResultPath(), nil, []string{"ui5app1", "ui5app2"}, true, false, "cf", os.Getwd)
+			err := ExecuteSoloBuild(getTestPath("mtaWithPatternBuildResults"), "", getResultPath(), nil, []string{"ui5app1", "ui5app2"}, true, false, "cf", "", os.Getwd)
 			Ω(err).Should(HaveOccurred())
 			Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(multiBuildWithPathsConflictMsg, "ui5app1", "ui5app2", getResultPath(), "test.zip")))
 			Ω(getTestPath("result", "test.zip")).Should(BeAnExistingFile())
 		})
 
 		It("modules ui5app1 and ui5app3 have no conflicting build results because different file names detected by globs", func() {
-			err := ExecuteSoloBuild(getTestPath("mtaWithPatternBuildResults"), "", getResultPath(), nil, []string{"ui5app1", "ui5app3"}, true, false, "cf", os.Getwd)
+			err := ExecuteSoloBuild(getTestPath("mtaWithPatternBuildResults"), "", getResultPath(), nil, []string{"ui5app1", "ui5app3"}, true, false, "cf", "", os.Getwd)
 			Ω(err).Should(Succeed())
 			Ω(getTestPath("result", "test.zip")).Should(BeAnExistingFile())
 			Ω(getTestPath("result", "test1.zip")).Should(BeAnExistingFile())
@@ -182,7 +190,7 @@ builders:
 				Ω(os.Remove(getTestPath("mtaModelsBuild", "ui5app", "test2.txt"))).Should(Succeed())
 			})
 			It("required module m2 has ready artifact 'test2.txt', only this one will be copied to m1", func() {
-				Ω(ExecuteSoloBuild(getTestPath("mtaModelsBuild"), "", getResultPath(), nil, []string{"m1", "m3"}, false, false, "", os.Getwd)).Should(Succeed())
+				Ω(ExecuteSoloBuild(getTestPath("mtaModelsBuild"), "", getResultPath(), nil, []string{"m1", "m3"}, false, false, "", "", os.Getwd)).Should(Succeed())
 				Ω(getTestPath("result", "data.zip")).Should(BeAnExistingFile())
 				Ω(getTestPath("result", "m3.zip")).Should(BeAnExistingFile())
 				validateArchiveContents([]string{"test.txt", "test2.txt"}, getTestPath("result", "data.zip"))
@@ -190,7 +198,7 @@ builders:
 		})
 
 		It("Sanity, no target path", func() {
-			Ω(ExecuteSoloBuild(getTestPath("mta"), "", "", nil, []string{"node-js"}, true, false, "",
+			Ω(ExecuteSoloBuild(getTestPath("mta"), "", "", nil, []string{"node-js"}, true, false, "", "",
 				func() (string, error) {
 					return getTestPath("result", "test_dir"), nil
 				})).Should(Succeed())
@@ -198,34 +206,34 @@ builders:
 		})
 
 		It("fails on empty list of modules", func() {
-			Ω(ExecuteSoloBuild(getTestPath("mta"), "", getResultPath(), nil, []string{}, true, false, "", os.Getwd)).Should(HaveOccurred())
+			Ω(ExecuteSoloBuild(getTestPath("mta"), "", getResultPath(), nil, []string{}, true, false, "", "", os.Getwd)).Should(HaveOccurred())
 		})
 
 		It("Fails on source getter", func() {
-			err := ExecuteSoloBuild("", "", "", nil, []string{"ui5app"}, true, false, "", failingGetWd)
+			err := ExecuteSoloBuild("", "", "", nil, []string{"ui5app"}, true, false, "", "", failingGetWd)
 			Ω(err).Should(HaveOccurred())
 			Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(buildFailedMsg, "ui5app")))
 		})
 
 		It("Fails on source getter with multiple modules", func() {
-			err := ExecuteSoloBuild("", "", "", nil, []string{"ui5app", "ui5app2"}, true, false, "", failingGetWd)
+			err := ExecuteSoloBuild("", "", "", nil, []string{"ui5app", "ui5app2"}, true, false, "", "", failingGetWd)
 			Ω(err).Should(HaveOccurred())
 			Ω(err.Error()).Should(ContainSubstring(multiBuildFailedMsg))
 		})
 
 		It("Fails on wrong build dependencies - on sortModules", func() {
 			Ω(ExecuteSoloBuild(getTestPath("mtahtml5"), "", "", []string{"mtaExtWithCyclicDependencies.yaml"},
-				[]string{"ui5app"}, true, false, "", os.Getwd)).Should(HaveOccurred())
+				[]string{"ui5app"}, true, false, "", "", os.Getwd)).Should(HaveOccurred())
 		})
 
 		It("Fails on unknown builder", func() {
 			Ω(ExecuteSoloBuild(getTestPath("mtahtml5"), "", "", []string{"mtaExtWithUnkownBuilder.yaml"},
-				[]string{"ui5app"}, true, false, "", os.Getwd)).Should(HaveOccurred())
+				[]string{"ui5app"}, true, false, "", "", os.Getwd)).Should(HaveOccurred())
 		})
 
 		It("Fails on location initialization", func() {
 			counter := 0
-			Ω(ExecuteSoloBuild("", "", "", nil, []string{"ui5app"}, true, false, "", func() (string, error) {
+			Ω(ExecuteSoloBuild("", "", "", nil, []string{"ui5app"}, true, false, "", "", func() (string, error) {
 				if counter == 0 {
 					counter++
 					return "", nil
@@ -235,12 +243,12 @@ builders:
 		})
 
 		It("Fails on wrong module", func() {
-			Ω(ExecuteSoloBuild(getTestPath("mta"), "", getResultPath(), nil, []string{"ui5app"}, true, false, "", os.Getwd)).Should(HaveOccurred())
+			Ω(ExecuteSoloBuild(getTestPath("mta"), "", getResultPath(), nil, []string{"ui5app"}, true, false, "", "", os.Getwd)).Should(HaveOccurred())
 		})
 
 		It("Fails on getting default source", func() {
 			Ω(ExecuteSoloBuild(getTestPath("mta"), "", "", nil, []string{"ui5app"}, true,
-				false, "",
+				false, "", "",
 				failingGetWd)).Should(HaveOccurred())
 		})
 
@@ -249,7 +257,7 @@ builders:
 		// failure on dir.Location after 2 successful calls to getSoloModuleBuildAbsSource & getSoloModuleBuildAbsTarget
 		It("Fails on creation of Location object", func() {
 			counter := 1
-			Ω(ExecuteSoloBuild("", "", "", nil, []string{"ui5app"}, true, false, "",
+			Ω(ExecuteSoloBuild("", "", "", nil, []string{"ui5app"}, true, false, "", "",
 				func() (string, error) {
 					if counter <= 2 {
 						counter++
@@ -267,7 +275,7 @@ builders:
 
 	Describe("generateMtad", func() {
 		It("fails on mtad location getter", func() {
-			Ω(generateMtad(nil, nil, "", "cf", nil, failingGetWd)).Should(HaveOccurred())
+			Ω(generateMtad(nil, nil, nil, "", "cf", nil, failingGetWd)).Should(HaveOccurred())
 		})
 	})
 
@@ -278,28 +286,28 @@ builders:
 		})
 
 		It("sanity", func() {
-			_, err := buildModules(getTestPath("mtahtml5"), "", getTestPath("result"), nil, []string{"ui5app"}, map[string]bool{"ui5app": true}, os.Getwd)
+			_, err := buildModules(getTestPath("mtahtml5"), "", getTestPath("result"), nil, []string{"ui5app"}, map[string]bool{"ui5app": true}, "", os.Getwd)
 			Ω(err).Should(Succeed())
 			Ω(getTestPath("result", "data.zip")).Should(BeAnExistingFile())
 		})
 
 		It("fails on module location getter", func() {
-			_, err := buildModules(getTestPath("mtahtml5"), "", "", nil, []string{"ui5app2"}, map[string]bool{}, failingGetWd)
+			_, err := buildModules(getTestPath("mtahtml5"), "", "", nil, []string{"ui5app2"}, map[string]bool{}, "", failingGetWd)
 			Ω(err).Should(HaveOccurred())
 		})
 
 		It("fails on wrong selected module", func() {
-			_, err := buildModules(getTestPath("mtahtml5"), "", "", nil, []string{"unknown"}, map[string]bool{"unknown": true}, os.Getwd)
+			_, err := buildModules(getTestPath("mtahtml5"), "", "", nil, []string{"unknown"}, map[string]bool{"unknown": true}, "", os.Getwd)
 			Ω(err).Should(HaveOccurred())
 		})
 
 		It("fails on module location getter of dependency", func() {
-			_, err := buildModules("", "", "", nil, []string{"ui5app"}, map[string]bool{"ui5app": true}, failingGetWd)
+			_, err := buildModules("", "", "", nil, []string{"ui5app"}, map[string]bool{"ui5app": true}, "", failingGetWd)
 			Ω(err).Should(HaveOccurred())
 		})
 
 		It("fails on buildModule because of the unknown builder", func() {
-			_, err := buildModules(getTestPath("mtahtml5"), "", getTestPath("result"), nil, []string{"ui5app3"}, map[string]bool{"ui5app3": true}, os.Getwd)
+			_, err := buildModules(getTestPath("mtahtml5"), "", getTestPath("result"), nil, []string{"ui5app3"}, map[string]bool{"ui5app3": true}, "", os.Getwd)
 			Ω(err).Should(HaveOccurred())
 		})
 	})
@@ -324,40 +332,40 @@ builders:
 
 		It("Sanity", func() {
 			Ω(ExecutePack(getTestPath("mta"), "", getResultPath(), nil, "node-js",
-				"cf", os.Getwd)).Should(Succeed())
+				"cf", "", os.Getwd)).Should(Succeed())
 			Ω(getFullPathInTmpFolder("mta", "node-js", "data.zip")).Should(BeAnExistingFile())
 		})
 
 		It("no-source module", func() {
 			Ω(ExecutePack(getTestPath("mta"), "", getResultPath(), nil, "no_source",
-				"cf", os.Getwd)).Should(Succeed())
+				"cf", "", os.Getwd)).Should(Succeed())
 			Ω(getFullPathInTmpFolder("mta", "node-js", "data.zip")).ShouldNot(BeAnExistingFile())
 		})
 
 		It("Fails on empty path", func() {
 			Ω(ExecutePack(getTestPath("mta_no_path"), "", getResultPath(), nil, "no_path",
-				"cf", os.Getwd)).Should(HaveOccurred())
+				"cf", "", os.Getwd)).Should(HaveOccurred())
 		})
 
 		It("Fails on platform validation", func() {
 			Ω(ExecutePack(getTestPath("mta"), "", getResultPath(), nil, "node-js",
-				"xx", os.Getwd)).Should(HaveOccurred())
+				"xx", "", os.Getwd)).Should(HaveOccurred())
 		})
 
 		It("Fails on location initialization", func() {
-			Ω(ExecutePack("", "", "", nil, "ui5app", "cf", failingGetWd)).Should(HaveOccurred())
+			Ω(ExecutePack("", "", "", nil, "ui5app", "cf", "", failingGetWd)).Should(HaveOccurred())
 		})
 
 		It("Fails on wrong module", func() {
 			Ω(ExecutePack(getTestPath("mta"), "", getResultPath(), nil, "ui5appx",
-				"cf", os.Getwd)).Should(HaveOccurred())
+				"cf", "", os.Getwd)).Should(HaveOccurred())
 		})
 
 		It("Target folder exists as file", func() {
 			createDirInTmpFolder("mta")
 			createFileInTmpFolder("mta", "node-js")
 			Ω(ExecutePack(getTestPath("mta"), "", getResultPath(), nil, "node-js",
-				"cf", os.Getwd)).Should(HaveOccurred())
+				"cf", "", os.Getwd)).Should(HaveOccurred())
 		})
 
 		When("module references the whole project", func() {
@@ -370,11 +378,11 @@ builders:
 
 			It("ignores default target folder when target is not defined and default is subfolder of packaged module content", func() {
 				// build first module to create some content in the default target folder
-				Ω(ExecutePack(projectFolder, "", "", nil, "mod1", "cf", getPathToFlatModule)).Should(Succeed())
+				Ω(ExecutePack(projectFolder, "", "", nil, "mod1", "cf", "", getPathToFlatModule)).Should(Succeed())
 				module1ZipPath := getTestPath("result", "mta_with_flat_module", ".mta_with_flat_module_mta_build_tmp", "mod1", "mod1.zip")
 				Ω(module1ZipPath).Should(BeAnExistingFile())
 				// build second module whose content is the whole project
-				Ω(ExecutePack(projectFolder, "", "", nil, "mod2", "cf", getPathToFlatModule)).Should(Succeed())
+				Ω(ExecutePack(projectFolder, "", "", nil, "mod2", "cf", "", getPathToFlatModule)).Should(Succeed())
 				module2ZipPath := getTestPath("result", "mta_with_flat_module", ".mta_with_flat_module_mta_build_tmp", "mod2", "data.zip")
 				Ω(module2ZipPath).Should(BeAnExistingFile())
 				validateArchiveContents([]string{"test.txt", "sub1/test1.txt", "mta.yaml", "sub1/", "sub2/", "sub2/test2.txt"}, module2ZipPath)
@@ -383,11 +391,11 @@ builders:
 			It("ignores temp folder in target folder when target is defined and target is sub folder of packaged module content", func() {
 				targetFolder := getTestPath("result", "mta_with_flat_module", "sub1")
 				// build first module to create some content in the specified target folder
-				Ω(ExecutePack(projectFolder, "", targetFolder, nil, "mod1", "cf", getPathToFlatModule)).Should(Succeed())
+				Ω(ExecutePack(projectFolder, "", targetFolder, nil, "mod1", "cf", "", getPathToFlatModule)).Should(Succeed())
 				module1ZipPath := getTestPath("result", "mta_with_flat_module", "sub1", ".mta_with_flat_module_mta_build_tmp", "mod1", "mod1.zip")
 				Ω(module1ZipPath).Should(BeAnExistingFile())
 				// build second module whose content is the whole project
-				Ω(ExecutePack(projectFolder, "", targetFolder, nil, "mod2", "cf", getPathToFlatModule)).Should(Succeed())
+				Ω(ExecutePack(projectFolder, "", targetFolder, nil, "mod2", "cf", "", getPathToFlatModule)).Should(Succeed())
 				module2ZipPath := getTestPath("result", "mta_with_flat_module", "sub1", ".mta_with_flat_module_mta_build_tmp", "mod2", "data.zip")
 				Ω(module2ZipPath).Should(BeAnExistingFile())
 				validateArchiveContents([]string{"test.txt", "sub1/test1.txt", "mta.yaml", "sub1/", "sub2/test2.txt", "sub2/"}, module2ZipPath)
@@ -405,7 +413,7 @@ builders:
 					TargetPath: getResultPath(),
 					Descriptor: dir.Dev,
 				}
-				Ω(packModule(&ep, &m, "node-js", "cf", "*.zip", true, map[string]string{})).Should(Succeed())
+				Ω(packModule(&ep, &m, decode(&m), "node-js", "cf", "*.zip", true, map[string]string{}, nil)).Should(Succeed())
 				Ω(getFullPathInTmpFolder("mta_with_zipped_module", "node-js", "abc.zip")).Should(BeAnExistingFile())
 			})
 			It("Build results - zip file not exists, fails", func() {
@@ -418,7 +426,7 @@ builders:
 					Name: "node-js",
 					Path: "notExists",
 				}
-				Ω(packModule(&ep, &mod, "node-js", "cf", "*.zip", true, map[string]string{})).Should(HaveOccurred())
+				Ω(packModule(&ep, &mod, decode(&mod), "node-js", "cf", "*.zip", true, map[string]string{}, nil)).Should(HaveOccurred())
 			})
 
 			It("zip file with ignored folder", func() {
@@ -435,18 +443,58 @@ builders:
 					TargetPath: getResultPath(),
 					Descriptor: dir.Dev,
 				}
-				Ω(packModule(&ep, &module, "htmlapp2", "cf", "", true, map[string]string{})).Should(Succeed())
+				Ω(packModule(&ep, &module, decode(&module), "htmlapp2", "cf", "", true, map[string]string{}, nil)).Should(Succeed())
 				Ω(getFullPathInTmpFolder("mta", "htmlapp2", "data.zip")).Should(BeAnExistingFile())
 				validateArchiveContentsExcludes([]string{"ignore"}, getFullPathInTmpFolder("mta", "htmlapp2", "data.zip"))
 			})
 
+			It("zip file with stored entries", func() {
+				module := mta.Module{
+					Name: "htmlapp2",
+					Path: "htmlapp2",
+					BuildParams: map[string]interface{}{
+						"compression": dir.CompressionStore,
+					},
+				}
+				ep := dir.Loc{
+					SourcePath: getTestPath("mta"),
+					TargetPath: getResultPath(),
+					Descriptor: dir.Dev,
+				}
+				Ω(packModule(&ep, &module, decode(&module), "htmlapp2", "cf", "", true, map[string]string{}, nil)).Should(Succeed())
+				reader, err := zip.OpenReader(getFullPathInTmpFolder("mta", "htmlapp2", "data.zip"))
+				Ω(err).Should(Succeed())
+				defer reader.Close()
+				Ω(reader.File).ShouldNot(BeEmpty())
+				for _, file := range reader.File {
+					Ω(file.Method).Should(Equal(zip.Store))
+				}
+			})
+
+			It("fails on the invalid compression", func() {
+				module := mta.Module{
+					Name: "htmlapp2",
+					Path: "htmlapp2",
+					BuildParams: map[string]interface{}{
+						"compression": "fastest",
+					},
+				}
+				ep := dir.Loc{
+					SourcePath: getTestPath("mta"),
+					TargetPath: getResultPath(),
+					Descriptor: dir.Dev,
+				}
+				err := packModule(&ep, &module, decode(&module), "htmlapp2", "cf", "", true, map[string]string{}, nil)
+				checkError(err, PackFailedOnArchMsg, "htmlapp2")
+			})
+
 			It("Default build-result - zip file, copy only fails - no file matching wildcard", func() {
 				ep := dir.Loc{
 					SourcePath: getTestPath("mta_with_zipped_module"),
 					TargetPath: getResultPath(),
 					Descriptor: dir.Dev,
 				}
-				Ω(packModule(&ep, &m, "node-js", "cf", "m*.zip", true, map[string]string{})).Should(HaveOccurred())
+				Ω(packModule(&ep, &m, decode(&m), "node-js", "cf", "m*.zip", true, map[string]string{}, nil)).Should(HaveOccurred())
 			})
 
 			// ep.GetTargetModuleDir(moduleName)
@@ -456,7 +504,7 @@ builders:
 					TargetPath: getResultPath(),
 					Descriptor: dir.Dev,
 				}
-				Ω(packModule(&ep, &m, "node-js", "cf", "", true, map[string]string{})).Should(HaveOccurred())
+				Ω(packModule(&ep, &m, decode(&m), "node-js", "cf", "", true, map[string]string{}, nil)).Should(HaveOccurred())
 			})
 			It("Target directory exists as a file", func() {
 				ep := dir.Loc{
@@ -466,7 +514,7 @@ builders:
 				}
 				Ω(dir.CreateDirIfNotExist(getFullPathInTmpFolder("mta_with_zipped_module"))).Should(Succeed())
 				createFileInTmpFolder("mta_with_zipped_module", "node-js")
-				Ω(packModule(&ep, &m, "node-js", "cf", "", true, map[string]string{})).Should(HaveOccurred())
+				Ω(packModule(&ep, &m, decode(&m), "node-js", "cf", "", true, map[string]string{}, nil)).Should(HaveOccurred())
 			})
 			When("build-artifact-name is defined for the module", func() {
 				var ep dir.Loc
@@ -487,7 +535,7 @@ builders:
 							"build-artifact-name": "myresult",
 						},
 					}
-					Ω(packModule(&ep, &m, "node-js", "cf", "", true, map[string]string{})).Should(Succeed())
+					Ω(packModule(&ep, &m, decode(&m), "node-js", "cf", "", true, map[string]string{}, nil)).Should(Succeed())
 					resultLocation := getFullPathInTmpFolder("mta_with_subfolder", "node-js", "res", "myresult.zip")
 					Ω(resultLocation).Should(BeAnExistingFile())
 					validateArchiveContents([]string{"file1"}, resultLocation)
@@ -500,7 +548,7 @@ builders:
 							"build-artifact-name": "myresult",
 						},
 					}
-					Ω(packModule(&ep, &m, "node-js", "cf", "", true, map[string]string{})).Should(Succeed())
+					Ω(packModule(&ep, &m, decode(&m), "node-js", "cf", "", true, map[string]string{}, nil)).Should(Succeed())
 					resultLocation := getFullPathInTmpFolder("mta_with_subfolder", "node-js", "myresult.zip")
 					Ω(resultLocation).Should(BeAnExistingFile())
 					validateArchiveContents([]string{"res/", "res/file1", "file2", "abc.war", "data.zip"}, resultLocation)
@@ -514,7 +562,7 @@ builders:
 							"build-artifact-name": "myresult",
 						},
 					}
-					Ω(packModule(&ep, &m, "node-js", "cf", "", true, map[string]string{})).Should(Succeed())
+					Ω(packModule(&ep, &m, decode(&m), "node-js", "cf", "", true, map[string]string{}, nil)).Should(Succeed())
 					resultLocation := getFullPathInTmpFolder("mta_with_subfolder", "node-js", "myresult.war")
 					Ω(resultLocation).Should(BeAnExistingFile())
 					validateArchiveContents([]string{"gulpfile.js", "server.js", "package.json"}, resultLocation)
@@ -528,7 +576,7 @@ builders:
 							"build-artifact-name": "myresult",
 						},
 					}
-					Ω(packModule(&ep, &m, "node-js", "cf", "", true, map[string]string{})).Should(HaveOccurred())
+					Ω(packModule(&ep, &m, decode(&m), "node-js", "cf", "", true, map[string]string{}, nil)).Should(HaveOccurred())
 				})
 				It("fails when build-artifact-name is not a string value", func() {
 					m := mta.Module{
@@ -538,9 +586,9 @@ builders:
 							"build-artifact-name": 1,
 						},
 					}
-					err := packModule(&ep, &m, "node-js", "cf", "", true, map[string]string{})
+					_, err := buildparams.Decode(&m)
 					Ω(err).Should(HaveOccurred())
-					Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(buildops.WrongBuildArtifactNameMsg, "1", "node-js")))
+					Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(buildparams.WrongParamMsg, "build-artifact-name", "node-js", "a string")))
 				})
 				It("creates data.zip when build-artifact-name is data", func() {
 					m := mta.Module{
@@ -550,7 +598,7 @@ builders:
 							"build-artifact-name": "data",
 						},
 					}
-					Ω(packModule(&ep, &m, "node-js", "cf", "", true, map[string]string{})).Should(Succeed())
+					Ω(packModule(&ep, &m, decode(&m), "node-js", "cf", "", true, map[string]string{}, nil)).Should(Succeed())
 					resultLocation := getFullPathInTmpFolder("mta_with_subfolder", "node-js", "data.zip")
 					Ω(resultLocation).Should(BeAnExistingFile())
 					validateArchiveContents([]string{"res/", "res/file1", "file2", "abc.war", "data.zip"}, resultLocation)
@@ -563,7 +611,7 @@ builders:
 							"build-artifact-name": "file2",
 						},
 					}
-					Ω(packModule(&ep, &m, "node-js", "cf", "", true, map[string]string{})).Should(Succeed())
+					Ω(packModule(&ep, &m, decode(&m), "node-js", "cf", "", true, map[string]string{}, nil)).Should(Succeed())
 					resultLocation := getFullPathInTmpFolder("mta_with_subfolder", "node-js", "file2.zip")
 					Ω(resultLocation).Should(BeAnExistingFile())
 					validateArchiveContents([]string{"res/", "res/file1", "file2", "abc.war", "data.zip"}, resultLocation)
@@ -576,7 +624,7 @@ builders:
 							"build-artifact-name": "abc",
 						},
 					}
-					Ω(packModule(&ep, &m, "node-js", "cf", "", true, map[string]string{})).Should(Succeed())
+					Ω(packModule(&ep, &m, decode(&m), "node-js", "cf", "", true, map[string]string{}, nil)).Should(Succeed())
 					resultLocation := getFullPathInTmpFolder("mta_with_subfolder", "node-js", "abc.zip")
 					Ω(resultLocation).Should(BeAnExistingFile())
 					validateArchiveContents([]string{"res/", "res/file1", "file2", "abc.war", "data.zip"}, resultLocation)
@@ -590,7 +638,7 @@ builders:
 							"build-artifact-name": "abc",
 						},
 					}
-					Ω(packModule(&ep, &m, "node-js", "cf", "", true, map[string]string{})).Should(Succeed())
+					Ω(packModule(&ep, &m, decode(&m), "node-js", "cf", "", true, map[string]string{}, nil)).Should(Succeed())
 					resultLocation := getFullPathInTmpFolder("mta_with_subfolder", "node-js", "abc.war")
 					Ω(resultLocation).Should(BeAnExistingFile())
 					validateArchiveContents([]string{"gulpfile.js", "server.js", "package.json"}, resultLocation)
@@ -604,7 +652,7 @@ builders:
 							"build-artifact-name": "data",
 						},
 					}
-					Ω(packModule(&ep, &m, "node-js", "cf", "", true, map[string]string{})).Should(Succeed())
+					Ω(packModule(&ep, &m, decode(&m), "node-js", "cf", "", true, map[string]string{}, nil)).Should(Succeed())
 					resultLocation := getFullPathInTmpFolder("mta_with_subfolder", "node-js", "data.war")
 					Ω(resultLocation).Should(BeAnExistingFile())
 					validateArchiveContents([]string{"gulpfile.js", "server.js", "package.json"}, resultLocation)
@@ -625,7 +673,7 @@ builders:
 					buildops.SupportedPlatformsParam: []string{},
 				},
 			}
-			Ω(packModule(&ep, &mNoPlatforms, "node-js", "cf", "", true, map[string]string{})).Should(Succeed())
+			Ω(packModule(&ep, &mNoPlatforms, decode(&mNoPlatforms), "node-js", "cf", "", true, map[string]string{}, nil)).Should(Succeed())
 			Ω(getFullPathInTmpFolder("mta_with_zipped_module", "node-js", "data.zip")).
 				ShouldNot(BeAnExistingFile())
 		})
@@ -657,30 +705,45 @@ builders:
 
 			It("Sanity", func() {
 				ep := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath()}
-				Ω(buildModule(&ep, &ep, "node-js", "cf", true, true, map[string]string{})).Should(Succeed())
+				Ω(buildModule(&ep, &ep, "node-js", "cf", true, true, map[string]string{}, nil, nil)).Should(Succeed())
 				Ω(getFullPathInTmpFolder("mta", "node-js", "data.zip")).Should(BeAnExistingFile())
 			})
 
+			It("Sanity, module environment variables", func() {
+				ep := dir.Loc{SourcePath: getTestPath("mta_env"), TargetPath: getResultPath()}
+				Ω(buildModule(&ep, &ep, "m1", "cf", true, true, map[string]string{}, nil, nil)).Should(Succeed())
+				err := buildModule(&ep, &ep, "m2", "cf", true, true, map[string]string{}, nil, nil)
+				checkError(err, buildFailedMsg, "m2")
+			})
+
+			It("Sanity, module commands policies", func() {
+				defer os.Remove(getTestPath("mta_retry", "m1", "attempts.txt"))
+				ep := dir.Loc{SourcePath: getTestPath("mta_retry"), TargetPath: getResultPath()}
+				Ω(buildModule(&ep, &ep, "m1", "cf", true, true, map[string]string{}, nil, nil)).Should(Succeed())
+				err := buildModule(&ep, &ep, "m2", "cf", true, true, map[string]string{}, nil, nil)
+				checkError(err, buildFailedMsg, "m2")
+			})
+
 			It("Sanity, not packed - platform not supported", func() {
 				ep := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath()}
-				Ω(buildModule(&ep, &ep, "node-js", "neo", true, true, map[string]string{})).Should(Succeed())
+				Ω(buildModule(&ep, &ep, "node-js", "neo", true, true, map[string]string{}, nil, nil)).Should(Succeed())
 				Ω(getFullPathInTmpFolder("mta", "node-js", "data.zip")).ShouldNot(BeAnExistingFile())
 			})
 
 			It("Sanity, packed - platform not checked", func() {
 				ep := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath()}
-				Ω(buildModule(&ep, &ep, "node-js", "neo", false, true, map[string]string{})).Should(Succeed())
+				Ω(buildModule(&ep, &ep, "node-js", "neo", false, true, map[string]string{}, nil, nil)).Should(Succeed())
 				Ω(getFullPathInTmpFolder("mta", "node-js", "data.zip")).Should(BeAnExistingFile())
 			})
 
 			It("empty path", func() {
 				ep := dir.Loc{SourcePath: getTestPath("mta_no_path"), TargetPath: getResultPath()}
-				Ω(buildModule(&ep, &ep, "no_path", "cf", true, true, map[string]string{})).Should(HaveOccurred())
+				Ω(buildModule(&ep, &ep, "no_path", "cf", true, true, map[string]string{}, nil, nil)).Should(HaveOccurred())
 			})
 
 			It("no source module", func() {
 				ep := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath()}
-				Ω(buildModule(&ep, &ep, "no_source", "cf", true, true, map[string]string{})).Should(Succeed())
+				Ω(buildModule(&ep, &ep, "no_source", "cf", true, true, map[string]string{}, nil, nil)).Should(Succeed())
 				Ω(getTestPath("mta", "node-js", "data.zip")).ShouldNot(BeAnExistingFile())
 			})
 
@@ -700,7 +763,7 @@ module-types:
 `)
 
 				ep := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath()}
-				Ω(buildModule(&ep, &ep, "node-js", "cf", true, true, map[string]string{})).Should(HaveOccurred())
+				Ω(buildModule(&ep, &ep, "node-js", "cf", true, true, map[string]string{}, nil, nil)).Should(HaveOccurred())
 			})
 
 			It("fails when the command is invalid", func() {
@@ -714,7 +777,7 @@ module-types:
 `)
 
 				ep := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath()}
-				err := buildModule(&ep, &ep, "node-js", "cf", true, true, map[string]string{})
+				err := buildModule(&ep, &ep, "node-js", "cf", true, true, map[string]string{}, nil, nil)
 				checkError(err, commands.BadCommandMsg, `sh -c "sleep 1`)
 			})
 
@@ -722,13 +785,13 @@ module-types:
 				createDirInTmpFolder("mta")
 				ep := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath()}
 				createFileInTmpFolder("mta", "node-js")
-				Ω(buildModule(&ep, &ep, "node-js", "cf", true, true, map[string]string{})).Should(HaveOccurred())
+				Ω(buildModule(&ep, &ep, "node-js", "cf", true, true, map[string]string{}, nil, nil)).Should(HaveOccurred())
 			})
 
 			var _ = DescribeTable("Invalid inputs", func(projectName, mtaFilename, moduleName string) {
 				ep := dir.Loc{SourcePath: getTestPath(projectName), TargetPath: getResultPath(), MtaFilename: mtaFilename}
 				Ω(ep.GetTargetTmpDir()).ShouldNot(BeADirectory())
-				Ω(buildModule(&ep, &ep, moduleName, "cf", true, true, map[string]string{})).Should(HaveOccurred())
+				Ω(buildModule(&ep, &ep, moduleName, "cf", true, true, map[string]string{}, nil, nil)).Should(HaveOccurred())
 				Ω(ep.GetTargetTmpDir()).ShouldNot(BeADirectory())
 			},
 				Entry("Invalid path to application", "mta1", "mta.yaml", "node-js"),
@@ -739,18 +802,63 @@ module-types:
 			When("build parameters has timeout", func() {
 				It("succeeds when timeout is not exceeded", func() {
 					ep := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath(), MtaFilename: "mta_with_timeout.yaml"}
-					Ω(buildModule(&ep, &ep, "m2", "cf", true, true, map[string]string{})).Should(Succeed())
+					Ω(buildModule(&ep, &ep, "m2", "cf", true, true, map[string]string{}, nil, nil)).Should(Succeed())
 					Ω(getFullPathInTmpFolder("mta", "m2", "data.zip")).Should(BeAnExistingFile())
 				})
 				It("fails when timeout is exceeded", func() {
 					ep := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath(), MtaFilename: "mta_with_timeout.yaml"}
-					err := buildModule(&ep, &ep, "m1", "cf", true, true, map[string]string{})
+					err := buildModule(&ep, &ep, "m1", "cf", true, true, map[string]string{}, nil, nil)
 					checkError(err, exec.ExecTimeoutMsg, "2s")
 				})
 				It("fails when timeout is not a string", func() {
-					ep := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath(), MtaFilename: "mta_with_timeout.yaml"}
-					err := buildModule(&ep, &ep, "m3", "cf", true, true, map[string]string{})
-					checkError(err, exec.ExecInvalidTimeoutMsg, "1")
+					ep := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath(), MtaFilename: "mta_with_wrong_timeout.yaml"}
+					err := buildModule(&ep, &ep, "m3", "cf", true, true, map[string]string{}, nil, nil)
+					checkError(err, buildparams.WrongParamMsg, "timeout", "m3", "a string")
+				})
+			})
+
+			It("skips the module of other build profiles", func() {
+				Ω(buildconfig.SetProfile("prod")).Should(Succeed())
+				ep := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath(), MtaFilename: "mta_with_profiles.yaml"}
+				Ω(buildModule(&ep, &ep, "m1", "cf", true, true, map[string]string{}, nil, nil)).Should(Succeed())
+				Ω(getFullPathInTmpFolder("mta", "m1", "data.zip")).ShouldNot(BeAnExistingFile())
+				Ω(ExecutePack(getTestPath("mta"), "mta_with_profiles.yaml", getResultPath(), nil, "m1", "cf", "", os.Getwd)).Should(Succeed())
+				Ω(getFullPathInTmpFolder("mta", "m1", "data.zip")).ShouldNot(BeAnExistingFile())
+			})
+
+			When("build parameters has hooks", func() {
+				hookLog := filepath.Join(getResultPath(), "hooks.log")
+
+				BeforeEach(func() {
+					Ω(os.MkdirAll(getResultPath(), os.ModePerm)).Should(Succeed())
+					Ω(os.Setenv("HOOK_LOG", hookLog)).Should(Succeed())
+				})
+				AfterEach(func() {
+					Ω(os.Unsetenv("HOOK_LOG")).Should(Succeed())
+				})
+
+				It("runs the hooks around the module build commands and before packing", func() {
+					ep := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath(), MtaFilename: "mta_with_hooks.yaml"}
+					Ω(buildModule(&ep, &ep, "m1", "cf", true, true, map[string]string{}, nil, nil)).Should(Succeed())
+					Ω(getFullPathInTmpFolder("mta", "m1", "data.zip")).Should(BeAnExistingFile())
+					Ω(ioutil.ReadFile(hookLog)).Should(BeEquivalentTo("before-build\nbuild\nafter-build\nbefore-pack mta\n"))
+				})
+				It("does not run the before-pack hook when the module is not packed", func() {
+					ep := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath(), MtaFilename: "mta_with_hooks.yaml"}
+					Ω(buildModule(&ep, &ep, "m1", "cf", true, false, map[string]string{}, nil, nil)).Should(Succeed())
+					Ω(ioutil.ReadFile(hookLog)).Should(BeEquivalentTo("before-build\nbuild\nafter-build\n"))
+				})
+				It("fails when the hook timeout is exceeded", func() {
+					ep := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath(), MtaFilename: "mta_with_hooks.yaml"}
+					err := buildModule(&ep, &ep, "m2", "cf", true, true, map[string]string{}, nil, nil)
+					checkError(err, execHookFailedMsg, commands.BeforeBuildHook, "m2")
+					Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(exec.ExecTimeoutMsg, "1s")))
+				})
+				It("fails when the hook command fails and does not pack the module", func() {
+					ep := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getResultPath(), MtaFilename: "mta_with_hooks.yaml"}
+					err := buildModule(&ep, &ep, "m3", "cf", true, true, map[string]string{}, nil, nil)
+					checkError(err, execHookFailedMsg, commands.AfterBuildHook, "m3")
+					Ω(getFullPathInTmpFolder("mta", "m3", "data.zip")).ShouldNot(BeAnExistingFile())
 				})
 			})
 		})
@@ -921,7 +1029,7 @@ module-types:
 	Describe("checkResolvedBuildResultsConflicts", func() {
 		DescribeTable("conflicting and none conflicting cases", func(target string, modules []string, result func() types.GomegaMatcher) {
 			mtaObj := getMtaObj("mtahtml5", "mta.yaml")
-			Ω(checkResolvedBuildResultsConflicts(mtaObj, getTestPath("mtahtml5"), "", target, nil, modules, os.Getwd)).Should(result())
+			Ω(checkResolvedBuildResultsConflicts(mtaObj, decodeAll(mtaObj), getTestPath("mtahtml5"), "", target, nil, modules, os.Getwd)).Should(result())
 		},
 			Entry("one module, no conflicts", getTestPath("result"), []string{"ui5app"}, Succeed),
 			Entry("2 none conflicting modules, because target not provided and modules results are in different sub folders", "", []string{"ui5app", "ui5app2"}, Succeed),
@@ -930,20 +1038,24 @@ module-types:
 
 		It("fails on location initialization", func() {
 			mtaObj := getMtaObj("mtahtml5", "mta.yaml")
-			Ω(checkResolvedBuildResultsConflicts(mtaObj, getTestPath("mtahtml5"), "", "", nil, []string{"ui5app"}, failingGetWd)).Should(HaveOccurred())
+			Ω(checkResolvedBuildResultsConflicts(mtaObj, decodeAll(mtaObj), getTestPath("mtahtml5"), "", "", nil, []string{"ui5app"}, failingGetWd)).Should(HaveOccurred())
 		})
 
 		It("skips check of module with pattern build result", func() {
 			mtaObj := getMtaObj("mtaWithPatternBuildResults", "mta.yaml")
-			Ω(checkResolvedBuildResultsConflicts(mtaObj, getTestPath("mtahtml5"), "", getResultPath(), nil, []string{"ui5app1", "ui5app2"}, os.Getwd)).Should(Succeed())
+			Ω(checkResolvedBuildResultsConflicts(mtaObj, decodeAll(mtaObj), getTestPath("mtahtml5"), "", getResultPath(), nil, []string{"ui5app1", "ui5app2"}, os.Getwd)).Should(Succeed())
 		})
 
 		DescribeTable("fails on the mta yaml problems", func(filename string) {
 			mtaObj := getMtaObj("mtahtml5", filename)
-			Ω(checkResolvedBuildResultsConflicts(mtaObj, getTestPath("mtahtml5"), "", getTestPath("result"), nil, []string{"ui5app"}, os.Getwd)).Should(HaveOccurred())
+			Ω(checkResolvedBuildResultsConflicts(mtaObj, decodeAll(mtaObj), getTestPath("mtahtml5"), "", getTestPath("result"), nil, []string{"ui5app"}, os.Getwd)).Should(HaveOccurred())
 		},
-			Entry("unknown builder", "mtaWithUnknownBuilder.yaml"),
-			Entry("wrong definition of the build result property", "mtaWithWrongBuildResult.yaml"))
+			Entry("unknown builder", "mtaWithUnknownBuilder.yaml"))
+
+		It("the wrong definition of the build result property fails on decoding the build parameters", func() {
+			_, err := buildparams.DecodeAll(getMtaObj("mtahtml5", "mtaWithWrongBuildResult.yaml"))
+			checkError(err, buildparams.WrongParamMsg, "build-result", "ui5app", "a string")
+		})
 	})
 
 	Describe("getModuleLocation", func() {
@@ -992,13 +1104,13 @@ module-types:
 
 		It("sanity", func() {
 			collection := make(map[string]bool)
-			err := collectSelectedModulesAndDependencies(mtaObj, collection, "m1")
+			err := collectSelectedModulesAndDependencies(mtaObj, decodeAll(mtaObj), collection, "m1")
 			Ω(err).Should(Succeed())
 			validateMapKeys(collection, []string{"m1", "m4", "m3", "m2"})
 		})
 		DescribeTable("failures", func(targetModule string) {
 			collection := make(map[string]bool)
-			err := collectSelectedModulesAndDependencies(mtaObj, collection, targetModule)
+			err := collectSelectedModulesAndDependencies(mtaObj, decodeAll(mtaObj), collection, targetModule)
 			Ω(err).Should(HaveOccurred())
 		},
 			Entry("fails on none existing target module", "m5"),
@@ -1009,7 +1121,7 @@ module-types:
 	Describe("sortModules", func() {
 		It("sanity", func() {
 			mtaObj := getMtaObj("mtahtml5", "mtaWithBuildRequirements.yaml")
-			allModulesSorted, err := buildops.GetModulesNames(mtaObj)
+			allModulesSorted, err := buildops.GetModulesNames(mtaObj, decodeAll(mtaObj))
 			Ω(err).Should(Succeed())
 			selectedModulesMap := map[string]bool{"n1": true, "m1": true}
 			selectedModulesSorted := sortModules(allModulesSorted, selectedModulesMap)
@@ -1027,11 +1139,11 @@ module-types:
 
 		It("ignores default target folder when target is not defined and default is subfolder of packaged module content", func() {
 			// build first module to create some content in the default target folder
-			Ω(buildSelectedModule(projectFolder, "", "", nil, "mod1", true, make(map[string]string), getPathToFlatModule)).Should(Succeed())
+			Ω(buildSelectedModule(projectFolder, "", "", nil, "mod1", true, make(map[string]string), "", getPathToFlatModule)).Should(Succeed())
 			module1ZipPath := getTestPath("result", "mta_with_flat_module", ".mta_with_flat_module_mta_build_tmp", "mod1", "mod1.zip")
 			Ω(module1ZipPath).Should(BeAnExistingFile())
 			// build second module whose content is the whole project
-			Ω(buildSelectedModule(projectFolder, "", "", nil, "mod2", true, make(map[string]string), getPathToFlatModule)).Should(Succeed())
+			Ω(buildSelectedModule(projectFolder, "", "", nil, "mod2", true, make(map[string]string), "", getPathToFlatModule)).Should(Succeed())
 			module2ZipPath := getTestPath("result", "mta_with_flat_module", ".mta_with_flat_module_mta_build_tmp", "mod2", "data.zip")
 			Ω(module2ZipPath).Should(BeAnExistingFile())
 			validateArchiveContents([]string{"test.txt", "sub1/test1.txt", "mta.yaml", "sub1/", "sub2/", "sub2/test2.txt"}, module2ZipPath)
@@ -1040,11 +1152,11 @@ module-types:
 		It("ignores specified target folder when target is defined and target is subfolder of packaged module content", func() {
 			targetFolder := getTestPath("result", "mta_with_flat_module", "target")
 			// build first module to create some content in the specified target folder
-			Ω(buildSelectedModule(projectFolder, "", targetFolder, nil, "mod1", true, make(map[string]string), getPathToFlatModule)).Should(Succeed())
+			Ω(buildSelectedModule(projectFolder, "", targetFolder, nil, "mod1", true, make(map[string]string), "", getPathToFlatModule)).Should(Succeed())
 			module1ZipPath := getTestPath("result", "mta_with_flat_module", "target", "mod1.zip")
 			Ω(module1ZipPath).Should(BeAnExistingFile())
 			// build second module whose content is the whole project
-			Ω(buildSelectedModule(projectFolder, "", targetFolder, nil, "mod2", true, make(map[string]string), getPathToFlatModule)).Should(Succeed())
+			Ω(buildSelectedModule(projectFolder, "", targetFolder, nil, "mod2", true, make(map[string]string), "", getPathToFlatModule)).Should(Succeed())
 			module2ZipPath := getTestPath("result", "mta_with_flat_module", "target", "data.zip")
 			Ω(module2ZipPath).Should(BeAnExistingFile())
 			validateArchiveContents([]string{"test.txt", "sub1/test1.txt", "mta.yaml", "sub1/", "sub2/test2.txt", "sub2/"}, module2ZipPath)
@@ -5,17 +5,19 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strconv"
 	"strings"
 
 	"github.com/pkg/errors"
 
+	"github.com/SAP/cloud-mta-build-tool/internal/buildconfig"
 	"github.com/SAP/cloud-mta/mta"
 
 	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
 	"github.com/SAP/cloud-mta-build-tool/internal/buildops"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
 	"github.com/SAP/cloud-mta-build-tool/internal/logs"
+	"github.com/SAP/cloud-mta-build-tool/internal/platform"
 )
 
 type mtadLoc struct {
@@ -49,16 +51,23 @@ func ExecuteMtadGen(source, mtaYamlFilename, target string, extensions []string,
 	return executeGenMetaByLocation(loc, &mtadLoc{target}, platform, false, false)
 }
 
-func validatePlatform(platform string) (string, error) {
-	result := strings.ToLower(platform)
-	if result != "xsa" && result != "cf" && result != "neo" {
-		return "", fmt.Errorf(invalidPlatformMsg, platform)
+// validatePlatform - checks that the platform is defined in the embedded or external platforms configuration
+func validatePlatform(platformName string) (string, error) {
+	result := strings.ToLower(platformName)
+	names, err := platform.GetPlatformNames()
+	if err != nil {
+		return "", err
+	}
+	for _, name := range names {
+		if name == result {
+			return result, nil
+		}
 	}
-	return result, nil
+	return "", fmt.Errorf(invalidPlatformMsg, platformName, `"`+strings.Join(names, `", "`)+`"`)
 }
 
 // genMtad generates an mtad.yaml file from a mta.yaml file and a platform configuration file.
-func genMtad(mtaStr *mta.MTA, ep dir.ITargetArtifacts, targetPathGetter dir.ITargetPath, deploymentDesc bool,
+func genMtad(mtaStr *mta.MTA, params buildparams.Modules, ep dir.ITargetArtifacts, targetPathGetter dir.ITargetPath, deploymentDesc bool,
 	platform string, validatePaths bool, packedModulePaths map[string]string,
 	marshal func(interface{}) (out []byte, err error)) error {
 
@@ -69,13 +78,25 @@ func genMtad(mtaStr *mta.MTA, ep dir.ITargetArtifacts, targetPathGetter dir.ITar
 
 	logs.Logger.Info(fmt.Sprintf(genMTADMsg, mtadAbsPath))
 
-	removeUndeployedModules(mtaStr, platform)
+	err = removeUndeployedModules(mtaStr, params, platform)
+	if err != nil {
+		return err
+	}
 
-	setPlatformSpecificParameters(mtaStr, platform)
+	err = setPlatformSpecificParameters(mtaStr, platform)
+	if err != nil {
+		return errors.Wrapf(err, genMTADParamsMsg, platform)
+	}
 
 	if !deploymentDesc {
+		// convert modules types according to platform;
+		// the types mappings can match the build parameters, so the types are converted before the build parameters are removed
+		err = ConvertTypes(*mtaStr, platform)
+		if err != nil {
+			return errors.Wrapf(err, genMTADTypeTypeCnvMsg, platform)
+		}
 
-		err := removeBuildParamsFromMta(targetPathGetter, mtaStr, validatePaths)
+		err = removeBuildParamsFromMta(targetPathGetter, mtaStr, params, validatePaths)
 		if err != nil {
 			return err
 		}
@@ -98,12 +119,6 @@ func genMtad(mtaStr *mta.MTA, ep dir.ITargetArtifacts, targetPathGetter dir.ITar
 				}
 			}
 		}
-		// convert modules types according to platform
-		err = ConvertTypes(*mtaStr, platform)
-		if err != nil {
-			return errors.Wrapf(err, genMTADTypeTypeCnvMsg, platform)
-		}
-
 	}
 
 	err = adjustSchemaVersion(mtaStr)
@@ -129,14 +144,16 @@ func genMtad(mtaStr *mta.MTA, ep dir.ITargetArtifacts, targetPathGetter dir.ITar
 // Function is used in process of deployment artifacts preparation
 // SupportedPlatforms of module's build parameters indicate if module has to be deployed
 // if SupportedPlatforms property defined with empty list of properties
-// module will not be packed, not listed in MTAD yaml and in manifest
-func removeUndeployedModules(mtaStr *mta.MTA, platform string) {
+// module will not be packed, not listed in MTAD yaml and in manifest.
+// The modules and resources tied to other build profiles are removed the same way,
+// together with the requirements of the remaining modules to the removed resources
+func removeUndeployedModules(mtaStr *mta.MTA, params buildparams.Modules, platform string) error {
 
 	// remove modules with no platforms defined
 	for doCleaning := true; doCleaning; {
 		doCleaning = false
 		for i, m := range mtaStr.Modules {
-			if !buildops.PlatformDefined(m, platform) {
+			if !isModulePacked(params[m.Name], platform) {
 				// join slices before and after removed module
 				mtaStr.Modules = mtaStr.Modules[:i+copy(mtaStr.Modules[i:], mtaStr.Modules[i+1:])]
 				doCleaning = true
@@ -144,60 +161,51 @@ func removeUndeployedModules(mtaStr *mta.MTA, platform string) {
 			}
 		}
 	}
-}
 
-// setPlatformSpecificParameters handles platform-specific logic like setting additional parameters in the MTA and its modules
-func setPlatformSpecificParameters(mtaStr *mta.MTA, platform string) {
-	//TODO move to configuration
-	if platform == "neo" {
-		if mtaStr.Parameters == nil {
-			mtaStr.Parameters = make(map[string]interface{})
-		}
-		if mtaStr.Parameters["hcp-deployer-version"] == nil {
-			mtaStr.Parameters["hcp-deployer-version"] = "1.1.0"
+	// remove resources of other profiles; the "profiles" parameter is used only by the build
+	removedResources := make(map[string]bool)
+	resources := mtaStr.Resources[:0]
+	for _, r := range mtaStr.Resources {
+		if buildops.ResourceProfileDefined(r) {
+			delete(r.Parameters, buildparams.ProfilesParam)
+			resources = append(resources, r)
+		} else {
+			removedResources[r.Name] = true
 		}
+	}
+	mtaStr.Resources = resources
+	delete(mtaStr.Parameters, buildparams.ProfilesParam)
 
-		for _, m := range mtaStr.Modules {
-			if m.Parameters == nil {
-				m.Parameters = make(map[string]interface{})
-			}
-			if m.Parameters["name"] == nil {
-				m.Parameters["name"] = adjustNeoAppName(m.Name)
+	// the requirements to the removed resources cannot be resolved by the deployment
+	for _, m := range mtaStr.Modules {
+		requires := m.Requires[:0]
+		for _, r := range m.Requires {
+			if removedResources[r.Name] {
+				logs.Logger.Warnf(genMTADRequiresMsg, m.Name, r.Name, buildconfig.Profile())
+				continue
 			}
+			requires = append(requires, r)
 		}
+		m.Requires = requires
 	}
+	return nil
 }
 
-func adjustNeoAppName(name string) string {
-	// Application names in neo must adhere to the following:
-	// 1. Starts with a letter
-	// 2. Contains only lowercase letters and numbers
-	// 3. Contains up to 30 characters
-
-	// Make all letters lowercase
-	name = strings.ToLower(name)
-
-	// Remove non-alphanumeric characters
-	reg := regexp.MustCompile("[^a-z0-9]+")
-	name = reg.ReplaceAllLiteralString(name, "")
-
-	// Remove numbers from the beginning of the name
-	reg = regexp.MustCompile("^[0-9]+")
-	name = reg.ReplaceAllLiteralString(name, "")
-
-	// Shorten to 30 characters
-	if len(name) > 30 {
-		name = name[:30]
+// setPlatformSpecificParameters sets the parameters of the MTA and its modules that are not defined,
+// according to the rules of the platform in the embedded and external platforms configuration
+func setPlatformSpecificParameters(mtaStr *mta.MTA, platformName string) error {
+	platformCfg, err := platform.GetPlatforms()
+	if err != nil {
+		return err
 	}
-
-	return name
+	return platform.ApplyRules(mtaStr, platformCfg, platformName)
 }
 
 // if module has to be deployed we clean build parameters from module,
 // as this section is not used in MTAD yaml
-func removeBuildParamsFromMta(loc dir.ITargetPath, mtaStr *mta.MTA, validatePaths bool) error {
+func removeBuildParamsFromMta(loc dir.ITargetPath, mtaStr *mta.MTA, params buildparams.Modules, validatePaths bool) error {
 	for _, m := range mtaStr.Modules {
-		err := adaptModulePath(loc, m, validatePaths)
+		err := adaptModulePath(loc, m, params[m.Name], validatePaths)
 		if err != nil {
 			return errors.Wrapf(err, adaptationMsg, m.Name)
 		}
@@ -207,8 +215,8 @@ func removeBuildParamsFromMta(loc dir.ITargetPath, mtaStr *mta.MTA, validatePath
 	return nil
 }
 
-func adaptModulePath(loc dir.ITargetPath, module *mta.Module, validatePaths bool) error {
-	if buildops.IfNoSource(module) {
+func adaptModulePath(loc dir.ITargetPath, module *mta.Module, params *buildparams.Module, validatePaths bool) error {
+	if params.NoSource {
 		return nil
 	}
 	modulePath := filepath.Join(loc.GetTargetTmpDir(), module.Name)
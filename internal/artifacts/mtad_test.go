@@ -1,16 +1,18 @@
 package artifacts
 
 import (
+	"io/ioutil"
 	"os"
 
 	. "github.com/onsi/ginkgo"
-	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v2"
 
 	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildconfig"
 	"github.com/SAP/cloud-mta-build-tool/internal/buildops"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
 	"github.com/SAP/cloud-mta-build-tool/internal/platform"
 	"github.com/SAP/cloud-mta/mta"
 )
@@ -53,6 +55,64 @@ var _ = Describe("Mtad", func() {
 		It("Fails on wrong source path - parse fails", func() {
 			Ω(ExecuteMtadGen(getTestPath("mtax"), "", getTestPath("result"), nil, "cf", os.Getwd)).Should(HaveOccurred())
 		})
+		It("generates the descriptor for the platform of the external platforms configuration", func() {
+			configPath := getTestPath("result", "platforms.yaml")
+			Ω(ioutil.WriteFile(configPath, []byte(`
+platform:
+- name: Kyma
+  modules:
+  - native-type: nodejs
+    platform-type: kyma.nodejs
+`), 0644)).Should(Succeed())
+			Ω(platform.LoadExternalConfig("", configPath)).Should(Succeed())
+			defer func() {
+				Ω(platform.LoadExternalConfig("", "")).Should(Succeed())
+			}()
+			Ω(ExecuteMtadGen(getTestPath("mta"), "", getTestPath("result"), nil, "KYMA", os.Getwd)).Should(Succeed())
+			content, err := ioutil.ReadFile(getTestPath("result", "mtad.yaml"))
+			Ω(err).Should(Succeed())
+			mtadObj, err := mta.Unmarshal(content)
+			Ω(err).Should(Succeed())
+			// the module supported only on the "cf" platform is removed
+			Ω(len(mtadObj.Modules)).Should(Equal(1))
+			Ω(mtadObj.Modules[0].Name).Should(Equal("no_source"))
+			Ω(mtadObj.Modules[0].Type).Should(Equal("kyma.nodejs"))
+
+			err = ExecuteMtadGen(getTestPath("mta"), "", getTestPath("result"), nil, "ab", os.Getwd)
+			checkError(err, invalidPlatformMsg, "ab", `"cf", "kyma", "neo", "xsa"`)
+		})
+		It("converts the module types by the build parameters and adds the properties of the mapping", func() {
+			configPath := getTestPath("result", "platforms.yaml")
+			Ω(ioutil.WriteFile(configPath, []byte(`
+platform:
+- name: kyma
+  modules:
+  - native-type: nodejs
+    platform-type: kyma.nodejs
+  - native-type: nodejs
+    platform-type: kyma.static
+    conditions:
+    - in: build-parameters
+      name: no-source
+      equals: "true"
+    module-properties:
+    - name: APP_NAME
+      from: name
+`), 0644)).Should(Succeed())
+			Ω(platform.LoadExternalConfig("", configPath)).Should(Succeed())
+			defer func() {
+				Ω(platform.LoadExternalConfig("", "")).Should(Succeed())
+			}()
+			Ω(ExecuteMtadGen(getTestPath("mta"), "", getTestPath("result"), nil, "kyma", os.Getwd)).Should(Succeed())
+			content, err := ioutil.ReadFile(getTestPath("result", "mtad.yaml"))
+			Ω(err).Should(Succeed())
+			mtadObj, err := mta.Unmarshal(content)
+			Ω(err).Should(Succeed())
+			Ω(len(mtadObj.Modules)).Should(Equal(1))
+			Ω(mtadObj.Modules[0].Type).Should(Equal("kyma.static"))
+			Ω(mtadObj.Modules[0].Properties).Should(Equal(map[string]interface{}{"APP_NAME": "no_source"}))
+			Ω(mtadObj.Modules[0].BuildParams).Should(BeEmpty())
+		})
 		It("Fails on broken platforms configuration", func() {
 			cfg := platform.PlatformConfig
 			platform.PlatformConfig = []byte("abc abc")
@@ -72,7 +132,7 @@ var _ = Describe("Mtad", func() {
 			Ω(err).Should(Succeed())
 			mtaStr, err := ep.ParseFile()
 			Ω(err).Should(Succeed())
-			Ω(genMtad(mtaStr, &ep, &ep, ep.IsDeploymentDescriptor(), "cf", true, nil, yaml.Marshal)).Should(HaveOccurred())
+			Ω(genMtad(mtaStr, decodeAll(mtaStr), &ep, &ep, ep.IsDeploymentDescriptor(), "cf", true, nil, yaml.Marshal)).Should(HaveOccurred())
 			Ω(file.Close()).Should(Succeed())
 		})
 
@@ -80,14 +140,14 @@ var _ = Describe("Mtad", func() {
 			ep := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getTestPath("result")}
 			mtaStr, err := ep.ParseFile()
 			Ω(err).Should(Succeed())
-			Ω(genMtad(mtaStr, &ep, &ep, ep.IsDeploymentDescriptor(), "cf", false, nil, yaml.Marshal)).Should(HaveOccurred())
+			Ω(genMtad(mtaStr, decodeAll(mtaStr), &ep, &ep, ep.IsDeploymentDescriptor(), "cf", false, nil, yaml.Marshal)).Should(HaveOccurred())
 		})
 
 		It("Fails on mtad marshalling", func() {
 			ep := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getTestPath("result")}
 			mtaStr, err := ep.ParseFile()
 			Ω(err).Should(Succeed())
-			Ω(genMtad(mtaStr, &ep, &ep, ep.IsDeploymentDescriptor(), "cf", false, nil, func(i interface{}) (out []byte, err error) {
+			Ω(genMtad(mtaStr, decodeAll(mtaStr), &ep, &ep, ep.IsDeploymentDescriptor(), "cf", false, nil, func(i interface{}) (out []byte, err error) {
 				return nil, errors.New("err")
 			})).Should(HaveOccurred())
 		})
@@ -95,7 +155,7 @@ var _ = Describe("Mtad", func() {
 			ep := dir.Loc{SourcePath: getTestPath("mta"), TargetPath: getTestPath("result"), MtaFilename: "mtaBadSchemaVersion.yaml"}
 			mtaStr, err := ep.ParseFile()
 			Ω(err).Should(Succeed())
-			Ω(genMtad(mtaStr, &ep, &ep, ep.IsDeploymentDescriptor(), "cf", true, nil, yaml.Marshal)).Should(HaveOccurred())
+			Ω(genMtad(mtaStr, decodeAll(mtaStr), &ep, &ep, ep.IsDeploymentDescriptor(), "cf", true, nil, yaml.Marshal)).Should(HaveOccurred())
 		})
 	})
 
@@ -104,11 +164,12 @@ var _ = Describe("Mtad", func() {
 var _ = Describe("adaptModulePath", func() {
 	It("path by module name", func() {
 		mod := mta.Module{Name: "htmlapp2", Path: "xyz"}
-		Ω(adaptModulePath(&testMtadLoc{}, &mod, true)).Should(Succeed())
+		Ω(adaptModulePath(&testMtadLoc{}, &mod, decode(&mod), true)).Should(Succeed())
 		Ω(mod.Path).Should(Equal("htmlapp2"))
 	})
 	It("Fails on location initialization - validatePaths is set to true", func() {
-		Ω(adaptModulePath(&testMtadLoc{}, &mta.Module{Name: "htmlapp", Path: "xxx"}, true)).Should(HaveOccurred())
+		mod := mta.Module{Name: "htmlapp", Path: "xxx"}
+		Ω(adaptModulePath(&testMtadLoc{}, &mod, decode(&mod), true)).Should(HaveOccurred())
 	})
 })
 
@@ -144,10 +205,57 @@ var _ = Describe("removeUndeployedModules", func() {
 				},
 			},
 		}
-		removeUndeployedModules(&mta, "neo")
+		Ω(removeUndeployedModules(&mta, decodeAll(&mta), "neo")).Should(Succeed())
 		Ω(len(mta.Modules)).Should(Equal(1))
 		Ω(mta.Modules[0].Name).Should(Equal("htmlapp2"))
 	})
+
+	It("removes the modules and resources of other build profiles", func() {
+		Ω(buildconfig.SetProfile("trial")).Should(Succeed())
+		mta := mta.MTA{
+			ID:         "mta_proj",
+			Version:    "1.0.0",
+			Parameters: map[string]interface{}{buildparams.ProfilesParam: []interface{}{"trial"}, "a": "b"},
+			Modules: []*mta.Module{
+				{Name: "trial", BuildParams: map[string]interface{}{buildparams.ProfilesParam: []interface{}{"trial"}}},
+				{Name: "prod", BuildParams: map[string]interface{}{buildparams.ProfilesParam: []interface{}{"prod"}}},
+				{Name: "all"},
+			},
+			Resources: []*mta.Resource{
+				{Name: "trial-db", Parameters: map[string]interface{}{buildparams.ProfilesParam: []interface{}{"trial"}, "service": "db"}},
+				{Name: "prod-db", Parameters: map[string]interface{}{buildparams.ProfilesParam: []interface{}{"prod"}}},
+				{Name: "uaa"},
+			},
+		}
+		Ω(removeUndeployedModules(&mta, decodeAll(&mta), "cf")).Should(Succeed())
+		Ω(mta.Modules).Should(HaveLen(2))
+		Ω(mta.Modules[0].Name).Should(Equal("trial"))
+		Ω(mta.Modules[1].Name).Should(Equal("all"))
+		Ω(mta.Resources).Should(HaveLen(2))
+		Ω(mta.Resources[0].Name).Should(Equal("trial-db"))
+		Ω(mta.Resources[0].Parameters).Should(Equal(map[string]interface{}{"service": "db"}))
+		Ω(mta.Resources[1].Name).Should(Equal("uaa"))
+		Ω(mta.Parameters).Should(Equal(map[string]interface{}{"a": "b"}))
+	})
+
+	It("removes the requirements of the included modules to the resources of other build profiles", func() {
+		Ω(buildconfig.SetProfile("trial")).Should(Succeed())
+		mtaObj := mta.MTA{
+			ID:      "mta_proj",
+			Version: "1.0.0",
+			Modules: []*mta.Module{
+				{Name: "all", Requires: []mta.Requires{{Name: "prod-db"}, {Name: "uaa"}, {Name: "trial-db"}}},
+			},
+			Resources: []*mta.Resource{
+				{Name: "trial-db", Parameters: map[string]interface{}{buildparams.ProfilesParam: []interface{}{"trial"}}},
+				{Name: "prod-db", Parameters: map[string]interface{}{buildparams.ProfilesParam: []interface{}{"prod"}}},
+				{Name: "uaa"},
+			},
+		}
+		Ω(removeUndeployedModules(&mtaObj, decodeAll(&mtaObj), "cf")).Should(Succeed())
+		Ω(mtaObj.Resources).Should(HaveLen(2))
+		Ω(mtaObj.Modules[0].Requires).Should(Equal([]mta.Requires{{Name: "uaa"}, {Name: "trial-db"}}))
+	})
 })
 
 var _ = Describe("setPlatformSpecificParameters", func() {
@@ -183,7 +291,7 @@ var _ = Describe("setPlatformSpecificParameters", func() {
 			},
 		}
 
-		setPlatformSpecificParameters(&mta, "neo")
+		Ω(setPlatformSpecificParameters(&mta, "neo")).Should(Succeed())
 
 		Ω(mta.Parameters["hcp-deployer-version"]).ShouldNot(BeNil())
 
@@ -198,21 +306,23 @@ var _ = Describe("setPlatformSpecificParameters", func() {
 		Ω(mta.Modules[2].Parameters).ShouldNot(BeNil())
 		Ω(mta.Modules[2].Parameters["name"]).Should(Equal("someName"))
 	})
-})
 
-var _ = DescribeTable("adjustNeoAppName", func(name string, expected string) {
-	Ω(adjustNeoAppName(name)).Should(Equal(expected))
-},
-	Entry("supported name", "abc123cde", "abc123cde"),
-	Entry("name with uppercase letters", "Abc123P", "abc123p"),
-	Entry("name starts with numbers", "87xaa8s", "xaa8s"),
-	Entry("name starts with numbers and uppercase", "87Xaa8s", "xaa8s"),
-	Entry("name with unsupported characters", "my-module", "mymodule"),
-	Entry("name starts with unsupported characters and numbers", "!~11-2ave_1gne", "ave1gne"),
-	Entry("name longer than 30 characters before removing unsupported characters", "a123456789-12345678901234567890", "a12345678912345678901234567890"),
-	Entry("name longer than 30 characters after removing unsupported characters", "a1234567890-12345678901234567890", "a12345678901234567890123456789"),
-	Entry("mixed conditions", "1234567890abcdeABCDE--==?12345mymodule", "abcdeabcde12345mymodule"),
-)
+	It("does not set the parameters for the platform without rules", func() {
+		mta := mta.MTA{Modules: []*mta.Module{{Name: "my-html-app", Type: "html5"}}}
+		Ω(setPlatformSpecificParameters(&mta, "cf")).Should(Succeed())
+		Ω(mta.Parameters).Should(BeNil())
+		Ω(mta.Modules[0].Parameters).Should(BeNil())
+	})
+
+	It("fails on broken platforms configuration", func() {
+		cfg := platform.PlatformConfig
+		platform.PlatformConfig = []byte("abc abc")
+		defer func() {
+			platform.PlatformConfig = cfg
+		}()
+		Ω(setPlatformSpecificParameters(&mta.MTA{}, "neo")).Should(HaveOccurred())
+	})
+})
 
 type testMtadLoc struct {
 }
@@ -16,8 +16,10 @@ const (
 	mtarExtension = ".mtar"
 )
 
-// ExecuteGenMtar - generates MTAR
-func ExecuteGenMtar(source, mtaYamlFilename, target, targetProvided, desc string, extensions []string, mtarName string, wdGetter func() (string, error)) error {
+// ExecuteGenMtar - generates MTAR;
+// if the report folder is provided, the path of the generated MTAR is written to it
+func ExecuteGenMtar(source, mtaYamlFilename, target, targetProvided, desc string, extensions []string, mtarName, reportDir string,
+	wdGetter func() (string, error)) error {
 	logs.Logger.Info("generating the MTA archive...")
 	loc, err := dir.Location(source, mtaYamlFilename, target, desc, extensions, wdGetter)
 	if err != nil {
@@ -28,7 +30,7 @@ func ExecuteGenMtar(source, mtaYamlFilename, target, targetProvided, desc string
 		return err
 	}
 	logs.Logger.Infof("the MTA archive generated at: %s", path)
-	return nil
+	return writeMtarReport(reportDir, path)
 }
 
 func isTargetProvided(target, provided string) bool {
@@ -58,7 +60,16 @@ func generateMtar(targetLoc dir.ITargetPath, targetArtifacts dir.ITargetArtifact
 
 	// archive building artifacts to mtar
 	mtarPath := filepath.Join(mtarFolderPath, getMtarFileName(m, mtarName))
-	err = dir.Archive(targetTmpDir, mtarPath, nil)
+	// sign the content of the archive if the signing key is set
+	err = signMtarContent(targetTmpDir)
+	if err != nil {
+		return "", err
+	}
+	storedExtensions, err := getStoredExtensions()
+	if err != nil {
+		return "", errors.Wrap(err, genMTARArchMsg)
+	}
+	err = dir.ArchiveWithCompression(targetTmpDir, mtarPath, nil, dir.GetMtarCompression(), storedExtensions)
 	if err != nil {
 		return "", errors.Wrap(err, genMTARArchMsg)
 	}
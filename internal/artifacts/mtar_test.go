@@ -1,8 +1,11 @@
 package artifacts
 
 import (
+	"archive/zip"
 	"errors"
+	"io/ioutil"
 	"os"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -23,25 +26,25 @@ var _ = Describe("Mtar", func() {
 			It("Sanity, target provided", func() {
 				createMtahtml5TmpFolder()
 				Ω(ExecuteGenMeta(getTestPath("mtahtml5"), "", getResultPath(), "dev", nil, "cf", os.Getwd)).Should(Succeed())
-				Ω(ExecuteGenMtar(getTestPath("mtahtml5"), "", getResultPath(), "true", "dev", nil, "", os.Getwd)).Should(Succeed())
+				Ω(ExecuteGenMtar(getTestPath("mtahtml5"), "", getResultPath(), "true", "dev", nil, "", "", os.Getwd)).Should(Succeed())
 				Ω(getTestPath("result", "mtahtml5_0.0.1.mtar")).Should(BeAnExistingFile())
 			})
 
 			It("Sanity, target not provided", func() {
 				createMtahtml5TmpFolder()
 				Ω(ExecuteGenMeta(getTestPath("mtahtml5"), "", getResultPath(), "dev", nil, "cf", os.Getwd)).Should(Succeed())
-				Ω(ExecuteGenMtar(getTestPath("mtahtml5"), "", getResultPath(), "false", "dev", nil, "", os.Getwd)).Should(Succeed())
+				Ω(ExecuteGenMtar(getTestPath("mtahtml5"), "", getResultPath(), "false", "dev", nil, "", "", os.Getwd)).Should(Succeed())
 				Ω(getTestPath("result", "mta_archives", "mtahtml5_0.0.1.mtar")).Should(BeAnExistingFile())
 			})
 
 			It("Fails on location initialization", func() {
-				Ω(ExecuteGenMtar("", "", getResultPath(), "true", "dev", nil, "", func() (string, error) {
+				Ω(ExecuteGenMtar("", "", getResultPath(), "true", "dev", nil, "", "", func() (string, error) {
 					return "", errors.New("err")
 				})).Should(HaveOccurred())
 			})
 
 			It("Fails - wrong source", func() {
-				Ω(ExecuteGenMtar(getTestPath("mtahtml6"), "", getResultPath(), "true", "dev", nil, "", os.Getwd)).Should(HaveOccurred())
+				Ω(ExecuteGenMtar(getTestPath("mtahtml6"), "", getResultPath(), "true", "dev", nil, "", "", os.Getwd)).Should(HaveOccurred())
 			})
 		})
 
@@ -54,6 +57,39 @@ var _ = Describe("Mtar", func() {
 			Ω(mtarPath).Should(BeAnExistingFile())
 		})
 
+		It("Generate Mtar - reproducible", func() {
+			Ω(os.Setenv(dir.SourceDateEpochEnv, "1600000000")).Should(Succeed())
+			defer os.Unsetenv(dir.SourceDateEpochEnv)
+			build := func(mtarName string) []byte {
+				Ω(ExecBuild("", getTestPath("mta_native_build"), "", getResultPath(), nil, "", mtarName, "cf", true, 0, false, os.Getwd,
+					nil, true, false, "", NativeEngine, "")).Should(Succeed())
+				content, err := ioutil.ReadFile(getTestPath("result", mtarName+".mtar"))
+				Ω(err).Should(Succeed())
+				return content
+			}
+			defer os.RemoveAll(getTestPath("mta_native_build", "m1", "from_m2"))
+			defer os.RemoveAll(getTestPath("mta_native_build", "m2", "m2.txt"))
+			first := build("first")
+			time.Sleep(time.Second)
+			Ω(build("second")).Should(Equal(first))
+		})
+
+		It("Generate Mtar - stored entries", func() {
+			Ω(dir.SetMtarCompression(dir.CompressionStore)).Should(Succeed())
+			defer dir.SetMtarCompression("")
+			ep := dir.Loc{SourcePath: getTestPath("mtahtml5"), TargetPath: getResultPath()}
+			createMtahtml5TmpFolder()
+			Ω(generateMeta(&ep, &ep, false, "cf", true, true)).Should(Succeed())
+			mtarPath, err := generateMtar(&ep, &ep, &ep, true, "")
+			Ω(err).Should(Succeed())
+			reader, err := zip.OpenReader(mtarPath)
+			Ω(err).Should(Succeed())
+			defer reader.Close()
+			for _, file := range reader.File {
+				Ω(file.Method).Should(Equal(zip.Store))
+			}
+		})
+
 		It("Generate Mtar - Fails on wrong source", func() {
 			ep := dir.Loc{SourcePath: getTestPath("not_existing"), TargetPath: getResultPath()}
 			ep1 := dir.Loc{SourcePath: getTestPath("mtahtml5"), TargetPath: getResultPath()}
@@ -0,0 +1,201 @@
+package artifacts
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildops"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
+	"github.com/SAP/cloud-mta-build-tool/internal/logs"
+	"github.com/SAP/cloud-mta-build-tool/internal/tpl"
+	"github.com/SAP/cloud-mta/mta"
+)
+
+const (
+	// MakeEngine - the build engine that generates a Makefile and executes it with GNU Make
+	MakeEngine = "make"
+	// NativeEngine - the build engine that schedules the build steps in-process, without GNU Make
+	NativeEngine = "native"
+)
+
+type moduleBuildResult struct {
+	module string
+	err    error
+}
+
+// validateEngine - checks that the build engine is supported
+func validateEngine(engine string) error {
+	if engine != "" && engine != MakeEngine && engine != NativeEngine {
+		return fmt.Errorf(invalidEngineMsg, engine)
+	}
+	return nil
+}
+
+// execNativeBuild - executes the same steps as the generated Makefile, in the same order, without running GNU Make:
+// validation, pre-build, modules build, post-build, metadata generation, MTA archive generation and cleanup.
+// In "verbose" mode modules are built in parallel by a bounded pool of workers, otherwise they are built one by one.
+func execNativeBuild(source, mtaYamlFilename, target string, extensions []string, mode, mtar, platform string,
+	strict bool, jobs int, reportDir string, wdGetter func() (string, error), numCPUGetter func() int) error {
+
+	if mode != "" && !tpl.IsVerboseMode(mode) {
+		return fmt.Errorf(nativeModeNotSupportedMsg, mode)
+	}
+	platform, err := validatePlatform(platform)
+	if err != nil {
+		return err
+	}
+
+	// the Makefile is executed from the project folder, so a relative target is resolved against it
+	source, err = getSoloModuleBuildAbsSource(source, wdGetter)
+	if err != nil {
+		return errors.Wrap(err, nativeBuildFailedOnLocMsg)
+	}
+	targetProvided := target != ""
+	if !targetProvided {
+		target = source
+	} else if !filepath.IsAbs(target) {
+		target = filepath.Join(source, target)
+	}
+
+	loc, err := dir.Location(source, mtaYamlFilename, target, dir.Dev, extensions, wdGetter)
+	if err != nil {
+		return errors.Wrap(err, nativeBuildFailedOnLocMsg)
+	}
+	mtaObj, err := loc.ParseFile()
+	if err != nil {
+		return err
+	}
+	// the modules are filtered by their build parameters, so the invalid build parameters are reported before the build
+	params, err := buildparams.DecodeAll(mtaObj)
+	if err != nil {
+		return err
+	}
+	modules, err := buildops.GetModulesNames(mtaObj, params)
+	if err != nil {
+		return err
+	}
+
+	strictStr := strconv.FormatBool(strict)
+	// pre_validate, pre_build and validate targets
+	err = ExecuteValidation(source, mtaYamlFilename, dir.Dev, extensions, "", strictStr, "paths", wdGetter)
+	if err != nil {
+		return err
+	}
+	err = ExecuteProjectBuild(source, mtaYamlFilename, "", dir.Dev, extensions, "pre", wdGetter)
+	if err != nil {
+		return err
+	}
+	err = ExecuteValidation(source, mtaYamlFilename, dir.Dev, extensions, "", strictStr, "", wdGetter)
+	if err != nil {
+		return err
+	}
+
+	// modules targets
+	workers := 1
+	if tpl.IsVerboseMode(mode) {
+		workers = getBuildJobs(jobs, numCPUGetter)
+	}
+	err = scheduleModuleBuilds(mtaObj, params, modules, workers, func(module string) error {
+		return ExecuteBuild(source, mtaYamlFilename, target, extensions, module, platform, reportDir, wdGetter)
+	})
+	if err != nil {
+		return err
+	}
+
+	// post_build, meta, mtar and cleanup targets
+	err = ExecuteProjectBuild(source, mtaYamlFilename, target, dir.Dev, extensions, "post", wdGetter)
+	if err != nil {
+		return err
+	}
+	err = ExecuteGenMeta(source, mtaYamlFilename, target, dir.Dev, extensions, platform, wdGetter)
+	if err != nil {
+		return err
+	}
+	err = ExecuteGenMtar(source, mtaYamlFilename, target, strconv.FormatBool(targetProvided), dir.Dev, extensions, mtar, reportDir, wdGetter)
+	if err != nil {
+		return err
+	}
+	return ExecuteCleanup(source, mtaYamlFilename, target, dir.Dev, wdGetter)
+}
+
+// scheduleModuleBuilds - runs the build function for each of the modules with at most "jobs" builds running at once.
+// Modules are started in the given order, but a module is started only after all the modules that it requires
+// in its build parameters are built. When a build fails no more builds are started and the first error is returned
+// after the running builds end.
+func scheduleModuleBuilds(mtaObj *mta.MTA, params buildparams.Modules, modules []string, jobs int, build func(module string) error) error {
+	if jobs < 1 {
+		jobs = 1
+	}
+
+	deps := make(map[string][]string)
+	for _, moduleName := range modules {
+		module, err := mtaObj.GetModuleByName(moduleName)
+		if err != nil {
+			return err
+		}
+		for _, req := range params[module.Name].Requires {
+			deps[moduleName] = append(deps[moduleName], req.Name)
+		}
+	}
+
+	pending := append([]string{}, modules...)
+	built := make(map[string]bool)
+	resultCh := make(chan moduleBuildResult, len(modules))
+	running := 0
+	var buildErr error
+	for {
+		if buildErr == nil {
+			pending = startReadyModules(pending, deps, built, jobs-running, resultCh, build, &running)
+		}
+		if running == 0 {
+			break
+		}
+		result := <-resultCh
+		running--
+		built[result.module] = true
+		if result.err != nil && buildErr == nil {
+			buildErr = result.err
+		}
+	}
+	if buildErr != nil {
+		return buildErr
+	}
+	if len(pending) > 0 {
+		return errors.Errorf(nativeBuildNotScheduledMsg, pending[0])
+	}
+	return nil
+}
+
+// startReadyModules - starts up to "slots" builds of the pending modules whose dependencies are built
+// and returns the modules that are still pending
+func startReadyModules(pending []string, deps map[string][]string, built map[string]bool, slots int,
+	resultCh chan<- moduleBuildResult, build func(module string) error, running *int) []string {
+
+	var stillPending []string
+	for _, module := range pending {
+		if slots > 0 && dependenciesBuilt(deps[module], built) {
+			slots--
+			*running++
+			logs.Logger.Debugf(nativeBuildStartMsg, module)
+			go func(module string) {
+				resultCh <- moduleBuildResult{module: module, err: build(module)}
+			}(module)
+		} else {
+			stillPending = append(stillPending, module)
+		}
+	}
+	return stillPending
+}
+
+func dependenciesBuilt(deps []string, built map[string]bool) bool {
+	for _, dep := range deps {
+		if !built[dep] {
+			return false
+		}
+	}
+	return true
+}
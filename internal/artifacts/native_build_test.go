@@ -0,0 +1,177 @@
+package artifacts
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/SAP/cloud-mta-build-tool/internal/buildconfig"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
+	"github.com/SAP/cloud-mta/mta"
+)
+
+var _ = Describe("NativeBuild", func() {
+
+	var _ = Describe("ExecBuild with the native engine", func() {
+		BeforeEach(func() {
+			Ω(os.Mkdir(getResultPath(), os.ModePerm)).Should(Succeed())
+		})
+		AfterEach(func() {
+			Ω(os.RemoveAll(getResultPath())).Should(Succeed())
+			Ω(os.RemoveAll(getTestPath("mta_native_build", "m1", "from_m2"))).Should(Succeed())
+			Ω(os.RemoveAll(getTestPath("mta_native_build", "m2", "m2.txt"))).Should(Succeed())
+		})
+
+		It("Sanity", func() {
+			err := ExecBuild("", getTestPath("mta_native_build"), "", getResultPath(), nil, "", "", "cf", true, 0, false, os.Getwd,
+				func(strings [][]string, b bool) error {
+					return fmt.Errorf("make should not be executed")
+				}, true, false, "", NativeEngine, "")
+			Ω(err).Should(Succeed())
+			Ω(getTestPath("result", "mta_native_build_0.0.1.mtar")).Should(BeAnExistingFile())
+			Ω(getFullPathInTmpFolder("mta_native_build")).ShouldNot(BeADirectory())
+		})
+
+		It("Sanity - verbose mode", func() {
+			err := ExecBuild("", getTestPath("mta_native_build"), "", getResultPath(), nil, "verbose", "native.mtar", "cf", true, 2, false, os.Getwd,
+				nil, true, false, "", NativeEngine, "")
+			Ω(err).Should(Succeed())
+			Ω(getTestPath("result", "native.mtar")).Should(BeAnExistingFile())
+		})
+
+		It("builds the required module of other build profile without packing it", func() {
+			Ω(buildconfig.SetProfile("prod")).Should(Succeed())
+			err := ExecBuild("", getTestPath("mta_native_build"), "mtaProfiles.yaml", getResultPath(), nil, "", "", "cf", true, 0, false, os.Getwd,
+				nil, true, false, "", NativeEngine, "")
+			Ω(err).Should(Succeed())
+			mtarPath := getTestPath("result", "mta_native_build_0.0.1.mtar")
+			_, err = getFileContentFromZip(mtarPath, "m1/data.zip")
+			Ω(err).Should(Succeed())
+			_, err = getFileContentFromZip(mtarPath, "m2/data.zip")
+			Ω(err).Should(HaveOccurred())
+		})
+
+		It("Fails on module build", func() {
+			err := ExecBuild("", getTestPath("mta_native_build"), "mtaFailing.yaml", getResultPath(), nil, "", "", "cf", true, 0, false, os.Getwd,
+				nil, true, false, "", NativeEngine, "")
+			checkError(err, buildFailedMsg, "m1")
+			Ω(getTestPath("result", "mta_native_build_0.0.1.mtar")).ShouldNot(BeAnExistingFile())
+		})
+
+		It("Fails on wrong build parameters before the module build", func() {
+			err := ExecBuild("", getTestPath("mta_native_build"), "mtaWrongParams.yaml", getResultPath(), nil, "", "", "cf", true, 0, false, os.Getwd,
+				nil, true, false, "", NativeEngine, "")
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(buildparams.WrongParamMsg, buildparams.SupportedPlatformsParam, "m1", "a sequence of strings")))
+			Ω(err.Error()).ShouldNot(ContainSubstring(fmt.Sprintf(buildFailedMsg, "m1")))
+		})
+
+		It("Fails on wrong platform", func() {
+			err := ExecBuild("", getTestPath("mta_native_build"), "", getResultPath(), nil, "", "", "xx", true, 0, false, os.Getwd,
+				nil, true, false, "", NativeEngine, "")
+			checkError(err, invalidPlatformMsg, "xx", `"cf", "neo", "xsa"`)
+		})
+
+		It("Fails on wrong mode", func() {
+			err := ExecBuild("", getTestPath("mta_native_build"), "", getResultPath(), nil, "xx", "", "cf", true, 0, false, os.Getwd,
+				nil, true, false, "", NativeEngine, "")
+			checkError(err, nativeModeNotSupportedMsg, "xx")
+		})
+
+		It("Fails on wrong engine", func() {
+			err := ExecBuild("", getTestPath("mta_native_build"), "", getResultPath(), nil, "", "", "cf", true, 0, false, os.Getwd,
+				nil, true, false, "", "xx", "")
+			checkError(err, invalidEngineMsg, "xx")
+		})
+	})
+
+	var _ = Describe("scheduleModuleBuilds", func() {
+		mtaObj := &mta.MTA{
+			Modules: []*mta.Module{
+				{Name: "a", BuildParams: map[string]interface{}{"requires": []interface{}{map[string]interface{}{"name": "c"}}}},
+				{Name: "b"},
+				{Name: "c"},
+				{Name: "d", BuildParams: map[string]interface{}{"requires": []interface{}{map[string]interface{}{"name": "a"}}}},
+			},
+		}
+
+		It("builds the dependencies first", func() {
+			var mutex sync.Mutex
+			var order []string
+			err := scheduleModuleBuilds(mtaObj, decodeAll(mtaObj), []string{"b", "c", "a", "d"}, 4, func(module string) error {
+				mutex.Lock()
+				defer mutex.Unlock()
+				order = append(order, module)
+				return nil
+			})
+			Ω(err).Should(Succeed())
+			Ω(order).Should(ConsistOf("a", "b", "c", "d"))
+			Ω(indexOf(order, "c")).Should(BeNumerically("<", indexOf(order, "a")))
+			Ω(indexOf(order, "a")).Should(BeNumerically("<", indexOf(order, "d")))
+		})
+
+		It("keeps the order of the modules with one job", func() {
+			var order []string
+			err := scheduleModuleBuilds(mtaObj, decodeAll(mtaObj), []string{"b", "c", "a", "d"}, 1, func(module string) error {
+				order = append(order, module)
+				return nil
+			})
+			Ω(err).Should(Succeed())
+			Ω(order).Should(Equal([]string{"b", "c", "a", "d"}))
+		})
+
+		It("does not run more builds than the number of jobs", func() {
+			var mutex sync.Mutex
+			running := 0
+			maxRunning := 0
+			err := scheduleModuleBuilds(mtaObj, decodeAll(mtaObj), []string{"b", "c", "a", "d"}, 2, func(module string) error {
+				mutex.Lock()
+				running++
+				if running > maxRunning {
+					maxRunning = running
+				}
+				mutex.Unlock()
+				time.Sleep(50 * time.Millisecond)
+				mutex.Lock()
+				running--
+				mutex.Unlock()
+				return nil
+			})
+			Ω(err).Should(Succeed())
+			Ω(maxRunning).Should(Equal(2))
+		})
+
+		It("does not start builds after a failure", func() {
+			var order []string
+			err := scheduleModuleBuilds(mtaObj, decodeAll(mtaObj), []string{"b", "c", "a", "d"}, 1, func(module string) error {
+				order = append(order, module)
+				if module == "c" {
+					return fmt.Errorf("c failed")
+				}
+				return nil
+			})
+			Ω(err).Should(MatchError("c failed"))
+			Ω(order).Should(Equal([]string{"b", "c"}))
+		})
+
+		It("fails on unknown module", func() {
+			err := scheduleModuleBuilds(mtaObj, decodeAll(mtaObj), []string{"x"}, 1, func(module string) error {
+				return nil
+			})
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+})
+
+func indexOf(list []string, value string) int {
+	for i, v := range list {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
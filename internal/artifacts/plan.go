@@ -0,0 +1,362 @@
+package artifacts
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildconfig"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildops"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
+	"github.com/SAP/cloud-mta-build-tool/internal/commands"
+	"github.com/SAP/cloud-mta-build-tool/internal/exec"
+	"github.com/SAP/cloud-mta/mta"
+)
+
+// buildPlan - the resolved steps of the build, printed in the dry-run mode instead of executing them
+type buildPlan struct {
+	Platform string
+	Modules  []*modulePlan
+	// Mtar - the path of the MTA archive; empty if the MTA archive is not generated
+	Mtar string
+}
+
+// modulePlan - the resolved build steps of the module
+type modulePlan struct {
+	Name string
+	// Skipped - the reason why the module is not built; empty if the module is built
+	Skipped    string
+	WorkingDir string
+	Commands   []string
+	Timeout    string
+	Hooks      map[string]*hookPlan
+	Copies     []*copyPlan
+	// NotPacked - the reason why the build result of the module is not packed; empty if it is packed
+	NotPacked   string
+	BuildResult string
+	Artifact    string
+	Ignore      []string
+}
+
+// hookPlan - the commands of the module build hook
+type hookPlan struct {
+	WorkingDir string
+	Commands   []string
+}
+
+// copyPlan - the copying of the build artifacts of the required module, defined in the "requires" build parameter
+type copyPlan struct {
+	Module    string
+	Source    string
+	Target    string
+	Artifacts []string
+	Exclude   []string
+	// Rename - the new paths of the copied files and folders, relative to the target path
+	Rename map[string]string
+	// Extract - the archive build result of the required module is unpacked to the target path
+	Extract bool
+}
+
+// planLoc - the module location of the build plan; the build results do not exist before the build,
+// so the relative path of a missing build result is derived from its path: a pattern or an archive is a file, otherwise it is a folder
+type planLoc struct {
+	dir.IModule
+}
+
+func (loc *planLoc) GetSourceModuleArtifactRelPath(modulePath, artifactPath string) (string, error) {
+	relPath, err := loc.IModule.GetSourceModuleArtifactRelPath(modulePath, artifactPath)
+	if err == nil || !os.IsNotExist(err) {
+		return relPath, err
+	}
+	isArchive, _ := buildops.IsArchive(artifactPath, false)
+	if isArchive || strings.ContainsAny(filepath.Base(artifactPath), "*?[") {
+		artifactPath = filepath.Dir(artifactPath)
+	}
+	return filepath.Rel(loc.GetSourceModuleDir(modulePath), artifactPath)
+}
+
+// ExecuteBuildDryRun - prints the resolved plan of the MTA project build without executing it:
+// the modules in their build order with their build steps and the path of the MTA archive
+func ExecuteBuildDryRun(source, mtaYamlFilename, target string, extensions []string, mtar, platform string,
+	out io.Writer, wdGetter func() (string, error)) error {
+
+	platform, err := validatePlatform(platform)
+	if err != nil {
+		return err
+	}
+	source, err = getSoloModuleBuildAbsSource(source, wdGetter)
+	if err != nil {
+		return errors.Wrap(err, dryRunFailedOnLocMsg)
+	}
+	// the build target is resolved the same way the build resolves it
+	targetProvided := target != ""
+	if !targetProvided {
+		target = source
+	} else if !filepath.IsAbs(target) {
+		target = filepath.Join(source, target)
+	}
+	loc, err := dir.Location(source, mtaYamlFilename, target, dir.Dev, extensions, wdGetter)
+	if err != nil {
+		return errors.Wrap(err, dryRunFailedOnLocMsg)
+	}
+	mtaObj, err := loc.ParseFile()
+	if err != nil {
+		return err
+	}
+	params, err := buildparams.DecodeAll(mtaObj)
+	if err != nil {
+		return err
+	}
+	modules, err := buildops.GetModulesNames(mtaObj, params)
+	if err != nil {
+		return err
+	}
+
+	plan := &buildPlan{Platform: platform, Mtar: filepath.Join(loc.GetMtarDir(targetProvided), getMtarFileName(mtaObj, mtar))}
+	for _, moduleName := range modules {
+		modulePlan, err := getModulePlan(loc, mtaObj, params, moduleName, platform, true, true)
+		if err != nil {
+			return err
+		}
+		plan.Modules = append(plan.Modules, modulePlan)
+	}
+	return printBuildPlan(plan, out)
+}
+
+// ExecuteSoloBuildDryRun - prints the resolved plan of the modules build without executing it;
+// the modules that are built only as the dependencies of the selected modules are not packed
+func ExecuteSoloBuildDryRun(source, mtaYamlFilename, target string, extensions []string, modulesNames []string, allDependencies bool,
+	out io.Writer, wdGetter func() (string, error)) error {
+
+	if len(modulesNames) == 0 {
+		return errors.New(buildFailedOnEmptyModulesMsg)
+	}
+	sourceDir, err := getSoloModuleBuildAbsSource(source, wdGetter)
+	if err != nil {
+		return errors.Wrap(err, dryRunFailedOnLocMsg)
+	}
+	loc, err := dir.Location(sourceDir, mtaYamlFilename, "", dir.Dev, extensions, wdGetter)
+	if err != nil {
+		return errors.Wrap(err, dryRunFailedOnLocMsg)
+	}
+	mtaObj, err := loc.ParseFile()
+	if err != nil {
+		return err
+	}
+	params, err := buildparams.DecodeAll(mtaObj)
+	if err != nil {
+		return err
+	}
+	allModulesSorted, err := buildops.GetModulesNames(mtaObj, params)
+	if err != nil {
+		return err
+	}
+
+	selectedModulesMap := make(map[string]bool)
+	for _, moduleName := range modulesNames {
+		selectedModulesMap[moduleName] = true
+	}
+	modulesToBuild := selectedModulesMap
+	if allDependencies {
+		modulesToBuild = make(map[string]bool)
+		for module := range selectedModulesMap {
+			err = collectSelectedModulesAndDependencies(mtaObj, params, modulesToBuild, module)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	plan := &buildPlan{}
+	for _, moduleName := range sortModules(allModulesSorted, modulesToBuild) {
+		moduleLoc, err := getModuleLocation(sourceDir, mtaYamlFilename, target, moduleName, extensions, wdGetter)
+		if err != nil {
+			return err
+		}
+		modulePlan, err := getModulePlan(moduleLoc, mtaObj, params, moduleName, "", false, selectedModulesMap[moduleName])
+		if err != nil {
+			return err
+		}
+		plan.Modules = append(plan.Modules, modulePlan)
+	}
+	return printBuildPlan(plan, out)
+}
+
+// getModulePlan - resolves the build steps of the module the same way the module build resolves them
+func getModulePlan(moduleLoc dir.IModule, mtaObj *mta.MTA, modulesParams buildparams.Modules, moduleName, platform string,
+	checkPlatform bool, toPack bool) (*modulePlan, error) {
+
+	module, params, mCmd, defaultBuildResult, err := commands.GetParsedModuleAndCommands(mtaObj, modulesParams, moduleName)
+	if err != nil {
+		return nil, errors.Wrapf(err, buildFailedOnCommandsMsg, moduleName)
+	}
+	plan := &modulePlan{Name: moduleName}
+	if params.NoSource {
+		plan.Skipped = planNoSourceMsg
+		return plan, nil
+	}
+	profileBuilt, err := buildops.ProfileBuilt(mtaObj, modulesParams, moduleName)
+	if err != nil {
+		return nil, errors.Wrapf(err, buildFailedMsg, moduleName)
+	}
+	if !profileBuilt {
+		plan.Skipped = fmt.Sprintf(planProfileMsg, buildconfig.Profile())
+		return plan, nil
+	}
+	if module.Path == "" {
+		return nil, fmt.Errorf(buildFailedOnEmptyPathMsg, moduleName)
+	}
+
+	plan.WorkingDir = moduleLoc.GetSourceModuleDir(module.Path)
+	plan.Commands = mCmd
+	plan.Timeout, err = getModuleTimeout(params)
+	if err != nil {
+		return nil, err
+	}
+	plan.Hooks = getModuleHooksPlan(plan.WorkingDir, params)
+	for _, req := range params.Requires {
+		req := req
+		sourcePath, targetPath, artifacts, err := buildops.GetRequiresArtifacts(moduleLoc, mtaObj, modulesParams, &req, moduleName, false)
+		if err != nil {
+			return nil, errors.Wrapf(err, buildFailedOnDepsMsg, moduleName)
+		}
+		plan.Copies = append(plan.Copies, &copyPlan{Module: req.Name, Source: sourcePath, Target: targetPath, Artifacts: artifacts,
+			Exclude: req.Exclude, Rename: req.Rename, Extract: req.Extract})
+	}
+
+	if !toPack {
+		plan.NotPacked = planNotSelectedMsg
+		return plan, nil
+	}
+	if checkPlatform && !buildops.PlatformDefined(params, platform) {
+		plan.NotPacked = fmt.Sprintf(planPlatformMsg, platform)
+		return plan, nil
+	}
+	if !buildops.ProfileDefined(params) {
+		plan.NotPacked = fmt.Sprintf(planProfileMsg, buildconfig.Profile())
+		return plan, nil
+	}
+	// the build result usually does not exist before the build, so its path is resolved only if it exists
+	resolveBuildResult := true
+	plan.BuildResult, err = buildops.GetModuleSourceArtifactPath(moduleLoc, false, module, params, defaultBuildResult, true)
+	if err != nil {
+		resolveBuildResult = false
+		plan.BuildResult, err = buildops.GetModuleSourceArtifactPath(moduleLoc, false, module, params, defaultBuildResult, false)
+		if err != nil {
+			return nil, errors.Wrapf(err, packFailedOnBuildArtifactMsg, moduleName)
+		}
+	}
+	artifact, toArchive, err := buildops.GetModuleTargetArtifactPath(&planLoc{moduleLoc}, false, module, params, defaultBuildResult, resolveBuildResult)
+	if err != nil {
+		return nil, errors.Wrapf(err, packFailedOnTargetArtifactMsg, moduleName)
+	}
+	plan.Artifact = artifact
+	if toArchive {
+		plan.Ignore = getIgnores(moduleLoc, params.Ignore, plan.BuildResult)
+	}
+	return plan, nil
+}
+
+// getModuleTimeout - gets the timeout of the module build commands, or the default timeout if it is not defined
+func getModuleTimeout(params *buildparams.Module) (string, error) {
+	timeoutDuration, err := exec.GetTimeout(params.Timeout)
+	if err != nil {
+		return "", err
+	}
+	return timeoutDuration.String(), nil
+}
+
+// getModuleHooksPlan - gets the defined module build hooks by their phases
+func getModuleHooksPlan(modulePath string, params *buildparams.Module) map[string]*hookPlan {
+	hooks := make(map[string]*hookPlan)
+	for _, phase := range []string{commands.BeforeBuildHook, commands.AfterBuildHook, commands.BeforePackHook} {
+		hook := commands.GetModuleHook(params, phase)
+		if hook == nil {
+			continue
+		}
+		hooks[phase] = &hookPlan{WorkingDir: filepath.Join(modulePath, hook.WorkingDir), Commands: hook.Commands}
+	}
+	return hooks
+}
+
+func printBuildPlan(plan *buildPlan, out io.Writer) error {
+	var b strings.Builder
+	b.WriteString(planHeaderMsg + "\n")
+	if plan.Platform != "" {
+		fmt.Fprintf(&b, "platform: %s\n", plan.Platform)
+	}
+	for i, module := range plan.Modules {
+		fmt.Fprintf(&b, "\n%d. %s\n", i+1, module.Name)
+		if module.Skipped != "" {
+			fmt.Fprintf(&b, "   skipped: %s\n", module.Skipped)
+			continue
+		}
+		fmt.Fprintf(&b, "   working directory: %s\n", module.WorkingDir)
+		for _, c := range module.Copies {
+			fmt.Fprintf(&b, "   copy from the %q module: %s -> %s\n", c.Module, c.Source, c.Target)
+			writePlanList(&b, "artifacts", c.Artifacts)
+			writePlanList(&b, "exclude", c.Exclude)
+			writePlanList(&b, "rename", getPlanRenames(c.Rename))
+			if c.Extract {
+				b.WriteString("     extract: the archive is unpacked to the target\n")
+			}
+		}
+		writePlanHook(&b, commands.BeforeBuildHook, module.Hooks[commands.BeforeBuildHook])
+		writePlanCommands(&b, "commands", module.Commands)
+		fmt.Fprintf(&b, "   timeout: %s\n", module.Timeout)
+		writePlanHook(&b, commands.AfterBuildHook, module.Hooks[commands.AfterBuildHook])
+		if module.NotPacked != "" {
+			fmt.Fprintf(&b, "   not packed: %s\n", module.NotPacked)
+			continue
+		}
+		writePlanHook(&b, commands.BeforePackHook, module.Hooks[commands.BeforePackHook])
+		fmt.Fprintf(&b, "   build result: %s\n", module.BuildResult)
+		fmt.Fprintf(&b, "   artifact: %s\n", module.Artifact)
+		if len(module.Ignore) > 0 {
+			fmt.Fprintf(&b, "   ignore: %s\n", strings.Join(module.Ignore, ", "))
+		}
+	}
+	if plan.Mtar != "" {
+		fmt.Fprintf(&b, "\nMTA archive: %s\n", plan.Mtar)
+	}
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+func writePlanCommands(b *strings.Builder, title string, commands []string) {
+	if len(commands) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "   %s:\n", title)
+	for _, command := range commands {
+		fmt.Fprintf(b, "     %s\n", command)
+	}
+}
+
+func writePlanHook(b *strings.Builder, phase string, hook *hookPlan) {
+	if hook != nil {
+		writePlanCommands(b, fmt.Sprintf("%s hook in %s", phase, hook.WorkingDir), hook.Commands)
+	}
+}
+
+// getPlanRenames - gets the renamed paths sorted by the original path
+func getPlanRenames(rename map[string]string) []string {
+	renames := make([]string, 0, len(rename))
+	for from, to := range rename {
+		renames = append(renames, from+" -> "+to)
+	}
+	sort.Strings(renames)
+	return renames
+}
+
+func writePlanList(b *strings.Builder, title string, values []string) {
+	if len(values) > 0 {
+		fmt.Fprintf(b, "     %s: %s\n", title, strings.Join(values, ", "))
+	}
+}
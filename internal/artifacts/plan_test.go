@@ -0,0 +1,101 @@
+package artifacts
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
+)
+
+var _ = Describe("Plan", func() {
+
+	var out bytes.Buffer
+
+	BeforeEach(func() {
+		out.Reset()
+	})
+
+	AfterEach(func() {
+		Ω(os.RemoveAll(getResultPath())).Should(Succeed())
+	})
+
+	Describe("ExecuteBuildDryRun", func() {
+		It("prints the resolved build plan without building the modules", func() {
+			Ω(ExecuteBuildDryRun(getTestPath("mta"), "mta_dry_run.yaml", getResultPath(), nil, "", "cf", &out, os.Getwd)).Should(Succeed())
+			Ω(getTestPath("result")).ShouldNot(BeADirectory())
+			Ω(out.String()).Should(Equal(`the build plan (dry run, nothing is executed):
+platform: cf
+
+1. ui
+   working directory: ` + getTestPath("mta", "htmlapp2") + `
+   commands:
+     npm run build
+   timeout: 10m0s
+   not packed: the module does not support the "cf" platform
+
+2. docs
+   skipped: the "no-source" build parameter is set to "true"
+
+3. srv
+   working directory: ` + getTestPath("mta", "node-js") + `
+   copy from the "ui" module: ` + getTestPath("mta", "htmlapp2", "dist") + ` -> ` + getTestPath("mta", "node-js", "public") + `
+     artifacts: *.html
+     exclude: test.html
+     rename: about.html -> info.html, index.html -> main.html
+     extract: the archive is unpacked to the target
+   commands:
+     mvn -B dependency:copy -Dartifact=com.sap.mta:srv:1.0.0 -DoutputDirectory=./target
+   timeout: 15m0s
+   before-pack hook in ` + getTestPath("mta") + `:
+     rm -rf node-js/test
+   build result: ` + getTestPath("mta", "node-js", "target", "*.*") + `
+   artifact: ` + getFullPathInTmpFolder("mta", "srv", "target", "srv.zip") + `
+   ignore: *.log
+
+MTA archive: ` + getTestPath("result", "mta_0.0.1.mtar") + `
+`))
+		})
+		It("prints the MTA archive name provided by the user", func() {
+			Ω(ExecuteBuildDryRun(getTestPath("mta"), "mta_dry_run.yaml", getResultPath(), nil, "app", "neo", &out, os.Getwd)).Should(Succeed())
+			Ω(out.String()).Should(ContainSubstring("MTA archive: " + getTestPath("result", "app.mtar")))
+			Ω(out.String()).Should(ContainSubstring("   artifact: " + getFullPathInTmpFolder("mta", "ui", "dist", "data.zip")))
+		})
+		It("prints the modules of other build profiles as skipped", func() {
+			Ω(ExecuteBuildDryRun(getTestPath("mta"), "mta_with_profiles.yaml", getResultPath(), nil, "", "cf", &out, os.Getwd)).Should(Succeed())
+			Ω(out.String()).Should(ContainSubstring(`   skipped: the module is not included in the "" build profile`))
+		})
+		It("fails on the invalid platform", func() {
+			Ω(ExecuteBuildDryRun(getTestPath("mta"), "mta_dry_run.yaml", getResultPath(), nil, "", "abc", &out, os.Getwd)).Should(HaveOccurred())
+		})
+		It("fails when the MTA file does not exist", func() {
+			Ω(ExecuteBuildDryRun(getTestPath("mta"), "unknown.yaml", getResultPath(), nil, "", "cf", &out, os.Getwd)).Should(HaveOccurred())
+		})
+	})
+
+	Describe("ExecuteSoloBuildDryRun", func() {
+		It("prints the plan of the selected modules and their dependencies", func() {
+			Ω(ExecuteSoloBuildDryRun(getTestPath("mta"), "mta_dry_run.yaml", getResultPath(), nil, []string{"srv"}, true, &out, os.Getwd)).Should(Succeed())
+			Ω(out.String()).ShouldNot(ContainSubstring("platform:"))
+			Ω(out.String()).ShouldNot(ContainSubstring("MTA archive:"))
+			Ω(out.String()).Should(ContainSubstring("1. ui\n"))
+			Ω(out.String()).Should(ContainSubstring("   not packed: the module is built only as a dependency of the selected modules\n"))
+			Ω(out.String()).Should(ContainSubstring("   artifact: " + filepath.Join(getResultPath(), "srv.zip") + "\n"))
+		})
+		It("fails when no modules are selected", func() {
+			Ω(ExecuteSoloBuildDryRun(getTestPath("mta"), "mta_dry_run.yaml", getResultPath(), nil, nil, false, &out, os.Getwd)).Should(HaveOccurred())
+		})
+	})
+
+	DescribeTable("getModuleTimeout fails on the invalid timeout", func(timeout string) {
+		_, err := getModuleTimeout(&buildparams.Module{Timeout: timeout})
+		Ω(err).Should(HaveOccurred())
+	},
+		Entry("wrong duration", "abc"),
+		Entry("missing unit", "5"),
+	)
+})
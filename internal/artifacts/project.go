@@ -10,6 +10,7 @@ import (
 	"github.com/pkg/errors"
 
 	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
 	"github.com/SAP/cloud-mta-build-tool/internal/commands"
 	"github.com/SAP/cloud-mta-build-tool/internal/exec"
 	"github.com/SAP/cloud-mta-build-tool/internal/logs"
@@ -27,21 +28,77 @@ const (
 // ExecBuild - Execute MTA project build
 func ExecBuild(makefileTmp, source, mtaYamlFilename, target string, extensions []string, mode, mtar, platform string,
 	strict bool, jobs int, outputSync bool, wdGetter func() (string, error), wdExec func([][]string, bool) error,
-	useDefaultMbt bool, keepMakefile bool, sBomFilePath string) error {
+	useDefaultMbt bool, keepMakefile bool, sBomFilePath string, engine string, report string) error {
 	message, err := version.GetVersionMessage()
 	if err == nil {
 		logs.Logger.Info(message)
 	}
 
+	err = validateEngine(engine)
+	if err != nil {
+		return err
+	}
+
+	reportDir, err := createReportDir(report)
+	if err != nil {
+		return err
+	}
+	err = execBuild(makefileTmp, source, mtaYamlFilename, target, extensions, mode, mtar, platform, strict, jobs, outputSync,
+		wdGetter, wdExec, useDefaultMbt, keepMakefile, sBomFilePath, engine, reportDir)
+
+	// (5) generate build report
+	reportErr := writeBuildReport(report, reportDir, source, mtaYamlFilename, extensions, platform,
+		getSBomReportPath(source, sBomFilePath, wdGetter), err, wdGetter)
+	if err != nil {
+		if reportErr != nil {
+			logs.Logger.Error(reportErr)
+		}
+		return err
+	}
+	return reportErr
+}
+
+func execBuild(makefileTmp, source, mtaYamlFilename, target string, extensions []string, mode, mtar, platform string,
+	strict bool, jobs int, outputSync bool, wdGetter func() (string, error), wdExec func([][]string, bool) error,
+	useDefaultMbt bool, keepMakefile bool, sBomFilePath string, engine string, reportDir string) error {
+
+	if engine == NativeEngine {
+		// (1) - (3) execute the build steps in-process
+		err := execNativeBuild(source, mtaYamlFilename, target, extensions, mode, mtar, platform, strict, jobs, reportDir,
+			wdGetter, runtime.NumCPU)
+		if err != nil {
+			return errors.Wrap(err, execFailedMsg)
+		}
+	} else {
+		// (1) - (3) generate and execute the Makefile
+		err := execMakeBuild(makefileTmp, source, mtaYamlFilename, target, extensions, mode, mtar, platform, strict, jobs,
+			outputSync, reportDir, wdGetter, wdExec, useDefaultMbt, keepMakefile)
+		if err != nil {
+			return err
+		}
+	}
+
+	// (4) generate sbom file
+	sBomGenError := ExecuteProjectBuildeSBomGenerate(source, mtaYamlFilename, sBomFilePath, wdGetter)
+	if sBomGenError != nil {
+		return errors.Wrap(sBomGenError, execFailedMsg)
+	}
+	return nil
+}
+
+func execMakeBuild(makefileTmp, source, mtaYamlFilename, target string, extensions []string, mode, mtar, platform string,
+	strict bool, jobs int, outputSync bool, reportDir string, wdGetter func() (string, error), wdExec func([][]string, bool) error,
+	useDefaultMbt bool, keepMakefile bool) error {
+
 	// (1) generate build script
-	err = tpl.ExecuteMake(source, mtaYamlFilename, "", extensions, makefileTmp, mode, wdGetter, useDefaultMbt)
+	err := tpl.ExecuteMake(source, mtaYamlFilename, "", extensions, makefileTmp, mode, wdGetter, useDefaultMbt)
 	if err != nil {
 		return err
 	}
 
 	// (2) execute make command
 	cmdParams := createMakeCommand(makefileTmp, source, target, mode, mtar, platform, strict, jobs,
-		outputSync, runtime.NumCPU)
+		outputSync, reportDir, runtime.NumCPU)
 	execMakeFileError := wdExec([][]string{cmdParams}, false)
 
 	// (3) remove temporary Makefile
@@ -60,32 +117,20 @@ func ExecBuild(makefileTmp, source, mtaYamlFilename, target string, extensions [
 		return errors.Wrap(execMakeFileError, execFailedMsg)
 	}
 
-	if removeMakeFileError != nil {
-		return removeMakeFileError
-	}
-
-	// (4) generate sbom file
-	sBomGenError := ExecuteProjectBuildeSBomGenerate(source, mtaYamlFilename, sBomFilePath, wdGetter)
-	if sBomGenError != nil {
-		return errors.Wrap(sBomGenError, execFailedMsg)
-	}
-	return nil
+	return removeMakeFileError
 }
 
 func createMakeCommand(makefileName, source, target, mode, mtar, platform string, strict bool, jobs int,
-	outputSync bool, numCPUGetter func() int) []string {
+	outputSync bool, reportDir string, numCPUGetter func() int) []string {
 	cmdParams := []string{source, "make", "-f", makefileName, "p=" + platform, "mtar=" + mtar, "strict=" + strconv.FormatBool(strict), "mode=" + mode}
 	if target != "" {
 		cmdParams = append(cmdParams, `t="`+target+`"`)
 	}
+	if reportDir != "" {
+		cmdParams = append(cmdParams, `report_dir="`+reportDir+`"`)
+	}
 	if tpl.IsVerboseMode(mode) {
-		if jobs <= 0 {
-			jobs = numCPUGetter()
-			if jobs > MaxMakeParallel {
-				jobs = MaxMakeParallel
-			}
-		}
-		cmdParams = append(cmdParams, fmt.Sprintf("-j%d", jobs))
+		cmdParams = append(cmdParams, fmt.Sprintf("-j%d", getBuildJobs(jobs, numCPUGetter)))
 
 		if outputSync {
 			cmdParams = append(cmdParams, "-Otarget")
@@ -94,6 +139,18 @@ func createMakeCommand(makefileName, source, target, mode, mtar, platform string
 	return cmdParams
 }
 
+// getBuildJobs - gets the number of build jobs to run simultaneously;
+// if it is not set by the user, the number of CPUs is used (maximum MaxMakeParallel)
+func getBuildJobs(jobs int, numCPUGetter func() int) int {
+	if jobs <= 0 {
+		jobs = numCPUGetter()
+		if jobs > MaxMakeParallel {
+			jobs = MaxMakeParallel
+		}
+	}
+	return jobs
+}
+
 // ExecuteProjectBuild - execute pre or post phase of project build
 func ExecuteProjectBuild(source, mtaYamlFilename, target, descriptor string, extensions []string, phase string, getWd func() (string, error)) error {
 	if phase != "pre" && phase != "post" {
@@ -119,7 +176,11 @@ func execProjectBuilders(loc *dir.Loc, oMta *mta.MTA, phase string) error {
 		if err != nil {
 			return err
 		}
-		err = copyRequiredDependencyContent(loc.GetSource(), loc.GetTargetTmpDir(), oMta, copyInParallel)
+		params, err := buildparams.DecodeAll(oMta)
+		if err != nil {
+			return err
+		}
+		err = copyRequiredDependencyContent(loc.GetSource(), loc.GetTargetTmpDir(), oMta, params, copyInParallel)
 		if err != nil {
 			return err
 		}
@@ -163,6 +224,10 @@ func getProjectBuilderCommands(builder mta.ProjectBuilder) (commands.CommandList
 	if builder.Builder != "custom" && builder.Commands != nil && len(builder.Commands) != 0 {
 		logs.Logger.Warnf(commandsNotSupportedMsg, builder.Builder)
 	}
-	builderCommands, _, err := commands.CommandProvider(dummyModule)
+	params, err := buildparams.Decode(&dummyModule)
+	if err != nil {
+		return commands.CommandList{}, err
+	}
+	builderCommands, _, err := commands.CommandProvider(dummyModule, params)
 	return builderCommands, err
 }
@@ -12,6 +12,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
 	"github.com/SAP/cloud-mta-build-tool/internal/commands"
 	"github.com/SAP/cloud-mta-build-tool/internal/exec"
 	"github.com/SAP/cloud-mta/mta"
@@ -57,21 +58,21 @@ var _ = Describe("Project", func() {
 		It("Sanity", func() {
 			err := ExecBuild("Makefile_tmp.mta", getTestPath("mta_with_zipped_module"), "", getResultPath(), nil, "", "", "cf", true, 0, false, os.Getwd, func(strings [][]string, b bool) error {
 				return nil
-			}, true, false, "")
+			}, true, false, "", MakeEngine, "")
 			Ω(err).Should(Succeed())
 			Ω(filepath.Join(getTestPath("mta_with_zipped_module"), "Makefile_tmp.mta")).ShouldNot(BeAnExistingFile())
 		})
 		It("Sanity - keep makefile", func() {
 			err := ExecBuild("Makefile_tmp.mta", getTestPath("mta_with_zipped_module"), "", getResultPath(), nil, "", "", "cf", true, 0, false, os.Getwd, func(strings [][]string, b bool) error {
 				return nil
-			}, true, true, "")
+			}, true, true, "", MakeEngine, "")
 			Ω(err).Should(Succeed())
 			Ω(filepath.Join(getTestPath("mta_with_zipped_module"), "Makefile_tmp.mta")).Should(BeAnExistingFile())
 		})
 		It("Wrong - no platform", func() {
 			err := ExecBuild("Makefile_tmp.mta", getTestPath("mta_with_zipped_module"), "", getResultPath(), nil, "", "", "", true, 0, false, os.Getwd, func(strings [][]string, b bool) error {
 				return fmt.Errorf("failure")
-			}, true, false, "")
+			}, true, false, "", MakeEngine, "")
 			Ω(err).Should(HaveOccurred())
 		})
 		It("Wrong - ExecuteMake fails on wrong location", func() {
@@ -80,9 +81,18 @@ var _ = Describe("Project", func() {
 					return "", errors.New("wrong location")
 				}, func(strings [][]string, b bool) error {
 					return nil
-				}, true, false, "")
+				}, true, false, "", MakeEngine, "")
 			Ω(err).Should(HaveOccurred())
 		})
+		It("Wrong - build parameters are defined incorrectly", func() {
+			err := ExecBuild("Makefile_tmp.mta", getTestPath("mta_native_build"), "mtaWrongParams.yaml", getResultPath(), nil, "", "", "cf", true, 0, false, os.Getwd,
+				func(strings [][]string, b bool) error {
+					return fmt.Errorf("make should not be executed")
+				}, true, false, "", MakeEngine, "")
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(buildparams.WrongParamMsg, buildparams.SupportedPlatformsParam, "m1", "a sequence of strings")))
+			Ω(filepath.Join(getTestPath("mta_native_build"), "Makefile_tmp.mta")).ShouldNot(BeAnExistingFile())
+		})
 	})
 
 	var _ = Describe("getProjectBuilderCommands", func() {
@@ -236,7 +246,7 @@ builders:
 	})
 
 	var _ = DescribeTable("createMakeCommand", func(target, mode string, strict bool, jobs int, cpus int, outputSync bool, additionalExpectedArgs []string) {
-		command := createMakeCommand("Makefile_tmp", "./src", target, mode, "result.mtar", "cf", strict, jobs, outputSync, func() int {
+		command := createMakeCommand("Makefile_tmp", "./src", target, mode, "result.mtar", "cf", strict, jobs, outputSync, "", func() int {
 			return cpus
 		})
 		Ω(len(command)).To(Equal(8+len(additionalExpectedArgs)), "number of command arguments")
@@ -0,0 +1,231 @@
+package artifacts
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/SAP/cloud-mta/mta"
+
+	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
+	"github.com/SAP/cloud-mta-build-tool/internal/logs"
+)
+
+// repackLoc - the location of the unpacked MTA archive; the unpacked folder has the layout of the temporary folder of the build,
+// so it is used both as the source of the modules and as the folder to archive
+type repackLoc struct {
+	path   string
+	target string
+}
+
+func (loc *repackLoc) GetSourceModuleDir(modulePath string) string {
+	return filepath.Join(loc.path, filepath.Clean(modulePath))
+}
+
+func (loc *repackLoc) GetSourceModuleArtifactRelPath(modulePath, artifactPath string) (string, error) {
+	return filepath.Rel(loc.GetSourceModuleDir(modulePath), artifactPath)
+}
+
+func (loc *repackLoc) GetTargetModuleDir(moduleName string) string {
+	return filepath.Join(loc.path, moduleName)
+}
+
+func (loc *repackLoc) GetTargetTmpRoot() string {
+	return loc.path
+}
+
+// GetTargetCacheDir - the repacked archive is not built, so it has no build cache
+func (loc *repackLoc) GetTargetCacheDir() string {
+	return ""
+}
+
+func (loc *repackLoc) GetTarget() string {
+	return loc.target
+}
+
+func (loc *repackLoc) GetTargetTmpDir() string {
+	return loc.path
+}
+
+func (loc *repackLoc) GetMetaPath() string {
+	return filepath.Join(loc.path, "META-INF")
+}
+
+func (loc *repackLoc) GetMtadPath() string {
+	return filepath.Join(loc.GetMetaPath(), dir.Mtad)
+}
+
+func (loc *repackLoc) GetManifestPath() string {
+	return filepath.Join(loc.GetMetaPath(), "MANIFEST.MF")
+}
+
+func (loc *repackLoc) GetMtarDir(targetProvided bool) string {
+	return loc.target
+}
+
+func (loc *repackLoc) ParseFile() (*mta.MTA, error) {
+	content, err := ioutil.ReadFile(loc.GetMtadPath())
+	if err != nil {
+		return nil, err
+	}
+	return mta.Unmarshal(content)
+}
+
+// ExecuteUnpack - extracts the MTA archive to the target folder with the layout of the temporary folder of the build;
+// the paths of the deployment descriptor modules are set to the paths of their manifest entries,
+// so the folder can be packed back by the repack command
+func ExecuteUnpack(mtarPath, target string, wdGetter func() (string, error)) error {
+	if target == "" {
+		wd, err := wdGetter()
+		if err != nil {
+			return errors.Wrap(err, unpackFailedOnLocMsg)
+		}
+		target = filepath.Join(wd, strings.TrimSuffix(filepath.Base(mtarPath), filepath.Ext(mtarPath)))
+	}
+	logs.Logger.Infof(unpackMsg, mtarPath, target)
+
+	content, err := inspectMtar(mtarPath)
+	if err != nil {
+		return err
+	}
+	targetEntries, err := ioutil.ReadDir(target)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, unpackFailedOnTargetMsg, target)
+	}
+	if len(targetEntries) > 0 {
+		return errors.Errorf(unpackTargetNotEmptyMsg, target)
+	}
+	err = dir.CopyByPatternsWithOptions(mtarPath, target, nil, nil, nil, true)
+	if err != nil {
+		return errors.Wrapf(err, unpackFailedMsg, mtarPath)
+	}
+	return setUnpackedModulePaths(&repackLoc{path: target}, content)
+}
+
+// setUnpackedModulePaths - sets the paths of the deployment descriptor modules to the paths of their manifest entries;
+// the deployment descriptor is rewritten only if a path is changed
+func setUnpackedModulePaths(loc *repackLoc, content *mtarContent) error {
+	changed := false
+	for _, module := range content.Mtad.Modules {
+		modulePath := ""
+		for _, e := range content.Modules {
+			if e.EntryName == module.Name && e.EntryPath != "" && !e.Missing {
+				modulePath = strings.TrimSuffix(e.EntryPath, "/")
+			}
+		}
+		if module.Path != modulePath {
+			module.Path = modulePath
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	mtad, err := mta.Marshal(content.Mtad)
+	if err != nil {
+		return errors.Wrap(err, genMTADMarshMsg)
+	}
+	err = ioutil.WriteFile(loc.GetMtadPath(), mtad, os.ModePerm)
+	if err != nil {
+		return errors.Wrap(err, genMTADWriteMsg)
+	}
+	return nil
+}
+
+// ExecuteRepack - generates the manifest of the unpacked MTA archive and packs the folder to a new MTA archive;
+// if the target folder is not provided, the archive is saved in the "mta_archives" subfolder of the current folder
+func ExecuteRepack(source, target, mtarName string, wdGetter func() (string, error)) error {
+	if source == "" || target == "" {
+		wd, err := wdGetter()
+		if err != nil {
+			return errors.Wrap(err, repackFailedOnLocMsg)
+		}
+		if source == "" {
+			source = wd
+		}
+		if target == "" {
+			target = filepath.Join(wd, dir.MtarFolder)
+		}
+	}
+	sourcePath, err := filepath.Abs(source)
+	if err != nil {
+		return errors.Wrap(err, repackFailedOnLocMsg)
+	}
+	targetPath, err := filepath.Abs(target)
+	if err != nil {
+		return errors.Wrap(err, repackFailedOnLocMsg)
+	}
+	loc := &repackLoc{path: sourcePath, target: targetPath}
+	logs.Logger.Infof(repackMsg, loc.path)
+
+	m, err := loc.ParseFile()
+	if err != nil {
+		return errors.Wrapf(err, repackFailedOnMtadMsg, loc.GetMtadPath())
+	}
+	params, err := buildparams.DecodeAll(m)
+	if err != nil {
+		return errors.Wrapf(err, repackFailedOnMtadMsg, loc.GetMtadPath())
+	}
+	err = validateRepackLayout(loc, m, params)
+	if err != nil {
+		return errors.Wrapf(err, repackInvalidLayoutMsg, loc.path)
+	}
+
+	err = setManifestDesc(loc, loc, loc, true, m.Modules, params, m.Resources, "")
+	if err != nil {
+		return errors.Wrap(err, genMetaPopulatingMsg)
+	}
+	mtarPath, err := generateMtar(loc, loc, loc, true, mtarName)
+	if err != nil {
+		return err
+	}
+	logs.Logger.Infof(repackFinishedMsg, mtarPath)
+	return nil
+}
+
+// validateRepackLayout - checks that the paths of the modules, resources and required dependencies of the deployment descriptor
+// exist in the unpacked folder, and that the new archive is not saved in the unpacked folder
+func validateRepackLayout(loc *repackLoc, m *mta.MTA, params buildparams.Modules) error {
+	var issues []string
+	checkPath := func(path, kind, name string) {
+		_, err := os.Stat(filepath.Join(loc.path, filepath.FromSlash(path)))
+		if err != nil {
+			issues = append(issues, fmt.Sprintf(repackPathNotFoundMsg, path, kind, name))
+		}
+	}
+	// the deployment descriptor can be edited, so the path parameters are not necessarily strings
+	checkPathParam := func(value interface{}, kind, name string) {
+		path, ok := value.(string)
+		if !ok {
+			issues = append(issues, fmt.Sprintf(repackWrongPathMsg, value, kind, name))
+			return
+		}
+		checkPath(path, kind, name)
+	}
+	for _, module := range m.Modules {
+		if module.Path != "" && !params[module.Name].NoSource {
+			checkPath(module.Path, "module", module.Name)
+		}
+		for _, requiredDependency := range getRequiredDependencies(module) {
+			checkPathParam(requiredDependency.Parameters["path"], "required dependency", module.Name+"/"+requiredDependency.Name)
+		}
+	}
+	for _, resource := range m.Resources {
+		if resource.Name != "" && resource.Parameters["path"] != nil {
+			checkPathParam(resource.Parameters["path"], "resource", resource.Name)
+		}
+	}
+	relTarget, err := filepath.Rel(loc.path, loc.target)
+	if err == nil && (relTarget == "." || !strings.HasPrefix(relTarget, "..")) {
+		issues = append(issues, fmt.Sprintf(repackTargetInSourceMsg, loc.target))
+	}
+	if len(issues) > 0 {
+		return errors.New(strings.Join(issues, "\n"))
+	}
+	return nil
+}
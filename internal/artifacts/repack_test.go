@@ -0,0 +1,142 @@
+package artifacts
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/SAP/cloud-mta/mta"
+
+	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+)
+
+var _ = Describe("Unpack and repack", func() {
+
+	mtarPath := filepath.Join(getResultPath(), "verify.mtar")
+	unpackedPath := filepath.Join(getResultPath(), "unpacked")
+	targetPath := filepath.Join(getResultPath(), "target")
+	repackedPath := filepath.Join(targetPath, "verify_1.0.0.mtar")
+
+	BeforeEach(func() {
+		Ω(dir.Archive(getTestPath("mtar_verify"), mtarPath, nil)).Should(Succeed())
+	})
+
+	AfterEach(func() {
+		Ω(os.RemoveAll(getResultPath())).Should(Succeed())
+	})
+
+	readUnpackedMtad := func() *mta.MTA {
+		content, err := ioutil.ReadFile(filepath.Join(unpackedPath, "META-INF", "mtad.yaml"))
+		Ω(err).Should(Succeed())
+		m, err := mta.Unmarshal(content)
+		Ω(err).Should(Succeed())
+		return m
+	}
+
+	It("unpacks the MTA archive setting the module paths to the manifest entries", func() {
+		Ω(ExecuteUnpack(mtarPath, unpackedPath, os.Getwd)).Should(Succeed())
+		Ω(filepath.Join(unpackedPath, "ui", "data.zip")).Should(BeAnExistingFile())
+		Ω(filepath.Join(unpackedPath, "srv", "index.js")).Should(BeAnExistingFile())
+		Ω(filepath.Join(unpackedPath, "META-INF", "MANIFEST.MF")).Should(BeAnExistingFile())
+		m := readUnpackedMtad()
+		Ω(m.Modules[0].Path).Should(Equal("ui/data.zip"))
+		Ω(m.Modules[1].Path).Should(Equal("srv"))
+		Ω(m.Modules[2].Path).Should(BeEmpty())
+	})
+
+	It("unpacks to the folder named as the MTA archive in the current folder by default", func() {
+		Ω(ExecuteUnpack(mtarPath, "", func() (string, error) {
+			return getResultPath(), nil
+		})).Should(Succeed())
+		Ω(filepath.Join(getResultPath(), "verify", "META-INF", "mtad.yaml")).Should(BeAnExistingFile())
+	})
+
+	It("fails to unpack to the folder that is not empty", func() {
+		Ω(os.MkdirAll(unpackedPath, os.ModePerm)).Should(Succeed())
+		Ω(ioutil.WriteFile(filepath.Join(unpackedPath, "file.txt"), []byte("file"), os.ModePerm)).Should(Succeed())
+		checkError(ExecuteUnpack(mtarPath, unpackedPath, os.Getwd), unpackTargetNotEmptyMsg, unpackedPath)
+	})
+
+	It("fails to unpack the archive that does not exist", func() {
+		checkError(ExecuteUnpack(getTestPath("unknown.mtar"), unpackedPath, os.Getwd), mtarOpenFailedMsg, getTestPath("unknown.mtar"))
+	})
+
+	It("repacks the unpacked MTA archive with the patched deployment descriptor", func() {
+		Ω(ExecuteUnpack(mtarPath, unpackedPath, os.Getwd)).Should(Succeed())
+		m := readUnpackedMtad()
+		m.Modules[1].Parameters = map[string]interface{}{"memory": "512M"}
+		mtad, err := mta.Marshal(m)
+		Ω(err).Should(Succeed())
+		Ω(ioutil.WriteFile(filepath.Join(unpackedPath, "META-INF", "mtad.yaml"), mtad, os.ModePerm)).Should(Succeed())
+
+		Ω(ExecuteRepack(unpackedPath, targetPath, "", os.Getwd)).Should(Succeed())
+		result, err := verifyMtar(repackedPath, false, nil)
+		Ω(err).Should(Succeed())
+		Ω(result.Findings).Should(BeEmpty())
+		content, err := inspectMtar(repackedPath)
+		Ω(err).Should(Succeed())
+		Ω(content.Modules[0].entry).Should(Equal(entry{EntryName: "ui", EntryType: moduleEntry, ContentType: "application/zip", EntryPath: "ui/data.zip"}))
+		Ω(content.Modules[1].entry).Should(Equal(entry{EntryName: "srv", EntryType: moduleEntry, ContentType: dirContentType, EntryPath: "srv"}))
+		Ω(content.Resources[0].entry).Should(Equal(entry{EntryName: "config", EntryType: resourceEntry, ContentType: "application/json", EntryPath: "cfg/config.json"}))
+		Ω(content.Mtad.Modules[1].Parameters).Should(HaveKeyWithValue("memory", "512M"))
+		Ω(content.Unknown).Should(BeEmpty())
+	})
+
+	It("repacks the replaced module", func() {
+		Ω(ExecuteUnpack(mtarPath, unpackedPath, os.Getwd)).Should(Succeed())
+		Ω(ioutil.WriteFile(filepath.Join(unpackedPath, "ui", "data.zip"), []byte("new ui content"), os.ModePerm)).Should(Succeed())
+		Ω(ExecuteRepack(unpackedPath, targetPath, "patched", os.Getwd)).Should(Succeed())
+		content, err := inspectMtar(filepath.Join(targetPath, "patched.mtar"))
+		Ω(err).Should(Succeed())
+		Ω(content.Modules[0].Size).Should(Equal(int64(len("new ui content"))))
+	})
+
+	It("fails to repack when the paths of the deployment descriptor do not exist", func() {
+		Ω(ExecuteUnpack(mtarPath, unpackedPath, os.Getwd)).Should(Succeed())
+		Ω(os.RemoveAll(filepath.Join(unpackedPath, "srv"))).Should(Succeed())
+		Ω(os.RemoveAll(filepath.Join(unpackedPath, "cfg"))).Should(Succeed())
+		err := ExecuteRepack(unpackedPath, targetPath, "", os.Getwd)
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring(`the layout of the "` + unpackedPath + `" folder is not valid`))
+		Ω(err.Error()).Should(ContainSubstring(`the "srv" path of the module "srv" does not exist`))
+		Ω(err.Error()).Should(ContainSubstring(`the "cfg/config.json" path of the resource "config" does not exist`))
+		Ω(repackedPath).ShouldNot(BeAnExistingFile())
+	})
+
+	It("fails to repack when the paths of the deployment descriptor are not strings", func() {
+		Ω(ExecuteUnpack(mtarPath, unpackedPath, os.Getwd)).Should(Succeed())
+		m := readUnpackedMtad()
+		m.Modules[1].Requires = append(m.Modules[1].Requires, mta.Requires{Name: "config", Parameters: map[string]interface{}{"path": 5}})
+		m.Resources[0].Parameters["path"] = []interface{}{"cfg/config.json"}
+		mtad, err := mta.Marshal(m)
+		Ω(err).Should(Succeed())
+		Ω(ioutil.WriteFile(filepath.Join(unpackedPath, "META-INF", "mtad.yaml"), mtad, os.ModePerm)).Should(Succeed())
+		err = ExecuteRepack(unpackedPath, targetPath, "", os.Getwd)
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring(`the layout of the "` + unpackedPath + `" folder is not valid`))
+		Ω(err.Error()).Should(ContainSubstring(`the "5" path of the required dependency "srv/config" is not a string`))
+		Ω(err.Error()).Should(ContainSubstring(`the "[cfg/config.json]" path of the resource "config" is not a string`))
+		Ω(repackedPath).ShouldNot(BeAnExistingFile())
+	})
+
+	It("fails to repack when the target folder is inside the packed folder", func() {
+		Ω(ExecuteUnpack(mtarPath, unpackedPath, os.Getwd)).Should(Succeed())
+		target := filepath.Join(unpackedPath, "mta_archives")
+		err := ExecuteRepack(unpackedPath, "", "", func() (string, error) {
+			return unpackedPath, nil
+		})
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring(`the "` + target + `" target folder of the MTA archive is inside the packed folder`))
+	})
+
+	It("fails to repack the folder without the deployment descriptor", func() {
+		Ω(os.MkdirAll(unpackedPath, os.ModePerm)).Should(Succeed())
+		err := ExecuteRepack(unpackedPath, targetPath, "", os.Getwd)
+		Ω(err).Should(HaveOccurred())
+		Ω(strings.HasPrefix(err.Error(), `could not pack the MTA archive when reading the "`+filepath.Join(unpackedPath, "META-INF", "mtad.yaml")+`"`)).Should(BeTrue())
+	})
+})
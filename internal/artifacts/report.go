@@ -0,0 +1,312 @@
+package artifacts
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"os"
+	osexec "os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildops"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
+	"github.com/SAP/cloud-mta-build-tool/internal/commands"
+	"github.com/SAP/cloud-mta-build-tool/internal/logs"
+	"github.com/SAP/cloud-mta/mta"
+)
+
+const (
+	reportStatusSuccess = "success"
+	reportStatusFailure = "failure"
+
+	reportModulesFolder = "modules"
+	reportMtarFileName  = "mtar.json"
+	reportExtension     = ".json"
+)
+
+// buildReport - the machine-readable report of the MTA project build
+type buildReport struct {
+	Status     string          `json:"status"`
+	Error      string          `json:"error,omitempty"`
+	Platform   string          `json:"platform,omitempty"`
+	Extensions []string        `json:"extensions,omitempty"`
+	Mtar       string          `json:"mtar,omitempty"`
+	SBom       string          `json:"sbom,omitempty"`
+	Modules    []*moduleReport `json:"modules"`
+}
+
+// moduleReport - the report of a module build.
+// The module builds can run in separate processes (for example, when the build is executed by the generated Makefile),
+// so each module report is written to the report folder and the build report is assembled at the end of the build.
+type moduleReport struct {
+	Name        string   `json:"name"`
+	Builder     string   `json:"builder,omitempty"`
+	Commands    []string `json:"commands,omitempty"`
+	WorkingDir  string   `json:"working-dir,omitempty"`
+	ExitCode    int      `json:"exit-code"`
+	DurationMs  int64    `json:"duration-ms"`
+	Cached      bool     `json:"cached,omitempty"`
+	BuildResult string   `json:"build-result,omitempty"`
+	Artifact    string   `json:"artifact,omitempty"`
+	Size        int64    `json:"size,omitempty"`
+	SHA256      string   `json:"sha256,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+// newModuleReport - creates the module report if the report folder is provided, otherwise returns nil;
+// all the moduleReport methods can be called on the nil report
+func newModuleReport(reportDir, moduleName string) *moduleReport {
+	if reportDir == "" {
+		return nil
+	}
+	return &moduleReport{Name: moduleName}
+}
+
+// readModuleReport - reads the module report from the report folder if it was written by the module commands execution,
+// otherwise creates a new module report; returns nil if the report folder is not provided
+func readModuleReport(reportDir, moduleName string) *moduleReport {
+	report := newModuleReport(reportDir, moduleName)
+	if report == nil {
+		return nil
+	}
+	err := readReportFile(filepath.Join(reportDir, reportModulesFolder, moduleName+reportExtension), report)
+	if err != nil {
+		return newModuleReport(reportDir, moduleName)
+	}
+	return report
+}
+
+// setCommands - sets the resolved builder and the commands of the module
+func (r *moduleReport) setCommands(module *mta.Module, params *buildparams.Module, workingDir string, cmds []string) {
+	if r == nil {
+		return
+	}
+	r.Builder, _, _, _ = commands.GetBuilder(module, params)
+	r.WorkingDir = workingDir
+	r.Commands = cmds
+}
+
+// setExecResult - sets the duration and the exit code of the module commands execution
+func (r *moduleReport) setExecResult(start time.Time, err error) {
+	if r == nil {
+		return
+	}
+	r.DurationMs = time.Since(start).Nanoseconds() / int64(time.Millisecond)
+	r.ExitCode = 0
+	if err != nil {
+		r.ExitCode = -1
+		if exitErr, ok := errors.Cause(err).(*osexec.ExitError); ok {
+			r.ExitCode = exitErr.ExitCode()
+		}
+	}
+}
+
+// setArtifact - sets the build result and the packed artifact of the module with the artifact size and hash
+func (r *moduleReport) setArtifact(buildResult, artifact string) error {
+	if r == nil {
+		return nil
+	}
+	r.BuildResult = buildResult
+	r.Artifact = artifact
+	size, hash, err := getFileSizeAndHash(artifact)
+	if err != nil {
+		return err
+	}
+	r.Size = size
+	r.SHA256 = hash
+	return nil
+}
+
+// setError - sets the module build error
+func (r *moduleReport) setError(err error) {
+	if r == nil || err == nil {
+		return
+	}
+	r.Error = err.Error()
+}
+
+func getFileSizeAndHash(path string) (size int64, hash string, e error) {
+	file, e := os.Open(path)
+	if e != nil {
+		return 0, "", e
+	}
+	defer func() {
+		e = dir.CloseFile(file, e)
+	}()
+	hasher := sha256.New()
+	size, e = io.Copy(hasher, file)
+	if e != nil {
+		return 0, "", e
+	}
+	return size, hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// writeModuleReport - writes the module report to the report folder
+func writeModuleReport(reportDir string, report *moduleReport) error {
+	if report == nil {
+		return nil
+	}
+	return writeReportFile(filepath.Join(reportDir, reportModulesFolder, report.Name+reportExtension), report)
+}
+
+// writeMtarReport - writes the path of the generated MTA archive to the report folder
+func writeMtarReport(reportDir, mtarPath string) error {
+	if reportDir == "" {
+		return nil
+	}
+	return writeReportFile(filepath.Join(reportDir, reportMtarFileName), &buildReport{Mtar: mtarPath})
+}
+
+func writeReportFile(path string, report interface{}) error {
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, reportWriteFailedMsg, path)
+	}
+	err = dir.CreateDirIfNotExist(filepath.Dir(path))
+	if err != nil {
+		return errors.Wrapf(err, reportWriteFailedMsg, path)
+	}
+	err = ioutil.WriteFile(path, content, 0644)
+	if err != nil {
+		return errors.Wrapf(err, reportWriteFailedMsg, path)
+	}
+	return nil
+}
+
+// createReportDir - creates the temporary folder for the module reports if the report file is requested
+func createReportDir(reportFile string) (string, error) {
+	if reportFile == "" {
+		return "", nil
+	}
+	reportDir, err := ioutil.TempDir("", "mbt_report")
+	if err != nil {
+		return "", errors.Wrap(err, reportDirCreationFailedMsg)
+	}
+	return reportDir, nil
+}
+
+// getReportPath - gets the absolute path of the report file; a relative path is relative to the MTA project folder
+func getReportPath(source, reportFile string, wdGetter func() (string, error)) (string, error) {
+	if filepath.IsAbs(reportFile) {
+		return reportFile, nil
+	}
+	absSource, err := getSoloModuleBuildAbsSource(source, wdGetter)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(absSource, reportFile), nil
+}
+
+// writeBuildReport - writes the build report file, if it is requested, from the reports collected in the report folder
+func writeBuildReport(reportFile, reportDir, source, mtaYamlFilename string, extensions []string, platform, sbom string,
+	buildErr error, wdGetter func() (string, error)) error {
+
+	if reportFile == "" {
+		return nil
+	}
+	defer os.RemoveAll(reportDir)
+
+	reportPath, err := getReportPath(source, reportFile, wdGetter)
+	if err != nil {
+		return errors.Wrap(err, reportFailedMsg)
+	}
+	loc, err := dir.Location(source, mtaYamlFilename, "", dir.Dev, extensions, wdGetter)
+	if err != nil {
+		return errors.Wrap(err, reportFailedMsg)
+	}
+	err = generateBuildReport(reportPath, reportDir, loc, platform, extensions, sbom, buildErr)
+	if err != nil {
+		return errors.Wrap(err, reportFailedMsg)
+	}
+	logs.Logger.Infof(reportGeneratedMsg, reportPath)
+	return nil
+}
+
+// generateBuildReport - assembles the build report from the reports in the report folder and writes it to the report file
+func generateBuildReport(reportFile, reportDir string, mtaParser dir.IMtaParser, platform string, extensions []string,
+	sbom string, buildErr error) error {
+
+	report := &buildReport{Status: reportStatusSuccess, Platform: platform, Extensions: extensions, SBom: sbom, Modules: []*moduleReport{}}
+	if buildErr != nil {
+		report.Status = reportStatusFailure
+		report.Error = buildErr.Error()
+		// the SBOM file is generated only when the build succeeds
+		report.SBom = ""
+	}
+
+	mtarReport := &buildReport{}
+	err := readReportFile(filepath.Join(reportDir, reportMtarFileName), mtarReport)
+	if err == nil {
+		report.Mtar = mtarReport.Mtar
+	}
+
+	files, err := ioutil.ReadDir(filepath.Join(reportDir, reportModulesFolder))
+	if err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, reportReadFailedMsg, reportDir)
+	}
+	for _, file := range files {
+		moduleReport := &moduleReport{}
+		err = readReportFile(filepath.Join(reportDir, reportModulesFolder, file.Name()), moduleReport)
+		if err != nil {
+			return err
+		}
+		report.Modules = append(report.Modules, moduleReport)
+	}
+	sortModuleReports(report.Modules, mtaParser)
+
+	return writeReportFile(reportFile, report)
+}
+
+func readReportFile(path string, report interface{}) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Wrapf(err, reportReadFailedMsg, path)
+	}
+	err = json.Unmarshal(content, report)
+	if err != nil {
+		return errors.Wrapf(err, reportReadFailedMsg, path)
+	}
+	return nil
+}
+
+// sortModuleReports - sorts the module reports by the modules build order
+func sortModuleReports(reports []*moduleReport, mtaParser dir.IMtaParser) {
+	order := make(map[string]int)
+	mtaObj, err := mtaParser.ParseFile()
+	var params buildparams.Modules
+	if err == nil {
+		params, err = buildparams.DecodeAll(mtaObj)
+	}
+	if err == nil {
+		modules, err := buildops.GetModulesNames(mtaObj, params)
+		if err == nil {
+			for i, module := range modules {
+				order[module] = i
+			}
+		}
+	}
+	sort.SliceStable(reports, func(i, j int) bool {
+		return order[reports[i].Name] < order[reports[j].Name]
+	})
+}
+
+// getSBomReportPath - gets the absolute path of the SBOM file generated by the build, if it is requested
+func getSBomReportPath(source, sBomFilePath string, wdGetter func() (string, error)) string {
+	if strings.TrimSpace(sBomFilePath) == "" {
+		return ""
+	}
+	absSource, err := getSoloModuleBuildAbsSource(source, wdGetter)
+	if err != nil {
+		return ""
+	}
+	sbomPath, sbomName, _, _ := parseSBomFilePath(absSource, sBomFilePath)
+	return filepath.Join(sbomPath, sbomName)
+}
@@ -0,0 +1,169 @@
+package artifacts
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+)
+
+var _ = Describe("Report", func() {
+
+	readReport := func(path string) *buildReport {
+		content, err := ioutil.ReadFile(path)
+		Ω(err).Should(Succeed())
+		report := &buildReport{}
+		Ω(json.Unmarshal(content, report)).Should(Succeed())
+		return report
+	}
+
+	BeforeEach(func() {
+		Ω(os.Mkdir(getResultPath(), os.ModePerm)).Should(Succeed())
+	})
+	AfterEach(func() {
+		Ω(os.RemoveAll(getResultPath())).Should(Succeed())
+		Ω(os.RemoveAll(getTestPath("mta_native_build", "m1", "from_m2"))).Should(Succeed())
+		Ω(os.RemoveAll(getTestPath("mta_native_build", "m2", "m2.txt"))).Should(Succeed())
+	})
+
+	Describe("ExecBuild", func() {
+		It("writes the report of the successful build", func() {
+			reportPath := getTestPath("result", "report.json")
+			Ω(ExecBuild("", getTestPath("mta_native_build"), "", getResultPath(), nil, "", "", "cf", true, 0, false, os.Getwd,
+				nil, true, false, "", NativeEngine, reportPath)).Should(Succeed())
+			report := readReport(reportPath)
+			Ω(report.Status).Should(Equal(reportStatusSuccess))
+			Ω(report.Platform).Should(Equal("cf"))
+			Ω(report.Mtar).Should(Equal(getTestPath("result", "mta_native_build_0.0.1.mtar")))
+			Ω(report.Modules).Should(HaveLen(2))
+			Ω(report.Modules[0].Name).Should(Equal("m2"))
+			Ω(report.Modules[1].Name).Should(Equal("m1"))
+
+			m2 := report.Modules[0]
+			Ω(m2.Builder).Should(Equal("custom"))
+			Ω(m2.Commands).Should(Equal([]string{"sh -c 'echo m2 > m2.txt'"}))
+			Ω(m2.WorkingDir).Should(Equal(getTestPath("mta_native_build", "m2")))
+			Ω(m2.ExitCode).Should(Equal(0))
+			Ω(m2.BuildResult).Should(Equal(getTestPath("mta_native_build", "m2")))
+			Ω(m2.Artifact).Should(Equal(getFullPathInTmpFolder("mta_native_build", "m2", "data.zip")))
+			Ω(m2.Size).Should(BeNumerically(">", 0))
+			Ω(m2.SHA256).Should(HaveLen(64))
+			Ω(m2.Error).Should(BeEmpty())
+		})
+
+		It("writes the report relative to the project folder", func() {
+			reportPath := filepath.Join("..", "result", "report.json")
+			Ω(ExecBuild("", getTestPath("mta_native_build"), "", getResultPath(), nil, "", "", "cf", true, 0, false, os.Getwd,
+				nil, true, false, "", NativeEngine, reportPath)).Should(Succeed())
+			Ω(getTestPath("result", "report.json")).Should(BeAnExistingFile())
+		})
+
+		It("writes the report of the failed build", func() {
+			reportPath := getTestPath("result", "report.json")
+			err := ExecBuild("", getTestPath("mta_native_build"), "mtaFailing.yaml", getResultPath(), nil, "", "", "cf", true, 0, false, os.Getwd,
+				nil, true, false, "", NativeEngine, reportPath)
+			checkError(err, buildFailedMsg, "m1")
+			report := readReport(reportPath)
+			Ω(report.Status).Should(Equal(reportStatusFailure))
+			Ω(report.Error).ShouldNot(BeEmpty())
+			Ω(report.Mtar).Should(BeEmpty())
+			Ω(report.Modules).Should(HaveLen(1))
+			Ω(report.Modules[0].ExitCode).Should(Equal(1))
+			Ω(report.Modules[0].Artifact).Should(BeEmpty())
+			Ω(report.Modules[0].Error).ShouldNot(BeEmpty())
+		})
+
+		It("does not write the report when it is not requested", func() {
+			Ω(ExecBuild("", getTestPath("mta_native_build"), "", getResultPath(), nil, "", "", "cf", true, 0, false, os.Getwd,
+				nil, true, false, "", NativeEngine, "")).Should(Succeed())
+			Ω(getTestPath("mta_native_build", "report.json")).ShouldNot(BeAnExistingFile())
+		})
+	})
+
+	Describe("ExecuteSoloBuild", func() {
+		It("writes the report of the built modules", func() {
+			reportPath := getTestPath("result", "report.json")
+			Ω(ExecuteSoloBuild(getTestPath("mta_native_build"), "", getTestPath("result", "m2"), nil, []string{"m2"}, false, false, "cf",
+				reportPath, os.Getwd)).Should(Succeed())
+			report := readReport(reportPath)
+			Ω(report.Status).Should(Equal(reportStatusSuccess))
+			Ω(report.Mtar).Should(BeEmpty())
+			Ω(report.Modules).Should(HaveLen(1))
+			Ω(report.Modules[0].Name).Should(Equal("m2"))
+			Ω(report.Modules[0].Artifact).Should(Equal(getTestPath("result", "m2", "data.zip")))
+		})
+	})
+
+	Describe("ExecutePack", func() {
+		It("writes the module report to the report folder", func() {
+			reportDir := getTestPath("result", "report")
+			Ω(ExecutePack(getTestPath("mta"), "", getResultPath(), nil, "node-js", "cf", reportDir, os.Getwd)).Should(Succeed())
+			report := &moduleReport{}
+			Ω(readReportFile(filepath.Join(reportDir, reportModulesFolder, "node-js.json"), report)).Should(Succeed())
+			Ω(report.Name).Should(Equal("node-js"))
+			Ω(report.Commands).ShouldNot(BeEmpty())
+			Ω(report.Artifact).Should(Equal(getFullPathInTmpFolder("mta", "node-js", "data.zip")))
+		})
+	})
+
+	Describe("ExecuteModuleCommands", func() {
+		It("writes the failure of the module commands in verbose mode to the build report", func() {
+			reportDir := getTestPath("result", "report")
+			err := ExecuteModuleCommands([]string{"sh -c 'exit 3'"}, nil, "", getTestPath("mta_native_build", "m1"), "m1", reportDir)
+			Ω(err).Should(HaveOccurred())
+			reportPath := getTestPath("result", "report.json")
+			Ω(generateBuildReport(reportPath, reportDir, &dir.Loc{SourcePath: getTestPath("mta_native_build")}, "cf", nil, "", err)).Should(Succeed())
+			report := readReport(reportPath)
+			Ω(report.Status).Should(Equal(reportStatusFailure))
+			Ω(report.Modules).Should(HaveLen(1))
+			Ω(report.Modules[0].Name).Should(Equal("m1"))
+			Ω(report.Modules[0].ExitCode).Should(Equal(3))
+			Ω(report.Modules[0].Commands).Should(Equal([]string{"sh -c 'exit 3'"}))
+			Ω(report.Modules[0].WorkingDir).Should(Equal(getTestPath("mta_native_build", "m1")))
+			Ω(report.Modules[0].Artifact).Should(BeEmpty())
+			Ω(report.Modules[0].Error).ShouldNot(BeEmpty())
+		})
+		It("keeps the result of the module commands when the module is packed", func() {
+			reportDir := getTestPath("result", "report")
+			Ω(ExecuteModuleCommands([]string{"sh -c 'sleep 0.1'"}, nil, "", getTestPath("mta", "node-js"), "node-js", reportDir)).Should(Succeed())
+			Ω(ExecutePack(getTestPath("mta"), "", getResultPath(), nil, "node-js", "cf", reportDir, os.Getwd)).Should(Succeed())
+			report := &moduleReport{}
+			Ω(readReportFile(filepath.Join(reportDir, reportModulesFolder, "node-js.json"), report)).Should(Succeed())
+			Ω(report.ExitCode).Should(Equal(0))
+			Ω(report.DurationMs).Should(BeNumerically(">=", 100))
+			Ω(report.Artifact).Should(Equal(getFullPathInTmpFolder("mta", "node-js", "data.zip")))
+		})
+		It("does not write the report when the module is not provided", func() {
+			reportDir := getTestPath("result", "report")
+			Ω(ExecuteModuleCommands([]string{"sh -c 'exit 0'"}, nil, "", getResultPath(), "", reportDir)).Should(Succeed())
+			Ω(reportDir).ShouldNot(BeADirectory())
+		})
+	})
+
+	Describe("createMakeCommand", func() {
+		It("passes the report folder to the Makefile", func() {
+			command := createMakeCommand("Makefile_tmp", "./src", "", "", "result.mtar", "cf", true, 0, false, "/tmp/report", func() int {
+				return 1
+			})
+			Ω(command).Should(ContainElement(`report_dir="/tmp/report"`))
+		})
+	})
+
+	Describe("moduleReport", func() {
+		It("ignores the calls on the nil report", func() {
+			var report *moduleReport
+			report.setError(os.ErrNotExist)
+			Ω(report.setArtifact("", "unknown")).Should(Succeed())
+			Ω(writeModuleReport(getResultPath(), report)).Should(Succeed())
+		})
+		It("fails on a missing artifact", func() {
+			report := newModuleReport(getResultPath(), "m1")
+			Ω(report.setArtifact("", getTestPath("result", "unknown.zip"))).Should(HaveOccurred())
+		})
+	})
+})
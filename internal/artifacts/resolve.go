@@ -0,0 +1,389 @@
+package artifacts
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/SAP/cloud-mta/mta"
+
+	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
+)
+
+// referenceRegex - matches the "${<parameter>}" placeholders and the "~{<property>}" references
+var referenceRegex = regexp.MustCompile(`([$~])\{([^{}]+)\}`)
+
+// ExecuteResolve - prints the deployment view of the MTA project for the platform: the "mta.yaml" file merged with the MTA extension descriptors,
+// in which the placeholders of the parameters and the references to the provided properties are resolved by the descriptors and the provided values
+func ExecuteResolve(source, mtaYamlFilename string, extensions []string, platformName string, params []string, envFile, output string,
+	out io.Writer, wdGetter func() (string, error)) error {
+
+	if output != "" && output != OutputYAML && output != OutputJSON {
+		return errors.Errorf(resolveWrongOutputMsg, output)
+	}
+	platformName, err := validatePlatform(platformName)
+	if err != nil {
+		return err
+	}
+	values, err := getResolveValues(params, envFile)
+	if err != nil {
+		return err
+	}
+	loc, err := dir.Location(source, mtaYamlFilename, "", dir.Dev, extensions, wdGetter)
+	if err != nil {
+		return errors.Wrap(err, resolveFailedOnLocMsg)
+	}
+	m, err := loc.ParseFile()
+	if err != nil {
+		return errors.Wrap(err, resolveFailedOnParseMsg)
+	}
+	modulesParams, err := buildparams.DecodeAll(m)
+	if err != nil {
+		return errors.Wrap(err, resolveFailedOnParseMsg)
+	}
+	err = toDeploymentView(m, modulesParams, platformName)
+	if err != nil {
+		return errors.Wrapf(err, resolveFailedOnPlatformMsg, platformName)
+	}
+	err = newMtaResolver(m, values).resolve()
+	if err != nil {
+		return err
+	}
+	if output == "" {
+		output = OutputYAML
+	}
+	return printOutput(m, output, out, nil)
+}
+
+// toDeploymentView - changes the MTA the way the "mtad.yaml" file is generated for the platform,
+// without the paths of the module build results
+func toDeploymentView(m *mta.MTA, params buildparams.Modules, platformName string) error {
+	err := removeUndeployedModules(m, params, platformName)
+	if err != nil {
+		return err
+	}
+	err = setPlatformSpecificParameters(m, platformName)
+	if err != nil {
+		return err
+	}
+	err = ConvertTypes(*m, platformName)
+	if err != nil {
+		return err
+	}
+	m.BuildParams = nil
+	for _, module := range m.Modules {
+		module.BuildParams = nil
+	}
+	return nil
+}
+
+// resolveValues - the values provided for the placeholders;
+// the values with the "<module or resource>/<name>" names are used only for the placeholders of the module or resource
+type resolveValues struct {
+	global map[string]string
+	scoped map[string]map[string]string
+}
+
+func (v *resolveValues) add(name, value string) {
+	i := strings.Index(name, "/")
+	if i <= 0 {
+		v.global[name] = value
+		return
+	}
+	owner := name[:i]
+	if v.scoped[owner] == nil {
+		v.scoped[owner] = make(map[string]string)
+	}
+	v.scoped[owner][name[i+1:]] = value
+}
+
+// getResolveValues - gets the values of the environment file overridden by the values of the "<name>=<value>" parameters
+func getResolveValues(params []string, envFile string) (*resolveValues, error) {
+	values := &resolveValues{global: make(map[string]string), scoped: make(map[string]map[string]string)}
+	if envFile != "" {
+		err := readEnvFile(envFile, values)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, param := range params {
+		name, value, ok := splitResolveValue(param)
+		if !ok {
+			return nil, errors.Errorf(resolveInvalidParamMsg, param)
+		}
+		values.add(name, value)
+	}
+	return values, nil
+}
+
+// readEnvFile - reads the "<name>=<value>" lines of the environment file;
+// the empty lines and the lines starting with "#" are skipped, and the "export" prefix and the quotes of the values are removed
+func readEnvFile(path string, values *resolveValues) (e error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, resolveEnvFileReadMsg, path)
+	}
+	defer func() {
+		e = dir.CloseFile(file, e)
+	}()
+	scanner := bufio.NewScanner(file)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, ok := splitResolveValue(strings.TrimPrefix(line, "export "))
+		if !ok {
+			return errors.Errorf(resolveEnvFileLineMsg, lineNum, path)
+		}
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values.add(name, value)
+	}
+	return errors.Wrapf(scanner.Err(), resolveEnvFileReadMsg, path)
+}
+
+func splitResolveValue(s string) (name string, value string, ok bool) {
+	i := strings.Index(s, "=")
+	if i < 0 {
+		return "", "", false
+	}
+	name = strings.TrimSpace(s[:i])
+	return name, strings.TrimSpace(s[i+1:]), name != ""
+}
+
+// scopeParameters - the parameters of the MTA, module, resource or requirement that are available to the placeholders
+type scopeParameters struct {
+	values map[string]interface{}
+	desc   string
+}
+
+// resolveScope - the scope in which the placeholders and references of a value are resolved
+type resolveScope struct {
+	// owner - the name of the module or resource; empty for the global parameters
+	owner string
+	// params - the parameters in the order of their lookup, the parameters of the MTA are looked up after them
+	params []scopeParameters
+	// provider - the provider of the properties of the "~{<property>}" references
+	provider string
+}
+
+// mtaResolver - resolves the placeholders and references of the MTA in place and collects the unresolved ones;
+// the resolved values are set after all the values are resolved, so that the references always get the values of the descriptors
+type mtaResolver struct {
+	mta       *mta.MTA
+	values    *resolveValues
+	resolving map[string]bool
+	errors    map[string]bool
+	resolved  []resolvedValues
+}
+
+// resolvedValues - the resolved values of the parameters or properties to be set
+type resolvedValues struct {
+	target map[string]interface{}
+	values map[string]interface{}
+}
+
+func newMtaResolver(m *mta.MTA, values *resolveValues) *mtaResolver {
+	return &mtaResolver{mta: m, values: values, resolving: make(map[string]bool), errors: make(map[string]bool)}
+}
+
+func (r *mtaResolver) resolve() error {
+	r.resolveMap(r.mta.Parameters, resolveScope{}, "parameter", "the MTA")
+	for _, module := range r.mta.Modules {
+		desc := fmt.Sprintf(`the "%s" module`, module.Name)
+		scope := resolveScope{owner: module.Name, params: []scopeParameters{{module.Parameters, desc}}}
+		r.resolveMap(module.Parameters, scope, "parameter", desc)
+		r.resolveMap(module.Properties, scope, "property", desc)
+		for _, provides := range module.Provides {
+			r.resolveMap(provides.Properties, scope, "property", fmt.Sprintf(`the "%s" provided section of %s`, provides.Name, desc))
+		}
+		r.resolveRequires(module.Requires, scope, desc)
+	}
+	for _, resource := range r.mta.Resources {
+		desc := fmt.Sprintf(`the "%s" resource`, resource.Name)
+		scope := resolveScope{owner: resource.Name, params: []scopeParameters{{resource.Parameters, desc}}}
+		r.resolveMap(resource.Parameters, scope, "parameter", desc)
+		r.resolveMap(resource.Properties, scope, "property", desc)
+		r.resolveRequires(resource.Requires, scope, desc)
+	}
+	for _, resolved := range r.resolved {
+		for name, value := range resolved.values {
+			resolved.target[name] = value
+		}
+	}
+	if len(r.errors) == 0 {
+		return nil
+	}
+	messages := make([]string, 0, len(r.errors))
+	for message := range r.errors {
+		messages = append(messages, message)
+	}
+	sort.Strings(messages)
+	return errors.Errorf(unresolvedReferencesMsg, strings.Join(messages, "\n"))
+}
+
+func (r *mtaResolver) resolveRequires(requires []mta.Requires, ownerScope resolveScope, ownerDesc string) {
+	for i := range requires {
+		req := &requires[i]
+		desc := fmt.Sprintf(`the "%s" requirement of %s`, req.Name, ownerDesc)
+		scope := resolveScope{owner: ownerScope.owner, provider: req.Name,
+			params: append([]scopeParameters{{req.Parameters, desc}}, ownerScope.params...)}
+		r.resolveMap(req.Parameters, scope, "parameter", desc)
+		r.resolveMap(req.Properties, scope, "property", desc)
+	}
+}
+
+func (r *mtaResolver) resolveMap(values map[string]interface{}, scope resolveScope, kind, desc string) {
+	resolved := resolvedValues{target: values, values: make(map[string]interface{}, len(values))}
+	for name, value := range values {
+		resolved.values[name] = r.resolveValue(value, scope, fmt.Sprintf(`the "%s" %s of %s`, name, kind, desc))
+	}
+	r.resolved = append(r.resolved, resolved)
+}
+
+// resolveValue - gets the value in which the placeholders and references are resolved; the nested maps get string keys
+func (r *mtaResolver) resolveValue(value interface{}, scope resolveScope, location string) interface{} {
+	switch v := value.(type) {
+	case string:
+		return r.resolveString(v, scope, location)
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			result[key] = r.resolveValue(elem, scope, location)
+		}
+		return result
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			result[fmt.Sprint(key)] = r.resolveValue(elem, scope, location)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, elem := range v {
+			result[i] = r.resolveValue(elem, scope, location)
+		}
+		return result
+	}
+	return value
+}
+
+// resolveString - the value that consists of a single placeholder or reference gets the type of the resolved value;
+// otherwise, the string representations of the resolved values are inserted into the string
+func (r *mtaResolver) resolveString(value string, scope resolveScope, location string) interface{} {
+	matches := referenceRegex.FindAllStringSubmatch(value, -1)
+	if len(matches) == 1 && matches[0][0] == value {
+		return r.resolveReference(matches[0][1], matches[0][2], scope, location)
+	}
+	return referenceRegex.ReplaceAllStringFunc(value, func(ref string) string {
+		match := referenceRegex.FindStringSubmatch(ref)
+		return toResolvedString(r.resolveReference(match[1], match[2], scope, location))
+	})
+}
+
+func (r *mtaResolver) resolveReference(kind, name string, scope resolveScope, location string) interface{} {
+	if kind == "$" {
+		return r.resolveParameter(name, scope, location)
+	}
+	return r.resolveProperty(name, scope, location)
+}
+
+// resolveParameter - looks up the parameter in the scope, the values provided for the module or resource,
+// the parameters of the MTA and the global provided values
+func (r *mtaResolver) resolveParameter(name string, scope resolveScope, location string) interface{} {
+	ref := "${" + name + "}"
+	for _, params := range scope.params {
+		if value, ok := params.values[name]; ok {
+			return r.resolveNested("$"+params.desc+"/"+name, ref, value, scope, fmt.Sprintf(`the "%s" parameter of %s`, name, params.desc), location)
+		}
+	}
+	if value, ok := r.values.scoped[scope.owner][name]; ok && scope.owner != "" {
+		return value
+	}
+	if value, ok := r.mta.Parameters[name]; ok {
+		return r.resolveNested("$/"+name, ref, value, resolveScope{}, fmt.Sprintf(`the "%s" parameter of the MTA`, name), location)
+	}
+	if value, ok := r.values.global[name]; ok {
+		return value
+	}
+	r.errors[fmt.Sprintf(unresolvedParameterMsg, name, location)] = true
+	return ref
+}
+
+// resolveProperty - looks up the "~{<provider>/<property>}" reference or the "~{<property>}" reference of the required provider
+// in the properties of the provided section of a module or in the properties of a resource
+func (r *mtaResolver) resolveProperty(name string, scope resolveScope, location string) interface{} {
+	ref := "~{" + name + "}"
+	providerName, propName := scope.provider, name
+	if i := strings.Index(name, "/"); i > 0 {
+		providerName, propName = name[:i], name[i+1:]
+	} else if providerName == "" {
+		r.errors[fmt.Sprintf(unresolvedNoProviderMsg, name, location)] = true
+		return ref
+	}
+	props, providerScope, providerDesc, ok := r.findProvider(providerName)
+	if ok {
+		if value, ok := props[propName]; ok {
+			return r.resolveNested("~"+providerDesc+"/"+propName, ref, value, providerScope,
+				fmt.Sprintf(`the "%s" property of %s`, propName, providerDesc), location)
+		}
+	}
+	r.errors[fmt.Sprintf(unresolvedPropertyMsg, name, location)] = true
+	return ref
+}
+
+// resolveNested - resolves the value of the parameter or property in the scope of its definition;
+// the errors of the value are reported for its definition
+func (r *mtaResolver) resolveNested(key, ref string, value interface{}, scope resolveScope, definition, location string) interface{} {
+	if r.resolving[key] {
+		r.errors[fmt.Sprintf(circularReferenceMsg, ref, location)] = true
+		return ref
+	}
+	r.resolving[key] = true
+	defer delete(r.resolving, key)
+	return r.resolveValue(value, scope, definition)
+}
+
+func (r *mtaResolver) findProvider(name string) (map[string]interface{}, resolveScope, string, bool) {
+	for _, module := range r.mta.Modules {
+		for _, provides := range module.Provides {
+			if provides.Name == name {
+				moduleDesc := fmt.Sprintf(`the "%s" module`, module.Name)
+				scope := resolveScope{owner: module.Name, params: []scopeParameters{{module.Parameters, moduleDesc}}}
+				return provides.Properties, scope, fmt.Sprintf(`the "%s" provided section of %s`, name, moduleDesc), true
+			}
+		}
+	}
+	for _, resource := range r.mta.Resources {
+		if resource.Name == name {
+			desc := fmt.Sprintf(`the "%s" resource`, name)
+			return resource.Properties, resolveScope{owner: name, params: []scopeParameters{{resource.Parameters, desc}}}, desc, true
+		}
+	}
+	return nil, resolveScope{}, "", false
+}
+
+func toResolvedString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	case map[string]interface{}, []interface{}:
+		data, err := json.Marshal(v)
+		if err == nil {
+			return string(data)
+		}
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,195 @@
+package artifacts
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/SAP/cloud-mta/mta"
+)
+
+var _ = Describe("Resolve", func() {
+
+	resolve := func(extensions []string, platformName string, params []string, envFile string) (*mta.MTA, error) {
+		var out bytes.Buffer
+		err := ExecuteResolve(getTestPath("mta_resolve"), "", extensions, platformName, params, envFile, "", &out, os.Getwd)
+		if err != nil {
+			return nil, err
+		}
+		return mta.Unmarshal(out.Bytes())
+	}
+
+	AfterEach(func() {
+		Ω(os.RemoveAll(getTestPath("result"))).Should(Succeed())
+	})
+
+	It("resolves the placeholders and references by the descriptors and the provided values", func() {
+		m, err := resolve([]string{"dev.mtaext"}, "cf", []string{"space=test", "timeout=10"}, getTestPath("mta_resolve", "values.env"))
+		Ω(err).Should(Succeed())
+		Ω(m.Parameters).Should(Equal(map[string]interface{}{"app-domain": "cfapps.example.com"}))
+
+		srv := m.Modules[0]
+		Ω(srv.Type).Should(Equal("javascript.nodejs"))
+		Ω(srv.BuildParams).Should(BeNil())
+		// the value of the extension descriptor is used
+		Ω(srv.Parameters).Should(Equal(map[string]interface{}{"host": "my-org-test-srv", "memory": "512M"}))
+		Ω(srv.Provides[0].Properties).Should(Equal(map[string]interface{}{
+			"url":  "https://my-org-test-srv.cfapps.example.com",
+			"port": "8080",
+		}))
+
+		ui := m.Modules[1]
+		Ω(ui.Properties).Should(Equal(map[string]interface{}{"SRV_PORT": "8080", "TITLE": "My App"}))
+		// the parameter of the requirement is looked up before the provided values and keeps its type
+		Ω(ui.Requires[0].Properties).Should(Equal(map[string]interface{}{
+			"name":    "srv",
+			"url":     "https://my-org-test-srv.cfapps.example.com/api",
+			"timeout": 30,
+		}))
+
+		Ω(m.Resources[0].Parameters["service-name"]).Should(Equal("test-db"))
+		Ω(m.Resources[0].Properties).Should(Equal(map[string]interface{}{"schema": "test-db"}))
+	})
+
+	It("keeps the modules that are supported by the platform", func() {
+		m, err := resolve(nil, "neo", nil, getTestPath("mta_resolve", "values.env"))
+		Ω(err).Should(Succeed())
+		Ω(len(m.Modules)).Should(Equal(3))
+		Ω(m.Modules[2].Name).Should(Equal("java"))
+		Ω(m.Modules[2].Type).Should(Equal("java.tomcat"))
+	})
+
+	It("prints the resolved MTA in the JSON format", func() {
+		var out bytes.Buffer
+		Ω(ExecuteResolve(getTestPath("mta_resolve"), "", nil, "cf", nil, getTestPath("mta_resolve", "values.env"),
+			OutputJSON, &out, os.Getwd)).Should(Succeed())
+		m := mta.MTA{}
+		Ω(json.Unmarshal(out.Bytes(), &m)).Should(Succeed())
+		Ω(m.Modules[1].Properties["TITLE"]).Should(Equal("My App"))
+	})
+
+	It("reports all the unresolved placeholders and references", func() {
+		_, err := resolve(nil, "cf", nil, "")
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring(`the "${default-domain}" placeholder of the "app-domain" parameter of the MTA`))
+		Ω(err.Error()).Should(ContainSubstring(`the "${org}" placeholder of the "host" parameter of the "srv" module`))
+		Ω(err.Error()).Should(ContainSubstring(`the "${port}" placeholder of the "port" property of the "srv_api" provided section of the "srv" module`))
+		Ω(err.Error()).Should(ContainSubstring(`the "${title}" placeholder of the "TITLE" property of the "ui" module`))
+		// the placeholders of the referenced values are reported only for their definitions
+		Ω(err.Error()).ShouldNot(ContainSubstring(`of the "url" property`))
+		Ω(err.Error()).ShouldNot(ContainSubstring(`${timeout}`))
+	})
+
+	It("fails on the provided value in the invalid format", func() {
+		_, err := resolve(nil, "cf", []string{"space"}, "")
+		checkError(err, resolveInvalidParamMsg, "space")
+	})
+
+	It("fails on the invalid line of the environment file", func() {
+		Ω(os.MkdirAll(getTestPath("result"), os.ModePerm)).Should(Succeed())
+		envFile := getTestPath("result", "invalid.env")
+		Ω(ioutil.WriteFile(envFile, []byte("org=my-org\nspace\n"), 0644)).Should(Succeed())
+		_, err := resolve(nil, "cf", nil, envFile)
+		checkError(err, resolveEnvFileLineMsg, 2, envFile)
+	})
+
+	It("fails when the environment file does not exist", func() {
+		_, err := resolve(nil, "cf", nil, getTestPath("mta_resolve", "unknown.env"))
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring(getTestPath("mta_resolve", "unknown.env")))
+	})
+
+	It("fails on the invalid output format", func() {
+		err := ExecuteResolve(getTestPath("mta_resolve"), "", nil, "cf", nil, "", OutputTable, &bytes.Buffer{}, os.Getwd)
+		checkError(err, resolveWrongOutputMsg, OutputTable)
+	})
+
+	It("fails on the invalid platform", func() {
+		_, err := resolve(nil, "ab", nil, "")
+		Ω(err).Should(HaveOccurred())
+	})
+
+	It("fails when the MTA project does not exist", func() {
+		err := ExecuteResolve(getTestPath("unknown"), "", nil, "cf", nil, "", "", &bytes.Buffer{}, os.Getwd)
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring(resolveFailedOnParseMsg))
+	})
+
+	DescribeTable("the references of the MTA", func(content string, expected map[string]interface{}, expectedErr string) {
+		m, err := mta.Unmarshal([]byte(content))
+		Ω(err).Should(Succeed())
+		values := &resolveValues{global: map[string]string{"space": "dev"}, scoped: map[string]map[string]string{}}
+		err = newMtaResolver(m, values).resolve()
+		if expectedErr != "" {
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring(expectedErr))
+			return
+		}
+		Ω(err).Should(Succeed())
+		Ω(m.Modules[0].Properties).Should(Equal(expected))
+	},
+		Entry("nested values", `
+ID: a
+modules:
+- name: m
+  type: nodejs
+  properties:
+    config:
+      space: ${space}
+      list: [a, "${space}"]
+`, map[string]interface{}{"config": map[string]interface{}{"space": "dev", "list": []interface{}{"a", "dev"}}}, ""),
+		Entry("structured value inserted into a string", `
+ID: a
+modules:
+- name: m
+  type: nodejs
+  parameters:
+    limits:
+      memory: 1G
+  properties:
+    a: limits ${limits}
+`, map[string]interface{}{"a": `limits {"memory":"1G"}`}, ""),
+		Entry("property of a resource", `
+ID: a
+modules:
+- name: m
+  type: nodejs
+  properties:
+    a: ~{db/schema}
+resources:
+- name: db
+  properties:
+    schema: ${space}-schema
+`, map[string]interface{}{"a": "dev-schema"}, ""),
+		Entry("circular parameters", `
+ID: a
+modules:
+- name: m
+  type: nodejs
+  parameters:
+    a: ${b}
+    b: ${a}
+`, nil, `reference of the "b" parameter of the "m" module is circular`),
+		Entry("reference without provider", `
+ID: a
+modules:
+- name: m
+  type: nodejs
+  properties:
+    a: ~{url}
+`, nil, `the "~{url}" reference of the "a" property of the "m" module has no provider`),
+		Entry("unknown provider", `
+ID: a
+modules:
+- name: m
+  type: nodejs
+  properties:
+    a: ~{api/url}
+`, nil, `the "~{api/url}" reference of the "a" property of the "m" module`),
+	)
+})
@@ -11,6 +11,7 @@ import (
 
 	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
 	"github.com/SAP/cloud-mta-build-tool/internal/buildops"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
 	"github.com/SAP/cloud-mta-build-tool/internal/commands"
 	"github.com/SAP/cloud-mta-build-tool/internal/exec"
 	"github.com/SAP/cloud-mta-build-tool/internal/logs"
@@ -431,7 +432,11 @@ func executeSBomCommand(sbomCmds [][]string) error {
 // if module's builder is custom, skip it
 func generateSBomFiles(loc *dir.Loc, mtaObj *mta.MTA, sBomFileTmpDir string, sbomType string, sbomSuffix string) error {
 	// (1) sort module by dependency orders
-	sortedModuleNames, err := buildops.GetModulesNames(mtaObj)
+	params, err := buildparams.DecodeAll(mtaObj)
+	if err != nil {
+		return err
+	}
+	sortedModuleNames, err := buildops.GetModulesNames(mtaObj, params)
 	if err != nil {
 		return err
 	}
@@ -451,7 +456,7 @@ func generateSBomFiles(loc *dir.Loc, mtaObj *mta.MTA, sBomFileTmpDir string, sbo
 		sbomFileFullName := sbomFileName + sbomSuffix
 
 		// get sbom file generate command
-		sbomGenCmds, err := commands.GetModuleSBomGenCommands(loc, module, sbomFileName, sbomType, sbomSuffix)
+		sbomGenCmds, err := commands.GetModuleSBomGenCommands(loc, module, params[moduleName], sbomFileName, sbomType, sbomSuffix)
 		if err != nil {
 			return err
 		}
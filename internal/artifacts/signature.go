@@ -0,0 +1,442 @@
+package artifacts
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildconfig"
+	"github.com/SAP/cloud-mta-build-tool/internal/version"
+)
+
+const (
+	// SignKeyEnv - the environment variable with the PKCS#8 PEM private key, or the path to its file, used to sign the MTA archives
+	// when the "--sign-key" flag is not provided
+	SignKeyEnv = "MBT_SIGN_KEY"
+
+	signatureFilePath  = "META-INF/MTA.SF"
+	signatureBlockPath = "META-INF/MTA.SIG"
+
+	digestAttribute         = "SHA-256-Digest"
+	manifestDigestAttribute = "SHA-256-Digest-Manifest"
+
+	signatureBlockType = "MTA SIGNATURE"
+	publicKeyBlockType = "PUBLIC KEY"
+	privateKeyType     = "PRIVATE KEY"
+	algorithmHeader    = "Algorithm"
+
+	rsaAlgorithm     = "RSA-SHA256"
+	ecdsaAlgorithm   = "ECDSA-SHA256"
+	ed25519Algorithm = "Ed25519"
+)
+
+// signingKey - the key that signs the MTA archives created by the current process; nil if the archives are not signed
+var signingKey crypto.Signer
+
+// SetSigningKey - makes the MTA archives created by the current process signed by the key read from the PKCS#8 PEM file;
+// if the path is empty, the key is taken from the MBT_SIGN_KEY environment variable, which contains the key or the path to its file.
+// The key is kept in memory and is not exported to the environment of the build commands;
+// the child mbt processes get the absolute path of the key file in the "--sign-key" flag
+func SetSigningKey(path string) error {
+	signingKey = nil
+	buildconfig.SetSignKeyPath("")
+	if path == "" {
+		key, ok := os.LookupEnv(SignKeyEnv)
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil
+		}
+		content, err := readPEM(key)
+		if err != nil {
+			return errors.Wrap(err, signKeyReadFailedMsg)
+		}
+		signingKey, err = parseSigningKey(content)
+		return err
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return errors.Wrap(err, signKeyReadFailedMsg)
+	}
+	content, err := ioutil.ReadFile(absPath)
+	if err != nil {
+		return errors.Wrap(err, signKeyReadFailedMsg)
+	}
+	signer, err := parseSigningKey(content)
+	if err != nil {
+		return err
+	}
+	signingKey = signer
+	buildconfig.SetSignKeyPath(absPath)
+	return nil
+}
+
+// readPEM - reads the PEM content; the value is the PEM content itself or the path to its file
+func readPEM(value string) ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return ioutil.ReadFile(value)
+}
+
+// parseSigningKey - parses the PKCS#8 PEM private key
+func parseSigningKey(content []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(content)
+	if block == nil || block.Type != privateKeyType {
+		return nil, errors.New(signKeyNotPKCS8Msg)
+	}
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, signKeyNotPKCS8Msg)
+	}
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return nil, errors.New(signKeyNotPKCS8Msg)
+	}
+	return signer, nil
+}
+
+// parsePublicKey - parses the PEM public key in the PKIX format; the value is the PEM content or the path to its file
+func parsePublicKey(value string) (crypto.PublicKey, error) {
+	content, err := readPEM(value)
+	if err != nil {
+		return nil, errors.Wrap(err, publicKeyReadFailedMsg)
+	}
+	block, _ := pem.Decode(content)
+	if block == nil || block.Type != publicKeyBlockType {
+		return nil, errors.New(publicKeyNotPKIXMsg)
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, publicKeyNotPKIXMsg)
+	}
+	return publicKey, nil
+}
+
+// signMtarContent - signs the content of the folder that is archived to the MTA archive, if the signing key is set:
+// the SHA-256 digests of the files are added to the manifest, the signature file with the digest of the manifest
+// and the signature block with the signature of the signature file and the public key are written to the META-INF folder.
+// The signature of the previous build, e.g. of the unpacked MTA archive, is removed.
+func signMtarContent(folder string) error {
+	for _, path := range []string{signatureFilePath, signatureBlockPath} {
+		err := dir.RemoveIfExist(filepath.Join(folder, filepath.FromSlash(path)))
+		if err != nil {
+			return errors.Wrap(err, signFailedMsg)
+		}
+	}
+	signer := signingKey
+	if signer == nil {
+		return nil
+	}
+
+	digests, err := getFolderDigests(folder)
+	if err != nil {
+		return errors.Wrap(err, signFailedMsg)
+	}
+	manifestPath := filepath.Join(folder, filepath.FromSlash(manifestPathInMtar))
+	manifest, err := ioutil.ReadFile(manifestPath)
+	if err != nil {
+		return errors.Wrap(err, signFailedMsg)
+	}
+	manifest = addManifestDigests(manifest, digests)
+	err = ioutil.WriteFile(manifestPath, manifest, 0644)
+	if err != nil {
+		return errors.Wrap(err, signFailedMsg)
+	}
+
+	signatureFile, err := genSignatureFile(manifest)
+	if err != nil {
+		return errors.Wrap(err, signFailedMsg)
+	}
+	signatureBlock, err := genSignatureBlock(signer, signatureFile)
+	if err != nil {
+		return errors.Wrap(err, signFailedMsg)
+	}
+	err = ioutil.WriteFile(filepath.Join(folder, filepath.FromSlash(signatureFilePath)), signatureFile, 0644)
+	if err != nil {
+		return errors.Wrap(err, signFailedMsg)
+	}
+	err = ioutil.WriteFile(filepath.Join(folder, filepath.FromSlash(signatureBlockPath)), signatureBlock, 0644)
+	if err != nil {
+		return errors.Wrap(err, signFailedMsg)
+	}
+	return nil
+}
+
+// isSignedEntry - checks if the archive entry has a digest in the manifest;
+// all files are signed except the manifest and the signature files
+func isSignedEntry(path string) bool {
+	return path != manifestPathInMtar && path != signatureFilePath && path != signatureBlockPath && !strings.HasSuffix(path, "/")
+}
+
+// getFolderDigests - gets the SHA-256 digests of the signed files of the folder by their slash-separated relative paths
+func getFolderDigests(folder string) (map[string]string, error) {
+	digests := make(map[string]string)
+	err := filepath.Walk(folder, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		relPath, err := filepath.Rel(folder, path)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+		if !isSignedEntry(relPath) {
+			return nil
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		digests[relPath] = getDigest(content)
+		return nil
+	})
+	return digests, err
+}
+
+func getDigest(content []byte) string {
+	digest := sha256.Sum256(content)
+	return base64.StdEncoding.EncodeToString(digest[:])
+}
+
+// addManifestDigests - adds the digest attribute to the manifest section of each file, replacing the previous digests;
+// the sections of the files that are not in the manifest are added at the end, sorted by the name
+func addManifestDigests(manifest []byte, digests map[string]string) []byte {
+	var buf bytes.Buffer
+	added := make(map[string]bool)
+	name := ""
+	endSection := func() {
+		if digest, ok := digests[name]; ok && !added[name] {
+			fmt.Fprintf(&buf, "%s: %s\n", digestAttribute, digest)
+			added[name] = true
+		}
+		name = ""
+	}
+	lines := strings.Split(strings.TrimRight(strings.Replace(string(manifest), "\r\n", "\n", -1), "\n"), "\n")
+	for _, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			endSection()
+			buf.WriteString("\n")
+			continue
+		}
+		if strings.HasPrefix(line, digestAttribute+":") {
+			continue
+		}
+		if strings.HasPrefix(line, "Name:") {
+			name = strings.TrimSpace(strings.TrimPrefix(line, "Name:"))
+		}
+		buf.WriteString(line + "\n")
+	}
+	endSection()
+
+	var paths []string
+	for path := range digests {
+		if !added[path] {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		fmt.Fprintf(&buf, "\nName: %s\n%s: %s\n", path, digestAttribute, digests[path])
+	}
+	return buf.Bytes()
+}
+
+// genSignatureFile - generates the signature file with the digest of the manifest
+func genSignatureFile(manifest []byte) ([]byte, error) {
+	v, err := version.GetVersion()
+	if err != nil {
+		return nil, errors.Wrap(err, cliVersionMsg)
+	}
+	return []byte(fmt.Sprintf("Signature-Version: 1.0\nCreated-By: SAP Application Archive Builder %s\n%s: %s\n",
+		v.CliVersion, manifestDigestAttribute, getDigest(manifest))), nil
+}
+
+// genSignatureBlock - generates the PEM signature block with the signature of the signature file and the public key of the signer
+func genSignatureBlock(signer crypto.Signer, signatureFile []byte) ([]byte, error) {
+	var algorithm string
+	var signature []byte
+	var err error
+	digest := sha256.Sum256(signatureFile)
+	switch signer.Public().(type) {
+	case *rsa.PublicKey:
+		algorithm = rsaAlgorithm
+		signature, err = signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	case *ecdsa.PublicKey:
+		algorithm = ecdsaAlgorithm
+		key, ok := signer.(*ecdsa.PrivateKey)
+		if !ok {
+			return nil, errors.New(signKeyNotPKCS8Msg)
+		}
+		signature, err = signECDSA(key, digest[:])
+	case ed25519.PublicKey:
+		algorithm = ed25519Algorithm
+		signature, err = signer.Sign(rand.Reader, signatureFile, crypto.Hash(0))
+	default:
+		return nil, errors.New(signKeyNotPKCS8Msg)
+	}
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := x509.MarshalPKIXPublicKey(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	err = pem.Encode(&buf, &pem.Block{Type: signatureBlockType, Headers: map[string]string{algorithmHeader: algorithm}, Bytes: signature})
+	if err != nil {
+		return nil, err
+	}
+	err = pem.Encode(&buf, &pem.Block{Type: publicKeyBlockType, Bytes: publicKey})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// signECDSA - signs the digest by the ECDSA key with the nonce derived from the key and the digest as defined by RFC 6979,
+// so the same signature file always gets the same signature and the signed archives stay reproducible;
+// the RSA PKCS #1 v1.5 and Ed25519 signatures are deterministic by themselves
+func signECDSA(key *ecdsa.PrivateKey, digest []byte) ([]byte, error) {
+	n := key.Curve.Params().N
+	e := bitsToInt(digest, n)
+	nonces := newDeterministicNonces(key.D, digest, n)
+	for {
+		k := nonces.next()
+		x, _ := key.Curve.ScalarBaseMult(k.Bytes())
+		r := new(big.Int).Mod(x, n)
+		if r.Sign() == 0 {
+			continue
+		}
+		// s = k^-1 * (e + r * d) mod n
+		s := new(big.Int).Mul(r, key.D)
+		s.Add(s, e)
+		s.Mul(s, new(big.Int).ModInverse(k, n))
+		s.Mod(s, n)
+		if s.Sign() == 0 {
+			continue
+		}
+		return asn1.Marshal(struct {
+			R, S *big.Int
+		}{r, s})
+	}
+}
+
+// deterministicNonces - the HMAC-SHA256 based generator of the ECDSA nonces of RFC 6979, section 3.2
+type deterministicNonces struct {
+	n    *big.Int
+	k, v []byte
+}
+
+func newDeterministicNonces(privateKey *big.Int, digest []byte, n *big.Int) *deterministicNonces {
+	size := (n.BitLen() + 7) / 8
+	x := intToOctets(privateKey, size)
+	h := intToOctets(new(big.Int).Mod(bitsToInt(digest, n), n), size)
+	g := &deterministicNonces{n: n, k: make([]byte, sha256.Size), v: bytes.Repeat([]byte{1}, sha256.Size)}
+	g.k = g.mac(g.v, []byte{0}, x, h)
+	g.v = g.mac(g.v)
+	g.k = g.mac(g.v, []byte{1}, x, h)
+	g.v = g.mac(g.v)
+	return g
+}
+
+func (g *deterministicNonces) mac(data ...[]byte) []byte {
+	m := hmac.New(sha256.New, g.k)
+	for _, d := range data {
+		m.Write(d)
+	}
+	return m.Sum(nil)
+}
+
+// next - gets the next nonce in the range [1, n-1]
+func (g *deterministicNonces) next() *big.Int {
+	size := (g.n.BitLen() + 7) / 8
+	for {
+		var t []byte
+		for len(t) < size {
+			g.v = g.mac(g.v)
+			t = append(t, g.v...)
+		}
+		k := bitsToInt(t, g.n)
+		// the state is updated after each candidate, so the next call gets a new nonce
+		g.k = g.mac(g.v, []byte{0})
+		g.v = g.mac(g.v)
+		if k.Sign() > 0 && k.Cmp(g.n) < 0 {
+			return k
+		}
+	}
+}
+
+// bitsToInt - converts the leftmost bits of the value, as many as the bit length of n, to the integer
+func bitsToInt(value []byte, n *big.Int) *big.Int {
+	i := new(big.Int).SetBytes(value)
+	if excess := len(value)*8 - n.BitLen(); excess > 0 {
+		i.Rsh(i, uint(excess))
+	}
+	return i
+}
+
+// intToOctets - converts the non-negative integer less than 2^(8*size) to the big-endian bytes of the size
+func intToOctets(i *big.Int, size int) []byte {
+	b := i.Bytes()
+	return append(make([]byte, size-len(b)), b...)
+}
+
+// parseSignatureBlock - gets the algorithm, the signature and the public key of the signer from the signature block
+func parseSignatureBlock(content []byte) (algorithm string, signature []byte, publicKey crypto.PublicKey, err error) {
+	signatureBlock, rest := pem.Decode(content)
+	if signatureBlock == nil || signatureBlock.Type != signatureBlockType {
+		return "", nil, nil, errors.New(signatureBlockInvalidMsg)
+	}
+	publicKeyBlock, _ := pem.Decode(rest)
+	if publicKeyBlock == nil || publicKeyBlock.Type != publicKeyBlockType {
+		return "", nil, nil, errors.New(signatureBlockInvalidMsg)
+	}
+	publicKey, err = x509.ParsePKIXPublicKey(publicKeyBlock.Bytes)
+	if err != nil {
+		return "", nil, nil, errors.Wrap(err, signatureBlockInvalidMsg)
+	}
+	return signatureBlock.Headers[algorithmHeader], signatureBlock.Bytes, publicKey, nil
+}
+
+// verifySignatureFile - verifies the signature of the signature file by the algorithm and the public key
+func verifySignatureFile(algorithm string, publicKey crypto.PublicKey, signatureFile, signature []byte) bool {
+	digest := sha256.Sum256(signatureFile)
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return algorithm == rsaAlgorithm && rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature) == nil
+	case *ecdsa.PublicKey:
+		var ecdsaSignature struct {
+			R, S *big.Int
+		}
+		_, err := asn1.Unmarshal(signature, &ecdsaSignature)
+		return algorithm == ecdsaAlgorithm && err == nil && ecdsa.Verify(key, digest[:], ecdsaSignature.R, ecdsaSignature.S)
+	case ed25519.PublicKey:
+		return algorithm == ed25519Algorithm && ed25519.Verify(key, signatureFile, signature)
+	}
+	return false
+}
+
+// samePublicKeys - checks if the public keys are equal
+func samePublicKeys(key1, key2 crypto.PublicKey) bool {
+	data1, err1 := x509.MarshalPKIXPublicKey(key1)
+	data2, err2 := x509.MarshalPKIXPublicKey(key2)
+	return err1 == nil && err2 == nil && bytes.Equal(data1, data2)
+}
@@ -0,0 +1 @@
+console.log("srv")
\ No newline at end of file
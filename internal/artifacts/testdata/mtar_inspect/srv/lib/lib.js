@@ -0,0 +1 @@
+lib
\ No newline at end of file
@@ -0,0 +1,346 @@
+package artifacts
+
+import (
+	"archive/zip"
+	"crypto"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+
+	"github.com/SAP/cloud-mta/mta"
+	validate "github.com/SAP/cloud-mta/validations"
+
+	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
+	"github.com/SAP/cloud-mta-build-tool/internal/conttype"
+	"github.com/SAP/cloud-mta-build-tool/internal/logs"
+)
+
+const (
+	// SeverityError - the finding makes the MTA archive invalid
+	SeverityError = "error"
+	// SeverityWarning - the finding does not make the MTA archive invalid
+	SeverityWarning = "warning"
+
+	verifyEntryCheck       = "entry"
+	verifyModuleCheck      = "module"
+	verifyContentTypeCheck = "content-type"
+	verifySchemaCheck      = "schema"
+	verifySignatureCheck   = "signature"
+)
+
+// verifyFinding - the problem found in the MTA archive
+type verifyFinding struct {
+	Severity string `json:"severity" yaml:"severity"`
+	Check    string `json:"check" yaml:"check"`
+	Entry    string `json:"entry,omitempty" yaml:"entry,omitempty"`
+	Message  string `json:"message" yaml:"message"`
+}
+
+// verifyResult - the result of the MTA archive verification
+type verifyResult struct {
+	Archive  string           `json:"archive" yaml:"archive"`
+	Valid    bool             `json:"valid" yaml:"valid"`
+	Findings []*verifyFinding `json:"findings" yaml:"findings"`
+}
+
+func (r *verifyResult) add(severity, check, entry, message string, args ...interface{}) {
+	r.Findings = append(r.Findings, &verifyFinding{Severity: severity, Check: check, Entry: entry, Message: fmt.Sprintf(message, args...)})
+	if severity == SeverityError {
+		r.Valid = false
+	}
+}
+
+func (r *verifyResult) errorsCount() int {
+	count := 0
+	for _, finding := range r.Findings {
+		if finding.Severity == SeverityError {
+			count++
+		}
+	}
+	return count
+}
+
+// ExecuteVerify - checks that the MTA archive is internally consistent: the manifest entries reference existing archive entries
+// with the expected content types, the deployment descriptor modules have manifest entries and the deployment descriptor is valid;
+// if the signature is checked, the archive entries must match the digests of the signed manifest and, if the public key
+// (PEM content or the path to its file) is provided, the archive must be signed by its private key.
+// Prints the findings and fails when any of them is an error
+func ExecuteVerify(mtarPath, output string, signature bool, publicKey string, out io.Writer) error {
+	err := validateOutput(output)
+	if err != nil {
+		return err
+	}
+	var expectedPublicKey crypto.PublicKey
+	if publicKey != "" {
+		expectedPublicKey, err = parsePublicKey(publicKey)
+		if err != nil {
+			return err
+		}
+		signature = true
+	}
+	result, err := verifyMtar(mtarPath, signature, expectedPublicKey)
+	if err != nil {
+		return err
+	}
+	err = printOutput(result, output, out, func(out io.Writer) error {
+		return printVerifyResultTable(result, out)
+	})
+	if err != nil {
+		return err
+	}
+	if !result.Valid {
+		return errors.Errorf(verifyFailedMsg, mtarPath, result.errorsCount())
+	}
+	logs.Logger.Infof(verifySucceededMsg, mtarPath)
+	return nil
+}
+
+func verifyMtar(mtarPath string, signature bool, publicKey crypto.PublicKey) (result *verifyResult, e error) {
+	reader, err := zip.OpenReader(mtarPath)
+	if err != nil {
+		return nil, errors.Wrapf(err, mtarOpenFailedMsg, mtarPath)
+	}
+	defer func() {
+		e = dir.CloseFile(reader, e)
+	}()
+
+	manifestContent, mtadContent, err := readMtarDescriptors(&reader.Reader, mtarPath)
+	if err != nil {
+		return nil, err
+	}
+	contentTypes, err := conttype.GetContentTypes()
+	if err != nil {
+		return nil, errors.Wrap(err, contentTypeCfgMsg)
+	}
+
+	result = &verifyResult{Archive: mtarPath, Valid: true, Findings: []*verifyFinding{}}
+	entries := parseManifest(manifestContent)
+	verifyManifestEntries(result, reader.File, entries, contentTypes)
+
+	mtad, err := mta.Unmarshal(mtadContent)
+	if err != nil {
+		result.add(SeverityError, verifySchemaCheck, mtadPathInMtar, verifyMtadParseFailedMsg, err.Error())
+	} else {
+		verifyMtadModules(result, mtad.Modules, entries)
+	}
+
+	err = verifyMtadSchema(result, mtadContent)
+	if err != nil {
+		return nil, err
+	}
+	if signature {
+		err = verifyMtarSignature(result, &reader.Reader, manifestContent, publicKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// verifyManifestEntries - checks that the manifest entries reference existing archive entries with the expected content types
+func verifyManifestEntries(result *verifyResult, files []*zip.File, entries []entry, contentTypes *conttype.ContentTypes) {
+	for _, e := range entries {
+		entryName := e.EntryType + ": " + e.EntryName
+		_, missing := getMtarEntrySize(files, e.EntryPath, map[string]bool{})
+		if missing {
+			result.add(SeverityError, verifyEntryCheck, entryName, verifyEntryMissingMsg, e.EntryPath)
+			continue
+		}
+
+		expected := dirContentType
+		if !isMtarFolder(files, e.EntryPath) {
+			contentType, err := conttype.GetContentType(contentTypes, path.Ext(e.EntryPath))
+			if err != nil {
+				result.add(SeverityWarning, verifyContentTypeCheck, entryName, verifyContentTypeUnknownMsg, e.EntryPath)
+				continue
+			}
+			expected = contentType
+		}
+		if e.ContentType != expected {
+			result.add(SeverityError, verifyContentTypeCheck, entryName, verifyContentTypeMismatchMsg, e.ContentType, e.EntryPath, expected)
+		}
+	}
+}
+
+// isMtarFolder - checks if the archive path is a folder, i.e. there is no file with this name
+func isMtarFolder(files []*zip.File, entryPath string) bool {
+	if strings.HasSuffix(entryPath, "/") {
+		return true
+	}
+	for _, file := range files {
+		if file.Name == entryPath {
+			return false
+		}
+	}
+	return true
+}
+
+// verifyMtadModules - checks that the deployment descriptor modules with content have manifest entries
+func verifyMtadModules(result *verifyResult, modules []*mta.Module, entries []entry) {
+	for _, module := range modules {
+		// the build parameters are removed from the deployment descriptor, so the modules without content have no path
+		params, err := buildparams.Decode(module)
+		if err != nil {
+			result.add(SeverityError, verifyModuleCheck, module.Name, "%s", err.Error())
+			continue
+		}
+		if params.NoSource || module.Path == "" {
+			continue
+		}
+		found := false
+		for _, e := range entries {
+			if e.EntryType == moduleEntry && e.EntryName == module.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			result.add(SeverityError, verifyModuleCheck, module.Name, verifyModuleNotInManifestMsg, module.Name)
+		}
+	}
+}
+
+// verifyMtadSchema - validates the deployment descriptor against the MTA schema
+func verifyMtadSchema(result *verifyResult, mtadContent []byte) (e error) {
+	tmpDir, err := ioutil.TempDir("", "mbt-verify")
+	if err != nil {
+		return errors.Wrap(err, verifyTmpDirFailedMsg)
+	}
+	defer func() {
+		removeErr := os.RemoveAll(tmpDir)
+		if removeErr != nil && e == nil {
+			e = removeErr
+		}
+	}()
+	mtadFileName := filepath.Base(mtadPathInMtar)
+	err = ioutil.WriteFile(filepath.Join(tmpDir, mtadFileName), mtadContent, 0644)
+	if err != nil {
+		return errors.Wrap(err, verifyTmpDirFailedMsg)
+	}
+
+	warn, err := validate.MtaYaml(tmpDir, mtadFileName, true, false, true, "")
+	for _, message := range splitValidationMessages(warn) {
+		result.add(SeverityWarning, verifySchemaCheck, mtadPathInMtar, "%s", message)
+	}
+	if err != nil {
+		lines := strings.SplitN(err.Error(), "\n", 2)
+		// the first line of the validation error is a header with the validated file path
+		messages := splitValidationMessages(lines[len(lines)-1])
+		if len(lines) == 1 {
+			messages = lines
+		}
+		for _, message := range messages {
+			result.add(SeverityError, verifySchemaCheck, mtadPathInMtar, "%s", message)
+		}
+	}
+	return nil
+}
+
+func splitValidationMessages(messages string) []string {
+	var result []string
+	for _, message := range strings.Split(messages, "\n") {
+		if strings.TrimSpace(message) != "" {
+			result = append(result, strings.TrimSpace(message))
+		}
+	}
+	return result
+}
+
+// verifyMtarSignature - checks that the signature file is signed by the key of the signature block, and by the provided public key,
+// that the manifest matches the signature file and that the archive entries match the digests of the manifest
+func verifyMtarSignature(result *verifyResult, reader *zip.Reader, manifestContent []byte, publicKey crypto.PublicKey) error {
+	files := make(map[string]*zip.File)
+	for _, file := range reader.File {
+		files[file.Name] = file
+	}
+	for _, path := range []string{signatureFilePath, signatureBlockPath} {
+		if files[path] == nil {
+			result.add(SeverityError, verifySignatureCheck, path, verifyNotSignedMsg, path)
+			return nil
+		}
+	}
+	signatureFile, err := readMtarFile(files[signatureFilePath], signatureFilePath)
+	if err != nil {
+		return errors.Wrapf(err, mtarEntryReadFailedMsg, signatureFilePath, result.Archive)
+	}
+	signatureBlock, err := readMtarFile(files[signatureBlockPath], signatureBlockPath)
+	if err != nil {
+		return errors.Wrapf(err, mtarEntryReadFailedMsg, signatureBlockPath, result.Archive)
+	}
+
+	algorithm, signature, signer, err := parseSignatureBlock(signatureBlock)
+	if err != nil {
+		result.add(SeverityError, verifySignatureCheck, signatureBlockPath, "%s", err.Error())
+		return nil
+	}
+	if !verifySignatureFile(algorithm, signer, signatureFile, signature) {
+		result.add(SeverityError, verifySignatureCheck, signatureBlockPath, verifySignatureInvalidMsg, signatureFilePath)
+	}
+	if publicKey == nil {
+		result.add(SeverityWarning, verifySignatureCheck, signatureBlockPath, verifySignerUnknownMsg)
+	} else if !samePublicKeys(publicKey, signer) {
+		result.add(SeverityError, verifySignatureCheck, signatureBlockPath, verifySignerMismatchMsg)
+	}
+	sections := parseManifestSections(signatureFile)
+	if len(sections) == 0 || sections[0][manifestDigestAttribute] != getDigest(manifestContent) {
+		result.add(SeverityError, verifySignatureCheck, manifestPathInMtar, verifyManifestDigestInvalidMsg, signatureFilePath)
+	}
+
+	digests := make(map[string]string)
+	for _, section := range parseManifestSections(manifestContent) {
+		if section["Name"] != "" && section[digestAttribute] != "" {
+			digests[section["Name"]] = section[digestAttribute]
+		}
+	}
+	for _, file := range reader.File {
+		if !isSignedEntry(file.Name) {
+			continue
+		}
+		digest, ok := digests[file.Name]
+		if !ok {
+			result.add(SeverityError, verifySignatureCheck, file.Name, verifyDigestMissingMsg)
+			continue
+		}
+		content, err := readMtarFile(file, file.Name)
+		if err != nil {
+			return errors.Wrapf(err, mtarEntryReadFailedMsg, file.Name, result.Archive)
+		}
+		if getDigest(content) != digest {
+			result.add(SeverityError, verifySignatureCheck, file.Name, verifyDigestMismatchMsg)
+		}
+	}
+	var signedPaths []string
+	for path := range digests {
+		if files[path] == nil {
+			signedPaths = append(signedPaths, path)
+		}
+	}
+	sort.Strings(signedPaths)
+	for _, path := range signedPaths {
+		result.add(SeverityError, verifySignatureCheck, path, verifyDigestEntryMissingMsg)
+	}
+	return nil
+}
+
+func printVerifyResultTable(result *verifyResult, out io.Writer) error {
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "ARCHIVE:\t%s\n", result.Archive)
+	fmt.Fprintf(writer, "VALID:\t%t\n", result.Valid)
+	if len(result.Findings) > 0 {
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "SEVERITY\tCHECK\tENTRY\tMESSAGE")
+		for _, finding := range result.Findings {
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", finding.Severity, finding.Check, valueOrDash(finding.Entry), finding.Message)
+		}
+	}
+	return writer.Flush()
+}
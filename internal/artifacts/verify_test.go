@@ -0,0 +1,168 @@
+package artifacts
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v2"
+
+	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+)
+
+// createTestMtar - creates the MTA archive with the content of the source folder, replacing the overridden files
+func createTestMtar(source, mtarPath string, overrides map[string]string) {
+	files := make(map[string]string)
+	Ω(filepath.Walk(source, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(source, path)
+		files[filepath.ToSlash(relPath)] = string(content)
+		return err
+	})).Should(Succeed())
+	for path, content := range overrides {
+		files[path] = content
+	}
+
+	Ω(os.MkdirAll(filepath.Dir(mtarPath), os.ModePerm)).Should(Succeed())
+	out, err := os.Create(mtarPath)
+	Ω(err).Should(Succeed())
+	writer := zip.NewWriter(out)
+	for path, content := range files {
+		w, err := writer.Create(path)
+		Ω(err).Should(Succeed())
+		_, err = w.Write([]byte(content))
+		Ω(err).Should(Succeed())
+	}
+	Ω(writer.Close()).Should(Succeed())
+	Ω(out.Close()).Should(Succeed())
+}
+
+var _ = Describe("Verify", func() {
+
+	mtarPath := filepath.Join(getResultPath(), "verify.mtar")
+
+	AfterEach(func() {
+		Ω(os.RemoveAll(getResultPath())).Should(Succeed())
+	})
+
+	It("succeeds on the consistent MTA archive", func() {
+		Ω(dir.Archive(getTestPath("mtar_verify"), mtarPath, nil)).Should(Succeed())
+		var out bytes.Buffer
+		Ω(ExecuteVerify(mtarPath, "", false, "", &out)).Should(Succeed())
+		Ω(out.String()).Should(MatchRegexp(`VALID:\s+true`))
+		Ω(out.String()).ShouldNot(ContainSubstring("SEVERITY"))
+	})
+
+	It("reports the manifest entries referencing missing archive entries", func() {
+		Ω(dir.Archive(getTestPath("mtar_inspect"), mtarPath, nil)).Should(Succeed())
+		result, err := verifyMtar(mtarPath, false, nil)
+		Ω(err).Should(Succeed())
+		Ω(result.Valid).Should(BeFalse())
+		Ω(result.Findings).Should(Equal([]*verifyFinding{
+			{Severity: SeverityError, Check: verifyEntryCheck, Entry: "MTA-Module: missing",
+				Message: `the "missing/data.zip" path referenced by the manifest does not exist in the archive`},
+		}))
+	})
+
+	It("reports the content types that do not match the archive entries", func() {
+		manifest, err := ioutil.ReadFile(getTestPath("mtar_verify", "META-INF", "MANIFEST.MF"))
+		Ω(err).Should(Succeed())
+		manifestContent := strings.Replace(string(manifest), "Content-Type: text/directory", "Content-Type: application/zip", 1)
+		manifestContent += "Name: srv/data.json\nMTA-Requires: srv/data\nContent-Type: text/directory\n\n" +
+			"Name: srv/index.js\nMTA-Requires: srv/index\nContent-Type: text/plain\n\n"
+		createTestMtar(getTestPath("mtar_verify"), mtarPath, map[string]string{"META-INF/MANIFEST.MF": manifestContent, "srv/data.json": "{}"})
+		result, err := verifyMtar(mtarPath, false, nil)
+		Ω(err).Should(Succeed())
+		Ω(result.Valid).Should(BeFalse())
+		Ω(result.Findings).Should(Equal([]*verifyFinding{
+			{Severity: SeverityError, Check: verifyContentTypeCheck, Entry: "MTA-Module: srv",
+				Message: `the "application/zip" content type of the "srv/" path does not match the expected "text/directory" content type`},
+			{Severity: SeverityError, Check: verifyContentTypeCheck, Entry: "MTA-Requires: srv/data",
+				Message: `the "text/directory" content type of the "srv/data.json" path does not match the expected "application/json" content type`},
+			{Severity: SeverityWarning, Check: verifyContentTypeCheck, Entry: "MTA-Requires: srv/index",
+				Message: `the content type of the "srv/index.js" path cannot be checked because its extension is unknown`},
+		}))
+	})
+
+	It("reports the deployment descriptor modules without manifest entries", func() {
+		mtad, err := ioutil.ReadFile(getTestPath("mtar_verify", "META-INF", "mtad.yaml"))
+		Ω(err).Should(Succeed())
+		mtadContent := strings.Replace(string(mtad), "modules:\n", "modules:\n  - name: api\n    type: java\n    path: api\n", 1)
+		createTestMtar(getTestPath("mtar_verify"), mtarPath, map[string]string{"META-INF/mtad.yaml": mtadContent})
+		result, err := verifyMtar(mtarPath, false, nil)
+		Ω(err).Should(Succeed())
+		Ω(result.Findings).Should(Equal([]*verifyFinding{
+			{Severity: SeverityError, Check: verifyModuleCheck, Entry: "api",
+				Message: `the "api" module of the deployment descriptor has no entry in the manifest`},
+		}))
+	})
+
+	It("reports the deployment descriptor schema errors", func() {
+		mtad, err := ioutil.ReadFile(getTestPath("mtar_verify", "META-INF", "mtad.yaml"))
+		Ω(err).Should(Succeed())
+		mtadContent := strings.Replace(string(mtad), "ID: verify", "ID: verify\nunknown-property: value", 1)
+		createTestMtar(getTestPath("mtar_verify"), mtarPath, map[string]string{"META-INF/mtad.yaml": mtadContent})
+		result, err := verifyMtar(mtarPath, false, nil)
+		Ω(err).Should(Succeed())
+		Ω(result.Valid).Should(BeFalse())
+		Ω(result.Findings).ShouldNot(BeEmpty())
+		for _, finding := range result.Findings {
+			Ω(finding.Check).Should(Equal(verifySchemaCheck))
+			Ω(finding.Entry).Should(Equal(mtadPathInMtar))
+		}
+		Ω(result.Findings[0].Message).Should(ContainSubstring("unknown-property"))
+	})
+
+	It("prints the findings and fails with the number of errors", func() {
+		Ω(dir.Archive(getTestPath("mtar_inspect"), mtarPath, nil)).Should(Succeed())
+		var out bytes.Buffer
+		checkError(ExecuteVerify(mtarPath, OutputTable, false, "", &out), verifyFailedMsg, mtarPath, 1)
+		Ω(out.String()).Should(MatchRegexp(`VALID:\s+false`))
+		Ω(out.String()).Should(MatchRegexp(`error\s+entry\s+MTA-Module: missing\s+the "missing/data.zip" path`))
+	})
+
+	It("prints the findings in the JSON format", func() {
+		Ω(dir.Archive(getTestPath("mtar_inspect"), mtarPath, nil)).Should(Succeed())
+		var out bytes.Buffer
+		Ω(ExecuteVerify(mtarPath, OutputJSON, false, "", &out)).ShouldNot(Succeed())
+		var result map[string]interface{}
+		Ω(json.Unmarshal(out.Bytes(), &result)).Should(Succeed())
+		Ω(result["valid"]).Should(BeFalse())
+		Ω(result["findings"]).Should(ContainElement(HaveKeyWithValue("entry", "MTA-Module: missing")))
+	})
+
+	It("prints the findings in the YAML format", func() {
+		Ω(dir.Archive(getTestPath("mtar_verify"), mtarPath, nil)).Should(Succeed())
+		var out bytes.Buffer
+		Ω(ExecuteVerify(mtarPath, OutputYAML, false, "", &out)).Should(Succeed())
+		var result map[string]interface{}
+		Ω(yaml.Unmarshal(out.Bytes(), &result)).Should(Succeed())
+		Ω(result["valid"]).Should(BeTrue())
+		Ω(result["findings"]).Should(BeEmpty())
+	})
+
+	It("fails on the wrong output format", func() {
+		checkError(ExecuteVerify(mtarPath, "xml", false, "", &bytes.Buffer{}), wrongOutputMsg, "xml")
+	})
+
+	It("fails when the archive does not exist", func() {
+		checkError(ExecuteVerify(getTestPath("unknown.mtar"), "", false, "", &bytes.Buffer{}), mtarOpenFailedMsg, getTestPath("unknown.mtar"))
+	})
+
+	It("fails when the archive has no manifest", func() {
+		Ω(dir.Archive(getTestPath("mtar_verify"), mtarPath, []string{"META-INF/MANIFEST.MF"})).Should(Succeed())
+		checkError(ExecuteVerify(mtarPath, "", false, "", &bytes.Buffer{}), mtarManifestReadFailedMsg, mtarPath)
+	})
+})
@@ -0,0 +1,383 @@
+package artifacts
+
+import (
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildops"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
+	"github.com/SAP/cloud-mta-build-tool/internal/commands"
+	"github.com/SAP/cloud-mta-build-tool/internal/logs"
+	"github.com/SAP/cloud-mta/mta"
+)
+
+const (
+	// DefaultWatchInterval - the default interval of polling the module folders for changes
+	DefaultWatchInterval = time.Second
+	// DefaultWatchDebounce - the default time without changes after which the changed modules are rebuilt
+	DefaultWatchDebounce = 500 * time.Millisecond
+)
+
+// watchedModule - the module whose source folder is polled for changes
+type watchedModule struct {
+	name   string
+	path   string
+	ignore []string
+	state  map[string]string
+	// outputs - the patterns of the paths, relative to the module folder, that the module build writes
+	outputs []string
+	// written - the paths of the files outside of the outputs that the module build writes
+	written map[string]bool
+	// rebuilt - the paths whose changes during the previous build of the module caused its rebuild
+	rebuilt map[string]bool
+}
+
+// moduleWatcher - polls the source folders of the modules and rebuilds the changed modules and the modules that depend on them
+type moduleWatcher struct {
+	// modules - the watched modules in their build order
+	modules []*watchedModule
+	// buildOrder - the names of all the modules that are built, watched or not, in their build order
+	buildOrder []string
+	// dependents - the names of the modules that require the module in their build parameters
+	dependents map[string][]string
+	// excluded - the absolute paths of the build outputs that are not watched
+	excluded []string
+	interval time.Duration
+	debounce time.Duration
+	build    func(modules []string) error
+}
+
+// ExecuteWatch - watches the source folders of the modules and rebuilds the changed modules and the modules that depend on them
+// as the "module-build" command does, until the stop channel is closed. If no modules are provided, all the modules are watched.
+// The files matching the "ignore" build parameter of the module are not watched.
+func ExecuteWatch(source, mtaYamlFilename, target string, extensions []string, modulesNames []string, generateMtadFlag bool,
+	platform string, interval, debounce time.Duration, stop <-chan struct{}, wdGetter func() (string, error)) error {
+
+	if generateMtadFlag {
+		_, err := validatePlatform(platform)
+		if err != nil {
+			return err
+		}
+	}
+	sourceDir, err := getSoloModuleBuildAbsSource(source, wdGetter)
+	if err != nil {
+		return errors.Wrap(err, watchFailedOnLocMsg)
+	}
+	loc, err := dir.Location(sourceDir, mtaYamlFilename, "", dir.Dev, extensions, wdGetter)
+	if err != nil {
+		return errors.Wrap(err, watchFailedOnLocMsg)
+	}
+	mtaObj, err := loc.ParseFile()
+	if err != nil {
+		return err
+	}
+	excluded, err := getWatchExcludedPaths(sourceDir, target, wdGetter)
+	if err != nil {
+		return errors.Wrap(err, watchFailedOnLocMsg)
+	}
+
+	w, err := newModuleWatcher(loc, mtaObj, modulesNames, excluded, interval, debounce)
+	if err != nil {
+		return err
+	}
+	w.build = func(modules []string) error {
+		return ExecuteSoloBuild(sourceDir, mtaYamlFilename, target, extensions, modules, false, generateMtadFlag, platform, "", wdGetter)
+	}
+	return w.run(stop)
+}
+
+// getWatchExcludedPaths - gets the paths of the build results and of the deployment descriptor of the "module-build" command,
+// so that the build does not trigger itself when the module folder contains them
+func getWatchExcludedPaths(source, target string, wdGetter func() (string, error)) ([]string, error) {
+	targetRoot, err := getSoloModuleBuildAbsTarget(source, target, "", wdGetter)
+	if err != nil {
+		return nil, err
+	}
+	mtadDir, err := getMtadPath(target, wdGetter)
+	if err != nil {
+		return nil, err
+	}
+	mtadPath, err := filepath.Abs(filepath.Join(mtadDir, "mtad.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	_, projectFolderName := filepath.Split(source)
+	return []string{targetRoot, mtadPath, filepath.Join(source, dir.MtarFolder), filepath.Join(source, "."+projectFolderName+dir.CacheFolderSuffix)}, nil
+}
+
+func newModuleWatcher(loc *dir.Loc, mtaObj *mta.MTA, modulesNames []string, excluded []string,
+	interval, debounce time.Duration) (*moduleWatcher, error) {
+
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	if debounce < 0 {
+		debounce = DefaultWatchDebounce
+	}
+	modulesParams, err := buildparams.DecodeAll(mtaObj)
+	if err != nil {
+		return nil, err
+	}
+	allModulesSorted, err := buildops.GetModulesNames(mtaObj, modulesParams)
+	if err != nil {
+		return nil, err
+	}
+	profileModules, err := buildops.GetProfileModules(mtaObj, modulesParams)
+	if err != nil {
+		return nil, err
+	}
+	selected := make(map[string]bool)
+	for _, moduleName := range modulesNames {
+		module, err := mtaObj.GetModuleByName(moduleName)
+		if err != nil {
+			return nil, err
+		}
+		if module.Path == "" {
+			return nil, errors.Errorf(buildFailedOnEmptyPathMsg, moduleName)
+		}
+		selected[moduleName] = true
+	}
+
+	w := &moduleWatcher{dependents: make(map[string][]string), excluded: excluded, interval: interval, debounce: debounce}
+	for _, moduleName := range allModulesSorted {
+		module, err := mtaObj.GetModuleByName(moduleName)
+		if err != nil {
+			return nil, err
+		}
+		params := modulesParams[moduleName]
+		built := module.Path != "" && !params.NoSource && profileModules[moduleName]
+		// without the selected modules, the modules that are not built are not watched
+		watched := selected[moduleName] || len(selected) == 0 && built
+		if built || watched {
+			// the modules that are not watched are rebuilt too when the modules they require are changed
+			w.buildOrder = append(w.buildOrder, moduleName)
+			for _, req := range params.Requires {
+				w.dependents[req.Name] = append(w.dependents[req.Name], moduleName)
+			}
+		}
+		if !watched {
+			continue
+		}
+		outputs, err := getWatchedModuleOutputs(module, params)
+		if err != nil {
+			return nil, err
+		}
+		w.modules = append(w.modules, &watchedModule{name: moduleName, path: loc.GetSourceModuleDir(module.Path), ignore: params.Ignore,
+			outputs: outputs, written: make(map[string]bool)})
+	}
+	return w, nil
+}
+
+// getWatchedModuleOutputs - gets the patterns of the paths, relative to the module folder, that the module build writes:
+// the installed dependencies, the build result and the target folders of the artifacts copied from the required modules;
+// the module folder itself, which is the build result of many builders, is not an output
+func getWatchedModuleOutputs(module *mta.Module, params *buildparams.Module) ([]string, error) {
+	buildResult := params.BuildResult
+	if buildResult == "" {
+		_, defaultBuildResult, err := commands.CommandProvider(*module, params)
+		if err != nil {
+			return nil, errors.Wrapf(err, buildFailedOnCommandsMsg, module.Name)
+		}
+		buildResult = defaultBuildResult
+	}
+	paths := []string{"node_modules", buildResult}
+	for _, req := range params.Requires {
+		paths = append(paths, req.TargetPath)
+	}
+	var outputs []string
+	for _, p := range paths {
+		p = path.Clean(filepath.ToSlash(p))
+		if p != "." && p != "/" && !strings.HasPrefix(p, "../") {
+			outputs = append(outputs, p)
+		}
+	}
+	return outputs, nil
+}
+
+// run - polls the module folders until the stop channel is closed; the changed modules are rebuilt
+// when no more changes are found during the debounce time
+func (w *moduleWatcher) run(stop <-chan struct{}) error {
+	_, err := w.getChangedModules()
+	if err != nil {
+		return err
+	}
+	logs.Logger.Infof(watchStartedMsg, strings.Join(w.getModulesNames(), `", "`))
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	changed := make(map[string]bool)
+	var lastChange time.Time
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-ticker.C:
+		}
+		modules, err := w.getChangedModules()
+		if err != nil {
+			logs.Logger.Warn(err)
+			continue
+		}
+		if len(modules) > 0 {
+			for _, module := range modules {
+				changed[module] = true
+			}
+			lastChange = time.Now()
+			continue
+		}
+		if len(changed) == 0 || time.Since(lastChange) < w.debounce {
+			continue
+		}
+
+		affected := w.getAffectedModules(changed)
+		changed = make(map[string]bool)
+		logs.Logger.Infof(watchRebuildMsg, strings.Join(affected, `", "`))
+		err = w.build(affected)
+		if err != nil {
+			logs.Logger.Error(err)
+		}
+		err = w.collectChangesAfterBuild(affected, changed)
+		if err != nil {
+			logs.Logger.Warn(err)
+		}
+		if len(changed) > 0 {
+			lastChange = time.Now()
+		}
+		logs.Logger.Infof(watchWaitingMsg)
+	}
+}
+
+// collectChangesAfterBuild - compares the module folders with their state before the build and collects the changed modules
+// for the next rebuild; the changes that the build writes to the folders of the built modules are skipped,
+// while the changes of their sources made during the build are collected as the changes of the other modules are
+func (w *moduleWatcher) collectChangesAfterBuild(built []string, changed map[string]bool) error {
+	builtModules := make(map[string]bool)
+	for _, module := range built {
+		builtModules[module] = true
+	}
+	var skipped []string
+	for _, m := range w.modules {
+		state, err := dir.GetTreeState(m.path, m.ignore, w.excluded)
+		if err != nil {
+			return errors.Wrapf(err, watchFailedOnModuleMsg, m.name)
+		}
+		if builtModules[m.name] {
+			sourceChanged, outputChanged := m.getChangesDuringBuild(state)
+			if sourceChanged {
+				changed[m.name] = true
+			} else if outputChanged {
+				skipped = append(skipped, m.name)
+			}
+		} else if m.state != nil && !reflect.DeepEqual(m.state, state) {
+			changed[m.name] = true
+		}
+		m.state = state
+	}
+	if len(skipped) > 0 {
+		logs.Logger.Infof(watchChangesSkippedMsg, strings.Join(skipped, `", "`))
+	}
+	return nil
+}
+
+// getChangesDuringBuild - checks if the sources or the files written by the build of the module have changed during its build.
+// The files under the build outputs are written by the build; so are the files created during the build,
+// and the files that change again during the rebuild caused by their changes, which are skipped in the next builds too
+func (m *watchedModule) getChangesDuringBuild(state map[string]string) (sourceChanged bool, outputChanged bool) {
+	rebuilt := make(map[string]bool)
+	for _, p := range getChangedPaths(m.state, state) {
+		if _, existed := m.state[p]; !existed || m.rebuilt[p] {
+			m.written[p] = true
+		}
+		if m.written[p] || isBuildOutput(p, m.outputs) {
+			outputChanged = true
+		} else {
+			rebuilt[p] = true
+		}
+	}
+	m.rebuilt = rebuilt
+	return len(rebuilt) > 0, outputChanged
+}
+
+// getChangedPaths - gets the paths of the files that are added, removed or changed in the new state
+func getChangedPaths(oldState, newState map[string]string) []string {
+	var changed []string
+	for p, value := range newState {
+		if oldValue, ok := oldState[p]; !ok || oldValue != value {
+			changed = append(changed, p)
+		}
+	}
+	for p := range oldState {
+		if _, ok := newState[p]; !ok {
+			changed = append(changed, p)
+		}
+	}
+	return changed
+}
+
+// isBuildOutput - checks if the slash-separated path, relative to the module folder, or one of its parent folders matches the outputs
+func isBuildOutput(relPath string, outputs []string) bool {
+	for p := relPath; p != "." && p != "/"; p = path.Dir(p) {
+		for _, output := range outputs {
+			if matched, _ := path.Match(output, p); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// getChangedModules - reads the states of the module folders and provides the modules whose state has changed since the previous call
+func (w *moduleWatcher) getChangedModules() ([]string, error) {
+	var changed []string
+	for _, m := range w.modules {
+		state, err := dir.GetTreeState(m.path, m.ignore, w.excluded)
+		if err != nil {
+			return nil, errors.Wrapf(err, watchFailedOnModuleMsg, m.name)
+		}
+		if m.state != nil && !reflect.DeepEqual(m.state, state) {
+			changed = append(changed, m.name)
+		}
+		m.state = state
+	}
+	return changed, nil
+}
+
+// getAffectedModules - provides the changed modules and the modules that depend on them, directly or indirectly, in their build order;
+// the dependent modules are provided even if they are not watched
+func (w *moduleWatcher) getAffectedModules(changed map[string]bool) []string {
+	affected := make(map[string]bool)
+	var collect func(module string)
+	collect = func(module string) {
+		if affected[module] {
+			return
+		}
+		affected[module] = true
+		for _, dependent := range w.dependents[module] {
+			collect(dependent)
+		}
+	}
+	for module := range changed {
+		collect(module)
+	}
+	var result []string
+	for _, module := range w.buildOrder {
+		if affected[module] {
+			result = append(result, module)
+		}
+	}
+	return result
+}
+
+func (w *moduleWatcher) getModulesNames() []string {
+	names := make([]string, len(w.modules))
+	for i, m := range w.modules {
+		names[i] = m.name
+	}
+	return names
+}
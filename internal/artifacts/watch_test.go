@@ -0,0 +1,286 @@
+package artifacts
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
+	"github.com/SAP/cloud-mta/mta"
+)
+
+const watchMtaYaml = `ID: mta
+_schema-version: '3.1'
+version: 0.0.1
+
+modules:
+  - name: a
+    type: custom
+    path: a
+    build-parameters:
+      builder: custom
+      commands:
+        - sh -c 'echo built > built.txt'
+      ignore: ["node_modules/", "built.txt"]
+  - name: b
+    type: custom
+    path: b
+    build-parameters:
+      builder: custom
+      commands: []
+      build-artifact-name: b
+      requires:
+        - name: a
+  - name: c
+    type: custom
+    path: c
+    build-parameters:
+      builder: custom
+      commands: []
+  - name: d
+    type: custom
+    build-parameters:
+      no-source: true
+`
+
+var _ = Describe("Watch", func() {
+
+	var projectPath string
+
+	BeforeEach(func() {
+		projectPath = getTestPath("result", "watchproject")
+		for _, module := range []string{"a", "b", "c"} {
+			Ω(dir.CreateDirIfNotExist(filepath.Join(projectPath, module))).Should(Succeed())
+			Ω(ioutil.WriteFile(filepath.Join(projectPath, module, "index.js"), []byte(module), 0644)).Should(Succeed())
+		}
+		Ω(dir.CreateDirIfNotExist(filepath.Join(projectPath, "a", "node_modules"))).Should(Succeed())
+		Ω(ioutil.WriteFile(filepath.Join(projectPath, "mta.yaml"), []byte(watchMtaYaml), 0644)).Should(Succeed())
+	})
+
+	AfterEach(func() {
+		Ω(os.RemoveAll(getResultPath())).Should(Succeed())
+	})
+
+	getWatcher := func(modules ...string) (*moduleWatcher, error) {
+		loc, err := dir.Location(projectPath, "mta.yaml", "", dir.Dev, nil, os.Getwd)
+		Ω(err).Should(Succeed())
+		mtaObj, err := loc.ParseFile()
+		Ω(err).Should(Succeed())
+		return newModuleWatcher(loc, mtaObj, modules, []string{getTestPath("result", "target")}, 10*time.Millisecond, 50*time.Millisecond)
+	}
+
+	Describe("newModuleWatcher", func() {
+		It("watches all the modules with sources in their build order when no modules are selected", func() {
+			w, err := getWatcher()
+			Ω(err).Should(Succeed())
+			Ω(w.getModulesNames()).Should(Equal([]string{"a", "c", "b"}))
+			Ω(w.modules[0].path).Should(Equal(filepath.Join(projectPath, "a")))
+			Ω(w.modules[0].ignore).Should(Equal([]string{"node_modules/", "built.txt"}))
+			Ω(w.dependents).Should(Equal(map[string][]string{"a": {"b"}}))
+		})
+		It("watches the selected modules", func() {
+			w, err := getWatcher("c", "a")
+			Ω(err).Should(Succeed())
+			Ω(w.getModulesNames()).Should(Equal([]string{"a", "c"}))
+		})
+		It("collects the dependents of the selected modules from all the modules", func() {
+			w, err := getWatcher("a")
+			Ω(err).Should(Succeed())
+			Ω(w.getModulesNames()).Should(Equal([]string{"a"}))
+			Ω(w.buildOrder).Should(Equal([]string{"a", "c", "b"}))
+			Ω(w.dependents).Should(Equal(map[string][]string{"a": {"b"}}))
+		})
+		It("fails when the selected module is not defined", func() {
+			_, err := getWatcher("e")
+			Ω(err).Should(HaveOccurred())
+		})
+		It("fails when the selected module has no path", func() {
+			_, err := getWatcher("d")
+			Ω(err).Should(MatchError(`could not build the "d" module because the mandatory "path" property is missing or empty`))
+		})
+	})
+
+	Describe("getWatchedModuleOutputs", func() {
+		It("provides the build result, the installed dependencies and the target folders of the required modules", func() {
+			module := &mta.Module{Name: "m", Type: "custom", Path: "m", BuildParams: map[string]interface{}{
+				"builder":      "custom",
+				"commands":     []interface{}{},
+				"build-result": "dist/*.zip",
+				"requires": []interface{}{
+					map[string]interface{}{"name": "a", "target-path": "public/"},
+					map[string]interface{}{"name": "b"},
+				},
+			}}
+			params, err := buildparams.Decode(module)
+			Ω(err).Should(Succeed())
+			outputs, err := getWatchedModuleOutputs(module, params)
+			Ω(err).Should(Succeed())
+			Ω(outputs).Should(Equal([]string{"node_modules", "dist/*.zip", "public"}))
+			Ω(isBuildOutput("dist/m.zip", outputs)).Should(BeTrue())
+			Ω(isBuildOutput("public/css/main.css", outputs)).Should(BeTrue())
+			Ω(isBuildOutput("node_modules/dep/index.js", outputs)).Should(BeTrue())
+			Ω(isBuildOutput("dist/index.js", outputs)).Should(BeFalse())
+			Ω(isBuildOutput("index.js", outputs)).Should(BeFalse())
+		})
+		It("does not provide the module folder as the build result", func() {
+			w, err := getWatcher("a")
+			Ω(err).Should(Succeed())
+			Ω(w.modules[0].outputs).Should(Equal([]string{"node_modules"}))
+		})
+	})
+
+	Describe("getAffectedModules", func() {
+		It("provides the changed modules and their dependents in the build order", func() {
+			w, err := getWatcher()
+			Ω(err).Should(Succeed())
+			Ω(w.getAffectedModules(map[string]bool{"c": true, "a": true})).Should(Equal([]string{"a", "c", "b"}))
+			Ω(w.getAffectedModules(map[string]bool{"b": true})).Should(Equal([]string{"b"}))
+		})
+		It("provides the dependents that are not watched", func() {
+			w, err := getWatcher("a", "c")
+			Ω(err).Should(Succeed())
+			Ω(w.getAffectedModules(map[string]bool{"a": true})).Should(Equal([]string{"a", "b"}))
+			Ω(w.getAffectedModules(map[string]bool{"c": true})).Should(Equal([]string{"c"}))
+		})
+	})
+
+	Describe("run", func() {
+		var (
+			w       *moduleWatcher
+			stop    chan struct{}
+			done    chan error
+			mutex   sync.Mutex
+			built   [][]string
+			onBuild func(modules []string)
+		)
+
+		getBuilds := func() [][]string {
+			mutex.Lock()
+			defer mutex.Unlock()
+			return append([][]string{}, built...)
+		}
+
+		BeforeEach(func() {
+			var err error
+			w, err = getWatcher()
+			Ω(err).Should(Succeed())
+			built = nil
+			onBuild = nil
+			w.build = func(modules []string) error {
+				mutex.Lock()
+				defer mutex.Unlock()
+				built = append(built, modules)
+				if onBuild != nil {
+					onBuild(modules)
+				}
+				return nil
+			}
+			stop = make(chan struct{})
+			done = make(chan error)
+			go func() {
+				done <- w.run(stop)
+			}()
+			// wait for the initial state of the modules
+			Eventually(func() map[string]string { return w.modules[2].state }).ShouldNot(BeNil())
+		})
+
+		AfterEach(func() {
+			close(stop)
+			Eventually(done).Should(Receive(BeNil()))
+		})
+
+		It("rebuilds the changed module and its dependents once after the rapid changes", func() {
+			for i := 0; i < 3; i++ {
+				Ω(ioutil.WriteFile(filepath.Join(projectPath, "a", "index.js"), []byte{byte('a' + i)}, 0644)).Should(Succeed())
+			}
+			Ω(ioutil.WriteFile(filepath.Join(projectPath, "a", "new.js"), []byte("new"), 0644)).Should(Succeed())
+			Eventually(getBuilds).Should(Equal([][]string{{"a", "b"}}))
+			Consistently(getBuilds, 200*time.Millisecond).Should(HaveLen(1))
+		})
+		It("rebuilds the module when its file is removed", func() {
+			Ω(os.Remove(filepath.Join(projectPath, "c", "index.js"))).Should(Succeed())
+			Eventually(getBuilds).Should(Equal([][]string{{"c"}}))
+		})
+		It("rebuilds the module changed during the build of other modules and skips the changes of the built modules", func() {
+			mutex.Lock()
+			onBuild = func(modules []string) {
+				if modules[0] == "a" {
+					Ω(ioutil.WriteFile(filepath.Join(projectPath, "a", "output.js"), []byte("output"), 0644)).Should(Succeed())
+					Ω(ioutil.WriteFile(filepath.Join(projectPath, "c", "index.js"), []byte("edited"), 0644)).Should(Succeed())
+				}
+			}
+			mutex.Unlock()
+			Ω(ioutil.WriteFile(filepath.Join(projectPath, "a", "index.js"), []byte("edited"), 0644)).Should(Succeed())
+			Eventually(getBuilds).Should(Equal([][]string{{"a", "b"}, {"c"}}))
+			Consistently(getBuilds, 200*time.Millisecond).Should(HaveLen(2))
+		})
+		It("rebuilds the module when its source is edited during its build", func() {
+			mutex.Lock()
+			onBuild = func(modules []string) {
+				if len(built) == 1 {
+					Ω(ioutil.WriteFile(filepath.Join(projectPath, "a", "index.js"), []byte("edited during the build"), 0644)).Should(Succeed())
+				}
+			}
+			mutex.Unlock()
+			Ω(ioutil.WriteFile(filepath.Join(projectPath, "a", "index.js"), []byte("edited"), 0644)).Should(Succeed())
+			Eventually(getBuilds).Should(Equal([][]string{{"a", "b"}, {"a", "b"}}))
+			Consistently(getBuilds, 200*time.Millisecond).Should(HaveLen(2))
+		})
+		It("rebuilds the module once when each build rewrites its existing file", func() {
+			mutex.Lock()
+			onBuild = func(modules []string) {
+				Ω(ioutil.WriteFile(filepath.Join(projectPath, "a", "index.js"), []byte(time.Now().String()), 0644)).Should(Succeed())
+			}
+			mutex.Unlock()
+			Ω(ioutil.WriteFile(filepath.Join(projectPath, "a", "index.js"), []byte("edited"), 0644)).Should(Succeed())
+			Eventually(getBuilds).Should(Equal([][]string{{"a", "b"}, {"a", "b"}}))
+			Consistently(getBuilds, 200*time.Millisecond).Should(HaveLen(2))
+		})
+		It("does not rebuild the module when the ignored files change", func() {
+			Ω(ioutil.WriteFile(filepath.Join(projectPath, "a", "node_modules", "dep.js"), []byte("dep"), 0644)).Should(Succeed())
+			Ω(ioutil.WriteFile(filepath.Join(projectPath, "a", "built.txt"), []byte("built"), 0644)).Should(Succeed())
+			Consistently(getBuilds, 200*time.Millisecond).Should(BeEmpty())
+		})
+	})
+
+	Describe("ExecuteWatch", func() {
+		It("builds the changed module and its dependents and stops when the stop channel is closed", func() {
+			stop := make(chan struct{})
+			done := make(chan error)
+			go func() {
+				done <- ExecuteWatch(projectPath, "mta.yaml", getTestPath("result", "target"), nil, []string{"a"}, false, "cf",
+					10*time.Millisecond, 50*time.Millisecond, stop, os.Getwd)
+			}()
+			builtFile := filepath.Join(projectPath, "a", "built.txt")
+			// the file is changed until the watcher reads the initial state of the module and rebuilds it
+			Eventually(func() bool {
+				_ = ioutil.WriteFile(filepath.Join(projectPath, "a", "index.js"), []byte(time.Now().String()), 0644)
+				_, err := os.Stat(builtFile)
+				return err == nil
+			}, 5*time.Second, 100*time.Millisecond).Should(BeTrue())
+			Eventually(getTestPath("result", "target", "data.zip"), 5*time.Second).Should(BeAnExistingFile())
+			// the module that requires the changed module is rebuilt too, although it is not watched
+			Eventually(getTestPath("result", "target", "b.zip"), 5*time.Second).Should(BeAnExistingFile())
+			close(stop)
+			Eventually(done).Should(Receive(BeNil()))
+		})
+		It("fails when the selected module is not defined", func() {
+			Ω(ExecuteWatch(projectPath, "mta.yaml", "", nil, []string{"e"}, false, "cf",
+				0, 0, make(chan struct{}), os.Getwd)).Should(HaveOccurred())
+		})
+		It("fails when the platform is invalid and the deployment descriptor is generated", func() {
+			Ω(ExecuteWatch(projectPath, "mta.yaml", "", nil, []string{"a"}, true, "xyz",
+				0, 0, make(chan struct{}), os.Getwd)).Should(HaveOccurred())
+		})
+		It("fails when the MTA file is missing", func() {
+			Ω(ExecuteWatch(projectPath, "mta1.yaml", "", nil, []string{"a"}, false, "cf",
+				0, 0, make(chan struct{}), os.Getwd)).Should(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,95 @@
+package buildconfig
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/pkg/errors"
+)
+
+var profileNameRegexp = regexp.MustCompile(`^[A-Za-z0-9_.\-]+$`)
+
+// config - the configuration of the build set by the global flags of the mbt commands
+var config struct {
+	// profile - the build profile; the elements tied to other profiles are excluded from the build
+	profile string
+	// signKeyPath - the absolute path of the file with the key that signs the MTA archives; empty if the key is not provided by a file
+	signKeyPath string
+	// noCache - the build cache of the modules is not used; the modules are always built
+	noCache bool
+}
+
+// Reset - restores the default configuration of the build
+func Reset() {
+	config.profile = ""
+	config.signKeyPath = ""
+	config.noCache = false
+}
+
+// SetProfile - sets the build profile
+func SetProfile(profile string) error {
+	if profile != "" && !profileNameRegexp.MatchString(profile) {
+		return errors.Errorf(wrongProfileMsg, profile)
+	}
+	config.profile = profile
+	return nil
+}
+
+// Profile - gets the build profile; returns an empty string if the profile is not set
+func Profile() string {
+	return config.profile
+}
+
+// InProfile - checks if the element tied to the profiles is included in the build:
+// the element without profiles is always included, the element with profiles only when the build profile is one of them;
+// the profiles are defined as a list or as a single value
+func InProfile(profiles interface{}) bool {
+	if profiles == nil {
+		return true
+	}
+	values, ok := profiles.([]interface{})
+	if !ok {
+		values = []interface{}{profiles}
+	}
+	names := make([]string, len(values))
+	for i, value := range values {
+		names[i] = fmt.Sprint(value)
+	}
+	return InProfiles(names)
+}
+
+// InProfiles - checks if the element tied to the profiles is included in the build;
+// the element with the nil profiles is always included
+func InProfiles(profiles []string) bool {
+	if profiles == nil {
+		return true
+	}
+	for _, profile := range profiles {
+		if config.profile != "" && profile == config.profile {
+			return true
+		}
+	}
+	return false
+}
+
+// SetSignKeyPath - sets the absolute path of the file with the key that signs the MTA archives,
+// so that it is passed to the child mbt processes
+func SetSignKeyPath(path string) {
+	config.signKeyPath = path
+}
+
+// SignKeyPath - gets the absolute path of the file with the key that signs the MTA archives;
+// returns an empty string if the key is not provided by a file
+func SignKeyPath() string {
+	return config.signKeyPath
+}
+
+// SetNoCache - disables or enables the build cache of the modules
+func SetNoCache(noCache bool) {
+	config.noCache = noCache
+}
+
+// NoCache - checks if the build cache of the modules is disabled
+func NoCache() bool {
+	return config.noCache
+}
@@ -0,0 +1,5 @@
+package buildconfig
+
+const (
+	wrongProfileMsg = `the "%s" profile is invalid; the profile name can contain only letters, digits, "_", "-" and "."`
+)
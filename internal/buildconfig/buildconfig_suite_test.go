@@ -0,0 +1,17 @@
+package buildconfig
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestBuildConfig(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "BuildConfig Suite")
+}
+
+var _ = AfterEach(func() {
+	Reset()
+})
@@ -0,0 +1,47 @@
+package buildconfig
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Profile", func() {
+
+	It("sets the build profile", func() {
+		Ω(SetProfile("trial-1.0_a")).Should(Succeed())
+		Ω(Profile()).Should(Equal("trial-1.0_a"))
+	})
+
+	It("fails on the invalid profile", func() {
+		err := SetProfile("trial prod")
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(Equal(`the "trial prod" profile is invalid; the profile name can contain only letters, digits, "_", "-" and "."`))
+		Ω(Profile()).Should(BeEmpty())
+	})
+
+	DescribeTable("InProfile", func(profile string, profiles interface{}, expected bool) {
+		Ω(SetProfile(profile)).Should(Succeed())
+		Ω(InProfile(profiles)).Should(Equal(expected))
+	},
+		Entry("element without profiles and without build profile", "", nil, true),
+		Entry("element without profiles", "trial", nil, true),
+		Entry("element with profiles and without build profile", "", []interface{}{"trial"}, false),
+		Entry("element with the build profile", "trial", []interface{}{"prod", "trial"}, true),
+		Entry("element with other profiles", "dev", []interface{}{"prod", "trial"}, false),
+		Entry("element with empty profiles", "trial", []interface{}{}, false),
+		Entry("element with the single build profile", "trial", "trial", true),
+	)
+})
+
+var _ = Describe("Reset", func() {
+	It("restores the default configuration", func() {
+		Ω(SetProfile("trial")).Should(Succeed())
+		SetSignKeyPath("/keys/key.pem")
+		SetNoCache(true)
+		Reset()
+		Ω(Profile()).Should(BeEmpty())
+		Ω(SignKeyPath()).Should(BeEmpty())
+		Ω(NoCache()).Should(BeFalse())
+	})
+})
@@ -3,85 +3,26 @@ package buildops
 import (
 	"os"
 	"path/filepath"
-	"reflect"
 	"strings"
 
 	"github.com/pkg/errors"
 
 	"github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildconfig"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
 	"github.com/SAP/cloud-mta-build-tool/internal/commands"
 	"github.com/SAP/cloud-mta/mta"
 )
 
 const (
 	// SupportedPlatformsParam - name of build-params property for supported platforms
-	SupportedPlatformsParam = "supported-platforms"
+	SupportedPlatformsParam = buildparams.SupportedPlatformsParam
 
 	// ModuleArtifactDefaultName - the default name of the build artifact.
 	// It can be changed using properties like build-result or build-artifact-name in the build parameters.
 	ModuleArtifactDefaultName = "data.zip"
-	builderParam              = "builder"
-	requiresParam             = "requires"
-	buildResultParam          = "build-result"
-	nameParam                 = "name"
-	artifactsParam            = "artifacts"
-	buildArtifactNameParam    = "build-artifact-name"
-	targetPathParam           = "target-path"
-	noSourceParam             = "no-source"
 )
 
-// BuildRequires - build requires section.
-type BuildRequires struct {
-	Name       string   `yaml:"name,omitempty"`
-	Artifacts  []string `yaml:"artifacts,omitempty"`
-	TargetPath string   `yaml:"target-path,omitempty"`
-}
-
-// GetBuildRequires - gets Requires property of module's build-params property
-// as generic property and converts it to slice of BuildRequires structures
-func GetBuildRequires(module *mta.Module) []BuildRequires {
-	// check existence of module's build-params.require property
-	if module.BuildParams != nil && module.BuildParams[requiresParam] != nil {
-		requires := module.BuildParams[requiresParam].([]interface{})
-		buildRequires := []BuildRequires{}
-		// go through requirements
-		for _, reqI := range requires {
-			// cast requirement to generic map
-			reqMap, ok := reqI.(map[string]interface{})
-			if !ok {
-				reqMap = commands.ConvertMap(reqI.(map[interface{}]interface{}))
-			}
-			// init resulting typed requirement
-			reqStr := BuildRequires{
-				Name:       getStrParam(reqMap, nameParam),
-				Artifacts:  []string{},
-				TargetPath: getStrParam(reqMap, targetPathParam),
-			}
-			// fill Artifacts field of resulting requirement
-			if reqMap[artifactsParam] == nil {
-				reqStr.Artifacts = nil
-			} else {
-				for _, artifact := range reqMap[artifactsParam].([]interface{}) {
-					reqStr.Artifacts = append(reqStr.Artifacts, []string{artifact.(string)}...)
-				}
-			}
-			// add typed requirement to result
-			buildRequires = append(buildRequires, []BuildRequires{reqStr}...)
-
-		}
-		return buildRequires
-	}
-	return nil
-}
-
-// getStrParam - get string parameter from the map
-func getStrParam(m map[string]interface{}, param string) string {
-	if m[param] == nil {
-		return ""
-	}
-	return m[param].(string)
-}
-
 // Order of modules building is done according to the dependencies defined in build parameters.
 // In case of problems in this definition build process should not start and corresponding error must be provided.
 // Possible problems:
@@ -89,7 +30,8 @@ func getStrParam(m map[string]interface{}, param string) string {
 // 2.	Dependency on not defined module
 
 // GetRequiresArtifacts returns the source path, target path and patterns of files and folders to copy from a module's requires section
-func GetRequiresArtifacts(ep dir.ISourceModule, mta *mta.MTA, requires *BuildRequires, moduleName string, resolveBuildResult bool) (source string, target string, patterns []string, err error) {
+func GetRequiresArtifacts(ep dir.ISourceModule, mta *mta.MTA, params buildparams.Modules, requires *buildparams.Requires, moduleName string,
+	resolveBuildResult bool) (source string, target string, patterns []string, err error) {
 	// validate module names - both in process and required
 	module, err := mta.GetModuleByName(moduleName)
 	if err != nil {
@@ -101,13 +43,13 @@ func GetRequiresArtifacts(ep dir.ISourceModule, mta *mta.MTA, requires *BuildReq
 		return "", "", nil, errors.Wrapf(err, reqFailedOnModuleGetMsg, moduleName, requires.Name, requires.Name)
 	}
 
-	_, defaultBuildResult, err := commands.CommandProvider(*requiredModule)
+	_, defaultBuildResult, err := commands.CommandProvider(*requiredModule, params[requiredModule.Name])
 	if err != nil {
 		return "", "", nil, errors.Wrapf(err, reqFailedOnCommandsGetMsg, moduleName, requires.Name, requires.Name)
 	}
 
 	// Build paths for artifacts copying
-	sourcePath, err := GetModuleSourceArtifactPath(ep, false, requiredModule, defaultBuildResult, resolveBuildResult)
+	sourcePath, err := GetModuleSourceArtifactPath(ep, false, requiredModule, params[requiredModule.Name], defaultBuildResult, resolveBuildResult)
 	if err != nil {
 		return "", "", nil, errors.Wrapf(err, reqFailedOnBuildResultMsg, moduleName, requires.Name)
 	}
@@ -116,33 +58,31 @@ func GetRequiresArtifacts(ep dir.ISourceModule, mta *mta.MTA, requires *BuildReq
 }
 
 // ProcessRequirements - Processes build requirement of module (using moduleName).
-func ProcessRequirements(ep dir.ISourceModule, mta *mta.MTA, requires *BuildRequires, moduleName string) error {
-	sourcePath, targetPath, artifacts, err := GetRequiresArtifacts(ep, mta, requires, moduleName, true)
+func ProcessRequirements(ep dir.ISourceModule, mta *mta.MTA, params buildparams.Modules, requires *buildparams.Requires, moduleName string) error {
+	sourcePath, targetPath, artifacts, err := GetRequiresArtifacts(ep, mta, params, requires, moduleName, true)
 	if err != nil {
 		return err
 	}
 	// execute copy of artifacts
-	err = dir.CopyByPatterns(sourcePath, targetPath, artifacts)
+	err = dir.CopyByPatternsWithOptions(sourcePath, targetPath, artifacts, requires.Exclude, requires.Rename, requires.Extract)
 	if err != nil {
 		return errors.Wrapf(err, reqFailedOnCopyMsg, moduleName, requires.Name)
 	}
 	return nil
 }
 
-// GetModuleSourceArtifactPath - get the module's artifact that has to be archived in the mtar, from the project sources
-func GetModuleSourceArtifactPath(loc dir.ISourceModule, depDesc bool, module *mta.Module, defaultBuildResult string, resolveBuildResult bool) (path string, e error) {
+// GetModuleSourceArtifactPath - get the module's artifact that has to be archived in the mtar, from the project sources;
+// the build parameters of the module are not used for the deployment descriptor
+func GetModuleSourceArtifactPath(loc dir.ISourceModule, depDesc bool, module *mta.Module, params *buildparams.Module,
+	defaultBuildResult string, resolveBuildResult bool) (path string, e error) {
 	if module.Path == "" {
 		return "", nil
 	}
 	path = loc.GetSourceModuleDir(module.Path)
 	if !depDesc {
 		buildResult := defaultBuildResult
-		var ok bool
-		if module.BuildParams != nil && module.BuildParams[buildResultParam] != nil {
-			buildResult, ok = module.BuildParams[buildResultParam].(string)
-			if !ok {
-				return "", errors.Errorf(WrongBuildResultMsg, module.BuildParams[buildResultParam], module.Name)
-			}
+		if params.BuildResult != "" {
+			buildResult = params.BuildResult
 		}
 		if buildResult != "" {
 			path = filepath.Join(path, buildResult)
@@ -182,8 +122,8 @@ func guessIfArchive(path string) bool {
 }
 
 // GetModuleTargetArtifactPath - get the path to where the module's artifact should be created in the temp folder, from which it's archived in the mtar
-func GetModuleTargetArtifactPath(moduleLoc dir.IModule, depDesc bool, module *mta.Module, defaultBuildResult string,
-	resolveBuildResult bool) (path string, toArchive bool, e error) {
+func GetModuleTargetArtifactPath(moduleLoc dir.IModule, depDesc bool, module *mta.Module, params *buildparams.Module,
+	defaultBuildResult string, resolveBuildResult bool) (path string, toArchive bool, e error) {
 
 	if module.Path == "" {
 		return "", false, nil
@@ -191,7 +131,7 @@ func GetModuleTargetArtifactPath(moduleLoc dir.IModule, depDesc bool, module *mt
 	if depDesc {
 		path = filepath.Join(moduleLoc.GetTargetModuleDir(module.Path))
 	} else {
-		moduleSourceArtifactPath, err := GetModuleSourceArtifactPath(moduleLoc, depDesc, module, defaultBuildResult, resolveBuildResult)
+		moduleSourceArtifactPath, err := GetModuleSourceArtifactPath(moduleLoc, depDesc, module, params, defaultBuildResult, resolveBuildResult)
 		if err != nil {
 			return "", false, err
 		}
@@ -199,10 +139,7 @@ func GetModuleTargetArtifactPath(moduleLoc dir.IModule, depDesc bool, module *mt
 		if err != nil {
 			return "", false, errors.Wrapf(err, wrongPathMsg, moduleSourceArtifactPath)
 		}
-		artifactName, artifactExt, err := getArtifactInfo(isArchive, module, moduleSourceArtifactPath)
-		if err != nil {
-			return "", false, err
-		}
+		artifactName, artifactExt := getArtifactInfo(isArchive, params, moduleSourceArtifactPath)
 		toArchive = !isArchive
 
 		artifactRelPath, err := moduleLoc.GetSourceModuleArtifactRelPath(module.Path, moduleSourceArtifactPath)
@@ -214,8 +151,7 @@ func GetModuleTargetArtifactPath(moduleLoc dir.IModule, depDesc bool, module *mt
 	return path, toArchive, nil
 }
 
-func getArtifactInfo(isArchive bool, module *mta.Module, moduleSourceArtifactPath string) (artifactName, artifactExt string, err error) {
-	var ok bool
+func getArtifactInfo(isArchive bool, params *buildparams.Module, moduleSourceArtifactPath string) (artifactName, artifactExt string) {
 	var artifactFullName string
 	if isArchive {
 		artifactFullName = filepath.Base(moduleSourceArtifactPath)
@@ -224,17 +160,14 @@ func getArtifactInfo(isArchive bool, module *mta.Module, moduleSourceArtifactPat
 	}
 	artifactExt = filepath.Ext(artifactFullName)
 	artifactName = artifactFullName[0 : len(artifactFullName)-len(artifactExt)]
-	if module.BuildParams != nil && module.BuildParams[buildArtifactNameParam] != nil {
-		artifactName, ok = module.BuildParams[buildArtifactNameParam].(string)
-		if !ok {
-			return "", "", errors.Errorf(WrongBuildArtifactNameMsg, module.BuildParams[buildArtifactNameParam], module.Name)
-		}
+	if params.BuildArtifactName != "" {
+		artifactName = params.BuildArtifactName
 	}
 	return
 }
 
 // getRequiredTargetPath - provides path of required artifacts
-func getRequiredTargetPath(ep dir.ISourceModule, module *mta.Module, requires *BuildRequires) string {
+func getRequiredTargetPath(ep dir.ISourceModule, module *mta.Module, requires *buildparams.Requires) string {
 	path := ep.GetSourceModuleDir(module.Path)
 	if requires.TargetPath != "" {
 		// if target folder provided - artifacts will be saved in the sub-folder of the module folder
@@ -246,34 +179,29 @@ func getRequiredTargetPath(ep dir.ISourceModule, module *mta.Module, requires *B
 // PlatformDefined - if platform defined
 // If platforms parameter not defined then no limitations on platform, method returns true
 // Non empty list of platforms has to contain specific platform
-func PlatformDefined(module *mta.Module, platform string) bool {
-	if module.BuildParams == nil || module.BuildParams[SupportedPlatformsParam] == nil {
+func PlatformDefined(params *buildparams.Module, platform string) bool {
+	if params.SupportedPlatforms == nil {
 		return true
 	}
-	supportedPlatforms := module.BuildParams[SupportedPlatformsParam]
-	if reflect.TypeOf(supportedPlatforms).Elem().Kind() == reflect.String {
-		sp := supportedPlatforms.([]string)
-		for _, p := range sp {
-			if strings.ToLower(p) == platform {
-				return true
-			}
-		}
-		return false
-	}
-	sp := supportedPlatforms.([]interface{})
-	for _, p := range sp {
-		if strings.ToLower(p.(string)) == platform {
+	for _, p := range params.SupportedPlatforms {
+		if strings.ToLower(p) == platform {
 			return true
 		}
 	}
 	return false
 }
 
-// IfNoSource - checks if "no-source" build parameter defined and set to "true"
-func IfNoSource(module *mta.Module) bool {
-	if module.BuildParams != nil && module.BuildParams[noSourceParam] != nil {
-		noSource, ok := module.BuildParams[noSourceParam].(bool)
-		return ok && noSource
+// ProfileDefined - checks if the module is included in the build profile;
+// the module without the "profiles" build parameter is included in all the builds
+func ProfileDefined(params *buildparams.Module) bool {
+	return buildconfig.InProfiles(params.Profiles)
+}
+
+// ResourceProfileDefined - checks if the resource is included in the build profile;
+// resources have no build parameters, so the resource is tied to the build profiles by the "profiles" parameter
+func ResourceProfileDefined(resource *mta.Resource) bool {
+	if resource.Parameters == nil {
+		return true
 	}
-	return false
+	return buildconfig.InProfile(resource.Parameters[buildparams.ProfilesParam])
 }
@@ -10,6 +10,8 @@ import (
 	. "github.com/onsi/gomega"
 
 	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildconfig"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
 	"github.com/SAP/cloud-mta-build-tool/internal/commands"
 	"github.com/SAP/cloud-mta/mta"
 )
@@ -18,7 +20,7 @@ var _ = Describe("BuildParams", func() {
 
 	var _ = DescribeTable("valid cases", func(module *mta.Module, expected string) {
 		loc := &dir.Loc{SourcePath: getTestPath("mtahtml5")}
-		path, err := GetModuleSourceArtifactPath(loc, false, module, "", true)
+		path, err := GetModuleSourceArtifactPath(loc, false, module, decode(module), "", true)
 		Ω(err).Should(Succeed())
 		Ω(path).Should(Equal(expected))
 	},
@@ -26,24 +28,24 @@ var _ = Describe("BuildParams", func() {
 		Entry("Explicit Build Results Path",
 			&mta.Module{
 				Path:        "testapp",
-				BuildParams: map[string]interface{}{buildResultParam: filepath.Join("webapp", "controller")},
+				BuildParams: map[string]interface{}{"build-result": filepath.Join("webapp", "controller")},
 			}, getTestPath("mtahtml5", "testapp", "webapp", "controller")))
 
 	var _ = Describe("GetBuildResultsPath", func() {
 		It("empty path, no build results", func() {
 			module := &mta.Module{}
 			buildResult, _ := GetModuleSourceArtifactPath(
-				&dir.Loc{SourcePath: getTestPath("testbuildparams", "ui2", "deep", "folder")}, false, module, "", true)
+				&dir.Loc{SourcePath: getTestPath("testbuildparams", "ui2", "deep", "folder")}, false, module, decode(module), "", true)
 			Ω(buildResult).Should(Equal(""))
 		})
 
 		It("build results - pattern", func() {
 			module := &mta.Module{
 				Path:        "inui2",
-				BuildParams: map[string]interface{}{buildResultParam: "*.txt"},
+				BuildParams: map[string]interface{}{"build-result": "*.txt"},
 			}
 			buildResult, _ := GetModuleSourceArtifactPath(
-				&dir.Loc{SourcePath: getTestPath("testbuildparams", "ui2", "deep", "folder")}, false, module, "", true)
+				&dir.Loc{SourcePath: getTestPath("testbuildparams", "ui2", "deep", "folder")}, false, module, decode(module), "", true)
 			Ω(buildResult).Should(HaveSuffix("anotherfile.txt"))
 		})
 
@@ -52,7 +54,7 @@ var _ = Describe("BuildParams", func() {
 				Path: "inui2",
 			}
 			buildResult, _ := GetModuleSourceArtifactPath(
-				&dir.Loc{SourcePath: getTestPath("testbuildparams", "ui2", "deep", "folder")}, false, module, "*.txt", true)
+				&dir.Loc{SourcePath: getTestPath("testbuildparams", "ui2", "deep", "folder")}, false, module, decode(module), "*.txt", true)
 			Ω(buildResult).Should(HaveSuffix("anotherfile.txt"))
 		})
 		It("default build results - no file answers pattern", func() {
@@ -60,30 +62,30 @@ var _ = Describe("BuildParams", func() {
 				Path: "inui2",
 			}
 			_, err := GetModuleSourceArtifactPath(
-				&dir.Loc{SourcePath: getTestPath("testbuildparams", "ui2", "deep", "folder")}, false, module, "b*.txt", true)
+				&dir.Loc{SourcePath: getTestPath("testbuildparams", "ui2", "deep", "folder")}, false, module, decode(module), "b*.txt", true)
 			Ω(err).Should(HaveOccurred())
 		})
 	})
 
-	var _ = DescribeTable("getRequiredTargetPath", func(requires BuildRequires, module mta.Module, expected string) {
+	var _ = DescribeTable("getRequiredTargetPath", func(requires buildparams.Requires, module mta.Module, expected string) {
 		Ω(getRequiredTargetPath(&dir.Loc{}, &module, &requires)).Should(HaveSuffix(expected))
 	},
-		Entry("Implicit Target Path", BuildRequires{}, mta.Module{Path: "mPath"}, "mPath"),
-		Entry("Explicit Target Path", BuildRequires{TargetPath: "artifacts"}, mta.Module{Path: "mPath"}, filepath.Join("mPath", "artifacts")))
+		Entry("Implicit Target Path", buildparams.Requires{}, mta.Module{Path: "mPath"}, "mPath"),
+		Entry("Explicit Target Path", buildparams.Requires{TargetPath: "artifacts"}, mta.Module{Path: "mPath"}, filepath.Join("mPath", "artifacts")))
 
 	var _ = Describe("ProcessRequirements", func() {
 		wd, _ := os.Getwd()
 		ep := dir.Loc{SourcePath: filepath.Join(wd, "testdata", "testproject"), TargetPath: filepath.Join(wd, "testdata", "result")}
-		require := BuildRequires{
+		require := buildparams.Requires{
 			Name:       "A",
 			TargetPath: "./b_copied_artifacts",
 		}
-		require1 := BuildRequires{
+		require1 := buildparams.Requires{
 			Name:       "C",
 			TargetPath: "./b_copied_artifacts",
 		}
-		reqs := []BuildRequires{require}
-		reqs1 := []BuildRequires{require1}
+		reqs := []buildparams.Requires{require}
+		reqs1 := []buildparams.Requires{require1}
 		mtaObj := mta.MTA{
 			Modules: []*mta.Module{
 				{
@@ -94,36 +96,43 @@ var _ = Describe("BuildParams", func() {
 					Name: "B",
 					Path: "moduleB",
 					BuildParams: map[string]interface{}{
-						requiresParam: reqs,
+						"requires": reqs,
 					},
 				},
 				{
 					Name: "C",
 					Path: "ui5app",
 					BuildParams: map[string]interface{}{
-						buildResultParam: "xxx.xxx",
+						"build-result": "xxx.xxx",
 					},
 				},
 				{
 					Name: "D",
 					Path: "ui5app",
 					BuildParams: map[string]interface{}{
-						requiresParam: reqs1,
+						"requires": reqs1,
 					},
 				},
 			},
 		}
+		// the in-memory requires are not decoded from the MTA file, so the build parameters are provided as is
+		params := buildparams.Modules{
+			"A": {},
+			"B": {Requires: reqs},
+			"C": {BuildResult: "xxx.xxx"},
+			"D": {Requires: reqs1},
+		}
 
 		It("wrong builders configuration", func() {
 			conf := commands.BuilderTypeConfig
 			commands.BuilderTypeConfig = []byte("bad bad bad")
-			Ω(ProcessRequirements(&ep, &mtaObj, &require, "B")).Should(HaveOccurred())
+			Ω(ProcessRequirements(&ep, &mtaObj, params, &require, "B")).Should(HaveOccurred())
 			commands.BuilderTypeConfig = conf
 		})
 
 		It("default build results - no file answers pattern", func() {
 			err := ProcessRequirements(&dir.Loc{SourcePath: getTestPath("testbuildparams", "ui2", "deep", "folder")},
-				&mtaObj, &require1, "D")
+				&mtaObj, params, &require1, "D")
 			Ω(err).Should(HaveOccurred())
 		})
 
@@ -133,7 +142,7 @@ var _ = Describe("BuildParams", func() {
 
 		var _ = DescribeTable("Valid cases", func(artifacts []string, expectedPath string) {
 			require.Artifacts = artifacts
-			Ω(ProcessRequirements(&ep, &mtaObj, &require, "B")).Should(Succeed())
+			Ω(ProcessRequirements(&ep, &mtaObj, params, &require, "B")).Should(Succeed())
 			Ω(filepath.Join(wd, expectedPath)).Should(BeADirectory())
 			Ω(filepath.Join(wd, expectedPath, "webapp", "Component.js")).Should(BeAnExistingFile())
 		},
@@ -141,22 +150,22 @@ var _ = Describe("BuildParams", func() {
 			Entry("Require All - single value", []string{"*"}, filepath.Join("testdata", "testproject", "moduleB", "b_copied_artifacts")),
 			Entry("Require All From Parent", []string{"."}, filepath.Join("testdata", "testproject", "moduleB", "b_copied_artifacts", "ui5app")))
 
-		var _ = DescribeTable("Invalid cases", func(lp *dir.Loc, require BuildRequires, mtaObj mta.MTA, moduleName, buildResult string) {
-			Ω(ProcessRequirements(lp, &mtaObj, &require, moduleName)).Should(HaveOccurred())
+		var _ = DescribeTable("Invalid cases", func(lp *dir.Loc, require buildparams.Requires, mtaObj mta.MTA, moduleName, buildResult string) {
+			Ω(ProcessRequirements(lp, &mtaObj, decodeAll(&mtaObj), &require, moduleName)).Should(HaveOccurred())
 		},
 			Entry("Module not defined",
 				&dir.Loc{},
-				BuildRequires{Name: "A", Artifacts: []string{"*"}, TargetPath: "b_copied_artifacts"},
+				buildparams.Requires{Name: "A", Artifacts: []string{"*"}, TargetPath: "b_copied_artifacts"},
 				mta.MTA{Modules: []*mta.Module{{Name: "A", Path: "ui5app"}, {Name: "B", Path: "moduleB"}}},
 				"C", ""),
 			Entry("Required Module not defined",
 				&dir.Loc{},
-				BuildRequires{Name: "C", Artifacts: []string{"*"}, TargetPath: "b_copied_artifacts"},
+				buildparams.Requires{Name: "C", Artifacts: []string{"*"}, TargetPath: "b_copied_artifacts"},
 				mta.MTA{Modules: []*mta.Module{{Name: "A", Path: "ui5app"}, {Name: "B", Path: "moduleB"}}},
 				"B", ""),
 			Entry("Target path - file",
 				&dir.Loc{SourcePath: getTestPath("testbuildparams")},
-				BuildRequires{Name: "ui1", Artifacts: []string{"*"}, TargetPath: "file.txt"},
+				buildparams.Requires{Name: "ui1", Artifacts: []string{"*"}, TargetPath: "file.txt"},
 				mta.MTA{Modules: []*mta.Module{{Name: "ui1", Path: "ui1"}, {Name: "node", Path: "node"}}},
 				"node", ""))
 
@@ -166,46 +175,44 @@ var _ = Describe("BuildParams", func() {
 var _ = Describe("GetModuleTargetArtifactPath", func() {
 	It("path is empty", func() {
 		loc := dir.Loc{}
-		path, _, err := GetModuleTargetArtifactPath(&loc, false, &mta.Module{}, "", true)
+		path, _, err := GetModuleTargetArtifactPath(&loc, false, &mta.Module{}, &buildparams.Module{}, "", true)
 		Ω(err).Should(Succeed())
 		Ω(path).Should(BeEmpty())
 	})
 	It("fails when path doesn't exist", func() {
 		loc := dir.Loc{SourcePath: getTestPath("mtahtml5"), TargetPath: getTestPath("result")}
-		_, _, err := GetModuleTargetArtifactPath(&loc, false, &mta.Module{Path: "abc"}, "", true)
+		_, _, err := GetModuleTargetArtifactPath(&loc, false, &mta.Module{Path: "abc"}, &buildparams.Module{}, "", true)
 		Ω(err).Should(HaveOccurred())
 	})
 	It("deployment descriptor", func() {
 		loc := dir.Loc{SourcePath: getTestPath("mtahtml5"), TargetPath: getTestPath("result")}
-		path, _, err := GetModuleTargetArtifactPath(&loc, true, &mta.Module{Path: "abc"}, "", true)
+		path, _, err := GetModuleTargetArtifactPath(&loc, true, &mta.Module{Path: "abc"}, nil, "", true)
 		Ω(err).Should(Succeed())
 		Ω(path).Should(Equal(getTestPath("result", ".mtahtml5_mta_build_tmp", "abc")))
 	})
 	It("fails on wrong definition of build result", func() {
-		loc := dir.Loc{SourcePath: getTestPath("mtahtml5"), TargetPath: getTestPath("result")}
 		module := &mta.Module{
 			Name: "web",
 			Path: "webapp",
 			BuildParams: map[string]interface{}{
-				buildResultParam: 1,
+				"build-result": 1,
 			},
 		}
-		_, _, err := GetModuleTargetArtifactPath(&loc, false, module, "", true)
+		_, err := buildparams.Decode(module)
 		Ω(err).Should(HaveOccurred())
-		Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(WrongBuildResultMsg, 1, "web")))
+		Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(buildparams.WrongParamMsg, "build-result", "web", "a string")))
 	})
 	It("fails on wrong definition of build artifact name", func() {
-		loc := dir.Loc{SourcePath: getTestPath("mtahtml5"), TargetPath: getTestPath("result")}
 		module := &mta.Module{
 			Name: "web",
 			Path: filepath.Join("testapp", "webapp"),
 			BuildParams: map[string]interface{}{
-				buildArtifactNameParam: 1,
+				"build-artifact-name": 1,
 			},
 		}
-		_, _, err := GetModuleTargetArtifactPath(&loc, false, module, "", true)
+		_, err := buildparams.Decode(module)
 		Ω(err).Should(HaveOccurred())
-		Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(WrongBuildArtifactNameMsg, 1, "web")))
+		Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(buildparams.WrongParamMsg, "build-artifact-name", "web", "a string")))
 	})
 	It("artifact is a folder", func() {
 		loc := dir.Loc{SourcePath: getTestPath("mtahtml5"), TargetPath: getTestPath("result")}
@@ -213,10 +220,10 @@ var _ = Describe("GetModuleTargetArtifactPath", func() {
 			Name: "web",
 			Path: filepath.Join("testapp", "webapp"),
 			BuildParams: map[string]interface{}{
-				buildArtifactNameParam: "test",
+				"build-artifact-name": "test",
 			},
 		}
-		path, toArchive, err := GetModuleTargetArtifactPath(&loc, false, module, "", true)
+		path, toArchive, err := GetModuleTargetArtifactPath(&loc, false, module, decode(module), "", true)
 		Ω(err).Should(Succeed())
 		Ω(path).Should(Equal(getTestPath("result", ".mtahtml5_mta_build_tmp", "web", "test.zip")))
 		Ω(toArchive).Should(BeTrue())
@@ -227,10 +234,10 @@ var _ = Describe("GetModuleTargetArtifactPath", func() {
 			Name: "web",
 			Path: filepath.Join("testapp", "webapp", "Component.js"),
 			BuildParams: map[string]interface{}{
-				buildArtifactNameParam: "test",
+				"build-artifact-name": "test",
 			},
 		}
-		path, toArchive, err := GetModuleTargetArtifactPath(&loc, false, module, "", true)
+		path, toArchive, err := GetModuleTargetArtifactPath(&loc, false, module, decode(module), "", true)
 		Ω(err).Should(Succeed())
 		Ω(path).Should(Equal(getTestPath("result", ".mtahtml5_mta_build_tmp", "web", "test.zip")))
 		Ω(toArchive).Should(BeTrue())
@@ -241,11 +248,11 @@ var _ = Describe("GetModuleTargetArtifactPath", func() {
 			Name: "web",
 			Path: "testapp",
 			BuildParams: map[string]interface{}{
-				buildResultParam:       filepath.Join("webapp", "controller", "View1.controller.js"),
-				buildArtifactNameParam: "ctrl",
+				"build-result":        filepath.Join("webapp", "controller", "View1.controller.js"),
+				"build-artifact-name": "ctrl",
 			},
 		}
-		path, toArchive, err := GetModuleTargetArtifactPath(&loc, false, module, "", true)
+		path, toArchive, err := GetModuleTargetArtifactPath(&loc, false, module, decode(module), "", true)
 		Ω(err).Should(Succeed())
 		Ω(path).Should(Equal(getTestPath("result", ".mtahtml5_mta_build_tmp", "web", "webapp", "controller", "ctrl.zip")))
 		Ω(toArchive).Should(BeTrue())
@@ -256,10 +263,10 @@ var _ = Describe("GetModuleTargetArtifactPath", func() {
 			Name: "web",
 			Path: filepath.Join("testapp", "webapp", "Component.jar"),
 			BuildParams: map[string]interface{}{
-				buildArtifactNameParam: "test",
+				"build-artifact-name": "test",
 			},
 		}
-		path, toArchive, err := GetModuleTargetArtifactPath(&loc, false, module, "", true)
+		path, toArchive, err := GetModuleTargetArtifactPath(&loc, false, module, decode(module), "", true)
 		Ω(err).Should(Succeed())
 		Ω(path).Should(Equal(getTestPath("result", ".mtahtml5_mta_build_tmp", "web", "test.jar")))
 		Ω(toArchive).Should(BeFalse())
@@ -271,7 +278,7 @@ var _ = Describe("GetModuleTargetArtifactPath", func() {
 			Name: "web",
 			Path: filepath.Join("testapp", "webapp", "*.jar"),
 		}
-		path, toArchive, err := GetModuleTargetArtifactPath(moduleLoc, false, module, "", false)
+		path, toArchive, err := GetModuleTargetArtifactPath(moduleLoc, false, module, decode(module), "", false)
 		Ω(err).Should(Succeed())
 		Ω(path).Should(Equal(getTestPath("result", "*.jar")))
 		Ω(toArchive).Should(BeFalse())
@@ -292,8 +299,8 @@ var _ = Describe("Process complex list of requirements", func() {
 		mtaObj, _ := lp.ParseFile()
 		for _, m := range mtaObj.Modules {
 			if m.Name == "node" {
-				for _, r := range GetBuildRequires(m) {
-					Ω(ProcessRequirements(&lp, mtaObj, &r, "node")).Should(Succeed())
+				for _, r := range decode(m).Requires {
+					Ω(ProcessRequirements(&lp, mtaObj, decodeAll(mtaObj), &r, "node")).Should(Succeed())
 				}
 			}
 		}
@@ -317,14 +324,14 @@ var _ = Describe("PlatformDefined", func() {
 				SupportedPlatformsParam: []string{},
 			},
 		}
-		Ω(PlatformDefined(&m, "cf")).Should(Equal(false))
+		Ω(PlatformDefined(decode(&m), "cf")).Should(Equal(false))
 	})
 	It("All platforms", func() {
 		m := mta.Module{
 			Name:        "x",
 			BuildParams: map[string]interface{}{},
 		}
-		Ω(PlatformDefined(&m, "cf")).Should(Equal(true))
+		Ω(PlatformDefined(decode(&m), "cf")).Should(Equal(true))
 	})
 	It("Matching platform", func() {
 		m := mta.Module{
@@ -333,7 +340,17 @@ var _ = Describe("PlatformDefined", func() {
 				SupportedPlatformsParam: []string{"CF"},
 			},
 		}
-		Ω(PlatformDefined(&m, "cf")).Should(Equal(true))
+		Ω(PlatformDefined(decode(&m), "cf")).Should(Equal(true))
+	})
+	It("Matching platform of the external platforms configuration", func() {
+		m := mta.Module{
+			Name: "x",
+			BuildParams: map[string]interface{}{
+				SupportedPlatformsParam: []interface{}{"cf", "Kyma"},
+			},
+		}
+		Ω(PlatformDefined(decode(&m), "kyma")).Should(Equal(true))
+		Ω(PlatformDefined(decode(&m), "neo")).Should(Equal(false))
 	})
 	It("Not Matching platform", func() {
 		m := mta.Module{
@@ -342,7 +359,7 @@ var _ = Describe("PlatformDefined", func() {
 				SupportedPlatformsParam: []string{"neo"},
 			},
 		}
-		Ω(PlatformDefined(&m, "cf")).Should(Equal(false))
+		Ω(PlatformDefined(decode(&m), "cf")).Should(Equal(false))
 	})
 	It("Matching platform - interface", func() {
 		m := mta.Module{
@@ -351,7 +368,7 @@ var _ = Describe("PlatformDefined", func() {
 				SupportedPlatformsParam: []interface{}{"cf"},
 			},
 		}
-		Ω(PlatformDefined(&m, "cf")).Should(Equal(true))
+		Ω(PlatformDefined(decode(&m), "cf")).Should(Equal(true))
 	})
 	It("Not Matching platform - interface", func() {
 		m := mta.Module{
@@ -360,7 +377,55 @@ var _ = Describe("PlatformDefined", func() {
 				SupportedPlatformsParam: []interface{}{"neo"},
 			},
 		}
-		Ω(PlatformDefined(&m, "cf")).Should(Equal(false))
+		Ω(PlatformDefined(decode(&m), "cf")).Should(Equal(false))
+	})
+	It("Wrong platforms", func() {
+		m := mta.Module{
+			Name: "x",
+			BuildParams: map[string]interface{}{
+				SupportedPlatformsParam: "cf",
+			},
+		}
+		_, err := buildparams.Decode(&m)
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(buildparams.WrongParamMsg, SupportedPlatformsParam, "x", "a sequence of strings")))
+	})
+})
+
+var _ = Describe("ProfileDefined", func() {
+	It("Module without profiles", func() {
+		Ω(buildconfig.SetProfile("trial")).Should(Succeed())
+		Ω(ProfileDefined(decode(&mta.Module{Name: "x"}))).Should(Equal(true))
+	})
+	It("Matching profile", func() {
+		Ω(buildconfig.SetProfile("trial")).Should(Succeed())
+		m := mta.Module{Name: "x", BuildParams: map[string]interface{}{buildparams.ProfilesParam: []interface{}{"trial", "prod"}}}
+		Ω(ProfileDefined(decode(&m))).Should(Equal(true))
+	})
+	It("Not matching profile", func() {
+		Ω(buildconfig.SetProfile("dev")).Should(Succeed())
+		m := mta.Module{Name: "x", BuildParams: map[string]interface{}{buildparams.ProfilesParam: []interface{}{"trial", "prod"}}}
+		Ω(ProfileDefined(decode(&m))).Should(Equal(false))
+	})
+	It("Module with profiles and no build profile", func() {
+		m := mta.Module{Name: "x", BuildParams: map[string]interface{}{buildparams.ProfilesParam: []interface{}{"trial"}}}
+		Ω(ProfileDefined(decode(&m))).Should(Equal(false))
+	})
+	It("Wrong profiles", func() {
+		m := mta.Module{Name: "x", BuildParams: map[string]interface{}{buildparams.ProfilesParam: map[string]interface{}{"trial": true}}}
+		_, err := buildparams.Decode(&m)
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(buildparams.WrongParamMsg, buildparams.ProfilesParam, "x", "a single value or a sequence of values")))
+	})
+	It("Resource without parameters", func() {
+		Ω(ResourceProfileDefined(&mta.Resource{Name: "r"})).Should(Equal(true))
+	})
+	It("Resource with matching and not matching profile", func() {
+		r := mta.Resource{Name: "r", Parameters: map[string]interface{}{buildparams.ProfilesParam: []interface{}{"trial"}}}
+		Ω(buildconfig.SetProfile("trial")).Should(Succeed())
+		Ω(ResourceProfileDefined(&r)).Should(Equal(true))
+		Ω(buildconfig.SetProfile("prod")).Should(Succeed())
+		Ω(ResourceProfileDefined(&r)).Should(Equal(false))
 	})
 })
 
@@ -373,64 +438,60 @@ var _ = Describe("GetBuilder", func() {
 				SupportedPlatformsParam: []string{},
 			},
 		}
-		builder, custom, _, cmds, err := commands.GetBuilder(&m)
+		builder, custom, _, cmds := commands.GetBuilder(&m, decode(&m))
 		Ω(builder).Should(Equal("node-js"))
 		Ω(custom).Should(BeFalse())
 		Ω(cmds).Should(BeNil())
-		Ω(err).Should(Succeed())
 	})
 	It("Builder defined by build params", func() {
 		m := mta.Module{
 			Name: "x",
 			Type: "node-js",
 			BuildParams: map[string]interface{}{
-				builderParam: "npm",
+				"builder": "npm",
 				"npm-opts": map[string]interface{}{
 					"no-optional": nil,
 				},
 			},
 		}
-		builder, custom, _, cmds, err := commands.GetBuilder(&m)
+		builder, custom, _, cmds := commands.GetBuilder(&m, decode(&m))
 		Ω(builder).Should(Equal("npm"))
 		Ω(custom).Should(Equal(true))
 		Ω(len(cmds)).Should(Equal(0))
-		Ω(err).Should(Succeed())
 	})
 	It("Builder defined by build params", func() {
 		m := mta.Module{
 			Name: "x",
 			Type: "node-js",
 			BuildParams: map[string]interface{}{
-				builderParam: "custom",
-				"commands":   []string{"command1"},
+				"builder":  "custom",
+				"commands": []string{"command1"},
 			},
 		}
-		builder, custom, _, cmds, err := commands.GetBuilder(&m)
+		builder, custom, _, cmds := commands.GetBuilder(&m, decode(&m))
 		Ω(builder).Should(Equal("custom"))
 		Ω(custom).Should(Equal(true))
 		Ω(cmds[0]).Should(Equal("command1"))
-		Ω(err).Should(Succeed())
 	})
 	It("fetcher builder defined by build params", func() {
 		m := mta.Module{
 			Name: "x",
 			Type: "node-js",
 			BuildParams: map[string]interface{}{
-				builderParam: "fetcher",
+				"builder": "fetcher",
 				"fetcher-opts": map[interface{}]interface{}{
 					"repo-type":        "maven",
 					"repo-coordinates": "com.sap.xs.java:xs-audit-log-api:1.2.3",
 				},
 			},
 		}
-		builder, custom, options, cmds, err := commands.GetBuilder(&m)
+		builder, custom, options, cmds := commands.GetBuilder(&m, decode(&m))
 		Ω(options).Should(Equal(map[string]string{
 			"repo-type":        "maven",
 			"repo-coordinates": "com.sap.xs.java:xs-audit-log-api:1.2.3"}))
 		Ω(builder).Should(Equal("fetcher"))
 		Ω(custom).Should(BeTrue())
 		Ω(cmds).Should(BeNil())
-		Ω(err).Should(Succeed())
 	})
 	It("fetcher builder defined by build params from mta.yaml", func() {
 		currDir, err := os.Getwd()
@@ -441,34 +502,39 @@ var _ = Describe("GetBuilder", func() {
 		m, err := loc.ParseFile()
 		Ω(err).Should(Succeed())
 
-		builder, custom, options, cmds, err := commands.GetBuilder(m.Modules[0])
+		builder, custom, options, cmds := commands.GetBuilder(m.Modules[0], decode(m.Modules[0]))
 		Ω(options).Should(Equal(map[string]string{
 			"repo-type":        "maven",
 			"repo-coordinates": "mygroup:myart:1.0.0"}))
 		Ω(builder).Should(Equal("fetcher"))
 		Ω(custom).Should(BeTrue())
 		Ω(cmds).Should(BeNil())
-		Ω(err).Should(Succeed())
 	})
 })
 
-var _ = Describe("IfNoSource", func() {
+var _ = Describe("NoSource", func() {
 	It("no source module", func() {
 		buildParams := make(map[string]interface{})
-		buildParams[noSourceParam] = true
+		buildParams["no-source"] = true
 		module := mta.Module{BuildParams: buildParams}
-		Ω(IfNoSource(&module)).Should(BeTrue())
+		Ω(decode(&module).NoSource).Should(BeTrue())
 	})
 	It("no source module", func() {
 		buildParams := make(map[string]interface{})
-		buildParams[noSourceParam] = false
+		buildParams["no-source"] = false
 		module := mta.Module{BuildParams: buildParams}
-		Ω(IfNoSource(&module)).Should(BeFalse())
+		Ω(decode(&module).NoSource).Should(BeFalse())
 	})
 	It("not no source module", func() {
 		buildParams := make(map[string]interface{})
 		module := mta.Module{BuildParams: buildParams}
-		Ω(IfNoSource(&module)).Should(BeFalse())
+		Ω(decode(&module).NoSource).Should(BeFalse())
+	})
+	It("wrong no source value", func() {
+		module := mta.Module{Name: "x", BuildParams: map[string]interface{}{"no-source": "yes"}}
+		_, err := buildparams.Decode(&module)
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(buildparams.WrongParamMsg, "no-source", "x", "a boolean value")))
 	})
 })
 
@@ -476,3 +542,54 @@ func getTestPath(relPath ...string) string {
 	wd, _ := os.Getwd()
 	return filepath.Join(wd, "testdata", filepath.Join(relPath...))
 }
+
+func decode(module *mta.Module) *buildparams.Module {
+	params, err := buildparams.Decode(module)
+	Ω(err).Should(Succeed())
+	return params
+}
+
+func decodeAll(m *mta.MTA) buildparams.Modules {
+	params, err := buildparams.DecodeAll(m)
+	Ω(err).Should(Succeed())
+	return params
+}
+
+var _ = Describe("Process requirements with copy options", func() {
+	lp := dir.Loc{
+		SourcePath: getTestPath("testrequiresopts"),
+		TargetPath: getTestPath("result"),
+	}
+
+	AfterEach(func() {
+		Ω(os.RemoveAll(getTestPath("testrequiresopts", "app", "classes"))).Should(Succeed())
+		Ω(os.RemoveAll(getTestPath("testrequiresopts", "app", "static"))).Should(Succeed())
+	})
+
+	It("GetBuildRequires gets the copy options", func() {
+		mtaObj, err := lp.ParseFile()
+		Ω(err).Should(Succeed())
+		module, err := mtaObj.GetModuleByName("app")
+		Ω(err).Should(Succeed())
+		Ω(decode(module).Requires).Should(Equal([]buildparams.Requires{
+			{Name: "lib", TargetPath: "classes", Exclude: []string{"META-INF"}, Extract: true},
+			{Name: "ui", Artifacts: []string{"dist/*"}, TargetPath: "static", Exclude: []string{"*.map"},
+				Rename: map[string]string{"app.js": "public/main.js"}},
+		}))
+	})
+
+	It("ProcessRequirements extracts, excludes and renames the artifacts", func() {
+		mtaObj, err := lp.ParseFile()
+		Ω(err).Should(Succeed())
+		module, err := mtaObj.GetModuleByName("app")
+		Ω(err).Should(Succeed())
+		for _, r := range decode(module).Requires {
+			Ω(ProcessRequirements(&lp, mtaObj, decodeAll(mtaObj), &r, "app")).Should(Succeed())
+		}
+		Ω(getTestPath("testrequiresopts", "app", "classes", "com", "example", "Lib.class")).Should(BeAnExistingFile())
+		Ω(getTestPath("testrequiresopts", "app", "classes", "META-INF")).ShouldNot(BeAnExistingFile())
+		Ω(getTestPath("testrequiresopts", "app", "static", "public", "main.js")).Should(BeAnExistingFile())
+		Ω(getTestPath("testrequiresopts", "app", "static", "app.js")).ShouldNot(BeAnExistingFile())
+		Ω(getTestPath("testrequiresopts", "app", "static", "app.js.map")).ShouldNot(BeAnExistingFile())
+	})
+})
@@ -6,6 +6,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/SAP/cloud-mta-build-tool/internal/buildconfig"
 	"github.com/SAP/cloud-mta-build-tool/internal/logs"
 )
 
@@ -17,3 +18,8 @@ func TestBuildops(t *testing.T) {
 var _ = BeforeSuite(func() {
 	logs.NewLogger()
 })
+
+// the build configuration set by the specs is not kept for the next specs
+var _ = AfterEach(func() {
+	buildconfig.Reset()
+})
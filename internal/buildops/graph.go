@@ -0,0 +1,304 @@
+package buildops
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
+	"github.com/SAP/cloud-mta-build-tool/internal/commands"
+	"github.com/SAP/cloud-mta/mta"
+)
+
+const (
+	// GraphDOT - the graph is printed in the Graphviz DOT format
+	GraphDOT = "dot"
+	// GraphMermaid - the graph is printed as a Mermaid flowchart
+	GraphMermaid = "mermaid"
+	// GraphJSON - the graph is printed in the JSON format
+	GraphJSON = "json"
+
+	cycleColor = "red"
+)
+
+// ModulesGraph - the build dependency graph of the MTA modules
+type ModulesGraph struct {
+	Nodes []*ModuleNode `json:"nodes"`
+	Edges []*ModuleEdge `json:"edges"`
+	// Cycles - the names of the modules of each circular dependency
+	Cycles [][]string `json:"cycles"`
+}
+
+// ModuleNode - the module of the build dependency graph
+type ModuleNode struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Builder string `json:"builder"`
+	Path    string `json:"path,omitempty"`
+	// Platforms - the "supported-platforms" build parameter of the module; nil means that all the platforms are supported
+	Platforms []string `json:"platforms"`
+	InCycle   bool     `json:"in-cycle,omitempty"`
+}
+
+// ModuleEdge - the build dependency of the module on the required module;
+// the edge goes from the required module, that is built first, to the module that requires it
+type ModuleEdge struct {
+	From       string   `json:"from"`
+	To         string   `json:"to"`
+	Artifacts  []string `json:"artifacts,omitempty"`
+	TargetPath string   `json:"target-path,omitempty"`
+	InCycle    bool     `json:"in-cycle,omitempty"`
+}
+
+// ExecuteGraph - prints the build dependency graph of the MTA project modules in the DOT, Mermaid or JSON format
+func ExecuteGraph(source, mtaYamlFilename string, extensions []string, format string, out io.Writer, wdGetter func() (string, error)) error {
+	if format != "" && format != GraphDOT && format != GraphMermaid && format != GraphJSON {
+		return errors.Errorf(wrongGraphFormatMsg, format)
+	}
+	loc, err := dir.Location(source, mtaYamlFilename, "", "", extensions, wdGetter)
+	if err != nil {
+		return errors.Wrap(err, graphLocFailedMsg)
+	}
+	m, err := loc.ParseFile()
+	if err != nil {
+		return err
+	}
+	params, err := buildparams.DecodeAll(m)
+	if err != nil {
+		return err
+	}
+	graph, err := GetModulesGraph(m, params)
+	if err != nil {
+		return err
+	}
+	switch format {
+	case GraphMermaid:
+		return printMermaidGraph(graph, out)
+	case GraphJSON:
+		data, err := json.MarshalIndent(graph, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(out, string(data))
+		return err
+	}
+	return printDOTGraph(graph, out)
+}
+
+// GetModulesGraph - gets the build dependency graph of the modules defined by the "requires" build parameters;
+// unlike the build order, the graph is provided also when the modules have circular dependencies, which are marked in the graph
+func GetModulesGraph(m *mta.MTA, modulesParams buildparams.Modules) (*ModulesGraph, error) {
+	graph := &ModulesGraph{Nodes: []*ModuleNode{}, Edges: []*ModuleEdge{}, Cycles: [][]string{}}
+	for _, module := range m.Modules {
+		params := modulesParams[module.Name]
+		builder, _, _, _ := commands.GetBuilder(module, params)
+		graph.Nodes = append(graph.Nodes, &ModuleNode{
+			Name:      module.Name,
+			Type:      module.Type,
+			Builder:   builder,
+			Path:      module.Path,
+			Platforms: params.SupportedPlatforms,
+		})
+		for _, req := range params.Requires {
+			_, err := m.GetModuleByName(req.Name)
+			if err != nil {
+				return nil, err
+			}
+			graph.Edges = append(graph.Edges, &ModuleEdge{From: req.Name, To: module.Name, Artifacts: req.Artifacts, TargetPath: req.TargetPath})
+		}
+	}
+	graph.markCycles()
+	return graph, nil
+}
+
+// markCycles - finds the circular dependencies as the strongly connected components of the graph
+// with more than one module or with a module that requires itself (Tarjan's algorithm), and marks their nodes and edges
+func (g *ModulesGraph) markCycles() {
+	deps := make(map[string][]string)
+	for _, edge := range g.Edges {
+		deps[edge.To] = append(deps[edge.To], edge.From)
+	}
+	index := make(map[string]int)
+	lowLink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	cycleOf := make(map[string]int)
+
+	var connect func(name string)
+	connect = func(name string) {
+		index[name] = len(index)
+		lowLink[name] = index[name]
+		stack = append(stack, name)
+		onStack[name] = true
+		for _, dep := range deps[name] {
+			if _, visited := index[dep]; !visited {
+				connect(dep)
+				lowLink[name] = minInt(lowLink[name], lowLink[dep])
+			} else if onStack[dep] {
+				lowLink[name] = minInt(lowLink[name], index[dep])
+			}
+		}
+		if lowLink[name] != index[name] {
+			return
+		}
+		component := make(map[string]bool)
+		for {
+			last := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			onStack[last] = false
+			component[last] = true
+			if last == name {
+				break
+			}
+		}
+		if len(component) > 1 || stringInSlice(name, deps[name]) {
+			g.addCycle(component, cycleOf)
+		}
+	}
+	for _, node := range g.Nodes {
+		if _, visited := index[node.Name]; !visited {
+			connect(node.Name)
+		}
+	}
+
+	for _, edge := range g.Edges {
+		fromCycle, okFrom := cycleOf[edge.From]
+		toCycle, okTo := cycleOf[edge.To]
+		edge.InCycle = okFrom && okTo && fromCycle == toCycle
+	}
+}
+
+// addCycle - adds the modules of the circular dependency in the order of their definition
+func (g *ModulesGraph) addCycle(component map[string]bool, cycleOf map[string]int) {
+	var cycle []string
+	for _, node := range g.Nodes {
+		if component[node.Name] {
+			node.InCycle = true
+			cycle = append(cycle, node.Name)
+			cycleOf[node.Name] = len(g.Cycles)
+		}
+	}
+	g.Cycles = append(g.Cycles, cycle)
+}
+
+func printDOTGraph(g *ModulesGraph, out io.Writer) error {
+	var b strings.Builder
+	b.WriteString("digraph modules {\n")
+	b.WriteString("  node [shape=box];\n")
+	for _, node := range g.Nodes {
+		attrs := ""
+		if node.InCycle {
+			attrs = ", color=" + cycleColor
+		}
+		fmt.Fprintf(&b, "  %s [label=%s%s];\n", quoteDOT(node.Name), quoteDOT(getNodeLabel(node, "\n")), attrs)
+	}
+	for _, edge := range g.Edges {
+		var attrs []string
+		if label := getEdgeLabel(edge, "\n"); label != "" {
+			attrs = append(attrs, "label="+quoteDOT(label))
+		}
+		if edge.InCycle {
+			attrs = append(attrs, "color="+cycleColor)
+		}
+		attrsStr := ""
+		if len(attrs) > 0 {
+			attrsStr = " [" + strings.Join(attrs, ", ") + "]"
+		}
+		fmt.Fprintf(&b, "  %s -> %s%s;\n", quoteDOT(edge.From), quoteDOT(edge.To), attrsStr)
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+func printMermaidGraph(g *ModulesGraph, out io.Writer) error {
+	var b strings.Builder
+	b.WriteString("flowchart TD\n")
+	// the module names can contain characters that are not allowed in the Mermaid node IDs
+	ids := make(map[string]string)
+	var cycleIDs []string
+	for i, node := range g.Nodes {
+		ids[node.Name] = fmt.Sprintf("m%d", i)
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", ids[node.Name], quoteMermaid(getNodeLabel(node, "<br/>")))
+		if node.InCycle {
+			cycleIDs = append(cycleIDs, ids[node.Name])
+		}
+	}
+	var cycleEdges []string
+	for i, edge := range g.Edges {
+		label := ""
+		if edgeLabel := getEdgeLabel(edge, "<br/>"); edgeLabel != "" {
+			label = fmt.Sprintf("|\"%s\"|", quoteMermaid(edgeLabel))
+		}
+		fmt.Fprintf(&b, "  %s -->%s %s\n", ids[edge.From], label, ids[edge.To])
+		if edge.InCycle {
+			cycleEdges = append(cycleEdges, fmt.Sprint(i))
+		}
+	}
+	if len(cycleIDs) > 0 {
+		fmt.Fprintf(&b, "  classDef cycle stroke:%s,stroke-width:2px\n", cycleColor)
+		fmt.Fprintf(&b, "  class %s cycle\n", strings.Join(cycleIDs, ","))
+	}
+	if len(cycleEdges) > 0 {
+		fmt.Fprintf(&b, "  linkStyle %s stroke:%s,stroke-width:2px\n", strings.Join(cycleEdges, ","), cycleColor)
+	}
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+func getNodeLabel(node *ModuleNode, separator string) string {
+	platforms := "all"
+	if node.Platforms != nil {
+		platforms = strings.Join(node.Platforms, ", ")
+		if platforms == "" {
+			platforms = "none"
+		}
+	}
+	lines := []string{node.Name, "builder: " + node.Builder}
+	if node.Path != "" {
+		lines = append(lines, "path: "+node.Path)
+	}
+	lines = append(lines, "platforms: "+platforms)
+	return strings.Join(lines, separator)
+}
+
+func getEdgeLabel(edge *ModuleEdge, separator string) string {
+	var lines []string
+	if len(edge.Artifacts) > 0 {
+		lines = append(lines, "artifacts: "+strings.Join(edge.Artifacts, ", "))
+	}
+	if edge.TargetPath != "" {
+		lines = append(lines, "target-path: "+edge.TargetPath)
+	}
+	return strings.Join(lines, separator)
+}
+
+func quoteDOT(s string) string {
+	s = strings.Replace(s, `\`, `\\`, -1)
+	s = strings.Replace(s, `"`, `\"`, -1)
+	return `"` + strings.Replace(s, "\n", `\n`, -1) + `"`
+}
+
+func quoteMermaid(s string) string {
+	return strings.Replace(s, `"`, "#quot;", -1)
+}
+
+func stringInSlice(s string, list []string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
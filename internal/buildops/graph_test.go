@@ -0,0 +1,101 @@
+package buildops
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta/mta"
+)
+
+var _ = Describe("Graph", func() {
+
+	Describe("GetModulesGraph", func() {
+		It("provides the modules, their dependencies and the circular dependencies", func() {
+			ep := dir.Loc{SourcePath: getTestPath(), MtaFilename: "mtaGraph.yaml"}
+			m, err := ep.ParseFile()
+			Ω(err).Should(Succeed())
+			graph, err := GetModulesGraph(m, decodeAll(m))
+			Ω(err).Should(Succeed())
+			Ω(graph.Nodes).Should(Equal([]*ModuleNode{
+				{Name: "db", Type: "hdb", Builder: "hdb", Path: "db", Platforms: []string{"cf"}},
+				{Name: "srv", Type: "nodejs", Builder: "npm", Path: "srv"},
+				{Name: "ui", Type: "html5", Builder: "html5", Path: "ui", Platforms: []string{}, InCycle: true},
+				{Name: "deployer", Type: "com.sap.application.content", Builder: "custom", InCycle: true},
+			}))
+			Ω(graph.Edges).Should(Equal([]*ModuleEdge{
+				{From: "db", To: "srv", Artifacts: []string{"*.json"}, TargetPath: "gen/db"},
+				{From: "deployer", To: "ui", InCycle: true},
+				{From: "ui", To: "deployer", Artifacts: []string{"dist/*"}, InCycle: true},
+			}))
+			Ω(graph.Cycles).Should(Equal([][]string{{"ui", "deployer"}}))
+		})
+		It("marks the module that requires itself", func() {
+			m := createMtaWithRequires("m1", "m1")
+			graph, err := GetModulesGraph(m, decodeAll(m))
+			Ω(err).Should(Succeed())
+			Ω(graph.Cycles).Should(Equal([][]string{{"m1"}}))
+			Ω(graph.Edges[0].InCycle).Should(BeTrue())
+		})
+		It("fails when the required module is not defined", func() {
+			m := createMtaWithRequires("m1", "abc")
+			_, err := GetModulesGraph(m, decodeAll(m))
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(Equal(`the "abc" module is not defined`))
+		})
+	})
+
+	Describe("ExecuteGraph", func() {
+		var out bytes.Buffer
+
+		BeforeEach(func() {
+			out.Reset()
+		})
+
+		It("prints the graph in the DOT format by default", func() {
+			Ω(ExecuteGraph(getTestPath(), "mtaGraph.yaml", nil, "", &out, os.Getwd)).Should(Succeed())
+			Ω(out.String()).Should(HavePrefix("digraph modules {\n"))
+			Ω(out.String()).Should(ContainSubstring(`  "db" [label="db\nbuilder: hdb\npath: db\nplatforms: cf"];`))
+			Ω(out.String()).Should(ContainSubstring(`  "deployer" [label="deployer\nbuilder: custom\nplatforms: all", color=red];`))
+			Ω(out.String()).Should(ContainSubstring(`  "db" -> "srv" [label="artifacts: *.json\ntarget-path: gen/db"];`))
+			Ω(out.String()).Should(ContainSubstring(`  "deployer" -> "ui" [color=red];`))
+		})
+		It("prints the graph in the Mermaid format", func() {
+			Ω(ExecuteGraph(getTestPath(), "mtaGraph.yaml", nil, GraphMermaid, &out, os.Getwd)).Should(Succeed())
+			Ω(out.String()).Should(HavePrefix("flowchart TD\n"))
+			Ω(out.String()).Should(ContainSubstring(`  m2["ui<br/>builder: html5<br/>path: ui<br/>platforms: none"]`))
+			Ω(out.String()).Should(ContainSubstring(`  m0 -->|"artifacts: *.json<br/>target-path: gen/db"| m1`))
+			Ω(out.String()).Should(ContainSubstring("  class m2,m3 cycle\n"))
+			Ω(out.String()).Should(ContainSubstring("  linkStyle 1,2 stroke:red,stroke-width:2px\n"))
+		})
+		It("prints the graph in the JSON format", func() {
+			Ω(ExecuteGraph(getTestPath(), "mtaGraph.yaml", nil, GraphJSON, &out, os.Getwd)).Should(Succeed())
+			graph := ModulesGraph{}
+			Ω(json.Unmarshal(out.Bytes(), &graph)).Should(Succeed())
+			Ω(len(graph.Nodes)).Should(Equal(4))
+			Ω(graph.Cycles).Should(Equal([][]string{{"ui", "deployer"}}))
+		})
+		It("fails on the wrong format", func() {
+			err := ExecuteGraph(getTestPath(), "mtaGraph.yaml", nil, "svg", &out, os.Getwd)
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring(`the "svg" graph format is invalid`))
+		})
+		It("fails when the MTA file does not exist", func() {
+			Ω(ExecuteGraph(getTestPath(), "unknown.yaml", nil, GraphDOT, &out, os.Getwd)).Should(HaveOccurred())
+		})
+	})
+})
+
+func createMtaWithRequires(moduleName, requiredModuleName string) *mta.MTA {
+	return &mta.MTA{Modules: []*mta.Module{{
+		Name: moduleName,
+		Type: "html5",
+		BuildParams: map[string]interface{}{
+			"requires": []interface{}{map[string]interface{}{"name": requiredModuleName}},
+		},
+	}}}
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/pkg/errors"
 
 	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildconfig"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
 	"github.com/SAP/cloud-mta/mta"
 )
 
@@ -26,34 +28,84 @@ func ProvideModules(source, mtaYamlFilename, desc string, extensions []string, w
 	if err != nil {
 		return err
 	}
-	modules, err := GetModulesNames(m)
+	params, err := buildparams.DecodeAll(m)
 	if err != nil {
 		return err
 	}
-	// Get list of modules names
-	fmt.Println(modules)
+	modules, err := GetModulesNames(m, params)
+	if err != nil {
+		return err
+	}
+	// Get list of modules names; the modules tied to other build profiles are not built unless they are required
+	built, err := GetProfileModules(m, params)
+	if err != nil {
+		return err
+	}
+	var profileModules []string
+	for _, name := range modules {
+		if built[name] {
+			profileModules = append(profileModules, name)
+		}
+	}
+	fmt.Println(profileModules)
 	return nil
 }
 
+// GetProfileModules - gets the names of the modules built with the build profile: the modules included in the profile
+// and the modules whose build results they require, directly or through other required modules.
+// The required modules that are not included in the profile are built, but not packed and not deployed
+func GetProfileModules(m *mta.MTA, params buildparams.Modules) (map[string]bool, error) {
+	built := make(map[string]bool)
+	var queue []*buildparams.Module
+	for _, module := range m.Modules {
+		if buildconfig.InProfiles(params[module.Name].Profiles) {
+			built[module.Name] = true
+			queue = append(queue, params[module.Name])
+		}
+	}
+	for len(queue) > 0 {
+		moduleParams := queue[0]
+		queue = queue[1:]
+		// the build requires of the modules without source are not processed
+		if moduleParams.NoSource {
+			continue
+		}
+		for _, req := range moduleParams.Requires {
+			if built[req.Name] {
+				continue
+			}
+			_, err := m.GetModuleByName(req.Name)
+			if err != nil {
+				return nil, err
+			}
+			built[req.Name] = true
+			queue = append(queue, params[req.Name])
+		}
+	}
+	return built, nil
+}
+
+// ProfileBuilt - checks if the module is built with the build profile
+func ProfileBuilt(m *mta.MTA, params buildparams.Modules, moduleName string) (bool, error) {
+	built, err := GetProfileModules(m, params)
+	if err != nil {
+		return false, err
+	}
+	return built[moduleName], nil
+}
+
 // ProcessDependencies - processes module dependencies
 // function prepares all artifacts required for module
 // copying them from required modules
-func ProcessDependencies(mtaParser dir.IMtaParser, moduleSource dir.ISourceModule, moduleName string) error {
-	m, err := mtaParser.ParseFile()
-	if err != nil {
-		return err
-	}
+func ProcessDependencies(m *mta.MTA, params buildparams.Modules, moduleSource dir.ISourceModule, moduleName string) error {
 	module, err := m.GetModuleByName(moduleName)
 	if err != nil {
 		return err
 	}
-	requires := GetBuildRequires(module)
-	if len(requires) > 0 {
-		for _, req := range requires {
-			e := ProcessRequirements(moduleSource, m, &req, module.Name)
-			if e != nil {
-				return e
-			}
+	for _, req := range params[module.Name].Requires {
+		e := ProcessRequirements(moduleSource, m, params, &req, module.Name)
+		if e != nil {
+			return e
 		}
 	}
 	return nil
@@ -68,24 +120,21 @@ func newGn(module *string, deps mapset.Set, index int) *graphNode {
 type graphs map[string]*graphNode
 
 // GetModulesNames returns a list of module names.
-func GetModulesNames(m *mta.MTA) ([]string, error) {
-	return getModulesOrder(m)
+func GetModulesNames(m *mta.MTA, params buildparams.Modules) ([]string, error) {
+	return getModulesOrder(m, params)
 }
 
 // getModulesOrder - Provides Modules ordered according to build-parameters' dependencies
-func getModulesOrder(m *mta.MTA) ([]string, error) {
+func getModulesOrder(m *mta.MTA, params buildparams.Modules) ([]string, error) {
 	var graph = make(graphs)
 	for index, module := range m.Modules {
 		deps := mapset.NewSet()
-		requires := GetBuildRequires(module)
-		if len(requires) > 0 {
-			for _, req := range requires {
-				_, err := m.GetModuleByName(req.Name)
-				if err != nil {
-					return nil, err
-				}
-				deps.Add(req.Name)
+		for _, req := range params[module.Name].Requires {
+			_, err := m.GetModuleByName(req.Name)
+			if err != nil {
+				return nil, err
 			}
+			deps.Add(req.Name)
 		}
 		graph[module.Name] = newGn(&module.Name, deps, index)
 	}
@@ -15,6 +15,8 @@ import (
 	"strings"
 
 	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildconfig"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
 	"github.com/SAP/cloud-mta/mta"
 )
 
@@ -27,23 +29,23 @@ var _ = Describe("ModulesDeps", func() {
 
 		It("Sanity", func() {
 			ep := dir.Loc{SourcePath: getTestPath("mtahtml5"), TargetPath: getTestPath("result"), MtaFilename: "mtaWithBuildParams.yaml"}
-			Ω(ProcessDependencies(&ep, &ep, "ui5app")).Should(Succeed())
+			Ω(processDependencies(&ep, "ui5app")).Should(Succeed())
 		})
 		It("Invalid artifacts", func() {
 			ep := dir.Loc{SourcePath: getTestPath("mtahtml5"), TargetPath: getTestPath("result"), MtaFilename: "mtaWithBuildParamsWithWrongArtifacts.yaml"}
-			Ω(ProcessDependencies(&ep, &ep, "ui5app")).Should(HaveOccurred())
+			Ω(processDependencies(&ep, "ui5app")).Should(HaveOccurred())
 		})
 		It("Invalid mta", func() {
 			ep := dir.Loc{SourcePath: getTestPath("mtahtml5"), MtaFilename: "mta1.yaml"}
-			Ω(ProcessDependencies(&ep, &ep, "ui5app")).Should(HaveOccurred())
+			Ω(processDependencies(&ep, "ui5app")).Should(HaveOccurred())
 		})
 		It("Invalid module name", func() {
 			ep := dir.Loc{SourcePath: getTestPath("mtahtml5")}
-			Ω(ProcessDependencies(&ep, &ep, "xxx")).Should(HaveOccurred())
+			Ω(processDependencies(&ep, "xxx")).Should(HaveOccurred())
 		})
 		It("Invalid module name", func() {
 			ep := dir.Loc{SourcePath: getTestPath("mtahtml5"), MtaFilename: "mtaWithWrongBuildParams.yaml"}
-			Ω(ProcessDependencies(&ep, &ep, "ui5app")).Should(HaveOccurred())
+			Ω(processDependencies(&ep, "ui5app")).Should(HaveOccurred())
 		})
 	})
 
@@ -51,7 +53,7 @@ var _ = Describe("ModulesDeps", func() {
 		wd, _ := os.Getwd()
 		ep := dir.Loc{SourcePath: filepath.Join(wd, "testdata"), MtaFilename: "mta_multiapps.yaml"}
 		mtaStr, _ := ep.ParseFile()
-		actual, _ := getModulesOrder(mtaStr)
+		actual, _ := getModulesOrder(mtaStr, decodeAll(mtaStr))
 		// last module depends on others
 		Ω(actual[len(actual)-1]).Should(Equal("eb-uideployer"))
 	})
@@ -60,7 +62,7 @@ var _ = Describe("ModulesDeps", func() {
 		wd, _ := os.Getwd()
 		ep := dir.Loc{SourcePath: filepath.Join(wd, "testdata"), MtaFilename: "mta_multiapps_cyclic_deps.yaml"}
 		mtaStr, _ := ep.ParseFile()
-		_, err := getModulesOrder(mtaStr)
+		_, err := getModulesOrder(mtaStr, decodeAll(mtaStr))
 		Ω(err).Should(HaveOccurred())
 		Ω(err.Error()).Should(ContainSubstring("eb-ui-conf-eb"))
 	})
@@ -68,17 +70,30 @@ var _ = Describe("ModulesDeps", func() {
 	var _ = Describe("GetModulesNames", func() {
 		It("Sanity", func() {
 			mtaStr := &mta.MTA{Modules: []*mta.Module{{Name: "someproj-db"}, {Name: "someproj-java"}}}
-			Ω(GetModulesNames(mtaStr)).Should(Equal([]string{"someproj-db", "someproj-java"}))
+			Ω(GetModulesNames(mtaStr, decodeAll(mtaStr))).Should(Equal([]string{"someproj-db", "someproj-java"}))
 		})
 		It("Required module not defined", func() {
 			mtaContent := readFile(getTestPath("mtahtml5", "mtaRequiredModuleNotDefined.yaml"))
 			mtaStr, _ := mta.Unmarshal(mtaContent)
-			_, err := GetModulesNames(mtaStr)
+			_, err := GetModulesNames(mtaStr, decodeAll(mtaStr))
 			Ω(err.Error()).Should(Equal(`the "abc" module is not defined`))
 		})
 	})
 })
 
+// processDependencies - processes the dependencies of the module defined in the MTA file of the location
+func processDependencies(ep *dir.Loc, moduleName string) error {
+	m, err := ep.ParseFile()
+	if err != nil {
+		return err
+	}
+	params, err := buildparams.DecodeAll(m)
+	if err != nil {
+		return err
+	}
+	return ProcessDependencies(m, params, ep, moduleName)
+}
+
 func readFile(file string) []byte {
 	content, err := ioutil.ReadFile(file)
 	Ω(err).Should(Succeed())
@@ -126,6 +141,24 @@ var _ = Describe("Provide", func() {
 		Ω(out).Should(ContainSubstring("[ui5app ui5app2]"))
 	})
 
+	It("Skips the modules of other build profiles", func() {
+		Ω(buildconfig.SetProfile("prod")).Should(Succeed())
+		out, err := executeAndProvideOutput(func() error {
+			return ProvideModules(filepath.Join("testdata", "mtahtml5"), "mtaProfiles.yaml", "dev", nil, os.Getwd)
+		})
+		Ω(err).Should(Succeed())
+		Ω(out).Should(ContainSubstring("[ui5app2]"))
+	})
+
+	It("Provides the modules of other build profiles required by the modules of the build profile", func() {
+		Ω(buildconfig.SetProfile("prod")).Should(Succeed())
+		out, err := executeAndProvideOutput(func() error {
+			return ProvideModules(filepath.Join("testdata", "mtahtml5"), "mtaProfilesRequires.yaml", "dev", nil, os.Getwd)
+		})
+		Ω(err).Should(Succeed())
+		Ω(out).Should(ContainSubstring("[ui5app ui5app2]"))
+	})
+
 	It("Invalid path to yaml", func() {
 		Ω(ProvideModules(filepath.Join("testdata", "mtahtml6"), "", "dev", nil, os.Getwd)).Should(HaveOccurred())
 	})
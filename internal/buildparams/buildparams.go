@@ -0,0 +1,579 @@
+package buildparams
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/SAP/cloud-mta/mta"
+)
+
+const (
+	// SupportedPlatformsParam - name of build-params property for supported platforms
+	SupportedPlatformsParam = "supported-platforms"
+	// ProfilesParam - the parameter that ties an element of the MTA to the build profiles:
+	// the "profiles" build parameter of the modules and the "profiles" parameter of the resources and of the extension files
+	ProfilesParam = "profiles"
+
+	builderParam            = "builder"
+	commandsParam           = "commands"
+	optionsSuffix           = "-opts"
+	requiresParam           = "requires"
+	buildResultParam        = "build-result"
+	buildArtifactNameParam  = "build-artifact-name"
+	noSourceParam           = "no-source"
+	timeoutParam            = "timeout"
+	ignoreParam             = "ignore"
+	compressionParam        = "compression"
+	envParam                = "env"
+	hooksParam              = "hooks"
+	retriesParam            = "retries"
+	retryDelayParam         = "retry-delay"
+	retryOnExitCodesParam   = "retry-on-exit-codes"
+	sbomCreateCommandsParam = "sbom-create-commands"
+
+	nameProp            = "name"
+	artifactsProp       = "artifacts"
+	targetPathProp      = "target-path"
+	excludeProp         = "exclude"
+	renameProp          = "rename"
+	extractProp         = "extract"
+	commandProp         = "command"
+	continueOnErrorProp = "continue-on-error"
+	hookCommandsProp    = "commands"
+	hookTimeoutProp     = "timeout"
+	hookWorkingDirProp  = "working-dir"
+
+	// BeforeBuildHook - the hook that runs before the module build commands
+	BeforeBuildHook = "before-build"
+	// AfterBuildHook - the hook that runs after the module build commands
+	AfterBuildHook = "after-build"
+	// BeforePackHook - the hook that runs before the module build result is packed
+	BeforePackHook = "before-pack"
+)
+
+// HookPhases - the module build phases that can have hooks
+var HookPhases = []string{BeforeBuildHook, AfterBuildHook, BeforePackHook}
+
+// Module - the typed build parameters of the module
+type Module struct {
+	// Builder - the builder of the module; empty if the module is built by the builder of its type
+	Builder string
+	// Commands - the commands of the custom builder; nil if the "commands" build parameter is not defined
+	Commands []Command
+	// Options - the "<builder>-opts" build parameter with the values of the placeholders of the builder commands;
+	// nil if the builder is not defined
+	Options map[string]string
+	// Requires - the modules whose build results are copied to the module before its build
+	Requires []Requires
+	// BuildResult - the path to the build results that are packaged, relative to the module folder
+	BuildResult string
+	// BuildArtifactName - the name of the packaged build artifact without the extension
+	BuildArtifactName string
+	// SupportedPlatforms - the deployment platforms of the module; nil if the module is deployed to all the platforms
+	SupportedPlatforms []string
+	// Profiles - the build profiles of the module; nil if the module is included in all the builds
+	Profiles []string
+	// NoSource - the module has no sources and is not built
+	NoSource bool
+	// Timeout - the timeout of the build commands in the form "[123h][123m][123s]"
+	Timeout string
+	// Ignore - the files and folders that are excluded from the package
+	Ignore []string
+	// Compression - the compression of the module archive
+	Compression string
+	// Env - the environment variables of the module build
+	Env map[string]string
+	// Hooks - the hooks of the module build phases
+	Hooks map[string]Hook
+	// Retries - the number of the build command executions after the first failed one
+	Retries int
+	// RetryDelay - the delay between the build command executions in the form "[123h][123m][123s]"
+	RetryDelay string
+	// RetryOnExitCodes - the exit codes on which the build commands are retried
+	RetryOnExitCodes []int
+	// SBomCreateCommands - the commands that create the SBOM of the module built by the custom builder
+	SBomCreateCommands []string
+}
+
+// Command - the command of the custom builder with its policy
+type Command struct {
+	Command string
+	// Retries - the number of the command executions after the first failed one; nil if the "retries" build parameter is used
+	Retries *int
+	// ContinueOnError - the build continues with the next command if the command fails
+	ContinueOnError bool
+}
+
+// Requires - the build requirement of the module
+type Requires struct {
+	Name       string   `yaml:"name,omitempty"`
+	Artifacts  []string `yaml:"artifacts,omitempty"`
+	TargetPath string   `yaml:"target-path,omitempty"`
+	// Exclude - patterns of the files and folders that are not copied
+	Exclude []string `yaml:"exclude,omitempty"`
+	// Rename - new paths of the copied files and folders, relative to the target path
+	Rename map[string]string `yaml:"rename,omitempty"`
+	// Extract - the archive build result of the required module is unpacked to the target path
+	Extract bool `yaml:"extract,omitempty"`
+}
+
+// Hook - the commands that run at a phase of the module build
+type Hook struct {
+	Commands []string
+	// Timeout - the timeout of the hook commands in the form "[123h][123m][123s]"; the default timeout of 10 minutes is used if it is empty
+	Timeout string
+	// WorkingDir - the working directory of the hook commands relative to the module folder
+	WorkingDir string
+}
+
+// CommandLines - gets the command lines of the custom builder commands
+func (m *Module) CommandLines() []string {
+	res := make([]string, len(m.Commands))
+	for i, cmd := range m.Commands {
+		res[i] = cmd.Command
+	}
+	return res
+}
+
+// Decode - decodes the build parameters of the module;
+// the invalid build parameters get their zero values and the error of the first invalid build parameter is returned
+func Decode(module *mta.Module) (*Module, error) {
+	d := decoder{params: module.BuildParams, module: module.Name}
+	res := &Module{
+		Builder:            d.getString(builderParam),
+		Commands:           d.getCommands(),
+		Requires:           d.getRequires(),
+		BuildResult:        d.getString(buildResultParam),
+		BuildArtifactName:  d.getString(buildArtifactNameParam),
+		SupportedPlatforms: d.getStrings(SupportedPlatformsParam),
+		Profiles:           d.getScalars(ProfilesParam),
+		NoSource:           d.getBool(noSourceParam),
+		Timeout:            d.getString(timeoutParam),
+		Ignore:             d.getStrings(ignoreParam),
+		Compression:        d.getScalar(compressionParam),
+		Env:                d.getStringMap(envParam),
+		Hooks:              d.getHooks(),
+		Retries:            d.getInt(retriesParam),
+		RetryDelay:         d.getScalar(retryDelayParam),
+		RetryOnExitCodes:   d.getInts(retryOnExitCodesParam),
+		SBomCreateCommands: d.getStrings(sbomCreateCommandsParam),
+	}
+	if res.Builder != "" {
+		res.Options = d.getStringMap(res.Builder + optionsSuffix)
+		if res.Options == nil {
+			res.Options = make(map[string]string)
+		}
+	}
+	return res, d.err
+}
+
+// Modules - the build parameters of the MTA modules by the module name
+type Modules map[string]*Module
+
+// DecodeAll - decodes the build parameters of all the modules once the MTA file is parsed,
+// so that the invalid build parameters are reported before the modules are processed
+func DecodeAll(m *mta.MTA) (Modules, error) {
+	res := make(Modules, len(m.Modules))
+	for _, module := range m.Modules {
+		params, err := Decode(module)
+		if err != nil {
+			return nil, err
+		}
+		res[module.Name] = params
+	}
+	return res, nil
+}
+
+// decoder - decodes the build parameters of the module and keeps the first error
+type decoder struct {
+	params map[string]interface{}
+	module string
+	err    error
+}
+
+func (d *decoder) fail(format string, args ...interface{}) {
+	if d.err == nil {
+		d.err = errors.Errorf(format, args...)
+	}
+}
+
+// get - gets the build parameter; the parameter with the empty value is not defined
+func (d *decoder) get(name string) (interface{}, bool) {
+	value, ok := d.params[name]
+	return value, ok && value != nil
+}
+
+func (d *decoder) getString(name string) string {
+	value, ok := d.get(name)
+	if !ok {
+		return ""
+	}
+	res, ok := value.(string)
+	if !ok {
+		d.fail(WrongParamMsg, name, d.module, aString)
+	}
+	return res
+}
+
+func (d *decoder) getBool(name string) bool {
+	value, ok := d.get(name)
+	if !ok {
+		return false
+	}
+	res, ok := value.(bool)
+	if !ok {
+		d.fail(WrongParamMsg, name, d.module, aBool)
+	}
+	return res
+}
+
+func (d *decoder) getScalar(name string) string {
+	value, ok := d.get(name)
+	if !ok {
+		return ""
+	}
+	res, ok := toScalar(value)
+	if !ok {
+		d.fail(WrongParamMsg, name, d.module, aScalar)
+	}
+	return res
+}
+
+func (d *decoder) getInt(name string) int {
+	value, ok := d.get(name)
+	if !ok {
+		return 0
+	}
+	res, ok := toInt(value)
+	if !ok {
+		d.fail(WrongParamMsg, name, d.module, anInt)
+	}
+	return res
+}
+
+func (d *decoder) getInts(name string) []int {
+	value, ok := d.get(name)
+	if !ok {
+		return nil
+	}
+	values, ok := value.([]interface{})
+	if !ok {
+		values = []interface{}{value}
+	}
+	res := make([]int, len(values))
+	for i, v := range values {
+		res[i], ok = toInt(v)
+		if !ok {
+			d.fail(WrongParamMsg, name, d.module, anIntList)
+			return nil
+		}
+	}
+	return res
+}
+
+func (d *decoder) getStrings(name string) []string {
+	value, ok := d.get(name)
+	if !ok {
+		return nil
+	}
+	res, ok := toStrings(value)
+	if !ok {
+		d.fail(WrongParamMsg, name, d.module, aStringList)
+	}
+	return res
+}
+
+// getScalars - gets the build parameter defined as a list of values or as a single value
+func (d *decoder) getScalars(name string) []string {
+	value, ok := d.get(name)
+	if !ok {
+		return nil
+	}
+	values, ok := value.([]interface{})
+	if !ok {
+		values = []interface{}{value}
+	}
+	res := make([]string, len(values))
+	for i, v := range values {
+		res[i], ok = toScalar(v)
+		if !ok {
+			d.fail(WrongParamMsg, name, d.module, aScalarList)
+			return nil
+		}
+	}
+	return res
+}
+
+// getStringMap - gets the build parameter defined as a map of values; the scalar values are converted to strings
+func (d *decoder) getStringMap(name string) map[string]string {
+	value, ok := d.get(name)
+	if !ok {
+		return nil
+	}
+	values, ok := toMap(value)
+	if !ok {
+		d.fail(WrongParamMsg, name, d.module, aStringMap)
+		return nil
+	}
+	res := make(map[string]string, len(values))
+	for key, v := range values {
+		res[key], ok = toScalar(v)
+		if !ok {
+			d.fail(WrongParamMsg, name, d.module, aStringMap)
+			return nil
+		}
+	}
+	return res
+}
+
+// getCommands - gets the commands defined as strings or as objects with the "command" property and the command policy
+func (d *decoder) getCommands() []Command {
+	value, ok := d.get(commandsParam)
+	if !ok {
+		return nil
+	}
+	if lines, ok := value.([]string); ok {
+		res := make([]Command, len(lines))
+		for i, line := range lines {
+			res[i] = Command{Command: line}
+		}
+		return res
+	}
+	values, ok := value.([]interface{})
+	if !ok {
+		d.fail(WrongParamMsg, commandsParam, d.module, aCommands)
+		return nil
+	}
+	res := make([]Command, 0, len(values))
+	for i, v := range values {
+		if line, ok := v.(string); ok {
+			res = append(res, Command{Command: line})
+			continue
+		}
+		props, ok := toMap(v)
+		line, okLine := props[commandProp].(string)
+		if !ok || !okLine {
+			d.fail(wrongCommandMsg, i+1, d.module)
+			continue
+		}
+		cmd := Command{Command: line}
+		if retries, ok := props[retriesParam]; ok {
+			n, ok := toInt(retries)
+			if !ok {
+				d.fail(wrongCommandPropMsg, retriesParam, line, d.module, anInt)
+			}
+			cmd.Retries = &n
+		}
+		if continueOnError, ok := props[continueOnErrorProp]; ok {
+			cmd.ContinueOnError, ok = continueOnError.(bool)
+			if !ok {
+				d.fail(wrongCommandPropMsg, continueOnErrorProp, line, d.module, aBool)
+			}
+		}
+		res = append(res, cmd)
+	}
+	return res
+}
+
+func (d *decoder) getRequires() []Requires {
+	value, ok := d.get(requiresParam)
+	if !ok {
+		return nil
+	}
+	values, ok := value.([]interface{})
+	if !ok {
+		d.fail(WrongParamMsg, requiresParam, d.module, aRequires)
+		return nil
+	}
+	res := make([]Requires, 0, len(values))
+	for i, v := range values {
+		props, ok := toMap(v)
+		if !ok {
+			d.fail(wrongRequiresMsg, i+1, d.module)
+			continue
+		}
+		res = append(res, d.getRequirement(props, i))
+	}
+	return res
+}
+
+func (d *decoder) getRequirement(props map[string]interface{}, index int) Requires {
+	req := Requires{}
+	var ok bool
+	id := strconv.Itoa(index + 1)
+	if props[nameProp] != nil {
+		req.Name, ok = props[nameProp].(string)
+		if !ok {
+			d.fail(WrongRequiresPropMsg, nameProp, id, d.module, aString)
+		}
+	}
+	if req.Name != "" {
+		id = req.Name
+	}
+	fail := func(prop, expected string) {
+		d.fail(WrongRequiresPropMsg, prop, id, d.module, expected)
+	}
+	if props[artifactsProp] != nil {
+		if req.Artifacts, ok = toStrings(props[artifactsProp]); !ok {
+			fail(artifactsProp, aStringList)
+		}
+	}
+	if props[targetPathProp] != nil {
+		if req.TargetPath, ok = props[targetPathProp].(string); !ok {
+			fail(targetPathProp, aString)
+		}
+	}
+	if props[excludeProp] != nil {
+		if req.Exclude, ok = toStrings(props[excludeProp]); !ok {
+			fail(excludeProp, aStringList)
+		}
+	}
+	if props[renameProp] != nil {
+		rename, ok := toMap(props[renameProp])
+		if !ok {
+			fail(renameProp, aStringMap)
+		}
+		for from, to := range rename {
+			if req.Rename == nil {
+				req.Rename = make(map[string]string)
+			}
+			if req.Rename[from], ok = to.(string); !ok {
+				fail(renameProp, aStringMap)
+			}
+		}
+	}
+	if props[extractProp] != nil {
+		if req.Extract, ok = props[extractProp].(bool); !ok {
+			fail(extractProp, aBool)
+		}
+	}
+	return req
+}
+
+// getHooks - gets the hooks of the module build phases; the hooks without properties are skipped
+func (d *decoder) getHooks() map[string]Hook {
+	value, ok := d.get(hooksParam)
+	if !ok {
+		return nil
+	}
+	hooks, ok := toMap(value)
+	if !ok {
+		d.fail(WrongParamMsg, hooksParam, d.module, aHookMap)
+		return nil
+	}
+	res := make(map[string]Hook)
+	for _, phase := range sortedKeys(hooks) {
+		if !inSlice(phase, HookPhases) {
+			d.fail(unknownHookMsg, phase, d.module, strings.Join(HookPhases, `", "`))
+			continue
+		}
+		if hooks[phase] == nil {
+			continue
+		}
+		props, ok := toMap(hooks[phase])
+		if !ok {
+			d.fail(wrongHookMsg, phase, d.module)
+			continue
+		}
+		hook := Hook{}
+		for _, prop := range sortedKeys(props) {
+			switch prop {
+			case hookCommandsProp:
+				hook.Commands, ok = toStrings(props[prop])
+			case hookTimeoutProp:
+				hook.Timeout, ok = props[prop].(string)
+			case hookWorkingDirProp:
+				hook.WorkingDir, ok = props[prop].(string)
+			default:
+				d.fail(unknownHookPropMsg, prop, phase, d.module)
+				continue
+			}
+			if !ok {
+				d.fail(wrongHookPropMsg, prop, phase, d.module)
+			}
+		}
+		if filepath.IsAbs(hook.WorkingDir) {
+			d.fail(wrongHookPropMsg, hookWorkingDirProp, phase, d.module)
+		}
+		res[phase] = hook
+	}
+	return res
+}
+
+func toMap(value interface{}) (map[string]interface{}, bool) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case map[interface{}]interface{}:
+		res := make(map[string]interface{}, len(v))
+		for key, value := range v {
+			res[fmt.Sprint(key)] = value
+		}
+		return res, true
+	}
+	return nil, false
+}
+
+func toStrings(value interface{}) ([]string, bool) {
+	if res, ok := value.([]string); ok {
+		return res, true
+	}
+	values, ok := value.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	res := make([]string, len(values))
+	for i, v := range values {
+		res[i], ok = v.(string)
+		if !ok {
+			return nil, false
+		}
+	}
+	return res, true
+}
+
+// toScalar - converts the string, number or boolean value to a string; the empty value is converted to the empty string
+func toScalar(value interface{}) (string, bool) {
+	switch value.(type) {
+	case nil:
+		return "", true
+	case map[string]interface{}, map[interface{}]interface{}, []interface{}, []string:
+		return "", false
+	}
+	return fmt.Sprint(value), true
+}
+
+// toInt - converts the integer or the string with an integer
+func toInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case string:
+		res, err := strconv.Atoi(strings.TrimSpace(v))
+		return res, err == nil
+	}
+	return 0, false
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func inSlice(s string, list []string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
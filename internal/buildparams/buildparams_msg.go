@@ -0,0 +1,29 @@
+package buildparams
+
+const (
+	// WrongParamMsg - message raised when the build parameter of the module has a wrong type
+	WrongParamMsg = `the "%s" build parameter of the "%s" module is defined incorrectly; the parameter must contain %s`
+	// WrongRequiresPropMsg - message raised when the property of the build requirement of the module has a wrong type
+	WrongRequiresPropMsg = `the "%s" property of the "%s" requirement of the "%s" module is defined incorrectly; the property must contain %s`
+
+	wrongRequiresMsg    = `the requirement number %d of the "requires" build parameter of the "%s" module is defined incorrectly; the requirement must contain a map of its properties`
+	wrongCommandMsg     = `the command number %d of the "commands" build parameter of the "%s" module is defined incorrectly; the command must contain a string or an object with the "command" property`
+	wrongCommandPropMsg = `the "%s" property of the "%s" command of the "%s" module is defined incorrectly; the property must contain %s`
+	unknownHookMsg      = `the "%s" hook of the "%s" module is not supported; the supported hooks are "%s"`
+	wrongHookMsg        = `the "%s" hook of the "%s" module is defined incorrectly; the hook must contain the "commands", "timeout" and "working-dir" properties`
+	unknownHookPropMsg  = `the "%s" property of the "%s" hook of the "%s" module is not supported`
+	wrongHookPropMsg    = `the "%s" property of the "%s" hook of the "%s" module is defined incorrectly`
+
+	// the expected types of the build parameters and their properties
+	aString     = "a string"
+	aBool       = "a boolean value"
+	anInt       = "an integer"
+	aScalar     = "a string, a number or a boolean value"
+	aStringList = "a sequence of strings"
+	aScalarList = "a single value or a sequence of values"
+	anIntList   = "an integer or a sequence of integers"
+	aStringMap  = "a map of strings"
+	aHookMap    = "a map of the hooks"
+	aCommands   = `a sequence of strings or objects with the "command" property`
+	aRequires   = "a sequence of the requirements"
+)
@@ -0,0 +1,13 @@
+package buildparams
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestBuildParams(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "BuildParams Suite")
+}
@@ -0,0 +1,168 @@
+package buildparams
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/SAP/cloud-mta/mta"
+)
+
+var _ = Describe("BuildParams", func() {
+
+	It("decodes the module without build parameters", func() {
+		params, err := Decode(&mta.Module{Name: "m1"})
+		Ω(err).Should(Succeed())
+		Ω(params).Should(Equal(&Module{}))
+	})
+
+	It("decodes the build parameters parsed from the MTA file", func() {
+		m, err := mta.Unmarshal([]byte(`
+ID: a
+_schema-version: "3.1"
+modules:
+- name: m1
+  type: nodejs
+  path: m1
+  build-parameters:
+    builder: custom
+    commands:
+    - npm install
+    - command: npm test
+      retries: 2
+      continue-on-error: true
+    custom-opts:
+      level: 3
+      mode:
+    requires:
+    - name: m2
+      artifacts: ["dist/*"]
+      target-path: static
+      exclude: ["*.map"]
+      rename:
+        app.js: main.js
+      extract: true
+    build-result: dist
+    build-artifact-name: app
+    supported-platforms: [cf, Kyma]
+    profiles: trial
+    no-source: false
+    timeout: 10m
+    ignore: ["node_modules/"]
+    compression: 9
+    env:
+      NODE_ENV: production
+      DEBUG: true
+    hooks:
+      before-pack:
+        commands: [rm -rf src]
+        timeout: 1m
+        working-dir: dist
+    retries: "1"
+    retry-delay: 5s
+    retry-on-exit-codes: [1, "137"]
+    sbom-create-commands: [npm run sbom]
+`))
+		Ω(err).Should(Succeed())
+		params, err := Decode(m.Modules[0])
+		Ω(err).Should(Succeed())
+		retries := 2
+		Ω(params).Should(Equal(&Module{
+			Builder: "custom",
+			Commands: []Command{
+				{Command: "npm install"},
+				{Command: "npm test", Retries: &retries, ContinueOnError: true},
+			},
+			Options: map[string]string{"level": "3", "mode": ""},
+			Requires: []Requires{{Name: "m2", Artifacts: []string{"dist/*"}, TargetPath: "static", Exclude: []string{"*.map"},
+				Rename: map[string]string{"app.js": "main.js"}, Extract: true}},
+			BuildResult:        "dist",
+			BuildArtifactName:  "app",
+			SupportedPlatforms: []string{"cf", "Kyma"},
+			Profiles:           []string{"trial"},
+			Timeout:            "10m",
+			Ignore:             []string{"node_modules/"},
+			Compression:        "9",
+			Env:                map[string]string{"NODE_ENV": "production", "DEBUG": "true"},
+			Hooks:              map[string]Hook{BeforePackHook: {Commands: []string{"rm -rf src"}, Timeout: "1m", WorkingDir: "dist"}},
+			Retries:            1,
+			RetryDelay:         "5s",
+			RetryOnExitCodes:   []int{1, 137},
+			SBomCreateCommands: []string{"npm run sbom"},
+		}))
+		Ω(params.CommandLines()).Should(Equal([]string{"npm install", "npm test"}))
+	})
+
+	It("distinguishes the empty lists from the undefined build parameters", func() {
+		params, err := Decode(&mta.Module{Name: "m1", BuildParams: map[string]interface{}{
+			"builder":             "custom",
+			"commands":            []interface{}{},
+			"supported-platforms": []string{},
+		}})
+		Ω(err).Should(Succeed())
+		Ω(params.Commands).ShouldNot(BeNil())
+		Ω(params.Commands).Should(BeEmpty())
+		Ω(params.SupportedPlatforms).ShouldNot(BeNil())
+		Ω(params.Profiles).Should(BeNil())
+		Ω(params.Options).Should(BeEmpty())
+	})
+
+	DescribeTable("fails on the invalid build parameter", func(buildParams map[string]interface{}, expected string) {
+		params, err := Decode(&mta.Module{Name: "m1", BuildParams: buildParams})
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(Equal(expected))
+		Ω(params).ShouldNot(BeNil())
+	},
+		Entry("builder", map[string]interface{}{"builder": 1},
+			`the "builder" build parameter of the "m1" module is defined incorrectly; the parameter must contain a string`),
+		Entry("commands", map[string]interface{}{"builder": "custom", "commands": "npm test"},
+			`the "commands" build parameter of the "m1" module is defined incorrectly; the parameter must contain a sequence of strings or objects with the "command" property`),
+		Entry("command", map[string]interface{}{"commands": []interface{}{"npm install", 5}},
+			`the command number 2 of the "commands" build parameter of the "m1" module is defined incorrectly; the command must contain a string or an object with the "command" property`),
+		Entry("command retries", map[string]interface{}{"commands": []interface{}{map[interface{}]interface{}{"command": "npm test", "retries": true}}},
+			`the "retries" property of the "npm test" command of the "m1" module is defined incorrectly; the property must contain an integer`),
+		Entry("builder options", map[string]interface{}{"builder": "npm", "npm-opts": map[string]interface{}{"a": []interface{}{"b"}}},
+			`the "npm-opts" build parameter of the "m1" module is defined incorrectly; the parameter must contain a map of strings`),
+		Entry("requires", map[string]interface{}{"requires": map[string]interface{}{"name": "m2"}},
+			`the "requires" build parameter of the "m1" module is defined incorrectly; the parameter must contain a sequence of the requirements`),
+		Entry("requirement", map[string]interface{}{"requires": []interface{}{"m2"}},
+			`the requirement number 1 of the "requires" build parameter of the "m1" module is defined incorrectly; the requirement must contain a map of its properties`),
+		Entry("requirement artifacts", map[string]interface{}{"requires": []interface{}{map[interface{}]interface{}{"name": "m2", "artifacts": "dist"}}},
+			`the "artifacts" property of the "m2" requirement of the "m1" module is defined incorrectly; the property must contain a sequence of strings`),
+		Entry("requirement without name", map[string]interface{}{"requires": []interface{}{map[interface{}]interface{}{"extract": "yes"}}},
+			`the "extract" property of the "1" requirement of the "m1" module is defined incorrectly; the property must contain a boolean value`),
+		Entry("supported platforms", map[string]interface{}{"supported-platforms": "cf"},
+			`the "supported-platforms" build parameter of the "m1" module is defined incorrectly; the parameter must contain a sequence of strings`),
+		Entry("no source", map[string]interface{}{"no-source": "true"},
+			`the "no-source" build parameter of the "m1" module is defined incorrectly; the parameter must contain a boolean value`),
+		Entry("timeout", map[string]interface{}{"timeout": 5},
+			`the "timeout" build parameter of the "m1" module is defined incorrectly; the parameter must contain a string`),
+		Entry("env", map[string]interface{}{"env": "A=b"},
+			`the "env" build parameter of the "m1" module is defined incorrectly; the parameter must contain a map of strings`),
+		Entry("retries", map[string]interface{}{"retries": "a"},
+			`the "retries" build parameter of the "m1" module is defined incorrectly; the parameter must contain an integer`),
+		Entry("retry exit codes", map[string]interface{}{"retry-on-exit-codes": []interface{}{1, "a"}},
+			`the "retry-on-exit-codes" build parameter of the "m1" module is defined incorrectly; the parameter must contain an integer or a sequence of integers`),
+		Entry("hooks", map[string]interface{}{"hooks": []interface{}{"echo"}},
+			`the "hooks" build parameter of the "m1" module is defined incorrectly; the parameter must contain a map of the hooks`),
+		Entry("unknown hook", map[string]interface{}{"hooks": map[string]interface{}{"after-pack": map[string]interface{}{}}},
+			`the "after-pack" hook of the "m1" module is not supported; the supported hooks are "before-build", "after-build", "before-pack"`),
+		Entry("the first invalid build parameter", map[string]interface{}{"builder": 1, "timeout": 5},
+			`the "builder" build parameter of the "m1" module is defined incorrectly; the parameter must contain a string`),
+	)
+
+	It("decodes and validates the build parameters of all the modules", func() {
+		m := &mta.MTA{Modules: []*mta.Module{
+			{Name: "m1", BuildParams: map[string]interface{}{"builder": "npm"}},
+			{Name: "m2", BuildParams: map[string]interface{}{"ignore": "node_modules/"}},
+		}}
+		_, err := DecodeAll(m)
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring(`the "ignore" build parameter of the "m2" module`))
+		m.Modules = m.Modules[:1]
+		params, err := DecodeAll(m)
+		Ω(err).Should(Succeed())
+		Ω(params).Should(HaveLen(1))
+		Ω(params["m1"].Builder).Should(Equal("npm"))
+	})
+})
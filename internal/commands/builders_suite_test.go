@@ -6,7 +6,9 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
 	"github.com/SAP/cloud-mta-build-tool/internal/logs"
+	"github.com/SAP/cloud-mta/mta"
 )
 
 func TestBuilders(t *testing.T) {
@@ -17,3 +19,10 @@ func TestBuilders(t *testing.T) {
 var _ = BeforeSuite(func() {
 	logs.Logger = logs.NewLogger()
 })
+
+// decode - decodes the build parameters of the module, which are defined correctly in the test
+func decode(module *mta.Module) *buildparams.Module {
+	params, err := buildparams.Decode(module)
+	Ω(err).Should(Succeed())
+	return params
+}
@@ -10,15 +10,13 @@ import (
 	"github.com/SAP/cloud-mta/mta"
 
 	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
 	"github.com/SAP/cloud-mta-build-tool/internal/logs"
 )
 
 const (
-	builderParam                 = "builder"
-	commandsParam                = "commands"
 	customBuilder                = "custom"
 	golangBuilder                = "golang"
-	optionsSuffix                = "-opts"
 	goModuleType                 = "go"
 	cyclonedx_npm                = "@cyclonedx/cyclonedx-npm"
 	cyclonedx_npm_version        = "1.19.3"
@@ -29,55 +27,34 @@ const (
 type CommandList struct {
 	Info    string
 	Command []string
+	// Env - the environment variables defined by the builder
+	Env map[string]string
 }
 
 // GetBuilder - gets builder type of the module and indicator of custom builder
 // if build-parameter == null or build-parameter.builder == null, return builder=module.type and custom=false
 // else if build-paramete.builder != custom, return builder=build-paramete.builder and custom=true
 // else if build-paramete.builder == custom, return builder=custom and custom=true
-func GetBuilder(module *mta.Module) (string, bool, map[string]string, []string, error) {
+func GetBuilder(module *mta.Module, params *buildparams.Module) (string, bool, map[string]string, []string) {
 	// builder defined in build params is prioritised
-	if module.BuildParams != nil && module.BuildParams[builderParam] != nil {
-		builderName := module.BuildParams[builderParam].(string)
-		checkDeprecatedBuilder(builderName)
-		optsParamName := builderName + optionsSuffix
-		// get options for builder from mta.yaml
-		options := getOpts(module, optsParamName)
+	if params.Builder != "" {
+		checkDeprecatedBuilder(params.Builder)
 		var cmds []string
-		if builderName == customBuilder {
-			cmdsParam, ok := module.BuildParams[commandsParam]
-			if !ok {
+		if params.Builder == customBuilder {
+			if params.Commands == nil {
 				logs.Logger.Warn(missingPropMsg)
-				return builderName, true, options, []string{}, nil
-			}
-			cmds, ok = cmdsParam.([]string)
-			if !ok {
-				cmdsI, okI := cmdsParam.([]interface{})
-				if okI {
-					ok = true
-					for _, cmdI := range cmdsI {
-						cmd, okCmd := cmdI.(string)
-						if !okCmd {
-							ok = false
-							break
-						}
-						cmds = append(cmds, cmd)
-					}
-				}
-			}
-			if !ok {
-				return builderName, true, options, cmds, fmt.Errorf(wrongPropMsg)
+				return params.Builder, true, params.Options, []string{}
 			}
+			cmds = params.CommandLines()
 		}
-
-		return builderName, true, options, cmds, nil
+		return params.Builder, true, params.Options, cmds
 	}
 	// default builder is defined by type property of the module
-	return module.Type, false, nil, nil, nil
+	return module.Type, false, nil, nil
 }
 
 func isNativeBuilderType(builderName string) (bool, error) {
-	builderTypes, err := parseBuilders(BuilderTypeConfig)
+	builderTypes, err := getBuilderTypes()
 	if err != nil {
 		return false, errors.Wrap(err, parseBuilderCfgFailedMsg)
 	}
@@ -91,7 +68,7 @@ func isNativeBuilderType(builderName string) (bool, error) {
 }
 
 func getSBomBuilderByModuleType(typeName string) (bool, string, error) {
-	moduleTypes, err := parseModuleTypes(ModuleTypeConfig)
+	moduleTypes, err := getModuleTypes()
 	if err != nil {
 		return false, "", errors.Wrap(err, parseModuleCfgFailedMsg)
 	}
@@ -111,29 +88,21 @@ func getSBomBuilderByModuleType(typeName string) (bool, string, error) {
 	return false, "", errors.Wrapf(err, notNativeModuleTypeMsg, typeName)
 }
 
-func getModuleSBomBuilder(module *mta.Module) (string, error) {
-	var builderName string
-	var err error
-
+func getModuleSBomBuilder(module *mta.Module, params *buildparams.Module) (string, error) {
 	// get builder by build-parameter.builder
-	if module.BuildParams != nil && module.BuildParams[builderParam] != nil {
-		builderName = module.BuildParams[builderParam].(string)
-		checkDeprecatedBuilder(builderName)
-		if builderName == customBuilder {
-			_, ok := module.BuildParams[commandsParam]
-			if !ok {
-				return builderName, errors.Wrap(err, missingPropMsg)
-			}
-			return builderName, nil
+	if params.Builder != "" {
+		checkDeprecatedBuilder(params.Builder)
+		if params.Builder == customBuilder {
+			return params.Builder, nil
 		}
 
 		// check if builder is native builder (builder_type_cfg.yaml)
-		isnativebuilder, err := isNativeBuilderType(builderName)
+		isnativebuilder, err := isNativeBuilderType(params.Builder)
 		if !isnativebuilder {
-			return builderName, errors.Wrapf(err, notNativeBuilderMsg, builderName)
+			return params.Builder, errors.Wrapf(err, notNativeBuilderMsg, params.Builder)
 		}
 
-		return builderName, nil
+		return params.Builder, nil
 	}
 
 	// get builder by module type
@@ -144,63 +113,23 @@ func getModuleSBomBuilder(module *mta.Module) (string, error) {
 	return builderName, nil
 }
 
-// Get options for builder from mta.yaml
-func getOpts(module *mta.Module, optsParamName string) map[string]string {
-	options := module.BuildParams[optsParamName]
-	optionsMap := make(map[string]string)
-	if options != nil {
-		optionsMapS, ok := options.(map[string]interface{})
-		if !ok {
-			optionsMapS = ConvertMap(options.(map[interface{}]interface{}))
-		}
-		optionsMap = convert(optionsMapS)
-	}
-
-	return optionsMap
-}
-
-// Convert type map[string]interface{} to map[string]string
-func convert(m map[string]interface{}) map[string]string {
-	res := make(map[string]string)
-	for strKey, value := range m {
-		strValue := ""
-		if value != nil {
-			strValue = value.(string)
-		}
-		res[strKey] = strValue
-	}
-
-	return res
-}
-
-// ConvertMap converts type map[interface{}]interface{} to map[string]interface{}
-func ConvertMap(m map[interface{}]interface{}) map[string]interface{} {
-	res := make(map[string]interface{})
-	for key, value := range m {
-		strKey := key.(string)
-		res[strKey] = value
-	}
-
-	return res
-}
-
 // CommandProvider - Get build command's to execute
 // noinspection GoExportedFuncWithUnexportedType
-func CommandProvider(module mta.Module) (CommandList, string, error) {
-	// Get config from ./commands_cfg.yaml as generated artifacts from source
-	moduleTypes, err := parseModuleTypes(ModuleTypeConfig)
+func CommandProvider(module mta.Module, params *buildparams.Module) (CommandList, string, error) {
+	// Get config from ./commands_cfg.yaml as generated artifacts from source merged with the external configuration files
+	moduleTypes, err := getModuleTypes()
 	if err != nil {
 		return CommandList{}, "", errors.Wrap(err, parseModuleCfgFailedMsg)
 	}
-	builderTypes, err := parseBuilders(BuilderTypeConfig)
+	builderTypes, err := getBuilderTypes()
 	if err != nil {
 		return CommandList{}, "", errors.Wrap(err, parseBuilderCfgFailedMsg)
 	}
-	return mesh(&module, &moduleTypes, &builderTypes)
+	return mesh(&module, params, &moduleTypes, &builderTypes)
 }
 
 // Match the object according to type and provide the respective command
-func mesh(module *mta.Module, moduleTypes *ModuleTypes, builderTypes *Builders) (CommandList, string, error) {
+func mesh(module *mta.Module, params *buildparams.Module, moduleTypes *ModuleTypes, builderTypes *Builders) (CommandList, string, error) {
 	// The object support deep struct for future use, can be simplified to flat object
 	var cmds CommandList
 	var cmdList []string
@@ -209,10 +138,7 @@ func mesh(module *mta.Module, moduleTypes *ModuleTypes, builderTypes *Builders)
 
 	// get builder - module type name or custom builder if defined
 	// and indicator if custom builder
-	builder, custom, options, cmdList, err := GetBuilder(module)
-	if err != nil {
-		return CommandList{Command: []string{}}, "", err
-	}
+	builder, custom, options, cmdList := GetBuilder(module, params)
 
 	// if module type used - get from module types configuration corresponding commands or custom builder if defined
 	if !custom {
@@ -244,6 +170,7 @@ func mesh(module *mta.Module, moduleTypes *ModuleTypes, builderTypes *Builders)
 		if err != nil {
 			return cmds, "", err
 		}
+		cmds.Env = getBuilderEnv(builderTypes, builder)
 	}
 
 	// prepare result
@@ -302,40 +229,45 @@ func CmdConverter(mPath string, cmdList []string) ([][]string, error) {
 	return cmd, nil
 }
 
-// GetModuleAndCommands - Get module from mta.yaml and
+// GetModuleAndCommands - Get module from mta.yaml with its build parameters and
 // commands (with resolved paths) configured for the module type
-func GetModuleAndCommands(loc dir.IMtaParser, module string) (*mta.Module, []string, string, error) {
+func GetModuleAndCommands(loc dir.IMtaParser, module string) (*mta.Module, *buildparams.Module, []string, string, error) {
 	mtaObj, err := loc.ParseFile()
 	if err != nil {
-		return nil, nil, "", err
+		return nil, nil, nil, "", err
+	}
+	params, err := buildparams.DecodeAll(mtaObj)
+	if err != nil {
+		return nil, nil, nil, "", err
 	}
 	// Get module respective command's to execute
-	return moduleCmd(mtaObj, module)
+	return GetParsedModuleAndCommands(mtaObj, params, module)
 }
 
-// Get commands for specific module type
-func moduleCmd(mta *mta.MTA, moduleName string) (*mta.Module, []string, string, error) {
+// GetParsedModuleAndCommands - Get module from the parsed MTA file with its build parameters and
+// commands configured for the module type
+func GetParsedModuleAndCommands(mta *mta.MTA, params buildparams.Modules, moduleName string) (*mta.Module, *buildparams.Module, []string, string, error) {
 	for _, m := range mta.Modules {
 		if m.Name == moduleName {
-			commandProvider, buildResults, err := CommandProvider(*m)
+			commandProvider, buildResults, err := CommandProvider(*m, params[m.Name])
 			if err != nil {
-				return nil, nil, "", err
+				return nil, nil, nil, "", err
 			}
-			return m, commandProvider.Command, buildResults, nil
+			return m, params[m.Name], commandProvider.Command, buildResults, nil
 		}
 	}
-	return nil, nil, "", errors.Errorf(undefinedModuleMsg, moduleName)
+	return nil, nil, nil, "", errors.Errorf(undefinedModuleMsg, moduleName)
 }
 
 // GetModuleSBomGenCommands - get sbom generate command for module
 // if unknow sbom gen builder or custom builder, empty [][]string and nil error will be return
-func GetModuleSBomGenCommands(loc *dir.Loc, module *mta.Module,
+func GetModuleSBomGenCommands(loc *dir.Loc, module *mta.Module, params *buildparams.Module,
 	sbomFileName string, sbomFileType string, sbomFileSuffix string) ([][]string, error) {
 	var cmd string
 	var cmds []string
 	var commandList [][]string
 
-	builder, err := getModuleSBomBuilder(module)
+	builder, err := getModuleSBomBuilder(module, params)
 	if err != nil {
 		return [][]string{}, err
 	}
@@ -360,9 +292,9 @@ func GetModuleSBomGenCommands(loc *dir.Loc, module *mta.Module,
 		cmds = append(cmds, cmd)
 	case "custom":
 		// first check if custom SBOM creation commands are provided
-		customSbomGenCmds, ok := module.BuildParams["sbom-create-commands"].([]string)
+		customSbomGenCmds := params.SBomCreateCommands
 		// in case no custom commands are provided use standard way of creating SBOM
-		if !ok || (ok && len(customSbomGenCmds) == 0) {
+		if len(customSbomGenCmds) == 0 {
 			switch module.Type {
 			case "nodejs":
 				cmd = "npm install"
@@ -379,10 +311,9 @@ func GetModuleSBomGenCommands(loc *dir.Loc, module *mta.Module,
 			// in case custom SBOM creation commands are provided use them
 		} else {
 			// replace fileName placeholder ${sbom-file-name} which is to be provided in the custom SBOM creation commands
-			for i := range customSbomGenCmds {
-				customSbomGenCmds[i] = strings.ReplaceAll(customSbomGenCmds[i], "${sbom-file-name}", sbomFileName+sbomFileSuffix)
+			for _, customCmd := range customSbomGenCmds {
+				cmds = append(cmds, strings.ReplaceAll(customCmd, "${sbom-file-name}", sbomFileName+sbomFileSuffix))
 			}
-			cmds = append(cmds, customSbomGenCmds...)
 		}
 	default:
 	}
@@ -2,7 +2,6 @@ package commands
 
 const (
 	missingPropMsg           = `the "commands" property is missing in the "custom" builder`
-	wrongPropMsg             = `the "commands" property is defined incorrectly; the property must contain a sequence of strings`
 	parseModuleCfgFailedMsg  = `could not parse the module types configuration`
 	parseBuilderCfgFailedMsg = `could not parse the builder types configuration`
 	wrongModuleTypeDefMsg    = `the module type definition can include either the builder or the commands; the %s module type includes both`
@@ -13,4 +12,11 @@ const (
 	notNativeBuilderMsg    = `the "%s" builder is not a natvie builder`
 	notNativeModuleTypeMsg = `the "%s" type is not a native module type`
 	emptySBomFileInputMsg  = `no sbom files in tmp dir to merge`
+	wrongEnvNameMsg        = `the "%s" environment variable name of the "%s" module is invalid`
+	readConfigFailedMsg    = `could not read the "%s" configuration file`
+	parseConfigFailedMsg   = `could not parse the "%s" configuration file`
+	wrongPolicyParamMsg    = `the "%s" build parameter of the "%s" module is defined incorrectly`
+	wrongRetriesMsg        = `invalid retries value %d; the value must not be negative`
+	wrongRetryDelayMsg     = `invalid retry delay value "%s", it should be in the form "[123h][123m][123s]"`
+	wrongPoliciesCountMsg  = `the number of the "%s" values must be 1 or the number of the commands (%d)`
 )
@@ -12,6 +12,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
 	"github.com/SAP/cloud-mta/mta"
 )
 
@@ -51,13 +52,13 @@ module-types:
 		commands := ModuleTypes{}
 		customCommands := Builders{}
 		Ω(yaml.Unmarshal(moduleTypesCfg, &commands)).Should(Succeed())
-		Ω(mesh(&modules, &commands, &customCommands)).Should(Equal(expected))
+		Ω(mesh(&modules, decode(&modules), &commands, &customCommands)).Should(Equal(expected))
 		modules = mta.Module{
 			Name: "uiapp1",
 			Type: "html5",
 			Path: "./",
 		}
-		_, _, err := mesh(&modules, &commands, &customCommands)
+		_, _, err := mesh(&modules, decode(&modules), &commands, &customCommands)
 		Ω(err).Should(Succeed())
 		modules = mta.Module{
 			Name: "uiapp1",
@@ -67,7 +68,7 @@ module-types:
 				"builder": "html5x",
 			},
 		}
-		_, _, err = mesh(&modules, &commands, &customCommands)
+		_, _, err = mesh(&modules, decode(&modules), &commands, &customCommands)
 		Ω(err).Should(HaveOccurred())
 	})
 
@@ -81,9 +82,7 @@ module-types:
 				"commands": "cmd",
 			},
 		}
-		commands := ModuleTypes{}
-		customCommands := Builders{}
-		_, _, err := mesh(&module, &commands, &customCommands)
+		_, err := buildparams.Decode(&module)
 		Ω(err).Should(HaveOccurred())
 	})
 
@@ -107,7 +106,7 @@ module-types:
 		commands := ModuleTypes{}
 		customCommands := Builders{}
 		Ω(yaml.Unmarshal(moduleTypesCfg, &commands)).Should(Succeed())
-		_, _, err := mesh(&modules, &commands, &customCommands)
+		_, _, err := mesh(&modules, decode(&modules), &commands, &customCommands)
 		Ω(err).Should(HaveOccurred())
 	})
 
@@ -117,13 +116,13 @@ module-types:
 			Type: "html5",
 			Path: "./",
 			BuildParams: map[string]interface{}{
-				builderParam:  "custom",
-				commandsParam: []string{"command1"},
+				"builder":  "custom",
+				"commands": []string{"command1"},
 			},
 		}
 		commands := ModuleTypes{}
 		customCommands := Builders{}
-		cmds, _, err := mesh(&modules, &commands, &customCommands)
+		cmds, _, err := mesh(&modules, decode(&modules), &commands, &customCommands)
 		Ω(err).Should(Succeed())
 		Ω(len(cmds.Command)).Should(Equal(1))
 		Ω(cmds.Command[0]).Should(Equal("command1"))
@@ -134,7 +133,7 @@ module-types:
 			Info:    "installing module dependencies & remove dev dependencies",
 			Command: []string{"npm install --production"},
 		}
-		Ω(CommandProvider(mta.Module{Type: "html5"})).Should(Equal(expected))
+		Ω(CommandProvider(mta.Module{Type: "html5"}, &buildparams.Module{})).Should(Equal(expected))
 	})
 
 	var _ = Describe("CommandProvider - Invalid module types cfg", func() {
@@ -163,7 +162,7 @@ module-types:
 		})
 
 		It("test", func() {
-			_, _, err := CommandProvider(mta.Module{Type: "html5"})
+			_, _, err := CommandProvider(mta.Module{Type: "html5"}, &buildparams.Module{})
 			Ω(err).Should(HaveOccurred())
 		})
 	})
@@ -203,7 +202,7 @@ builders:
 		})
 
 		It("test", func() {
-			_, _, err := CommandProvider(mta.Module{Type: "html5"})
+			_, _, err := CommandProvider(mta.Module{Type: "html5"}, &buildparams.Module{})
 			Ω(err).Should(HaveOccurred())
 		})
 	})
@@ -266,7 +265,9 @@ modules:
 			m, err := mta.Unmarshal(mtaCF)
 			// parse mta yaml
 			Ω(err).Should(Succeed())
-			module, commands, _, err := moduleCmd(m, "htmlapp")
+			params, err := buildparams.DecodeAll(m)
+			Ω(err).Should(Succeed())
+			module, _, commands, _, err := GetParsedModuleAndCommands(m, params, "htmlapp")
 			Ω(err).Should(Succeed())
 			Ω(module.Path).Should(Equal("app"))
 			Ω(commands).Should(Equal([]string{"npm install --production"}))
@@ -289,7 +290,9 @@ modules:
 			m, err := mta.Unmarshal(mtaCF)
 			// parse mta yaml
 			Ω(err).Should(Succeed())
-			module, commands, _, err := moduleCmd(m, "htmlapp")
+			params, err := buildparams.DecodeAll(m)
+			Ω(err).Should(Succeed())
+			module, _, commands, _, err := GetParsedModuleAndCommands(m, params, "htmlapp")
 			Ω(err).Should(BeNil())
 			Ω(module.Path).Should(Equal("app"))
 			Ω(commands).Should(Equal([]string{"npm install --production"}))
@@ -315,7 +318,9 @@ modules:
 			m, err := mta.Unmarshal(mtaCF)
 			// parse mta yaml
 			Ω(err).Should(Succeed())
-			module, commands, _, err := moduleCmd(m, "htmlapp")
+			params, err := buildparams.DecodeAll(m)
+			Ω(err).Should(Succeed())
+			module, _, commands, _, err := GetParsedModuleAndCommands(m, params, "htmlapp")
 			Ω(err).Should(BeNil())
 			Ω(module.Path).Should(Equal("app"))
 			Ω(commands).Should(Equal([]string{"mvn -B dependency:copy -Dartifact=com.sap.xs.java:xs-audit-log-api:1.2.3 -DoutputDirectory=./target"}))
@@ -324,7 +329,7 @@ modules:
 		It("Invalid case - wrong mta", func() {
 			wd, _ := os.Getwd()
 			ep := dir.Loc{SourcePath: filepath.Join(wd, "testdata"), MtaFilename: "mtaUnknown.yaml"}
-			_, _, _, err := GetModuleAndCommands(&ep, "node-js")
+			_, _, _, _, err := GetModuleAndCommands(&ep, "node-js")
 			Ω(err).Should(HaveOccurred())
 
 		})
@@ -333,9 +338,10 @@ modules:
 			It("Sanity", func() {
 				wd, _ := os.Getwd()
 				ep := dir.Loc{SourcePath: filepath.Join(wd, "testdata")}
-				module, cmd, _, err := GetModuleAndCommands(&ep, "node-js")
+				module, params, cmd, _, err := GetModuleAndCommands(&ep, "node-js")
 				Ω(err).Should(Succeed())
 				Ω(module.Name).Should(Equal("node-js"))
+				Ω(params).ShouldNot(BeNil())
 				Ω(len(cmd)).Should(Equal(1))
 				Ω(cmd[0]).Should(Equal("npm install --production"))
 
@@ -343,21 +349,21 @@ modules:
 			It("Invalid case - wrong module name", func() {
 				wd, _ := os.Getwd()
 				ep := dir.Loc{SourcePath: filepath.Join(wd, "testdata")}
-				_, _, _, err := GetModuleAndCommands(&ep, "node-js1")
+				_, _, _, _, err := GetModuleAndCommands(&ep, "node-js1")
 				Ω(err).Should(HaveOccurred())
 
 			})
 			It("Invalid case - wrong mta", func() {
 				wd, _ := os.Getwd()
 				ep := dir.Loc{SourcePath: filepath.Join(wd, "testdata"), MtaFilename: "mtaUnknown.yaml"}
-				_, _, _, err := GetModuleAndCommands(&ep, "node-js")
+				_, _, _, _, err := GetModuleAndCommands(&ep, "node-js")
 				Ω(err).Should(HaveOccurred())
 
 			})
 			It("Invalid case - wrong type", func() {
 				wd, _ := os.Getwd()
 				ep := dir.Loc{SourcePath: filepath.Join(wd, "testdata"), MtaFilename: "mtaUnknownBuilder.yaml"}
-				_, cmd, _, _ := GetModuleAndCommands(&ep, "node-js")
+				_, _, cmd, _, _ := GetModuleAndCommands(&ep, "node-js")
 				Ω(len(cmd)).Should(Equal(0))
 
 			})
@@ -366,7 +372,7 @@ modules:
 				ModuleTypeConfig = []byte("wrong config")
 				wd, _ := os.Getwd()
 				ep := dir.Loc{SourcePath: filepath.Join(wd, "testdata")}
-				_, _, _, err := GetModuleAndCommands(&ep, "node-js")
+				_, _, _, _, err := GetModuleAndCommands(&ep, "node-js")
 				ModuleTypeConfig = conf
 				Ω(err).Should(HaveOccurred())
 			})
@@ -379,48 +385,71 @@ modules:
 				Name: "x",
 				Type: "node-js",
 			}
-			Ω(GetBuilder(&m)).Should(Equal("node-js"))
+			Ω(GetBuilder(&m, decode(&m))).Should(Equal("node-js"))
 		})
 		It("Builder defined by build params", func() {
 			m := mta.Module{
 				Name: "x",
 				Type: "node-js",
 				BuildParams: map[string]interface{}{
-					builderParam: "npm",
+					"builder": "npm",
 				},
 			}
-			builder, custom, cmds, _, err := GetBuilder(&m)
+			builder, custom, cmds, _ := GetBuilder(&m, decode(&m))
 			Ω(builder).Should(Equal("npm"))
 			Ω(custom).Should(Equal(true))
 			Ω(len(cmds)).Should(Equal(0))
-			Ω(err).Should(Succeed())
 		})
 		It("Custom builder with no commands", func() {
 			m := mta.Module{
 				Name: "x",
 				Type: "node-js",
 				BuildParams: map[string]interface{}{
-					builderParam: customBuilder,
+					"builder": customBuilder,
 				},
 			}
-			builder, custom, _, _, err := GetBuilder(&m)
+			builder, custom, _, _ := GetBuilder(&m, decode(&m))
 			Ω(builder).Should(Equal(customBuilder))
 			Ω(custom).Should(Equal(true))
-			Ω(err).Should(Succeed())
 		})
 		It("Custom builder with wrong commands definition", func() {
 			m := mta.Module{
 				Name: "x",
 				Type: "node-js",
 				BuildParams: map[string]interface{}{
-					builderParam:  customBuilder,
-					commandsParam: "command1",
+					"builder":  customBuilder,
+					"commands": "command1",
 				},
 			}
-			builder, custom, _, _, err := GetBuilder(&m)
-			Ω(builder).Should(Equal(customBuilder))
-			Ω(custom).Should(Equal(true))
-			Ω(err.Error()).Should(Equal(`the "commands" property is defined incorrectly; the property must contain a sequence of strings`))
+			_, err := buildparams.Decode(&m)
+			Ω(err.Error()).Should(Equal(`the "commands" build parameter of the "x" module is defined incorrectly; the parameter must contain a sequence of strings or objects with the "command" property`))
+		})
+		It("Custom builder with command objects", func() {
+			m := mta.Module{
+				Name: "x",
+				Type: "node-js",
+				BuildParams: map[string]interface{}{
+					"builder": customBuilder,
+					"commands": []interface{}{
+						"npm install",
+						map[interface{}]interface{}{"command": "npm test", "retries": 2},
+					},
+				},
+			}
+			_, _, _, cmds := GetBuilder(&m, decode(&m))
+			Ω(cmds).Should(Equal([]string{"npm install", "npm test"}))
+		})
+		It("Custom builder with command object without command", func() {
+			m := mta.Module{
+				Name: "x",
+				Type: "node-js",
+				BuildParams: map[string]interface{}{
+					"builder":  customBuilder,
+					"commands": []interface{}{map[interface{}]interface{}{"retries": 2}},
+				},
+			}
+			_, err := buildparams.Decode(&m)
+			Ω(err.Error()).Should(Equal(`the command number 1 of the "commands" build parameter of the "x" module is defined incorrectly; the command must contain a string or an object with the "command" property`))
 		})
 	})
 })
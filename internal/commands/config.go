@@ -0,0 +1,182 @@
+package commands
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// ConfigDirEnv - the environment variable with the folder of the external builders and module types configuration files
+	ConfigDirEnv = "MBT_CONFIG_DIR"
+	// ProjectConfigFolder - the folder of the external builders and module types configuration files in the MTA project
+	ProjectConfigFolder = ".mbt"
+	// BuildersConfigFile - the file name of the external builders configuration in the configuration folders
+	BuildersConfigFile = "builders.yaml"
+	// ModuleTypesConfigFile - the file name of the external module types configuration in the configuration folders
+	ModuleTypesConfigFile = "module_types.yaml"
+)
+
+// externalConfig - the builders and module types loaded from the external configuration files;
+// they are merged over the embedded configuration by name
+type externalConfig struct {
+	builders    []builder
+	moduleTypes []moduleType
+	// the absolute paths of the configuration files provided explicitly
+	buildersConfigPath    string
+	moduleTypesConfigPath string
+}
+
+var extConfig externalConfig
+
+// LoadExternalConfig - loads the external builders and module types configuration files;
+// the later sources override the earlier ones: the files in the MBT_CONFIG_DIR folder,
+// the files in the .mbt folder of the MTA project and the explicitly provided files, which must exist
+func LoadExternalConfig(projectDir, buildersConfigPath, moduleTypesConfigPath string) error {
+	cfg := externalConfig{}
+	var dirs []string
+	if configDir := os.Getenv(ConfigDirEnv); configDir != "" {
+		dirs = append(dirs, configDir)
+	}
+	if projectDir != "" {
+		dirs = append(dirs, filepath.Join(projectDir, ProjectConfigFolder))
+	}
+	for _, dir := range dirs {
+		err := cfg.loadBuilders(filepath.Join(dir, BuildersConfigFile), false)
+		if err != nil {
+			return err
+		}
+		err = cfg.loadModuleTypes(filepath.Join(dir, ModuleTypesConfigFile), false)
+		if err != nil {
+			return err
+		}
+	}
+
+	var err error
+	if buildersConfigPath != "" {
+		cfg.buildersConfigPath, err = filepath.Abs(buildersConfigPath)
+		if err != nil {
+			return errors.Wrapf(err, readConfigFailedMsg, buildersConfigPath)
+		}
+		err = cfg.loadBuilders(cfg.buildersConfigPath, true)
+		if err != nil {
+			return err
+		}
+	}
+	if moduleTypesConfigPath != "" {
+		cfg.moduleTypesConfigPath, err = filepath.Abs(moduleTypesConfigPath)
+		if err != nil {
+			return errors.Wrapf(err, readConfigFailedMsg, moduleTypesConfigPath)
+		}
+		err = cfg.loadModuleTypes(cfg.moduleTypesConfigPath, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	extConfig = cfg
+	return nil
+}
+
+// GetExternalConfigPaths - gets the absolute paths of the explicitly provided builders and module types configuration files
+func GetExternalConfigPaths() (buildersConfigPath string, moduleTypesConfigPath string) {
+	return extConfig.buildersConfigPath, extConfig.moduleTypesConfigPath
+}
+
+func (cfg *externalConfig) loadBuilders(path string, mandatory bool) error {
+	content, err := readConfigFile(path, mandatory)
+	if err != nil || content == nil {
+		return err
+	}
+	builders, err := parseBuilders(content)
+	if err != nil {
+		return errors.Wrapf(err, parseConfigFailedMsg, path)
+	}
+	cfg.builders = mergeBuilders(cfg.builders, builders.Builders)
+	return nil
+}
+
+func (cfg *externalConfig) loadModuleTypes(path string, mandatory bool) error {
+	content, err := readConfigFile(path, mandatory)
+	if err != nil || content == nil {
+		return err
+	}
+	moduleTypes, err := parseModuleTypes(content)
+	if err != nil {
+		return errors.Wrapf(err, parseConfigFailedMsg, path)
+	}
+	cfg.moduleTypes = mergeModuleTypes(cfg.moduleTypes, moduleTypes.ModuleTypes)
+	return nil
+}
+
+// readConfigFile - reads the configuration file; a missing optional file is skipped
+func readConfigFile(path string, mandatory bool) ([]byte, error) {
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) && !mandatory {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, readConfigFailedMsg, path)
+	}
+	return content, nil
+}
+
+// getBuilderTypes - gets the embedded builders configuration merged with the external one
+func getBuilderTypes() (Builders, error) {
+	builders, err := parseBuilders(BuilderTypeConfig)
+	if err != nil {
+		return Builders{}, err
+	}
+	builders.Builders = mergeBuilders(builders.Builders, extConfig.builders)
+	return builders, nil
+}
+
+// getModuleTypes - gets the embedded module types configuration merged with the external one
+func getModuleTypes() (ModuleTypes, error) {
+	moduleTypes, err := parseModuleTypes(ModuleTypeConfig)
+	if err != nil {
+		return ModuleTypes{}, err
+	}
+	moduleTypes.ModuleTypes = mergeModuleTypes(moduleTypes.ModuleTypes, extConfig.moduleTypes)
+	return moduleTypes, nil
+}
+
+// mergeBuilders - replaces the builders with the overriding builders of the same name and appends the new ones
+func mergeBuilders(builders []builder, overrides []builder) []builder {
+	res := append([]builder{}, builders...)
+	for _, o := range overrides {
+		replaced := false
+		for i := range res {
+			if res[i].Name == o.Name {
+				res[i] = o
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			res = append(res, o)
+		}
+	}
+	return res
+}
+
+// mergeModuleTypes - replaces the module types with the overriding module types of the same name and appends the new ones
+func mergeModuleTypes(moduleTypes []moduleType, overrides []moduleType) []moduleType {
+	res := append([]moduleType{}, moduleTypes...)
+	for _, o := range overrides {
+		replaced := false
+		for i := range res {
+			if res[i].Name == o.Name {
+				res[i] = o
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			res = append(res, o)
+		}
+	}
+	return res
+}
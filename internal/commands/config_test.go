@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/SAP/cloud-mta/mta"
+)
+
+var _ = Describe("External configuration", func() {
+
+	configPath := func(relPath ...string) string {
+		wd, _ := os.Getwd()
+		return filepath.Join(append([]string{wd, "testdata", "config"}, relPath...)...)
+	}
+	getCommands := func(module mta.Module) (CommandList, string) {
+		cmds, buildResult, err := CommandProvider(module, decode(&module))
+		Ω(err).Should(Succeed())
+		return cmds, buildResult
+	}
+	builderModule := func(builder string) mta.Module {
+		return mta.Module{Name: "m1", Type: "html5", BuildParams: map[string]interface{}{"builder": builder}}
+	}
+
+	AfterEach(func() {
+		Ω(os.Unsetenv(ConfigDirEnv)).Should(Succeed())
+		Ω(LoadExternalConfig("", "", "")).Should(Succeed())
+	})
+
+	It("uses the embedded configuration when there are no external configuration files", func() {
+		Ω(LoadExternalConfig(configPath("global"), "", "")).Should(Succeed())
+		cmds, _ := getCommands(builderModule("npm"))
+		Ω(cmds.Command).Should(Equal([]string{"npm install --production"}))
+		yarnModule := builderModule("yarn")
+		_, _, err := CommandProvider(yarnModule, decode(&yarnModule))
+		Ω(err).Should(HaveOccurred())
+	})
+
+	It("merges the configuration files of the project over the MBT_CONFIG_DIR files", func() {
+		Ω(os.Setenv(ConfigDirEnv, configPath("global"))).Should(Succeed())
+		Ω(LoadExternalConfig(configPath("project"), "", "")).Should(Succeed())
+
+		cmds, buildResult := getCommands(builderModule("yarn"))
+		Ω(cmds.Command).Should(Equal([]string{"yarn install --frozen-lockfile", "yarn build"}))
+		Ω(buildResult).Should(Equal("dist"))
+		cmds, _ = getCommands(builderModule("pnpm"))
+		Ω(cmds.Command).Should(Equal([]string{"pnpm install"}))
+		cmds, _ = getCommands(builderModule("npm"))
+		Ω(cmds.Command).Should(Equal([]string{"npm ci"}))
+		cmds, _ = getCommands(mta.Module{Name: "m1", Type: "yarn-app"})
+		Ω(cmds.Command).Should(Equal([]string{"yarn install --frozen-lockfile", "yarn build"}))
+		// the other embedded builders and module types are kept
+		cmds, _ = getCommands(builderModule("grunt"))
+		Ω(cmds.Command).Should(Equal([]string{"npm install", "grunt"}))
+		Ω(GetExternalConfigPaths()).Should(Equal(""))
+	})
+
+	It("merges the explicitly provided configuration files", func() {
+		Ω(LoadExternalConfig(configPath("project"), configPath("gradle.yaml"), configPath("gradle_types.yaml"))).Should(Succeed())
+		cmds, buildResult := getCommands(mta.Module{Name: "m1", Type: "gradle-app"})
+		Ω(cmds.Command).Should(Equal([]string{"gradle build"}))
+		Ω(buildResult).Should(Equal("build/libs/*.jar"))
+		cmds, _ = getCommands(builderModule("yarn"))
+		Ω(cmds.Info).Should(Equal("install with yarn"))
+		buildersConfig, moduleTypesConfig := GetExternalConfigPaths()
+		Ω(buildersConfig).Should(Equal(configPath("gradle.yaml")))
+		Ω(moduleTypesConfig).Should(Equal(configPath("gradle_types.yaml")))
+	})
+
+	It("fails when the provided builders configuration file does not exist", func() {
+		err := LoadExternalConfig("", configPath("unknown.yaml"), "")
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring(configPath("unknown.yaml")))
+	})
+
+	It("fails when the provided module types configuration file does not exist", func() {
+		Ω(LoadExternalConfig("", "", configPath("unknown.yaml"))).Should(HaveOccurred())
+	})
+
+	It("fails on a broken configuration file and keeps the loaded configuration", func() {
+		Ω(LoadExternalConfig(configPath("project"), "", "")).Should(Succeed())
+		Ω(LoadExternalConfig("", configPath("broken.yaml"), "")).Should(HaveOccurred())
+		Ω(LoadExternalConfig("", "", configPath("broken.yaml"))).Should(HaveOccurred())
+		cmds, _ := getCommands(builderModule("yarn"))
+		Ω(cmds.Info).Should(Equal("install with yarn"))
+	})
+})
@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/SAP/cloud-mta/mta"
+
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
+)
+
+// the placeholders supported in the values of the environment variables:
+// ${mta.ID}, ${mta.version}, ${module.name} and ${env:VAR}, which is replaced by the VAR environment variable value
+var envPlaceholderRegexp = regexp.MustCompile(`\$\{(mta\.ID|mta\.version|module\.name|env:([A-Za-z_][A-Za-z0-9_]*))\}`)
+var envNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// EnvVar - environment variable of the module build
+type EnvVar struct {
+	Name  string
+	Value string
+}
+
+// GetModuleEnv - gets the environment variables of the module build sorted by name;
+// the variables defined in the "env" section of the module builder are overridden by the "env" build parameter of the module.
+// The placeholders in the values are not resolved
+func GetModuleEnv(module *mta.Module, params *buildparams.Module) ([]EnvVar, error) {
+	cmds, _, err := CommandProvider(*module, params)
+	if err != nil {
+		return nil, err
+	}
+	env := make(map[string]string)
+	for name, value := range cmds.Env {
+		env[name] = value
+	}
+	for name, value := range params.Env {
+		env[name] = value
+	}
+
+	var res []EnvVar
+	for name, value := range env {
+		if !envNameRegexp.MatchString(name) {
+			return nil, errors.Errorf(wrongEnvNameMsg, name, module.Name)
+		}
+		res = append(res, EnvVar{Name: name, Value: value})
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+	return res, nil
+}
+
+// GetModuleExecEnv - gets the environment variables of the module build with resolved values as "key=value" entries
+func GetModuleExecEnv(mtaObj *mta.MTA, module *mta.Module, params *buildparams.Module) ([]string, error) {
+	env, err := GetModuleEnv(module, params)
+	if err != nil {
+		return nil, err
+	}
+	var res []string
+	for _, v := range env {
+		value := ExpandEnvValue(v.Value, mtaObj, module, os.Getenv, func(s string) string {
+			return s
+		})
+		res = append(res, v.Name+"="+value)
+	}
+	return res, nil
+}
+
+// ExpandEnvValue - replaces the placeholders in the value of the module environment variable;
+// the ${env:VAR} placeholders are converted by the envRef function,
+// all the other parts of the value, including the resolved MTA and module placeholders, are converted by the literal function
+func ExpandEnvValue(value string, mtaObj *mta.MTA, module *mta.Module, envRef func(name string) string, literal func(s string) string) string {
+	var sb strings.Builder
+	last := 0
+	for _, match := range envPlaceholderRegexp.FindAllStringSubmatchIndex(value, -1) {
+		sb.WriteString(literal(value[last:match[0]]))
+		switch placeholder := value[match[2]:match[3]]; placeholder {
+		case "mta.ID":
+			sb.WriteString(literal(mtaObj.ID))
+		case "mta.version":
+			sb.WriteString(literal(mtaObj.Version))
+		case "module.name":
+			sb.WriteString(literal(module.Name))
+		default:
+			sb.WriteString(envRef(value[match[4]:match[5]]))
+		}
+		last = match[1]
+	}
+	sb.WriteString(literal(value[last:]))
+	return sb.String()
+}
+
+// getBuilderEnv - gets the environment variables defined in the "env" section of the builder
+func getBuilderEnv(builderTypes *Builders, builder string) map[string]string {
+	for _, b := range builderTypes.Builders {
+		if builder == b.Name {
+			return b.Env
+		}
+	}
+	return nil
+}
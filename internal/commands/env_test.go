@@ -0,0 +1,142 @@
+package commands
+
+import (
+	"os"
+
+	"gopkg.in/yaml.v2"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
+	"github.com/SAP/cloud-mta/mta"
+)
+
+var _ = Describe("Env", func() {
+
+	mtaObj := &mta.MTA{ID: "mta_id", Version: "1.0.0"}
+
+	It("Mesh - builder environment variables", func() {
+		var buildersCfg = []byte(`
+version: 1
+builders:
+  - name: npm
+    commands:
+    - command: npm install
+    env:
+      NODE_ENV: production
+`)
+		module := mta.Module{
+			Name: "uiapp",
+			Type: "html5",
+			Path: "./",
+			BuildParams: map[string]interface{}{
+				"builder": "npm",
+			},
+		}
+		builders := Builders{}
+		Ω(yaml.Unmarshal(buildersCfg, &builders)).Should(Succeed())
+		cmds, _, err := mesh(&module, decode(&module), &ModuleTypes{}, &builders)
+		Ω(err).Should(Succeed())
+		Ω(cmds.Env).Should(Equal(map[string]string{"NODE_ENV": "production"}))
+	})
+
+	Describe("GetModuleEnv", func() {
+		It("returns the sorted variables of the env build parameter", func() {
+			module := mta.Module{
+				Name: "m1",
+				Type: "html5",
+				BuildParams: map[string]interface{}{
+					"builder":  "custom",
+					"commands": []string{"command1"},
+					"env": map[interface{}]interface{}{
+						"NODE_ENV": "production",
+						"DEBUG":    true,
+						"PORT":     8080,
+						"EMPTY":    nil,
+					},
+				},
+			}
+			Ω(GetModuleEnv(&module, decode(&module))).Should(Equal([]EnvVar{
+				{Name: "DEBUG", Value: "true"},
+				{Name: "EMPTY", Value: ""},
+				{Name: "NODE_ENV", Value: "production"},
+				{Name: "PORT", Value: "8080"},
+			}))
+		})
+		It("returns no variables when the env build parameter is not defined", func() {
+			module := mta.Module{Name: "m1", Type: "html5"}
+			Ω(GetModuleEnv(&module, decode(&module))).Should(BeEmpty())
+		})
+		DescribeTable("fails on wrong env build parameter", func(env interface{}) {
+			module := mta.Module{
+				Name: "m1",
+				Type: "html5",
+				BuildParams: map[string]interface{}{
+					"env": env,
+				},
+			}
+			params, err := buildparams.Decode(&module)
+			if err == nil {
+				_, err = GetModuleEnv(&module, params)
+			}
+			Ω(err).Should(HaveOccurred())
+		},
+			Entry("not a map", []interface{}{"A=b"}),
+			Entry("not a scalar value", map[string]interface{}{"A": []interface{}{"b"}}),
+			Entry("invalid name", map[string]interface{}{"A-B": "c"}),
+		)
+		It("fails on unknown builder", func() {
+			module := mta.Module{
+				Name: "m1",
+				Type: "html5",
+				BuildParams: map[string]interface{}{
+					"builder": "unknown",
+				},
+			}
+			_, err := GetModuleEnv(&module, decode(&module))
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	Describe("GetModuleExecEnv", func() {
+		BeforeEach(func() {
+			Ω(os.Setenv("MBT_TEST_ENV", "value")).Should(Succeed())
+		})
+		AfterEach(func() {
+			Ω(os.Unsetenv("MBT_TEST_ENV")).Should(Succeed())
+		})
+		It("resolves the placeholders in the values", func() {
+			module := mta.Module{
+				Name: "m1",
+				Type: "html5",
+				BuildParams: map[string]interface{}{
+					"env": map[string]interface{}{
+						"NAME":  "${module.name} of ${mta.ID} ${mta.version}",
+						"VALUE": "${env:MBT_TEST_ENV}-${env:MBT_TEST_UNDEFINED}-${other}",
+					},
+				},
+			}
+			Ω(GetModuleExecEnv(mtaObj, &module, decode(&module))).Should(Equal([]string{
+				"NAME=m1 of mta_id 1.0.0",
+				"VALUE=value--${other}",
+			}))
+		})
+		It("fails on wrong environment variable name", func() {
+			module := mta.Module{Name: "m1", Type: "html5"}
+			_, err := GetModuleExecEnv(mtaObj, &module, &buildparams.Module{Env: map[string]string{"A-B": "c"}})
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	It("ExpandEnvValue converts the literal parts and the environment variables references", func() {
+		module := mta.Module{Name: "m1"}
+		value := ExpandEnvValue("a ${mta.ID} ${env:HOME} b", mtaObj, &module, func(name string) string {
+			return "<" + name + ">"
+		}, func(s string) string {
+			return "[" + s + "]"
+		})
+		Ω(value).Should(Equal("[a ][mta_id][ ]<HOME>[ b]"))
+	})
+})
@@ -0,0 +1,33 @@
+package commands
+
+import (
+	"path/filepath"
+
+	"github.com/SAP/cloud-mta/mta"
+
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
+)
+
+const (
+	// BeforeBuildHook - the hook that runs before the module build commands
+	BeforeBuildHook = buildparams.BeforeBuildHook
+	// AfterBuildHook - the hook that runs after the module build commands
+	AfterBuildHook = buildparams.AfterBuildHook
+	// BeforePackHook - the hook that runs before the module build result is packed
+	BeforePackHook = buildparams.BeforePackHook
+)
+
+// GetModuleHook - gets the hook of the module build phase defined in the "hooks" build parameter;
+// returns nil if the hook is not defined
+func GetModuleHook(params *buildparams.Module, phase string) *buildparams.Hook {
+	hook, ok := params.Hooks[phase]
+	if !ok {
+		return nil
+	}
+	return &hook
+}
+
+// GetHookDir - gets the working directory of the hook commands relative to the project folder
+func GetHookDir(module *mta.Module, hook *buildparams.Hook) string {
+	return filepath.ToSlash(filepath.Join(module.Path, hook.WorkingDir))
+}
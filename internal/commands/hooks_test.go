@@ -0,0 +1,61 @@
+package commands
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/SAP/cloud-mta/mta"
+
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
+)
+
+var _ = Describe("Hooks", func() {
+
+	Describe("GetModuleHook", func() {
+		It("returns nil when the module has no hooks", func() {
+			Ω(GetModuleHook(&buildparams.Module{}, BeforeBuildHook)).Should(BeNil())
+		})
+		It("returns nil when the hook of the phase is not defined", func() {
+			module := mta.Module{Name: "m1", BuildParams: map[string]interface{}{
+				"hooks": map[interface{}]interface{}{AfterBuildHook: map[interface{}]interface{}{"commands": []interface{}{"echo"}}},
+			}}
+			Ω(GetModuleHook(decode(&module), BeforeBuildHook)).Should(BeNil())
+		})
+		It("returns the hook of the phase", func() {
+			module := mta.Module{Name: "m1", Path: "app", BuildParams: map[string]interface{}{
+				"hooks": map[interface{}]interface{}{
+					BeforePackHook: map[interface{}]interface{}{
+						"commands":    []interface{}{"rm -rf src", "echo done"},
+						"timeout":     "2m",
+						"working-dir": "dist",
+					},
+				},
+			}}
+			hook := GetModuleHook(decode(&module), BeforePackHook)
+			Ω(hook).Should(Equal(&buildparams.Hook{Commands: []string{"rm -rf src", "echo done"}, Timeout: "2m", WorkingDir: "dist"}))
+			Ω(GetHookDir(&module, hook)).Should(Equal("app/dist"))
+		})
+		DescribeTable("fails on the wrong hook definition", func(hooks interface{}, message string) {
+			module := mta.Module{Name: "m1", BuildParams: map[string]interface{}{"hooks": hooks}}
+			_, err := buildparams.Decode(&module)
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(Equal(message))
+		},
+			Entry("wrong hooks", []interface{}{"echo"},
+				`the "hooks" build parameter of the "m1" module is defined incorrectly; the parameter must contain a map of the hooks`),
+			Entry("unknown hook", map[string]interface{}{"after-pack": map[string]interface{}{}},
+				`the "after-pack" hook of the "m1" module is not supported; the supported hooks are "before-build", "after-build", "before-pack"`),
+			Entry("wrong hook", map[string]interface{}{BeforeBuildHook: "echo"},
+				`the "before-build" hook of the "m1" module is defined incorrectly; the hook must contain the "commands", "timeout" and "working-dir" properties`),
+			Entry("unknown property", map[string]interface{}{BeforeBuildHook: map[string]interface{}{"command": "echo"}},
+				`the "command" property of the "before-build" hook of the "m1" module is not supported`),
+			Entry("wrong commands", map[string]interface{}{BeforeBuildHook: map[string]interface{}{"commands": "echo"}},
+				`the "commands" property of the "before-build" hook of the "m1" module is defined incorrectly`),
+			Entry("wrong timeout", map[string]interface{}{BeforeBuildHook: map[string]interface{}{"timeout": 5}},
+				`the "timeout" property of the "before-build" hook of the "m1" module is defined incorrectly`),
+			Entry("absolute working directory", map[string]interface{}{BeforeBuildHook: map[string]interface{}{"working-dir": "/tmp"}},
+				`the "working-dir" property of the "before-build" hook of the "m1" module is defined incorrectly`),
+		)
+	})
+})
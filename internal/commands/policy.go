@@ -0,0 +1,127 @@
+package commands
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/SAP/cloud-mta/mta"
+
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
+)
+
+const (
+	retriesParam         = "retries"
+	continueOnErrorParam = "continue-on-error"
+)
+
+// CommandPolicy - the retry and failure policy of a build command
+type CommandPolicy struct {
+	// Retries - the number of the command executions after the first failed one
+	Retries    int
+	RetryDelay time.Duration
+	// RetryOnExitCodes - the exit codes on which the command is retried; the command is retried on any failure if it is empty
+	RetryOnExitCodes []int
+	// ContinueOnError - the build continues with the next command if the command fails in all the attempts
+	ContinueOnError bool
+}
+
+// IsDefault - checks if the policy is the default one: no retries and the build stops on the command failure
+func (p CommandPolicy) IsDefault() bool {
+	return p.Retries == 0 && !p.ContinueOnError
+}
+
+// ShouldRetry - checks if the command is retried after the failed attempt with the exit code;
+// the exit code is -1 if the command did not exit
+func (p CommandPolicy) ShouldRetry(attempt int, exitCode int) bool {
+	if attempt > p.Retries {
+		return false
+	}
+	if len(p.RetryOnExitCodes) == 0 {
+		return true
+	}
+	for _, code := range p.RetryOnExitCodes {
+		if code == exitCode {
+			return true
+		}
+	}
+	return false
+}
+
+// NewCommandPolicies - creates the policies of the commands; the retries and continueOnError values are provided
+// for each command or as a single value for all the commands
+func NewCommandPolicies(count int, retries []int, retryDelay string, retryOnExitCodes []int, continueOnError []bool) ([]CommandPolicy, error) {
+	if len(retries) > 1 && len(retries) != count {
+		return nil, errors.Errorf(wrongPoliciesCountMsg, retriesParam, count)
+	}
+	if len(continueOnError) > 1 && len(continueOnError) != count {
+		return nil, errors.Errorf(wrongPoliciesCountMsg, continueOnErrorParam, count)
+	}
+	delay, err := parseRetryDelay(retryDelay)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make([]CommandPolicy, count)
+	for i := range policies {
+		policies[i] = CommandPolicy{RetryDelay: delay, RetryOnExitCodes: retryOnExitCodes}
+		if len(retries) > 0 {
+			policies[i].Retries = retries[min(i, len(retries)-1)]
+		}
+		if policies[i].Retries < 0 {
+			return nil, errors.Errorf(wrongRetriesMsg, policies[i].Retries)
+		}
+		if len(continueOnError) > 0 {
+			policies[i].ContinueOnError = continueOnError[min(i, len(continueOnError)-1)]
+		}
+	}
+	return policies, nil
+}
+
+// GetCommandPolicies - gets the policies of the module build commands: the "retries", "retry-delay" and "retry-on-exit-codes"
+// build parameters apply to all the commands; the "retries" and "continue-on-error" properties of the command objects
+// in the "commands" build parameter of the custom builder override them for the specific command
+func GetCommandPolicies(module *mta.Module, params *buildparams.Module, count int) ([]CommandPolicy, error) {
+
+	commandsRetries := make([]int, count)
+	continueOnError := make([]bool, count)
+	for i := range commandsRetries {
+		commandsRetries[i] = params.Retries
+	}
+	if params.Builder == customBuilder {
+		for i, cmd := range params.Commands {
+			if i >= count {
+				break
+			}
+			if cmd.Retries != nil {
+				commandsRetries[i] = *cmd.Retries
+			}
+			continueOnError[i] = cmd.ContinueOnError
+		}
+	}
+
+	policies, err := NewCommandPolicies(count, commandsRetries, params.RetryDelay, params.RetryOnExitCodes, continueOnError)
+	if err != nil {
+		return nil, errors.Wrapf(err, wrongPolicyParamMsg, retriesParam, module.Name)
+	}
+	return policies, nil
+}
+
+func parseRetryDelay(retryDelay string) (time.Duration, error) {
+	if strings.TrimSpace(retryDelay) == "" {
+		return 0, nil
+	}
+	delay, err := time.ParseDuration(strings.TrimSpace(retryDelay))
+	if err != nil || delay < 0 {
+		return 0, errors.Errorf(wrongRetryDelayMsg, retryDelay)
+	}
+	return delay, nil
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
+	"github.com/SAP/cloud-mta/mta"
+)
+
+var _ = Describe("Policy", func() {
+
+	Describe("GetCommandPolicies", func() {
+		It("returns the default policies when no policy is defined", func() {
+			module := mta.Module{Name: "m1", BuildParams: map[string]interface{}{"builder": "npm"}}
+			policies, err := GetCommandPolicies(&module, decode(&module), 2)
+			Ω(err).Should(Succeed())
+			Ω(policies).Should(Equal([]CommandPolicy{{}, {}}))
+			Ω(policies[0].IsDefault()).Should(BeTrue())
+		})
+		It("returns the default policies of the module without build parameters", func() {
+			policies, err := GetCommandPolicies(&mta.Module{Name: "m1"}, &buildparams.Module{}, 1)
+			Ω(err).Should(Succeed())
+			Ω(policies).Should(Equal([]CommandPolicy{{}}))
+		})
+		It("applies the module build parameters to all the commands", func() {
+			module := mta.Module{Name: "m1", BuildParams: map[string]interface{}{
+				"builder":             "npm",
+				retriesParam:          2,
+				"retry-delay":         "3s",
+				"retry-on-exit-codes": []interface{}{1, "137"},
+			}}
+			policies, err := GetCommandPolicies(&module, decode(&module), 2)
+			Ω(err).Should(Succeed())
+			expected := CommandPolicy{Retries: 2, RetryDelay: 3 * time.Second, RetryOnExitCodes: []int{1, 137}}
+			Ω(policies).Should(Equal([]CommandPolicy{expected, expected}))
+		})
+		It("overrides the module policy with the policy of the custom builder command", func() {
+			module := mta.Module{Name: "m1", BuildParams: map[string]interface{}{
+				"builder":    customBuilder,
+				retriesParam: 1,
+				"commands": []interface{}{
+					"npm install",
+					map[interface{}]interface{}{"command": "npm run lint", retriesParam: 0, continueOnErrorParam: true},
+				},
+			}}
+			policies, err := GetCommandPolicies(&module, decode(&module), 2)
+			Ω(err).Should(Succeed())
+			Ω(policies).Should(Equal([]CommandPolicy{{Retries: 1}, {ContinueOnError: true}}))
+		})
+		DescribeTable("fails on the wrong policy definition", func(buildParams map[string]interface{}) {
+			buildParams["builder"] = customBuilder
+			module := mta.Module{Name: "m1", BuildParams: buildParams}
+			params, err := buildparams.Decode(&module)
+			if err == nil {
+				_, err = GetCommandPolicies(&module, params, 1)
+			}
+			Ω(err).Should(HaveOccurred())
+		},
+			Entry("wrong retries", map[string]interface{}{retriesParam: "a"}),
+			Entry("negative retries", map[string]interface{}{retriesParam: -1}),
+			Entry("wrong retry delay", map[string]interface{}{"retry-delay": "abc"}),
+			Entry("wrong exit codes", map[string]interface{}{"retry-on-exit-codes": []interface{}{"a"}}),
+			Entry("wrong command retries", map[string]interface{}{
+				"commands": []interface{}{map[interface{}]interface{}{"command": "npm test", retriesParam: true}}}),
+			Entry("wrong continue-on-error", map[string]interface{}{
+				"commands": []interface{}{map[interface{}]interface{}{"command": "npm test", continueOnErrorParam: "yes"}}}),
+		)
+	})
+
+	Describe("NewCommandPolicies", func() {
+		It("applies the single values to all the commands", func() {
+			policies, err := NewCommandPolicies(2, []int{3}, "1m", nil, []bool{true})
+			Ω(err).Should(Succeed())
+			expected := CommandPolicy{Retries: 3, RetryDelay: time.Minute, ContinueOnError: true}
+			Ω(policies).Should(Equal([]CommandPolicy{expected, expected}))
+		})
+		It("applies the values per command", func() {
+			policies, err := NewCommandPolicies(2, []int{1, 0}, "", []int{2}, []bool{false, true})
+			Ω(err).Should(Succeed())
+			Ω(policies).Should(Equal([]CommandPolicy{
+				{Retries: 1, RetryOnExitCodes: []int{2}},
+				{RetryOnExitCodes: []int{2}, ContinueOnError: true},
+			}))
+		})
+		It("fails when the number of the values does not match the number of the commands", func() {
+			_, err := NewCommandPolicies(3, []int{1, 0}, "", nil, nil)
+			Ω(err).Should(MatchError(`the number of the "retries" values must be 1 or the number of the commands (3)`))
+			_, err = NewCommandPolicies(3, nil, "", nil, []bool{true, false})
+			Ω(err).Should(HaveOccurred())
+		})
+	})
+
+	DescribeTable("ShouldRetry", func(policy CommandPolicy, attempt, exitCode int, expected bool) {
+		Ω(policy.ShouldRetry(attempt, exitCode)).Should(Equal(expected))
+	},
+		Entry("no retries", CommandPolicy{}, 1, 1, false),
+		Entry("retry on any exit code", CommandPolicy{Retries: 1}, 1, 5, true),
+		Entry("retries are exhausted", CommandPolicy{Retries: 1}, 2, 5, false),
+		Entry("retry on the listed exit code", CommandPolicy{Retries: 1, RetryOnExitCodes: []int{5}}, 1, 5, true),
+		Entry("no retry on the unlisted exit code", CommandPolicy{Retries: 1, RetryOnExitCodes: []int{5}}, 1, 1, false),
+	)
+})
@@ -9,4 +9,6 @@ type ContentTypes struct {
 type ContentType struct {
 	Extension   string `yaml:"extension"`
 	ContentType string `yaml:"content-type"`
+	// BinaryArchive - the files with the extension are already compressed, so they are stored in the archives without compression
+	BinaryArchive bool `yaml:"binary-archive,omitempty"`
 }
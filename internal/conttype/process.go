@@ -25,3 +25,14 @@ func GetContentType(cfg *ContentTypes, extension string) (string, error) {
 	}
 	return "", errors.Errorf(ContentTypeUndefinedMsg, extension)
 }
+
+// GetBinaryArchiveExtensions - gets the extensions of the binary archive types, which are stored in the archives without compression
+func GetBinaryArchiveExtensions(cfg *ContentTypes) []string {
+	var extensions []string
+	for _, ct := range cfg.ContentTypes {
+		if ct.BinaryArchive {
+			extensions = append(extensions, ct.Extension)
+		}
+	}
+	return extensions
+}
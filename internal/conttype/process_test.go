@@ -14,6 +14,15 @@ var _ = Describe("Process", func() {
 		Ω(err).Should(HaveOccurred())
 	})
 
+	It("binary archive extensions getting", func() {
+		contentTypes, err := GetContentTypes()
+		Ω(err).Should(Succeed())
+		extensions := GetBinaryArchiveExtensions(contentTypes)
+		Ω(extensions).Should(ContainElement(".jar"))
+		Ω(extensions).Should(ContainElement(".zip"))
+		Ω(extensions).ShouldNot(ContainElement(".json"))
+	})
+
 	It("content types - wrong config", func() {
 
 		cfg := ContentTypeConfig
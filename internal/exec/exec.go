@@ -37,9 +37,33 @@ func ExecuteCommandsWithTimeout(commandsList []string, timeout string, path stri
 	return ExecuteWithTimeout(commandList, timeout, runIndicator)
 }
 
+// ExecuteCommandsWithPolicies parses the list of commands and executes them in the current working directory with a specified timeout,
+// retrying the failed commands according to their policies. If the timeout is reached an error is returned.
+func ExecuteCommandsWithPolicies(commandsList []string, policies []commands.CommandPolicy, timeout string, path string, runIndicator bool) error {
+	commandList, err := commands.CmdConverter(filepath.Clean(path), commandsList)
+	if err != nil {
+		return err
+	}
+	return ExecuteWithPolicies(commandList, policies, timeout, nil, runIndicator)
+}
+
 // ExecuteWithTimeout executes child processes and waits for the results. If the timeout is reached an error is returned and
 // the child process is killed.
 func ExecuteWithTimeout(cmdParams [][]string, timeout string, runIndicator bool) error {
+	return ExecuteWithTimeoutAndEnv(cmdParams, timeout, nil, runIndicator)
+}
+
+// ExecuteWithTimeoutAndEnv executes child processes with the additional environment variables ("key=value" entries)
+// and waits for the results. If the timeout is reached an error is returned and the child process is killed.
+func ExecuteWithTimeoutAndEnv(cmdParams [][]string, timeout string, env []string, runIndicator bool) error {
+	return ExecuteWithPolicies(cmdParams, nil, timeout, env, runIndicator)
+}
+
+// ExecuteWithPolicies executes child processes with the additional environment variables ("key=value" entries)
+// and waits for the results. The failed commands are retried according to their policies; the policy of the command
+// is taken by its index and the default policy is used for the commands without a policy.
+// If the timeout is reached an error is returned and the child process is killed.
+func ExecuteWithPolicies(cmdParams [][]string, policies []commands.CommandPolicy, timeout string, env []string, runIndicator bool) error {
 	timeoutDuration, err := parseTimeoutString(timeout)
 	if err != nil {
 		return errors.Wrapf(err, ExecInvalidTimeoutMsg, timeout)
@@ -47,7 +71,7 @@ func ExecuteWithTimeout(cmdParams [][]string, timeout string, runIndicator bool)
 	executeResultCh := make(chan error, 1)
 	terminateCh := make(chan struct{})
 	go func() {
-		executeResultCh <- executeWithTerminateCh(cmdParams, terminateCh, runIndicator)
+		executeResultCh <- executeWithTerminateCh(cmdParams, policies, env, terminateCh, runIndicator)
 	}()
 
 	select {
@@ -64,6 +88,16 @@ func ExecuteWithTimeout(cmdParams [][]string, timeout string, runIndicator bool)
 	}
 }
 
+// GetTimeout - gets the duration of the commands timeout in the form "[123h][123m][123s]";
+// the default timeout is provided if the timeout is empty
+func GetTimeout(timeout string) (time.Duration, error) {
+	timeoutDuration, err := parseTimeoutString(timeout)
+	if err != nil {
+		return 0, errors.Errorf(ExecInvalidTimeoutMsg, timeout)
+	}
+	return timeoutDuration, nil
+}
+
 func parseTimeoutString(timeoutString string) (time.Duration, error) {
 	if timeoutString == "" {
 		return 10 * time.Minute, nil
@@ -73,24 +107,78 @@ func parseTimeoutString(timeoutString string) (time.Duration, error) {
 
 // Execute - Execute child process and wait to results
 func Execute(cmdParams [][]string, runIndicator bool) error {
-	return executeWithTerminateCh(cmdParams, make(chan struct{}), runIndicator)
+	return executeWithTerminateCh(cmdParams, nil, nil, make(chan struct{}), runIndicator)
 }
 
-func executeWithTerminateCh(cmdParams [][]string, terminateCh <-chan struct{}, runIndicator bool) error {
-	for _, cp := range cmdParams {
-		var cmd *exec.Cmd
+func executeWithTerminateCh(cmdParams [][]string, policies []commands.CommandPolicy, env []string, terminateCh <-chan struct{}, runIndicator bool) error {
+	for i, cp := range cmdParams {
+		policy := commands.CommandPolicy{}
+		if i < len(policies) {
+			policy = policies[i]
+		}
 		commandString := shellquote.Join(cp[1:]...)
-		logs.Logger.Infof(execMsg, commandString)
-		cmd = makeCommand(cp[1:])
+		err := executeWithPolicy(cp, commandString, policy, env, terminateCh, runIndicator)
+		if err == nil {
+			continue
+		}
+		if !policy.ContinueOnError {
+			return err
+		}
+		logs.Logger.Warnf(execContinueOnErrorMsg, commandString, err.Error())
+	}
+	return nil
+}
+
+// executeWithPolicy - executes the command and retries it while its policy allows
+func executeWithPolicy(cp []string, commandString string, policy commands.CommandPolicy, env []string, terminateCh <-chan struct{}, runIndicator bool) error {
+	attempts := policy.Retries + 1
+	for attempt := 1; ; attempt++ {
+		if attempts > 1 {
+			logs.Logger.Infof(execAttemptMsg, commandString, attempt, attempts)
+		} else {
+			logs.Logger.Infof(execMsg, commandString)
+		}
+		cmd := makeCommand(cp[1:])
 		cmd.Dir = cp[0]
+		if len(env) > 0 {
+			// the later entries override the inherited variables with the same key
+			cmd.Env = append(os.Environ(), env...)
+		}
 
 		err := executeCommand(cmd, terminateCh, runIndicator)
-		if err != nil {
+		if err == nil {
+			return nil
+		}
+		if isTerminated(terminateCh) || !policy.ShouldRetry(attempt, getExitCode(err)) {
+			if attempt > 1 {
+				return errors.Wrapf(err, execFailedAfterAttemptsMsg, commandString, attempt)
+			}
 			return errors.Wrapf(err, execFailed, commandString)
 		}
+		logs.Logger.Warnf(execRetryMsg, commandString, attempt, err.Error())
+		select {
+		case <-terminateCh:
+			return errors.Wrapf(fmt.Errorf(execKilledMsg), execFailedAfterAttemptsMsg, commandString, attempt)
+		case <-time.After(policy.RetryDelay):
+		}
+	}
+}
 
+func isTerminated(terminateCh <-chan struct{}) bool {
+	select {
+	case <-terminateCh:
+		return true
+	default:
+		return false
 	}
-	return nil
+}
+
+// getExitCode - gets the exit code of the failed command; -1 is returned if the command did not exit
+func getExitCode(err error) int {
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return exitErr.ExitCode()
+	}
+	return -1
 }
 
 // executeCommand - executes individual command
@@ -1,11 +1,15 @@
 package exec
 
 const (
-	execMsg               = `executing the "%s" command...`
-	execFileMsg           = `the executable file is at "%s"`
-	execFailed            = `could not execute the "%s" command`
-	execFailedOnStdoutMsg = `could not get the "stdout" pipe`
-	execFailedOnStderrMsg = `could not get the "stderr" pipe`
+	execMsg                    = `executing the "%s" command...`
+	execFileMsg                = `the executable file is at "%s"`
+	execFailed                 = `could not execute the "%s" command`
+	execAttemptMsg             = `executing the "%s" command (attempt %d of %d)...`
+	execRetryMsg               = `the "%s" command failed in attempt %d: %s; retrying`
+	execContinueOnErrorMsg     = `the "%s" command failed: %s; continuing with the next command`
+	execFailedAfterAttemptsMsg = `could not execute the "%s" command after %d attempts`
+	execFailedOnStdoutMsg      = `could not get the "stdout" pipe`
+	execFailedOnStderrMsg      = `could not get the "stderr" pipe`
 	// ExecInvalidTimeoutMsg is the error message that occurs when a timeout value is invalid
 	ExecInvalidTimeoutMsg = `invalid timeout value "%s", it should be in the form "[123h][123m][123s]"`
 	// ExecTimeoutMsg is the error message that occurs when a timeout is reached during commands execution
@@ -11,6 +11,8 @@ import (
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+
+	"github.com/SAP/cloud-mta-build-tool/internal/commands"
 )
 
 type testStr struct {
@@ -99,6 +101,12 @@ var _ = Describe("Execute", func() {
 		Entry("returns error for bad timeout", "abc", "", true),
 	)
 
+	It("GetTimeout fails on the invalid timeout", func() {
+		_, err := GetTimeout("abc")
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(Equal(`invalid timeout value "abc", it should be in the form "[123h][123m][123s]"`))
+	})
+
 	var executeTester = func(executor func() error, minSeconds, maxSeconds int, isError bool, expectedTimeout string) {
 		start := time.Now()
 		err := executor()
@@ -128,6 +136,15 @@ var _ = Describe("Execute", func() {
 			[][]string{{"", "sh", "-c", "sleep 2"}, {"", "sh", "-c", "sleep 3"}}, "4s", 4, 5, true, "4s"),
 	)
 
+	It("ExecuteWithTimeoutAndEnv executes the commands with the additional environment variables", func() {
+		Ω(os.Setenv("MBT_TEST_INHERITED", "inherited")).Should(Succeed())
+		defer os.Unsetenv("MBT_TEST_INHERITED")
+		Ω(ExecuteWithTimeoutAndEnv([][]string{{"", "sh", "-c", `test "$MBT_TEST_ENV" = value && test "$MBT_TEST_INHERITED" = inherited`}},
+			"10s", []string{"MBT_TEST_ENV=value"}, false)).Should(Succeed())
+		Ω(ExecuteWithTimeoutAndEnv([][]string{{"", "sh", "-c", `test "$MBT_TEST_INHERITED" = inherited`}},
+			"10s", []string{"MBT_TEST_INHERITED=overridden"}, false)).Should(HaveOccurred())
+	})
+
 	It("ExecuteWithTimeout fails when timeout value is invalid", func() {
 		err := ExecuteWithTimeout([][]string{{"sh", "-c", "sleep 1"}}, "1234", true)
 		Ω(err).Should(HaveOccurred())
@@ -158,6 +175,49 @@ var _ = Describe("Execute", func() {
 		})
 	})
 
+	Describe("ExecuteCommandsWithPolicies", func() {
+		wd, _ := os.Getwd()
+		path := filepath.Join(wd, "testdata")
+		// the command fails until it is executed the third time
+		failTwice := `sh -c 'echo >> attempts.txt; test $(wc -l < attempts.txt) -ge 3'`
+		AfterEach(func() {
+			Ω(os.RemoveAll(filepath.Join(path, "attempts.txt"))).Should(Succeed())
+		})
+		It("retries the failed command", func() {
+			policies := []commands.CommandPolicy{{Retries: 2}}
+			Ω(ExecuteCommandsWithPolicies([]string{failTwice}, policies, "10s", path, false)).Should(Succeed())
+		})
+		It("fails when the retries are exhausted", func() {
+			policies := []commands.CommandPolicy{{Retries: 1}}
+			err := ExecuteCommandsWithPolicies([]string{failTwice}, policies, "10s", path, false)
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring("after 2 attempts"))
+		})
+		It("does not retry the command on the exit code that is not listed", func() {
+			policies := []commands.CommandPolicy{{Retries: 2, RetryOnExitCodes: []int{2}}}
+			err := ExecuteCommandsWithPolicies([]string{failTwice}, policies, "10s", path, false)
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(execFailed, "sh -c 'echo >> attempts.txt; test $(wc -l < attempts.txt) -ge 3'")))
+		})
+		It("waits for the retry delay", func() {
+			policies := []commands.CommandPolicy{{Retries: 2, RetryDelay: time.Second}}
+			start := time.Now()
+			Ω(ExecuteCommandsWithPolicies([]string{failTwice}, policies, "10s", path, false)).Should(Succeed())
+			Ω(time.Since(start)).Should(BeNumerically(">=", 2*time.Second))
+		})
+		It("continues with the next command on error", func() {
+			policies := []commands.CommandPolicy{{ContinueOnError: true}, {}}
+			Ω(ExecuteCommandsWithPolicies([]string{"sh -c 'exit 1'", "sh -c 'echo >> attempts.txt'"}, policies, "10s", path, false)).Should(Succeed())
+			Ω(filepath.Join(path, "attempts.txt")).Should(BeAnExistingFile())
+		})
+		It("stops retrying when the timeout is reached", func() {
+			policies := []commands.CommandPolicy{{Retries: 5, RetryDelay: 10 * time.Second}}
+			err := ExecuteCommandsWithPolicies([]string{"sh -c 'exit 1'"}, policies, "1s", path, false)
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(Equal(fmt.Sprintf(ExecTimeoutMsg, "1s")))
+		})
+	})
+
 	It("ExecuteCommandsWithTimeout fails when timeout value is invalid", func() {
 		err := ExecuteCommandsWithTimeout([]string{`sh -c "sleep 1"`}, "1234", ".", true)
 		Ω(err).Should(HaveOccurred())
@@ -0,0 +1,132 @@
+package platform
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/SAP/cloud-mta-build-tool/internal/commands"
+)
+
+// PlatformsConfigFile - the file name of the external platforms configuration in the configuration folders
+const PlatformsConfigFile = "platforms.yaml"
+
+// externalConfig - the platforms loaded from the external configuration files;
+// they are merged over the embedded configuration by name
+type externalConfig struct {
+	platforms []Modules
+	// the absolute path of the configuration file provided explicitly
+	configPath string
+}
+
+var extConfig externalConfig
+
+// LoadExternalConfig - loads the external platforms configuration files;
+// the later sources override the earlier ones: the file in the MBT_CONFIG_DIR folder,
+// the file in the .mbt folder of the MTA project and the explicitly provided file, which must exist
+func LoadExternalConfig(projectDir, configPath string) error {
+	cfg := externalConfig{}
+	var dirs []string
+	if configDir := os.Getenv(commands.ConfigDirEnv); configDir != "" {
+		dirs = append(dirs, configDir)
+	}
+	if projectDir != "" {
+		dirs = append(dirs, filepath.Join(projectDir, commands.ProjectConfigFolder))
+	}
+	for _, dir := range dirs {
+		err := cfg.loadPlatforms(filepath.Join(dir, PlatformsConfigFile), false)
+		if err != nil {
+			return err
+		}
+	}
+
+	if configPath != "" {
+		var err error
+		cfg.configPath, err = filepath.Abs(configPath)
+		if err != nil {
+			return errors.Wrapf(err, readConfigFailedMsg, configPath)
+		}
+		err = cfg.loadPlatforms(cfg.configPath, true)
+		if err != nil {
+			return err
+		}
+	}
+
+	extConfig = cfg
+	return nil
+}
+
+// GetExternalConfigPath - gets the absolute path of the explicitly provided platforms configuration file
+func GetExternalConfigPath() string {
+	return extConfig.configPath
+}
+
+func (cfg *externalConfig) loadPlatforms(path string, mandatory bool) error {
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) && !mandatory {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, readConfigFailedMsg, path)
+	}
+	platforms, err := Unmarshal(content)
+	if err != nil {
+		return errors.Wrapf(err, parseConfigFailedMsg, path)
+	}
+	for i, p := range platforms.Platforms {
+		if strings.TrimSpace(p.Name) == "" {
+			return errors.Errorf(emptyPlatformNameMsg, path)
+		}
+		// the platform provided to the commands is not case sensitive
+		platforms.Platforms[i].Name = strings.ToLower(p.Name)
+	}
+	cfg.platforms = mergePlatforms(cfg.platforms, platforms.Platforms)
+	return nil
+}
+
+// GetPlatforms - gets the embedded platforms configuration merged with the external one
+func GetPlatforms() (Platforms, error) {
+	platforms, err := Unmarshal(PlatformConfig)
+	if err != nil {
+		return Platforms{}, err
+	}
+	platforms.Platforms = mergePlatforms(platforms.Platforms, extConfig.platforms)
+	return platforms, nil
+}
+
+// GetPlatformNames - gets the sorted names of the embedded and external platforms
+func GetPlatformNames() ([]string, error) {
+	platforms, err := GetPlatforms()
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, p := range platforms.Platforms {
+		names = append(names, p.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// mergePlatforms - replaces the platforms with the overriding platforms of the same name and appends the new ones
+func mergePlatforms(platforms []Modules, overrides []Modules) []Modules {
+	res := append([]Modules{}, platforms...)
+	for _, o := range overrides {
+		replaced := false
+		for i := range res {
+			if res[i].Name == o.Name {
+				res[i] = o
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			res = append(res, o)
+		}
+	}
+	return res
+}
@@ -0,0 +1,77 @@
+package platform
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/SAP/cloud-mta-build-tool/internal/commands"
+	"github.com/SAP/cloud-mta/mta"
+)
+
+var _ = Describe("External configuration", func() {
+
+	configPath := func(relPath ...string) string {
+		wd, _ := os.Getwd()
+		return filepath.Join(append([]string{wd, "testdata", "config"}, relPath...)...)
+	}
+	convert := func(moduleType, targetPlatform string) string {
+		platforms, err := GetPlatforms()
+		Ω(err).Should(Succeed())
+		m := mta.MTA{Modules: []*mta.Module{{Name: "m1", Type: moduleType}}}
+		ConvertTypes(m, platforms, targetPlatform)
+		return m.Modules[0].Type
+	}
+
+	AfterEach(func() {
+		Ω(os.Unsetenv(commands.ConfigDirEnv)).Should(Succeed())
+		Ω(LoadExternalConfig("", "")).Should(Succeed())
+	})
+
+	It("uses the embedded platforms when there are no external configuration files", func() {
+		Ω(LoadExternalConfig(configPath("unknown"), "")).Should(Succeed())
+		Ω(GetPlatformNames()).Should(Equal([]string{"cf", "neo", "xsa"}))
+		Ω(GetExternalConfigPath()).Should(BeEmpty())
+	})
+
+	It("merges the configuration file of the project over the MBT_CONFIG_DIR file", func() {
+		Ω(os.Setenv(commands.ConfigDirEnv, configPath("global"))).Should(Succeed())
+		Ω(LoadExternalConfig(configPath("project"), "")).Should(Succeed())
+		Ω(GetPlatformNames()).Should(Equal([]string{"cf", "kyma", "neo", "xsa"}))
+		Ω(convert("nodejs", "kyma")).Should(Equal("kyma.function"))
+		Ω(convert("html5", "kyma")).Should(Equal("kyma.html5"))
+		// the platform of the same name replaces the embedded one
+		Ω(convert("nodejs", "cf")).Should(Equal("javascript.nodejs"))
+		Ω(convert("java", "cf")).Should(Equal("java"))
+		// the other embedded platforms are kept
+		Ω(convert("java", "neo")).Should(Equal("java.tomcat"))
+	})
+
+	It("merges the explicitly provided configuration file", func() {
+		Ω(os.Setenv(commands.ConfigDirEnv, configPath("global"))).Should(Succeed())
+		Ω(LoadExternalConfig("", configPath("private-cf.yaml"))).Should(Succeed())
+		Ω(GetPlatformNames()).Should(Equal([]string{"cf", "kyma", "neo", "private-cf", "xsa"}))
+		Ω(convert("nodejs", "kyma")).Should(Equal("kyma.nodejs"))
+		Ω(convert("java", "private-cf")).Should(Equal("private.tomcat"))
+		Ω(GetExternalConfigPath()).Should(Equal(configPath("private-cf.yaml")))
+	})
+
+	It("fails when the provided configuration file does not exist", func() {
+		err := LoadExternalConfig("", configPath("unknown.yaml"))
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring(configPath("unknown.yaml")))
+	})
+
+	It("fails on a platform without a name", func() {
+		err := LoadExternalConfig("", configPath("no_name.yaml"))
+		Ω(err).Should(MatchError(`could not parse the "` + configPath("no_name.yaml") + `" platforms configuration file because the name of the platform is missing`))
+	})
+
+	It("fails on a broken configuration file and keeps the loaded configuration", func() {
+		Ω(LoadExternalConfig(configPath("project"), "")).Should(Succeed())
+		Ω(LoadExternalConfig("", configPath("broken.yaml"))).Should(HaveOccurred())
+		Ω(GetPlatformNames()).Should(ContainElement("kyma"))
+	})
+})
@@ -7,8 +7,12 @@ type Platforms struct {
 
 // Modules -  modules list
 type Modules struct {
-	Name    string       `yaml:"name"`
-	Modules []Properties `yaml:"modules"`
+	Name string `yaml:"name"`
+	// Parameters - the rules of the global parameters of the MTA set for the platform
+	Parameters []ParameterRule `yaml:"parameters,omitempty"`
+	// ModuleParameters - the rules of the parameters set for all the modules
+	ModuleParameters []ParameterRule `yaml:"module-parameters,omitempty"`
+	Modules          []Properties    `yaml:"modules"`
 }
 
 // Properties - properties list
@@ -18,4 +22,34 @@ type Properties struct {
 	PlatformType string            `yaml:"platform-type"`
 	Properties   map[string]string `yaml:"properties,omitempty"`
 	Parameters   map[string]string `yaml:"parameters,omitempty"`
+	// BuildParameters - the values of the build parameters of the matching modules, for example, of the builder
+	BuildParameters map[string]string `yaml:"build-parameters,omitempty"`
+	// Conditions - the additional conditions on the properties, parameters and build parameters of the matching modules
+	Conditions []Condition `yaml:"conditions,omitempty"`
+	// ModuleParameters - the rules of the parameters set for the modules that match the mapping
+	ModuleParameters []ParameterRule `yaml:"module-parameters,omitempty"`
+	// ModuleProperties - the rules of the properties set for the modules that match the mapping
+	ModuleProperties []ParameterRule `yaml:"module-properties,omitempty"`
+}
+
+// Condition - the condition on the value of the property, parameter or build parameter of the module;
+// the value either equals the string, matches the regular expression or exists (or does not exist)
+type Condition struct {
+	// In - the section of the module: "properties", "parameters" or "build-parameters"
+	In      string `yaml:"in"`
+	Name    string `yaml:"name"`
+	Equals  string `yaml:"equals,omitempty"`
+	Matches string `yaml:"matches,omitempty"`
+	Exists  *bool  `yaml:"exists,omitempty"`
+}
+
+// ParameterRule - the rule of the parameter that is set when it is not defined in the MTA;
+// the value is either the constant value or the value of the field, derived by the transforms
+type ParameterRule struct {
+	Name  string `yaml:"name"`
+	Value string `yaml:"value,omitempty"`
+	// From - the field of the module ("name", "type" or "path") or of the MTA ("ID" or "version") that the value is derived from
+	From string `yaml:"from,omitempty"`
+	// Transforms - the transforms applied to the value in their order, for example, "lowercase", "strip-non-alnum" or "truncate:30"
+	Transforms []string `yaml:"transforms,omitempty"`
 }
@@ -3,4 +3,23 @@ package platform
 const (
 	// UnmarshalFailedMsg - message raised when platforms configuration unmarshal fails
 	UnmarshalFailedMsg = `could not unmarshal the platforms`
+
+	readConfigFailedMsg  = `could not read the "%s" platforms configuration file`
+	parseConfigFailedMsg = `could not parse the "%s" platforms configuration file`
+	emptyPlatformNameMsg = `could not parse the "%s" platforms configuration file because the name of the platform is missing`
+
+	invalidPlatformRulesMsg  = `invalid parameter rules of the "%s" platform`
+	emptyRuleNameMsg         = `the name of the parameter is missing`
+	ruleValueAndFieldMsg     = `the "%s" parameter can have either the "value" or the "from" property`
+	unknownRuleFieldMsg      = `the "%s" field of the "%s" parameter is unknown`
+	unknownTransformMsg      = `the "%s" transform is unknown; supported transforms: "lowercase", "uppercase", "strip-non-alnum", "trim-leading-digits", "truncate:<length>"`
+	invalidTruncateMsg       = `the length of the "%s" transform must be a positive number`
+	applyRuleFailedMsg       = `could not set the "%s" parameter`
+	applyModuleRuleFailedMsg = `could not set the parameters of the "%s" module`
+
+	invalidConditionsMsg       = `invalid conditions of the "%s" module type mapping of the "%s" platform`
+	unknownConditionSectionMsg = `the "%s" section of the "%s" condition is unknown; supported sections: "properties", "parameters", "build-parameters"`
+	emptyConditionNameMsg      = `the name of the condition is missing`
+	invalidConditionRegexMsg   = `the "%s" regular expression of the "%s" condition is invalid`
+	conditionChecksMsg         = `the "%s" condition must have exactly one of the "equals", "matches" or "exists" properties`
 )
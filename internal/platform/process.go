@@ -1,16 +1,31 @@
 package platform
 
 import (
+	"fmt"
+	"regexp"
+
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v2"
 
 	"github.com/SAP/cloud-mta/mta"
 )
 
+const (
+	conditionInProperties      = "properties"
+	conditionInParameters      = "parameters"
+	conditionInBuildParameters = "build-parameters"
+)
+
 // Unmarshal - unmarshal platform config
 func Unmarshal(data []byte) (Platforms, error) {
 	platforms := Platforms{}
 	err := yaml.UnmarshalStrict(data, &platforms)
+	if err == nil {
+		err = validateRules(platforms)
+	}
+	if err == nil {
+		err = validateConditions(platforms)
+	}
 	if err != nil {
 		return platforms, errors.Wrap(err, UnmarshalFailedMsg)
 	}
@@ -21,16 +36,23 @@ func Unmarshal(data []byte) (Platforms, error) {
 func ConvertTypes(iCfg mta.MTA, eCfg Platforms, targetPlatform string) {
 	tpl := platformConfig(eCfg, targetPlatform)
 	for i, v := range iCfg.Modules {
-		moduleAcc := -1
-		modulePlatformType := v.Type
-		for _, em := range tpl.Modules {
-			if ok, acc := satisfiesModuleConfig(v, &em); ok && acc > moduleAcc {
-				modulePlatformType = em.PlatformType
-				moduleAcc = acc
-			}
+		if mc := moduleConfig(v, &tpl); mc != nil {
+			iCfg.Modules[i].Type = mc.PlatformType
+		}
+	}
+}
+
+// moduleConfig - gets the most accurate module types mapping of the platform that the module satisfies; nil if there is no such mapping
+func moduleConfig(m *mta.Module, tpl *Modules) *Properties {
+	var res *Properties
+	moduleAcc := -1
+	for i := range tpl.Modules {
+		if ok, acc := satisfiesModuleConfig(m, &tpl.Modules[i]); ok && acc > moduleAcc {
+			res = &tpl.Modules[i]
+			moduleAcc = acc
 		}
-		iCfg.Modules[i].Type = modulePlatformType
 	}
+	return res
 }
 
 // Satisfies checks if the module m satisfies the conditions defined in the configuration mc.
@@ -53,15 +75,95 @@ func satisfiesModuleConfig(m *mta.Module, mc *Properties) (ok bool, accuracy int
 			return false, -1
 		}
 	}
-	return true, len(mc.Parameters) + len(mc.Properties)
+	for ckey, cval := range mc.BuildParameters {
+		if mval, ok := m.BuildParams[ckey]; !ok || mval != cval {
+			return false, -1
+		}
+	}
+	for _, c := range mc.Conditions {
+		if !c.satisfies(m) {
+			return false, -1
+		}
+	}
+	return true, len(mc.Parameters) + len(mc.Properties) + len(mc.BuildParameters) + len(mc.Conditions)
+}
+
+// satisfies checks if the value of the module property, parameter or build parameter satisfies the condition;
+// the values that are not strings are compared and matched by their string representation
+func (c *Condition) satisfies(m *mta.Module) bool {
+	values := c.getModuleValues(m)
+	mval, exists := values[c.Name]
+	switch {
+	case c.Exists != nil:
+		return exists == *c.Exists
+	case !exists:
+		return false
+	case c.Matches != "":
+		matches, err := regexp.MatchString(c.Matches, fmt.Sprint(mval))
+		return err == nil && matches
+	}
+	return fmt.Sprint(mval) == c.Equals
+}
+
+func (c *Condition) getModuleValues(m *mta.Module) map[string]interface{} {
+	switch c.In {
+	case conditionInProperties:
+		return m.Properties
+	case conditionInParameters:
+		return m.Parameters
+	case conditionInBuildParameters:
+		return m.BuildParams
+	}
+	return nil
+}
+
+// validateConditions - checks the conditions of the module types mappings, so that the broken configuration fails when it is loaded
+func validateConditions(platforms Platforms) error {
+	for _, p := range platforms.Platforms {
+		for _, mc := range p.Modules {
+			for _, c := range mc.Conditions {
+				err := c.validate()
+				if err != nil {
+					return errors.Wrapf(err, invalidConditionsMsg, mc.NativeType, p.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Condition) validate() error {
+	if c.In != conditionInProperties && c.In != conditionInParameters && c.In != conditionInBuildParameters {
+		return errors.Errorf(unknownConditionSectionMsg, c.In, c.Name)
+	}
+	if c.Name == "" {
+		return errors.New(emptyConditionNameMsg)
+	}
+	checks := 0
+	if c.Equals != "" {
+		checks++
+	}
+	if c.Matches != "" {
+		checks++
+		_, err := regexp.Compile(c.Matches)
+		if err != nil {
+			return errors.Wrapf(err, invalidConditionRegexMsg, c.Matches, c.Name)
+		}
+	}
+	if c.Exists != nil {
+		checks++
+	}
+	if checks != 1 {
+		return errors.Errorf(conditionChecksMsg, c.Name)
+	}
+	return nil
 }
 
 func platformConfig(eCfg Platforms, targetPlatform string) Modules {
 	var tpl Modules
 	for _, tp := range eCfg.Platforms {
 		if tp.Name == targetPlatform {
-			tpl.Name = tp.Name
-			tpl.Modules = tp.Modules
+			tpl = tp
 		}
 	}
 	return tpl
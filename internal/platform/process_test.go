@@ -236,5 +236,99 @@ platform:
 				prevDesc = c.desc
 			}
 		})
+
+		boolPtr := func(b bool) *bool { return &b }
+
+		DescribeTable("matches the build parameters and the conditions", func(c Properties, expected bool) {
+			m := mta.Module{
+				Type:        "a",
+				Properties:  map[string]interface{}{"a": "b"},
+				Parameters:  map[string]interface{}{"memory": "256M", "instances": 2},
+				BuildParams: map[string]interface{}{"builder": "npm-ci", "no-source": true},
+			}
+			c.NativeType = "a"
+			ok, _ := satisfiesModuleConfig(&m, &c)
+			Ω(ok).Should(Equal(expected))
+		},
+			Entry("build parameter matches", Properties{BuildParameters: map[string]string{"builder": "npm-ci"}}, true),
+			Entry("build parameter doesn't match", Properties{BuildParameters: map[string]string{"builder": "npm"}}, false),
+			Entry("build parameter doesn't exist", Properties{BuildParameters: map[string]string{"timeout": "5m"}}, false),
+			Entry("condition equals", Properties{Conditions: []Condition{{In: "build-parameters", Name: "builder", Equals: "npm-ci"}}}, true),
+			Entry("condition equals the string of the value", Properties{Conditions: []Condition{{In: "parameters", Name: "instances", Equals: "2"}}}, true),
+			Entry("condition doesn't equal", Properties{Conditions: []Condition{{In: "properties", Name: "a", Equals: "c"}}}, false),
+			Entry("condition matches", Properties{Conditions: []Condition{{In: "build-parameters", Name: "builder", Matches: "^npm"}}}, true),
+			Entry("condition matches the string of the value", Properties{Conditions: []Condition{{In: "build-parameters", Name: "no-source", Matches: "true|yes"}}}, true),
+			Entry("condition doesn't match", Properties{Conditions: []Condition{{In: "parameters", Name: "memory", Matches: "^[0-9]+G$"}}}, false),
+			Entry("condition doesn't match the missing value", Properties{Conditions: []Condition{{In: "parameters", Name: "disk-quota", Matches: ".*"}}}, false),
+			Entry("value exists", Properties{Conditions: []Condition{{In: "properties", Name: "a", Exists: boolPtr(true)}}}, true),
+			Entry("value doesn't exist", Properties{Conditions: []Condition{{In: "properties", Name: "b", Exists: boolPtr(false)}}}, true),
+			Entry("existing value is not expected", Properties{Conditions: []Condition{{In: "parameters", Name: "memory", Exists: boolPtr(false)}}}, false),
+			Entry("one condition doesn't match", Properties{BuildParameters: map[string]string{"builder": "npm-ci"},
+				Conditions: []Condition{{In: "properties", Name: "a", Exists: boolPtr(true)}, {In: "properties", Name: "a", Equals: "a"}}}, false),
+		)
+
+		It("counts the build parameters and the conditions in the accuracy", func() {
+			m := mta.Module{Type: "a", BuildParams: map[string]interface{}{"builder": "npm-ci"}}
+			_, acc1 := satisfiesModuleConfig(&m, &Properties{NativeType: "a", BuildParameters: map[string]string{"builder": "npm-ci"}})
+			_, acc2 := satisfiesModuleConfig(&m, &Properties{NativeType: "a", BuildParameters: map[string]string{"builder": "npm-ci"},
+				Conditions: []Condition{{In: "parameters", Name: "memory", Exists: boolPtr(false)}}})
+			Ω(acc1).Should(Equal(1))
+			Ω(acc2).Should(Equal(2))
+		})
 	})
+
+	It("ConvertTypes uses the mapping of the build parameters and the conditions", func() {
+		mtaObj := mta.MTA{
+			Modules: []*mta.Module{
+				{Name: "m1", Type: "nodejs", BuildParams: map[string]interface{}{"builder": "custom"}},
+				{Name: "m2", Type: "nodejs", BuildParams: map[string]interface{}{"builder": "npm-ci"}, Parameters: map[string]interface{}{"health-check-type": "http"}},
+				{Name: "m3", Type: "nodejs"},
+			},
+		}
+		platformsCfg, err := Unmarshal([]byte(`
+platform:
+- name: cf
+  modules:
+  - native-type: nodejs
+    platform-type: nodejs
+  - native-type: nodejs
+    platform-type: nodejs.custom
+    build-parameters:
+      builder: custom
+  - native-type: nodejs
+    platform-type: nodejs.npm
+    conditions:
+    - in: build-parameters
+      name: builder
+      matches: "^npm"
+    - in: parameters
+      name: health-check-type
+      exists: true
+`))
+		Ω(err).Should(Succeed())
+		ConvertTypes(mtaObj, platformsCfg, "cf")
+		Ω(mtaObj.Modules[0].Type).Should(Equal("nodejs.custom"))
+		Ω(mtaObj.Modules[1].Type).Should(Equal("nodejs.npm"))
+		Ω(mtaObj.Modules[2].Type).Should(Equal("nodejs"))
+	})
+
+	DescribeTable("Unmarshal - invalid conditions", func(condition string, expected string) {
+		_, err := Unmarshal([]byte(`
+platform:
+- name: cf
+  modules:
+  - native-type: nodejs
+    platform-type: nodejs
+    conditions:
+    - ` + condition + `
+`))
+		Ω(err).Should(HaveOccurred())
+		Ω(err.Error()).Should(ContainSubstring(`invalid conditions of the "nodejs" module type mapping of the "cf" platform: ` + expected))
+	},
+		Entry("unknown section", `{in: requires, name: a, exists: true}`, `the "requires" section of the "a" condition is unknown`),
+		Entry("missing name", `{in: parameters, exists: true}`, `the name of the condition is missing`),
+		Entry("invalid regular expression", `{in: parameters, name: a, matches: "[a-"}`, `the "[a-" regular expression of the "a" condition is invalid`),
+		Entry("no checks", `{in: parameters, name: a}`, `the "a" condition must have exactly one of the "equals", "matches" or "exists" properties`),
+		Entry("several checks", `{in: parameters, name: a, equals: b, exists: true}`, `the "a" condition must have exactly one of the "equals", "matches" or "exists" properties`),
+	)
 })
@@ -0,0 +1,175 @@
+package platform
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/SAP/cloud-mta/mta"
+)
+
+const (
+	transformLowercase         = "lowercase"
+	transformUppercase         = "uppercase"
+	transformStripNonAlnum     = "strip-non-alnum"
+	transformTrimLeadingDigits = "trim-leading-digits"
+	// transformTruncate - the transform with the maximal length, for example, "truncate:30"
+	transformTruncate = "truncate"
+)
+
+var (
+	nonAlnumRegex      = regexp.MustCompile("[^a-zA-Z0-9]+")
+	leadingDigitsRegex = regexp.MustCompile("^[0-9]+")
+)
+
+// the fields that the values of the MTA and module parameters are derived from
+var mtaRuleFields = map[string]func(m *mta.MTA) string{
+	"ID":      func(m *mta.MTA) string { return m.ID },
+	"version": func(m *mta.MTA) string { return m.Version },
+}
+
+var moduleRuleFields = map[string]func(m *mta.Module) string{
+	"name": func(m *mta.Module) string { return m.Name },
+	"type": func(m *mta.Module) string { return m.Type },
+	"path": func(m *mta.Module) string { return m.Path },
+}
+
+// ApplyRules - sets the parameters of the MTA and the parameters and properties of its modules that are not defined according to the rules of the target platform;
+// the rules of the module types mappings are applied to the modules that match them, so the rules are applied before the types conversion
+func ApplyRules(iCfg *mta.MTA, eCfg Platforms, targetPlatform string) error {
+	tpl := platformConfig(eCfg, targetPlatform)
+	for _, rule := range tpl.Parameters {
+		if iCfg.Parameters == nil {
+			iCfg.Parameters = make(map[string]interface{})
+		}
+		if iCfg.Parameters[rule.Name] != nil {
+			continue
+		}
+		value, err := rule.getValue(func(field string) string { return mtaRuleFields[field](iCfg) })
+		if err != nil {
+			return err
+		}
+		iCfg.Parameters[rule.Name] = value
+	}
+	for _, m := range iCfg.Modules {
+		parameterRules := tpl.ModuleParameters
+		var propertyRules []ParameterRule
+		if mc := moduleConfig(m, &tpl); mc != nil {
+			parameterRules = append(append([]ParameterRule{}, parameterRules...), mc.ModuleParameters...)
+			propertyRules = mc.ModuleProperties
+		}
+		var err error
+		m.Parameters, err = applyModuleRules(m, m.Parameters, parameterRules)
+		if err == nil {
+			m.Properties, err = applyModuleRules(m, m.Properties, propertyRules)
+		}
+		if err != nil {
+			return errors.Wrapf(err, applyModuleRuleFailedMsg, m.Name)
+		}
+	}
+	return nil
+}
+
+// applyModuleRules - sets the values of the module parameters or properties that are not defined according to the rules
+func applyModuleRules(m *mta.Module, values map[string]interface{}, rules []ParameterRule) (map[string]interface{}, error) {
+	for _, rule := range rules {
+		if values == nil {
+			values = make(map[string]interface{})
+		}
+		if values[rule.Name] != nil {
+			continue
+		}
+		value, err := rule.getValue(func(field string) string { return moduleRuleFields[field](m) })
+		if err != nil {
+			return values, err
+		}
+		values[rule.Name] = value
+	}
+	return values, nil
+}
+
+// getValue - gets the constant value of the rule or the value of its field, and applies the transforms
+func (rule *ParameterRule) getValue(fieldValue func(field string) string) (string, error) {
+	value := rule.Value
+	if rule.From != "" {
+		value = fieldValue(rule.From)
+	}
+	for _, t := range rule.Transforms {
+		var err error
+		value, err = applyTransform(value, t)
+		if err != nil {
+			return "", errors.Wrapf(err, applyRuleFailedMsg, rule.Name)
+		}
+	}
+	return value, nil
+}
+
+func applyTransform(value string, transform string) (string, error) {
+	switch transform {
+	case transformLowercase:
+		return strings.ToLower(value), nil
+	case transformUppercase:
+		return strings.ToUpper(value), nil
+	case transformStripNonAlnum:
+		return nonAlnumRegex.ReplaceAllLiteralString(value, ""), nil
+	case transformTrimLeadingDigits:
+		return leadingDigitsRegex.ReplaceAllLiteralString(value, ""), nil
+	}
+	if strings.HasPrefix(transform, transformTruncate+":") {
+		length, err := strconv.Atoi(strings.TrimPrefix(transform, transformTruncate+":"))
+		if err != nil || length <= 0 {
+			return "", errors.Errorf(invalidTruncateMsg, transform)
+		}
+		runes := []rune(value)
+		if len(runes) > length {
+			return string(runes[:length]), nil
+		}
+		return value, nil
+	}
+	return "", errors.Errorf(unknownTransformMsg, transform)
+}
+
+// validateRules - checks the rules of the platforms, so that the broken configuration fails when it is loaded
+func validateRules(platforms Platforms) error {
+	for _, p := range platforms.Platforms {
+		err := validatePlatformRules(p.Parameters, func(field string) bool {
+			_, ok := mtaRuleFields[field]
+			return ok
+		})
+		if err == nil {
+			rules := append([]ParameterRule{}, p.ModuleParameters...)
+			for _, mc := range p.Modules {
+				rules = append(append(rules, mc.ModuleParameters...), mc.ModuleProperties...)
+			}
+			err = validatePlatformRules(rules, func(field string) bool {
+				_, ok := moduleRuleFields[field]
+				return ok
+			})
+		}
+		if err != nil {
+			return errors.Wrapf(err, invalidPlatformRulesMsg, p.Name)
+		}
+	}
+	return nil
+}
+
+func validatePlatformRules(rules []ParameterRule, validField func(field string) bool) error {
+	for _, rule := range rules {
+		if rule.Name == "" {
+			return errors.New(emptyRuleNameMsg)
+		}
+		if rule.Value != "" && rule.From != "" {
+			return errors.Errorf(ruleValueAndFieldMsg, rule.Name)
+		}
+		if rule.From != "" && !validField(rule.From) {
+			return errors.Errorf(unknownRuleFieldMsg, rule.From, rule.Name)
+		}
+		_, err := rule.getValue(func(field string) string { return "" })
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
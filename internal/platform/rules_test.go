@@ -0,0 +1,172 @@
+package platform
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	"github.com/SAP/cloud-mta/mta"
+)
+
+var _ = Describe("Rules", func() {
+
+	Describe("ApplyRules", func() {
+		var platforms = Platforms{[]Modules{
+			{Name: "kyma",
+				Parameters: []ParameterRule{
+					{Name: "deployer-version", Value: "2.0.0"},
+					{Name: "app-id", From: "ID", Transforms: []string{"uppercase"}},
+				},
+				ModuleParameters: []ParameterRule{
+					{Name: "name", From: "name", Transforms: []string{"lowercase", "strip-non-alnum", "truncate:8"}},
+				},
+				Modules: []Properties{
+					{NativeType: "nodejs", PlatformType: "kyma.nodejs",
+						ModuleParameters: []ParameterRule{{Name: "runtime", From: "type"}}},
+					{NativeType: "nodejs", PlatformType: "kyma.function", Parameters: map[string]string{"function": "true"},
+						ModuleParameters: []ParameterRule{{Name: "runtime", Value: "function"}},
+						ModuleProperties: []ParameterRule{{Name: "FUNCTION_NAME", From: "name"}, {Name: "TIMEOUT", Value: "30"}}},
+				},
+			},
+		}}
+
+		It("sets the parameters that are not defined", func() {
+			m := mta.MTA{
+				ID:         "my-mta",
+				Parameters: map[string]interface{}{"deployer-version": "1.0.0"},
+				Modules: []*mta.Module{
+					{Name: "Node-Module-1", Type: "nodejs"},
+					{Name: "func", Type: "nodejs", Parameters: map[string]interface{}{"function": "true", "name": "my-func"},
+						Properties: map[string]interface{}{"TIMEOUT": "10"}},
+					{Name: "ui", Type: "html5"},
+				},
+			}
+			Ω(ApplyRules(&m, platforms, "kyma")).Should(Succeed())
+			Ω(m.Parameters).Should(Equal(map[string]interface{}{"deployer-version": "1.0.0", "app-id": "MY-MTA"}))
+			Ω(m.Modules[0].Parameters).Should(Equal(map[string]interface{}{"name": "nodemodu", "runtime": "nodejs"}))
+			// the rules of the most accurate module types mapping are applied
+			Ω(m.Modules[1].Parameters).Should(Equal(map[string]interface{}{"function": "true", "name": "my-func", "runtime": "function"}))
+			Ω(m.Modules[1].Properties).Should(Equal(map[string]interface{}{"FUNCTION_NAME": "func", "TIMEOUT": "10"}))
+			Ω(m.Modules[0].Properties).Should(BeNil())
+			Ω(m.Modules[2].Parameters).Should(Equal(map[string]interface{}{"name": "ui"}))
+			// the types are not converted
+			Ω(m.Modules[0].Type).Should(Equal("nodejs"))
+		})
+
+		It("does not change the MTA for the platform without rules", func() {
+			m := mta.MTA{Modules: []*mta.Module{{Name: "m1", Type: "nodejs"}}}
+			Ω(ApplyRules(&m, platforms, "cf")).Should(Succeed())
+			Ω(m.Parameters).Should(BeNil())
+			Ω(m.Modules[0].Parameters).Should(BeNil())
+		})
+
+		It("fails on the invalid transform", func() {
+			invalid := Platforms{[]Modules{{Name: "kyma", ModuleParameters: []ParameterRule{{Name: "name", From: "name", Transforms: []string{"reverse"}}}}}}
+			m := mta.MTA{Modules: []*mta.Module{{Name: "m1", Type: "nodejs"}}}
+			Ω(ApplyRules(&m, invalid, "kyma")).Should(HaveOccurred())
+		})
+	})
+
+	DescribeTable("the transforms of the neo application name", func(name string, expected string) {
+		rule := ParameterRule{Name: "name", From: "name", Transforms: []string{"lowercase", "strip-non-alnum", "trim-leading-digits", "truncate:30"}}
+		Ω(rule.getValue(func(field string) string { return name })).Should(Equal(expected))
+	},
+		Entry("supported name", "abc123cde", "abc123cde"),
+		Entry("name with uppercase letters", "Abc123P", "abc123p"),
+		Entry("name starts with numbers", "87xaa8s", "xaa8s"),
+		Entry("name starts with numbers and uppercase", "87Xaa8s", "xaa8s"),
+		Entry("name with unsupported characters", "my-module", "mymodule"),
+		Entry("name starts with unsupported characters and numbers", "!~11-2ave_1gne", "ave1gne"),
+		Entry("name longer than 30 characters before removing unsupported characters", "a123456789-12345678901234567890", "a12345678912345678901234567890"),
+		Entry("name longer than 30 characters after removing unsupported characters", "a1234567890-12345678901234567890", "a12345678901234567890123456789"),
+		Entry("mixed conditions", "1234567890abcdeABCDE--==?12345mymodule", "abcdeabcde12345mymodule"),
+	)
+
+	DescribeTable("validation of the rules", func(cfg string, expected string) {
+		_, err := Unmarshal([]byte(cfg))
+		if expected == "" {
+			Ω(err).Should(Succeed())
+		} else {
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring(expected))
+		}
+	},
+		Entry("valid rules", `
+platform:
+- name: kyma
+  parameters:
+  - name: version
+    from: version
+  module-parameters:
+  - name: name
+    from: name
+    transforms: [lowercase, "truncate:10"]
+  modules:
+  - native-type: nodejs
+    platform-type: kyma.nodejs
+    module-parameters:
+    - name: memory
+      value: 256M
+    module-properties:
+    - name: APP_NAME
+      from: name
+`, ""),
+		Entry("rule without name", `
+platform:
+- name: kyma
+  module-parameters:
+  - value: abc
+`, `invalid parameter rules of the "kyma" platform: the name of the parameter is missing`),
+		Entry("rule with value and field", `
+platform:
+- name: kyma
+  parameters:
+  - name: a
+    value: abc
+    from: ID
+`, `the "a" parameter can have either the "value" or the "from" property`),
+		Entry("module field in the global rule", `
+platform:
+- name: kyma
+  parameters:
+  - name: a
+    from: name
+`, `the "name" field of the "a" parameter is unknown`),
+		Entry("unknown field in the rule of the module types mapping", `
+platform:
+- name: kyma
+  modules:
+  - native-type: nodejs
+    platform-type: kyma.nodejs
+    module-parameters:
+    - name: a
+      from: ID
+`, `the "ID" field of the "a" parameter is unknown`),
+		Entry("unknown field in the property rule of the module types mapping", `
+platform:
+- name: kyma
+  modules:
+  - native-type: nodejs
+    platform-type: kyma.nodejs
+    module-properties:
+    - name: a
+      from: version
+`, `the "version" field of the "a" parameter is unknown`),
+		Entry("unknown transform", `
+platform:
+- name: kyma
+  module-parameters:
+  - name: a
+    from: name
+    transforms: [reverse]
+`, `the "reverse" transform is unknown`),
+		Entry("invalid truncate length", `
+platform:
+- name: kyma
+  module-parameters:
+  - name: a
+    from: name
+    transforms: ["truncate:-1"]
+`, `the length of the "truncate:-1" transform must be a positive number`),
+	)
+})
@@ -1,4 +1,4 @@
 package tpl
 
 // basePreDefault - do not edit
-var basePreDefault = []byte{0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x20, 0x3a, 0x3d, 0x20, 0x24, 0x28, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x20, 0x24, 0x28, 0x4d, 0x42, 0x54, 0x29, 0x20, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x20, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x20, 0x2d, 0x64, 0x3d, 0x64, 0x65, 0x76, 0x20, 0x7b, 0x7b, 0x2d, 0x20, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x41, 0x72, 0x67, 0x20, 0x22, 0x2d, 0x65, 0x22, 0x7d, 0x7d, 0x20, 0x7b, 0x7b, 0x2d, 0x20, 0x4d, 0x42, 0x54, 0x59, 0x61, 0x6d, 0x6c, 0x46, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x20, 0x22, 0x2d, 0x66, 0x22, 0x7d, 0x7d, 0x29, 0xa, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x20, 0x3a, 0x3d, 0x20, 0x24, 0x28, 0x73, 0x75, 0x62, 0x73, 0x74, 0x20, 0x5d, 0x2c, 0x2c, 0x24, 0x28, 0x73, 0x75, 0x62, 0x73, 0x74, 0x20, 0x5b, 0x2c, 0x2c, 0x24, 0x28, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x29, 0x29, 0x29, 0xa, 0x23, 0x20, 0x4c, 0x69, 0x73, 0x74, 0x20, 0x6f, 0x66, 0x20, 0x61, 0x6c, 0x6c, 0x20, 0x74, 0x68, 0x65, 0x20, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x73, 0x20, 0x74, 0x6f, 0x20, 0x62, 0x65, 0x20, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x64, 0x20, 0x64, 0x75, 0x72, 0x69, 0x6e, 0x67, 0x20, 0x74, 0x68, 0x65, 0x20, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x20, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0xa, 0x2e, 0x50, 0x48, 0x4f, 0x4e, 0x59, 0x3a, 0x20, 0x61, 0x6c, 0x6c, 0x20, 0x70, 0x72, 0x65, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x20, 0x70, 0x72, 0x65, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x20, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x20, 0x24, 0x28, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x29, 0x20, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x20, 0x6d, 0x65, 0x74, 0x61, 0x20, 0x6d, 0x74, 0x61, 0x72, 0x20, 0x63, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0xa, 0x23, 0x20, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x20, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x20, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x20, 0x61, 0x6c, 0x6c, 0xa, 0x61, 0x6c, 0x6c, 0x3a, 0x20, 0x70, 0x72, 0x65, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x20, 0x70, 0x72, 0x65, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x20, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x20, 0x24, 0x28, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x29, 0x20, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x20, 0x6d, 0x65, 0x74, 0x61, 0x20, 0x6d, 0x74, 0x61, 0x72, 0x20, 0x63, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0xa, 0x23, 0x20, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x20, 0x6d, 0x74, 0x61, 0x2e, 0x79, 0x61, 0x6d, 0x6c, 0xa, 0x70, 0x72, 0x65, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x3a, 0xa, 0x7b, 0x7b, 0x22, 0x5c, 0x74, 0x22, 0x7d, 0x7d, 0x40, 0x24, 0x28, 0x4d, 0x42, 0x54, 0x29, 0x20, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x20, 0x2d, 0x72, 0x3d, 0x24, 0x7b, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x7d, 0x20, 0x2d, 0x78, 0x3d, 0x22, 0x70, 0x61, 0x74, 0x68, 0x73, 0x22, 0x20, 0x7b, 0x7b, 0x2d, 0x20, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x41, 0x72, 0x67, 0x20, 0x22, 0x2d, 0x65, 0x22, 0x7d, 0x7d, 0x20, 0x7b, 0x7b, 0x2d, 0x20, 0x4d, 0x42, 0x54, 0x59, 0x61, 0x6d, 0x6c, 0x46, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x20, 0x22, 0x2d, 0x66, 0x22, 0x7d, 0x7d, 0xa, 0x70, 0x72, 0x65, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x3a, 0x20, 0x70, 0x72, 0x65, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0xa, 0x7b, 0x7b, 0x22, 0x5c, 0x74, 0x22, 0x7d, 0x7d, 0x40, 0x24, 0x28, 0x4d, 0x42, 0x54, 0x29, 0x20, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x20, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x20, 0x2d, 0x70, 0x3d, 0x70, 0x72, 0x65, 0x20, 0x7b, 0x7b, 0x2d, 0x20, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x41, 0x72, 0x67, 0x20, 0x22, 0x2d, 0x65, 0x22, 0x7d, 0x7d, 0x20, 0x7b, 0x7b, 0x2d, 0x20, 0x4d, 0x42, 0x54, 0x59, 0x61, 0x6d, 0x6c, 0x46, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x20, 0x22, 0x2d, 0x66, 0x22, 0x7d, 0x7d, 0xa, 0xa, 0xa}
+var basePreDefault = []byte{0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x20, 0x3a, 0x3d, 0x20, 0x24, 0x28, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x20, 0x24, 0x28, 0x4d, 0x42, 0x54, 0x29, 0x20, 0x70, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x20, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x20, 0x2d, 0x64, 0x3d, 0x64, 0x65, 0x76, 0x20, 0x7b, 0x7b, 0x2d, 0x20, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x41, 0x72, 0x67, 0x20, 0x22, 0x2d, 0x65, 0x22, 0x7d, 0x7d, 0x20, 0x7b, 0x7b, 0x2d, 0x20, 0x4d, 0x42, 0x54, 0x59, 0x61, 0x6d, 0x6c, 0x46, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x20, 0x22, 0x2d, 0x66, 0x22, 0x7d, 0x7d, 0x20, 0x7b, 0x7b, 0x2d, 0x20, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x41, 0x72, 0x67, 0x73, 0x7d, 0x7d, 0x29, 0xa, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x20, 0x3a, 0x3d, 0x20, 0x24, 0x28, 0x73, 0x75, 0x62, 0x73, 0x74, 0x20, 0x5d, 0x2c, 0x2c, 0x24, 0x28, 0x73, 0x75, 0x62, 0x73, 0x74, 0x20, 0x5b, 0x2c, 0x2c, 0x24, 0x28, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x29, 0x29, 0x29, 0xa, 0x23, 0x20, 0x4c, 0x69, 0x73, 0x74, 0x20, 0x6f, 0x66, 0x20, 0x61, 0x6c, 0x6c, 0x20, 0x74, 0x68, 0x65, 0x20, 0x72, 0x65, 0x63, 0x69, 0x70, 0x65, 0x73, 0x20, 0x74, 0x6f, 0x20, 0x62, 0x65, 0x20, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x64, 0x20, 0x64, 0x75, 0x72, 0x69, 0x6e, 0x67, 0x20, 0x74, 0x68, 0x65, 0x20, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x20, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0xa, 0x2e, 0x50, 0x48, 0x4f, 0x4e, 0x59, 0x3a, 0x20, 0x61, 0x6c, 0x6c, 0x20, 0x70, 0x72, 0x65, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x20, 0x70, 0x72, 0x65, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x20, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x20, 0x24, 0x28, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x29, 0x20, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x20, 0x6d, 0x65, 0x74, 0x61, 0x20, 0x6d, 0x74, 0x61, 0x72, 0x20, 0x63, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0xa, 0x23, 0x20, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x20, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x20, 0x63, 0x6f, 0x6d, 0x70, 0x69, 0x6c, 0x65, 0x20, 0x61, 0x6c, 0x6c, 0xa, 0x61, 0x6c, 0x6c, 0x3a, 0x20, 0x70, 0x72, 0x65, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x20, 0x70, 0x72, 0x65, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x20, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x20, 0x24, 0x28, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x29, 0x20, 0x70, 0x6f, 0x73, 0x74, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x20, 0x6d, 0x65, 0x74, 0x61, 0x20, 0x6d, 0x74, 0x61, 0x72, 0x20, 0x63, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0xa, 0x23, 0x20, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x20, 0x6d, 0x74, 0x61, 0x2e, 0x79, 0x61, 0x6d, 0x6c, 0xa, 0x70, 0x72, 0x65, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x3a, 0xa, 0x7b, 0x7b, 0x22, 0x5c, 0x74, 0x22, 0x7d, 0x7d, 0x40, 0x24, 0x28, 0x4d, 0x42, 0x54, 0x29, 0x20, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x20, 0x2d, 0x72, 0x3d, 0x24, 0x7b, 0x73, 0x74, 0x72, 0x69, 0x63, 0x74, 0x7d, 0x20, 0x2d, 0x78, 0x3d, 0x22, 0x70, 0x61, 0x74, 0x68, 0x73, 0x22, 0x20, 0x7b, 0x7b, 0x2d, 0x20, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x41, 0x72, 0x67, 0x20, 0x22, 0x2d, 0x65, 0x22, 0x7d, 0x7d, 0x20, 0x7b, 0x7b, 0x2d, 0x20, 0x4d, 0x42, 0x54, 0x59, 0x61, 0x6d, 0x6c, 0x46, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x20, 0x22, 0x2d, 0x66, 0x22, 0x7d, 0x7d, 0xa, 0x70, 0x72, 0x65, 0x5f, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x3a, 0x20, 0x70, 0x72, 0x65, 0x5f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0xa, 0x7b, 0x7b, 0x22, 0x5c, 0x74, 0x22, 0x7d, 0x7d, 0x40, 0x24, 0x28, 0x4d, 0x42, 0x54, 0x29, 0x20, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x20, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x20, 0x2d, 0x70, 0x3d, 0x70, 0x72, 0x65, 0x20, 0x7b, 0x7b, 0x2d, 0x20, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x41, 0x72, 0x67, 0x20, 0x22, 0x2d, 0x65, 0x22, 0x7d, 0x7d, 0x20, 0x7b, 0x7b, 0x2d, 0x20, 0x4d, 0x42, 0x54, 0x59, 0x61, 0x6d, 0x6c, 0x46, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x20, 0x22, 0x2d, 0x66, 0x22, 0x7d, 0x7d, 0x20, 0x7b, 0x7b, 0x2d, 0x20, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x41, 0x72, 0x67, 0x73, 0x7d, 0x7d, 0xa, 0xa, 0xa}
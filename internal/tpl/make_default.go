@@ -1,4 +1,4 @@
 package tpl
 
 // makeDefault - do not edit
-var makeDefault = []byte{0x23, 0x20, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x20, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x20, 0x62, 0x75, 0x69, 0x6c, 0x64, 0xa, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x65, 0x20, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x72, 0x75, 0x6c, 0x65, 0xa, 0x24, 0x28, 0x31, 0x29, 0x3a, 0x20, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0xa, 0x9, 0x40, 0x24, 0x28, 0x4d, 0x42, 0x54, 0x29, 0x20, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x20, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x20, 0x2d, 0x6d, 0x3d, 0x24, 0x28, 0x31, 0x29, 0x20, 0x2d, 0x70, 0x3d, 0x24, 0x7b, 0x70, 0x7d, 0x20, 0x2d, 0x74, 0x3d, 0x24, 0x7b, 0x74, 0x7d, 0x20, 0x7b, 0x7b, 0x2d, 0x20, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x41, 0x72, 0x67, 0x20, 0x22, 0x2d, 0x65, 0x22, 0x7d, 0x7d, 0x20, 0x7b, 0x7b, 0x2d, 0x20, 0x4d, 0x42, 0x54, 0x59, 0x61, 0x6d, 0x6c, 0x46, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x20, 0x22, 0x2d, 0x66, 0x22, 0x7d, 0x7d, 0xa, 0x65, 0x6e, 0x64, 0x65, 0x66, 0xa, 0xa, 0x24, 0x28, 0x66, 0x6f, 0x72, 0x65, 0x61, 0x63, 0x68, 0x20, 0x6d, 0x6f, 0x64, 0x2c, 0x24, 0x28, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x29, 0x2c, 0x24, 0x28, 0x65, 0x76, 0x61, 0x6c, 0x20, 0x24, 0x28, 0x63, 0x61, 0x6c, 0x6c, 0x20, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x72, 0x75, 0x6c, 0x65, 0x2c, 0x24, 0x28, 0x6d, 0x6f, 0x64, 0x29, 0x29, 0x29, 0x29}
+var makeDefault = []byte{0x23, 0x20, 0x45, 0x78, 0x65, 0x63, 0x75, 0x74, 0x65, 0x20, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x20, 0x62, 0x75, 0x69, 0x6c, 0x64, 0xa, 0x64, 0x65, 0x66, 0x69, 0x6e, 0x65, 0x20, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x72, 0x75, 0x6c, 0x65, 0xa, 0x24, 0x28, 0x31, 0x29, 0x3a, 0x20, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0xa, 0x9, 0x40, 0x24, 0x28, 0x4d, 0x42, 0x54, 0x29, 0x20, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x20, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x20, 0x2d, 0x6d, 0x3d, 0x24, 0x28, 0x31, 0x29, 0x20, 0x2d, 0x70, 0x3d, 0x24, 0x7b, 0x70, 0x7d, 0x20, 0x2d, 0x74, 0x3d, 0x24, 0x7b, 0x74, 0x7d, 0x20, 0x2d, 0x2d, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x2d, 0x64, 0x69, 0x72, 0x3d, 0x24, 0x7b, 0x72, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x5f, 0x64, 0x69, 0x72, 0x7d, 0x20, 0x7b, 0x7b, 0x2d, 0x20, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x41, 0x72, 0x67, 0x20, 0x22, 0x2d, 0x65, 0x22, 0x7d, 0x7d, 0x20, 0x7b, 0x7b, 0x2d, 0x20, 0x4d, 0x42, 0x54, 0x59, 0x61, 0x6d, 0x6c, 0x46, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x20, 0x22, 0x2d, 0x66, 0x22, 0x7d, 0x7d, 0x20, 0x7b, 0x7b, 0x2d, 0x20, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x41, 0x72, 0x67, 0x73, 0x7d, 0x7d, 0xa, 0x65, 0x6e, 0x64, 0x65, 0x66, 0xa, 0xa, 0x24, 0x28, 0x66, 0x6f, 0x72, 0x65, 0x61, 0x63, 0x68, 0x20, 0x6d, 0x6f, 0x64, 0x2c, 0x24, 0x28, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x29, 0x2c, 0x24, 0x28, 0x65, 0x76, 0x61, 0x6c, 0x20, 0x24, 0x28, 0x63, 0x61, 0x6c, 0x6c, 0x20, 0x62, 0x75, 0x69, 0x6c, 0x64, 0x5f, 0x72, 0x75, 0x6c, 0x65, 0x2c, 0x24, 0x28, 0x6d, 0x6f, 0x64, 0x29, 0x29, 0x29, 0x29}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -11,9 +13,12 @@ import (
 	"github.com/pkg/errors"
 
 	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildconfig"
 	"github.com/SAP/cloud-mta-build-tool/internal/buildops"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
 	"github.com/SAP/cloud-mta-build-tool/internal/commands"
 	"github.com/SAP/cloud-mta-build-tool/internal/logs"
+	"github.com/SAP/cloud-mta-build-tool/internal/platform"
 	"github.com/SAP/cloud-mta-build-tool/internal/version"
 	"github.com/SAP/cloud-mta/mta"
 )
@@ -56,7 +61,9 @@ func genMakefile(mtaParser dir.IMtaParser, loc dir.ITargetPath, srcLoc dir.ISour
 
 type templateData struct {
 	File mta.MTA
-	Loc  dir.ISourceModule
+	// Params - the build parameters of the modules, decoded once the MTA file is parsed
+	Params buildparams.Modules
+	Loc    dir.ISourceModule
 }
 
 type templateDepData struct {
@@ -64,6 +71,10 @@ type templateDepData struct {
 	SourcePath string
 	TargetPath string
 	Patterns   []string
+	Exclude    []string
+	// Rename - the "from=to" entries sorted by the source path, so the makefile content is stable
+	Rename  []string
+	Extract bool
 }
 
 // ConvertToShellArgument wraps a string in quotation marks if necessary and escapes necessary characters in it,
@@ -72,36 +83,176 @@ func (data templateData) ConvertToShellArgument(s string) string {
 	return shellquote.Join(s)
 }
 
+// getModule returns the module and its build parameters
+func (data templateData) getModule(moduleName string) (*mta.Module, *buildparams.Module, error) {
+	module, e := data.File.GetModuleByName(moduleName)
+	if e != nil {
+		return nil, nil, e
+	}
+	return module, data.Params[moduleName], nil
+}
+
 // IsNoSource checks if module has "no-source" build parameter
 func (data templateData) IsNoSource(moduleName string) (bool, error) {
-	module, e := data.File.GetModuleByName(moduleName)
+	module, params, e := data.getModule(moduleName)
 	if e != nil {
 		return false, e
 	}
-	noSource := buildops.IfNoSource(module)
-	if !noSource && module.Path == "" {
+	if !params.NoSource && module.Path == "" {
 		return false, errors.Errorf(noPathMsg, moduleName)
 	}
-	return noSource, nil
+	return params.NoSource, nil
+}
+
+// IsBuilt checks if the module is built: the module has source and is included in the build profile
+// or is required by a module built with the build profile
+func (data templateData) IsBuilt(moduleName string) (bool, error) {
+	noSource, e := data.IsNoSource(moduleName)
+	if e != nil || noSource {
+		return false, e
+	}
+	return buildops.ProfileBuilt(&data.File, data.Params, moduleName)
+}
+
+// GetModuleCommands returns the build commands of the module
+func (data templateData) GetModuleCommands(moduleName string) (commands.CommandList, error) {
+	module, params, e := data.getModule(moduleName)
+	if e != nil {
+		return commands.CommandList{}, e
+	}
+	cmds, _, e := commands.CommandProvider(*module, params)
+	return cmds, e
 }
 
 func (data templateData) GetModuleDeps(moduleName string) ([]templateDepData, error) {
-	module, e := data.File.GetModuleByName(moduleName)
+	_, params, e := data.getModule(moduleName)
 	if e != nil {
 		return nil, e
 	}
-	requires := buildops.GetBuildRequires(module)
-	templateDeps := make([]templateDepData, len(requires))
-	for index, req := range requires {
-		sourcePath, targetPath, artifacts, e := buildops.GetRequiresArtifacts(data.Loc, &data.File, &req, moduleName, false)
+	templateDeps := make([]templateDepData, len(params.Requires))
+	for index, req := range params.Requires {
+		sourcePath, targetPath, artifacts, e := buildops.GetRequiresArtifacts(data.Loc, &data.File, data.Params, &req, moduleName, false)
 		if e != nil {
 			return nil, e
 		}
-		templateDeps[index] = templateDepData{req.Name, sourcePath, targetPath, artifacts}
+		rename := make([]string, 0, len(req.Rename))
+		for from, to := range req.Rename {
+			rename = append(rename, from+"="+to)
+		}
+		sort.Strings(rename)
+		templateDeps[index] = templateDepData{req.Name, sourcePath, targetPath, artifacts, req.Exclude, rename, req.Extract}
 	}
 	return templateDeps, nil
 }
 
+// GetModuleEnv returns the shell variable assignments of the module build environment, which prefix the module "mbt execute" commands;
+// the variables are not defined as target-specific variables, because make applies those to the prerequisites of the target too.
+// The ${env:VAR} placeholders are converted to the make variables references, so they are resolved when the makefile runs
+func (data templateData) GetModuleEnv(moduleName string) (string, error) {
+	module, params, e := data.getModule(moduleName)
+	if e != nil {
+		return "", e
+	}
+	env, e := commands.GetModuleEnv(module, params)
+	if e != nil {
+		return "", e
+	}
+	var sb strings.Builder
+	for _, v := range env {
+		// the value is single-quoted for the shell; the make variables are expanded before the shell runs the command
+		value := commands.ExpandEnvValue(v.Value, &data.File, module, func(name string) string {
+			return `$(subst ',` + singleQuoteEscape + `,$(` + name + `))`
+		}, escapeMakeRecipeValue)
+		sb.WriteString(fmt.Sprintf("%s='%s' ", v.Name, value))
+	}
+	return sb.String(), nil
+}
+
+// GetModuleTimeoutArg returns the "mbt execute" flag of the timeout of the module build commands;
+// an empty string is returned when the timeout is not defined
+func (data templateData) GetModuleTimeoutArg(moduleName string) (string, error) {
+	_, params, e := data.getModule(moduleName)
+	if e != nil || params.Timeout == "" {
+		return "", e
+	}
+	return " -t=" + data.ConvertToShellArgument(params.Timeout), nil
+}
+
+// GetCommandPolicyArgs returns the "mbt execute" flags of the retry and failure policies of the module build commands;
+// the flags are omitted when all the commands have the default policy
+func (data templateData) GetCommandPolicyArgs(moduleName string) (string, error) {
+	module, params, e := data.getModule(moduleName)
+	if e != nil {
+		return "", e
+	}
+	cmds, _, e := commands.CommandProvider(*module, params)
+	if e != nil {
+		return "", e
+	}
+	policies, e := commands.GetCommandPolicies(module, params, len(cmds.Command))
+	if e != nil {
+		return "", e
+	}
+	isDefault := true
+	continueOnError := false
+	retries := make([]string, len(policies))
+	continueOnErrors := make([]string, len(policies))
+	for i, policy := range policies {
+		isDefault = isDefault && policy.IsDefault()
+		continueOnError = continueOnError || policy.ContinueOnError
+		retries[i] = strconv.Itoa(policy.Retries)
+		continueOnErrors[i] = strconv.FormatBool(policy.ContinueOnError)
+	}
+	if isDefault {
+		return "", nil
+	}
+	args := " --retries=" + strings.Join(retries, ",")
+	if policies[0].RetryDelay > 0 {
+		args += " --retry-delay=" + policies[0].RetryDelay.String()
+	}
+	if len(policies[0].RetryOnExitCodes) > 0 {
+		codes := make([]string, len(policies[0].RetryOnExitCodes))
+		for i, code := range policies[0].RetryOnExitCodes {
+			codes[i] = strconv.Itoa(code)
+		}
+		args += " --retry-on-exit-codes=" + strings.Join(codes, ",")
+	}
+	if continueOnError {
+		args += " --continue-on-error=" + strings.Join(continueOnErrors, ",")
+	}
+	return args, nil
+}
+
+// GetModuleHookArgs returns the "mbt execute" flags of the commands of the module build hook;
+// an empty string is returned when the hook is not defined
+func (data templateData) GetModuleHookArgs(moduleName, phase string) (string, error) {
+	module, params, e := data.getModule(moduleName)
+	if e != nil {
+		return "", e
+	}
+	hook := commands.GetModuleHook(params, phase)
+	if hook == nil || len(hook.Commands) == 0 {
+		return "", nil
+	}
+	args := fmt.Sprintf(` -d="$(PROJ_DIR)/%s"`, commands.GetHookDir(module, hook))
+	if hook.Timeout != "" {
+		args += " -t=" + data.ConvertToShellArgument(hook.Timeout)
+	}
+	for _, cmd := range hook.Commands {
+		args += " -c=" + data.ConvertToShellArgument(cmd)
+	}
+	return args, nil
+}
+
+// singleQuoteEscape - the replacement of the single quote in a single-quoted shell string
+const singleQuoteEscape = `'\''`
+
+// escapeMakeRecipeValue escapes the value for a single-quoted shell string in the makefile recipe,
+// where "#" does not start a comment
+func escapeMakeRecipeValue(s string) string {
+	return strings.NewReplacer("$", "$$", "'", singleQuoteEscape).Replace(s)
+}
+
 func (data templateData) GetPathArgument(innerPath string) string {
 	path, err := filepath.Rel(data.Loc.GetSourceModuleDir("."), innerPath)
 	if err != nil {
@@ -128,15 +279,22 @@ func makeFile(mtaParser dir.IMtaParser, loc dir.ITargetPath, srcLoc dir.ISourceM
 		return errors.Wrapf(err, genFailedMsg, makeFilename)
 	}
 
+	// the template functions filter the modules by their build parameters, so the invalid build parameters are reported first
+	params, err := buildparams.DecodeAll(m)
+	if err != nil {
+		return errors.Wrapf(err, genFailedMsg, makeFilename)
+	}
+
 	// Check for circular build dependencies between the modules. The error message from make is not clear so we
 	// should give an error here during the generation of the makefile.
-	_, e = buildops.GetModulesNames(m)
+	_, e = buildops.GetModulesNames(m, params)
 	if e != nil {
 		return e
 	}
 
 	// Template data
 	data.File = *m
+	data.Params = params
 	data.Loc = srcLoc
 
 	// path for creating the file
@@ -197,12 +355,40 @@ func getExtensionsArg(extensions []string, makefileDirPath string, argName strin
 	return fmt.Sprintf(` %s="%s"`, argName, strings.Join(relExtPaths, ","))
 }
 
+// getConfigArgs returns the flags of the external builders, module types and platforms configuration files, of the MTA archive compression,
+// of the number of archive workers, of the build profile, of the signing key file and of the disabled build cache provided to the tool, so the tool commands executed by the makefile use the same configuration
+func getConfigArgs() string {
+	buildersConfig, moduleTypesConfig := commands.GetExternalConfigPaths()
+	args := ""
+	if buildersConfig != "" {
+		args += fmt.Sprintf(` --builders-config="%s"`, buildersConfig)
+	}
+	if moduleTypesConfig != "" {
+		args += fmt.Sprintf(` --module-types-config="%s"`, moduleTypesConfig)
+	}
+	if platformsConfig := platform.GetExternalConfigPath(); platformsConfig != "" {
+		args += fmt.Sprintf(` --platform-config="%s"`, platformsConfig)
+	}
+	if compression := dir.GetMtarCompression(); compression != dir.CompressionDefault {
+		args += " --compression=" + compression
+	}
+	if workers := dir.GetArchiveWorkers(); workers != 1 {
+		args += fmt.Sprintf(" --archive-workers=%d", workers)
+	}
+	if profile := buildconfig.Profile(); profile != "" {
+		args += " --profile=" + profile
+	}
+	if signKeyPath := buildconfig.SignKeyPath(); signKeyPath != "" {
+		args += fmt.Sprintf(` --sign-key="%s"`, signKeyPath)
+	}
+	if buildconfig.NoCache() {
+		args += " --no-cache"
+	}
+	return args
+}
+
 func mapTpl(templateContent []byte, BasePreContent []byte, BasePostContent []byte, useDefaultMbt bool, extensions []string, makefileDirPath string, mtaYamlFilenName string) (*template.Template, error) {
 	funcMap := template.FuncMap{
-		"CommandProvider": func(modules mta.Module) (commands.CommandList, error) {
-			cmds, _, err := commands.CommandProvider(modules)
-			return cmds, err
-		},
 		"Version": version.GetVersion,
 		"MbtPath": func() string {
 			return getMbtPath(useDefaultMbt)
@@ -217,6 +403,7 @@ func mapTpl(templateContent []byte, BasePreContent []byte, BasePostContent []byt
 		"ExtensionsArg": func(argName string) string {
 			return getExtensionsArg(extensions, makefileDirPath, argName)
 		},
+		"ConfigArgs": getConfigArgs,
 	}
 	fullTemplate := append(baseArgs, BasePreContent...)
 	fullTemplate = append(fullTemplate, templateContent...)
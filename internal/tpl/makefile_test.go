@@ -13,7 +13,11 @@ import (
 	"github.com/pkg/errors"
 
 	dir "github.com/SAP/cloud-mta-build-tool/internal/archive"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildconfig"
+	"github.com/SAP/cloud-mta-build-tool/internal/buildparams"
+	"github.com/SAP/cloud-mta-build-tool/internal/commands"
 	"github.com/SAP/cloud-mta-build-tool/internal/logs"
+	"github.com/SAP/cloud-mta-build-tool/internal/platform"
 	"github.com/SAP/cloud-mta-build-tool/internal/version"
 	"github.com/SAP/cloud-mta/mta"
 )
@@ -83,6 +87,90 @@ makefile_version: 0.0.0
 			})
 		})
 
+		It("passes the external configuration files to the tool commands", func() {
+			buildersConfig := filepath.Join(wd, "testdata", "builders.yaml")
+			Ω(commands.LoadExternalConfig("", buildersConfig, "")).Should(Succeed())
+			defer func() {
+				Ω(commands.LoadExternalConfig("", "", "")).Should(Succeed())
+			}()
+			ep := dir.Loc{SourcePath: filepath.Join(wd, "testdata"), TargetPath: filepath.Join(wd, "testdata"), Descriptor: "dev"}
+			Ω(makeFile(&ep, &ep, &ep, nil, makeFileName, &tpl, true, "")).Should(Succeed())
+			makefileContent := getMakeFileContent(makeFileFullPath)
+			Ω(makefileContent).Should(ContainSubstring(fmt.Sprintf(`$(MBT) module pack -m=ui -p=${p} -t=${t} --report-dir=${report_dir} --builders-config="%s"`, buildersConfig)))
+			Ω(makefileContent).ShouldNot(ContainSubstring("--module-types-config"))
+		})
+
+		It("passes the external platforms configuration file to the tool commands", func() {
+			platformsConfig := filepath.Join(wd, "testdata", "platforms.yaml")
+			Ω(platform.LoadExternalConfig("", platformsConfig)).Should(Succeed())
+			defer func() {
+				Ω(platform.LoadExternalConfig("", "")).Should(Succeed())
+			}()
+			ep := dir.Loc{SourcePath: filepath.Join(wd, "testdata"), TargetPath: filepath.Join(wd, "testdata"), Descriptor: "dev"}
+			Ω(makeFile(&ep, &ep, &ep, nil, makeFileName, &tpl, true, "")).Should(Succeed())
+			makefileContent := getMakeFileContent(makeFileFullPath)
+			Ω(makefileContent).Should(ContainSubstring(fmt.Sprintf(`$(MBT) module pack -m=ui -p=${p} -t=${t} --report-dir=${report_dir} --platform-config="%s"`, platformsConfig)))
+		})
+
+		It("passes the MTA archive compression to the tool commands", func() {
+			Ω(dir.SetMtarCompression(dir.CompressionBest)).Should(Succeed())
+			defer func() {
+				Ω(dir.SetMtarCompression("")).Should(Succeed())
+			}()
+			ep := dir.Loc{SourcePath: filepath.Join(wd, "testdata"), TargetPath: filepath.Join(wd, "testdata"), Descriptor: "dev"}
+			Ω(makeFile(&ep, &ep, &ep, nil, makeFileName, &tpl, true, "")).Should(Succeed())
+			makefileContent := getMakeFileContent(makeFileFullPath)
+			Ω(makefileContent).Should(ContainSubstring(`$(MBT) module pack -m=ui -p=${p} -t=${t} --report-dir=${report_dir} --compression=best`))
+		})
+
+		It("passes the number of archive workers to the tool commands", func() {
+			Ω(dir.SetArchiveWorkers(2)).Should(Succeed())
+			defer func() {
+				Ω(dir.SetArchiveWorkers(1)).Should(Succeed())
+			}()
+			ep := dir.Loc{SourcePath: filepath.Join(wd, "testdata"), TargetPath: filepath.Join(wd, "testdata"), Descriptor: "dev"}
+			Ω(makeFile(&ep, &ep, &ep, nil, makeFileName, &tpl, true, "")).Should(Succeed())
+			makefileContent := getMakeFileContent(makeFileFullPath)
+			Ω(makefileContent).Should(ContainSubstring(`$(MBT) module pack -m=ui -p=${p} -t=${t} --report-dir=${report_dir} --archive-workers=2`))
+		})
+
+		It("excludes the modules of other build profiles and passes the build profile to the tool commands", func() {
+			Ω(buildconfig.SetProfile("prod")).Should(Succeed())
+			ep := dir.Loc{SourcePath: filepath.Join(wd, "testdata", "modulegen"), TargetPath: filepath.Join(wd, "testdata"), Descriptor: "dev", MtaFilename: "profiles.yaml"}
+			Ω(makeFile(&ep, &ep, &ep, nil, makeFileName, &tpl, true, "")).Should(Succeed())
+			makefileContent := getMakeFileContent(makeFileFullPath)
+			Ω(makefileContent).Should(ContainSubstring("modules = always\n"))
+			Ω(makefileContent).ShouldNot(ContainSubstring("trial_only"))
+			Ω(makefileContent).Should(ContainSubstring(`$(MBT) module pack -m=always -p=${p} -t=${t} --report-dir=${report_dir} --profile=prod`))
+		})
+
+		It("builds the required modules of other build profiles", func() {
+			Ω(buildconfig.SetProfile("prod")).Should(Succeed())
+			ep := dir.Loc{SourcePath: filepath.Join(wd, "testdata", "modulegen"), TargetPath: filepath.Join(wd, "testdata"), Descriptor: "dev", MtaFilename: "profiles_requires.yaml"}
+			Ω(makeFile(&ep, &ep, &ep, nil, makeFileName, &tpl, true, "")).Should(Succeed())
+			makefileContent := getMakeFileContent(makeFileFullPath)
+			Ω(makefileContent).Should(ContainSubstring("modules = trial_only always\n"))
+			Ω(makefileContent).Should(ContainSubstring("always: validate trial_only\n"))
+			Ω(makefileContent).Should(ContainSubstring("trial_only: validate\n"))
+		})
+
+		It("passes the path of the signing key file to the tool commands", func() {
+			keyPath := filepath.Join(wd, "testdata", "keys", "signing-key.pem")
+			buildconfig.SetSignKeyPath(keyPath)
+			ep := dir.Loc{SourcePath: filepath.Join(wd, "testdata"), TargetPath: filepath.Join(wd, "testdata"), Descriptor: "dev"}
+			Ω(makeFile(&ep, &ep, &ep, nil, makeFileName, &tpl, true, "")).Should(Succeed())
+			makefileContent := getMakeFileContent(makeFileFullPath)
+			Ω(makefileContent).Should(ContainSubstring(`$(MBT) module pack -m=ui -p=${p} -t=${t} --report-dir=${report_dir} --sign-key="` + keyPath + `"`))
+		})
+
+		It("passes the disabled build cache to the tool commands", func() {
+			buildconfig.SetNoCache(true)
+			ep := dir.Loc{SourcePath: filepath.Join(wd, "testdata"), TargetPath: filepath.Join(wd, "testdata"), Descriptor: "dev"}
+			Ω(makeFile(&ep, &ep, &ep, nil, makeFileName, &tpl, true, "")).Should(Succeed())
+			makefileContent := getMakeFileContent(makeFileFullPath)
+			Ω(makefileContent).Should(ContainSubstring(`$(MBT) module pack -m=ui -p=${p} -t=${t} --report-dir=${report_dir} --no-cache`))
+		})
+
 		It("createMakeFile testing", func() {
 			makeFilePath := filepath.Join(wd, "testdata")
 			file, _ := createMakeFile(makeFilePath, makeFileName)
@@ -126,6 +214,17 @@ makefile_version: 0.0.0
 			Entry("in verbose mode", "verbose"),
 		)
 
+		DescribeTable("genMakefile should fail when the build parameters are defined incorrectly", func(mode string) {
+			ep := dir.Loc{SourcePath: filepath.Join(wd, "testdata"), TargetPath: filepath.Join(wd, "testdata"), MtaFilename: "wrong_build_params.yaml"}
+			err := genMakefile(&ep, &ep, &ep, &ep, nil, makefile, mode, true, "")
+			Ω(err).Should(HaveOccurred())
+			Ω(err.Error()).Should(ContainSubstring(fmt.Sprintf(buildparams.WrongParamMsg, buildparams.SupportedPlatformsParam, "ui", "a sequence of strings")))
+			Ω(filepath.Join(wd, "testdata", makefile)).ShouldNot(BeAnExistingFile())
+		},
+			Entry("in default mode", ""),
+			Entry("in verbose mode", "verbose"),
+		)
+
 		DescribeTable("generate module build in verbose make file", func(mtaFileName, moduleName, expectedModuleCommandsGen string) {
 			ep := dir.Loc{SourcePath: filepath.Join(wd, "testdata", "modulegen"), TargetPath: filepath.Join(wd, "testdata"), Descriptor: "dev", MtaFilename: mtaFileName}
 			Ω(makeFile(&ep, &ep, &ep, nil, makeFileName, &tpl, true, "")).Should(Succeed())
@@ -134,7 +233,9 @@ makefile_version: 0.0.0
 
 			expectedModuleGen := fmt.Sprintf(`%s: validate
 	@echo 'INFO building the "%s" module...'
-	@%s`, moduleName, moduleName, expectedModuleCommandsGen)
+# Restore the module build artifacts from the build cache, or build the module and pack its build artifacts
+	@if $(MBT) module restore -m=%s -p=${p} -t=${t} --report-dir=${report_dir}; then :; else \
+	%s`, moduleName, moduleName, moduleName, expectedModuleCommandsGen)
 			Ω(makefileContent).Should(ContainSubstring(removeSpecialSymbols([]byte(expectedModuleGen))))
 		},
 			Entry("module with one command", "one_command.yaml", "one_command", `$(MBT) execute -d="$(PROJ_DIR)/one_command" -c=yarn`),
@@ -148,8 +249,56 @@ makefile_version: 0.0.0
 				"command_with_timeout.yaml", "command_with_timeout", `$(MBT) execute -d="$(PROJ_DIR)/command_with_timeout" -t=2s -c='sleep 1'`),
 			Entry("module with commands with special characters",
 				"commands_with_special_chars.yaml", "commands_with_special_chars", `$(MBT) execute -d="$(PROJ_DIR)/commands_with_special_chars" -c='sh -c '\''echo "a"'\' -c='echo "a\b"'`),
+			Entry("module with command policies",
+				"command_policies.yaml", "command_policies", `$(MBT) execute -d="$(PROJ_DIR)/command_policies" -c='npm install' -c='npm run lint' --retries=2,0 --retry-delay=5s --retry-on-exit-codes=1,137 --continue-on-error=false,true`),
 		)
 
+		It("generate module build with hooks in verbose make file", func() {
+			ep := dir.Loc{SourcePath: filepath.Join(wd, "testdata", "modulegen"), TargetPath: filepath.Join(wd, "testdata"), Descriptor: "dev", MtaFilename: "hooks.yaml"}
+			Ω(makeFile(&ep, &ep, &ep, nil, makeFileName, &tpl, true, "")).Should(Succeed())
+			makefileContent := getMakeFileContent(makeFileFullPath)
+
+			expectedModuleGen := `hooks: validate
+	@echo 'INFO building the "hooks" module...'
+# Restore the module build artifacts from the build cache, or build the module and pack its build artifacts
+	@if $(MBT) module restore -m=hooks -p=${p} -t=${t} --report-dir=${report_dir}; then :; else \
+	$(MBT) execute -d="$(PROJ_DIR)/hooks" -c='node stamp-version.js' && \
+	$(MBT) execute -d="$(PROJ_DIR)/hooks" -c='npm run build' -m=hooks --report-dir=${report_dir} && \
+	$(MBT) execute -d="$(PROJ_DIR)/hooks/dist" -t=1m -c='sh -c '\''echo done'\' && \
+	$(MBT) execute -d="$(PROJ_DIR)/." -c='rm -rf hooks/src' && \
+	$(MBT) module pack -m=hooks -p=${p} -t=${t} --report-dir=${report_dir}; fi`
+			Ω(makefileContent).Should(ContainSubstring(removeSpecialSymbols([]byte(expectedModuleGen))))
+		})
+
+		It("generate module build with environment variables in verbose make file", func() {
+			ep := dir.Loc{SourcePath: filepath.Join(wd, "testdata", "modulegen"), TargetPath: filepath.Join(wd, "testdata"), Descriptor: "dev", MtaFilename: "env.yaml"}
+			Ω(makeFile(&ep, &ep, &ep, nil, makeFileName, &tpl, true, "")).Should(Succeed())
+			makefileContent := getMakeFileContent(makeFileFullPath)
+
+			expectedModuleGen := `# build module env
+env: validate
+	@echo 'INFO building the "env" module...'
+# Restore the module build artifacts from the build cache, or build the module and pack its build artifacts
+	@if $(MBT) module restore -m=env -p=${p} -t=${t} --report-dir=${report_dir}; then :; else \
+	NODE_ENV='production' PROXY='$(subst ','\'',$(HTTP_PROXY))' VERSION='testmta-1.0.0#env$$' $(MBT) execute -d="$(PROJ_DIR)/env" -c=yarn`
+			Ω(makefileContent).Should(ContainSubstring(removeSpecialSymbols([]byte(expectedModuleGen))))
+		})
+
+		It("generate module build with environment variables only for the module commands in verbose make file", func() {
+			ep := dir.Loc{SourcePath: filepath.Join(wd, "testdata", "modulegen"), TargetPath: filepath.Join(wd, "testdata"), Descriptor: "dev", MtaFilename: "env_deps.yaml"}
+			Ω(makeFile(&ep, &ep, &ep, nil, makeFileName, &tpl, true, "")).Should(Succeed())
+			makefileContent := getMakeFileContent(makeFileFullPath)
+
+			Ω(makefileContent).ShouldNot(ContainSubstring("export"))
+			Ω(makefileContent).Should(ContainSubstring(removeSpecialSymbols([]byte(`app: validate lib`))))
+			Ω(makefileContent).Should(ContainSubstring(removeSpecialSymbols([]byte(`	NODE_ENV='production' $(MBT) execute -d="$(PROJ_DIR)/app" -c='npm run build'`))))
+			Ω(makefileContent).Should(ContainSubstring(removeSpecialSymbols([]byte(`lib: validate
+	@echo 'INFO building the "lib" module...'
+# Restore the module build artifacts from the build cache, or build the module and pack its build artifacts
+	@if $(MBT) module restore -m=lib -p=${p} -t=${t} --report-dir=${report_dir}; then :; else \
+	$(MBT) execute -d="$(PROJ_DIR)/lib" -c='npm run build'`))))
+		})
+
 		modulegen := filepath.Join(wd, "testdata", "modulegen")
 		DescribeTable("generate module build with dependencies in verbose make file", func(mtaFileName, moduleName, modulePath, expectedModuleDepNames string, expectedModuleDepCopyCommands string) {
 			ep := dir.Loc{SourcePath: modulegen, TargetPath: filepath.Join(wd, "testdata"), Descriptor: "dev", MtaFilename: mtaFileName}
@@ -159,7 +308,9 @@ makefile_version: 0.0.0
 
 			expectedModuleGen := fmt.Sprintf(`%s: validate %s
 	@echo 'INFO building the "%s" module...'%s
-	@$(MBT) execute -d="$(PROJ_DIR)/%s"`, moduleName, expectedModuleDepNames, moduleName, expectedModuleDepCopyCommands, modulePath)
+# Restore the module build artifacts from the build cache, or build the module and pack its build artifacts
+	@if $(MBT) module restore -m=%s -p=${p} -t=${t} --report-dir=${report_dir}; then :; else \
+	$(MBT) execute -d="$(PROJ_DIR)/%s"`, moduleName, expectedModuleDepNames, moduleName, expectedModuleDepCopyCommands, moduleName, modulePath)
 			Ω(makefileContent).Should(ContainSubstring(removeSpecialSymbols([]byte(expectedModuleGen))))
 		},
 			Entry("dependency with artifacts", "dep_with_patterns.yaml", "module1", "public", `dep`, fmt.Sprintf(`
@@ -176,6 +327,9 @@ makefile_version: 0.0.0
 	@$(MBT) cp -s=%s -t=%s -p=\*`,
 				escapeProjPath("client1", "dist"), escapeProjPath("public", "dep1_result"),
 				escapeProjPath("client2", "target/*.war"), escapeProjPath("public"))),
+			Entry("dependency with copy options", "dep_with_copy_options.yaml", "module1", "public", `dep`, fmt.Sprintf(`
+	@$(MBT) cp -s=%s -t=%s -p=dist/\* -x=\*.map -x=dist/test --rename=index.html=app/index.html --rename=main.js=app/main.js --extract`,
+				escapeProjPath("client"), escapeProjPath("public"))),
 		)
 	})
 
@@ -230,13 +384,13 @@ makefile_version: 0.0.0
 	)
 
 	It("GetModuleDeps returns error when module doesn't exist", func() {
-		data := templateData{File: mta.MTA{}}
+		data := newTemplateData(mta.MTA{})
 		_, err := data.GetModuleDeps("unknown")
 		Ω(err).Should(HaveOccurred())
 	})
 
 	It("GetModuleDeps returns error when module has dependency that doesn't exist", func() {
-		data := templateData{File: mta.MTA{Modules: []*mta.Module{
+		data := newTemplateData(mta.MTA{Modules: []*mta.Module{
 			{
 				Name: "m1",
 				BuildParams: map[string]interface{}{
@@ -245,13 +399,13 @@ makefile_version: 0.0.0
 					},
 				},
 			},
-		}}}
+		}})
 		_, err := data.GetModuleDeps("m1")
 		Ω(err).Should(HaveOccurred())
 	})
 
 	It("IsNoSource fails on not existing module", func() {
-		data := templateData{File: mta.MTA{Modules: []*mta.Module{
+		data := newTemplateData(mta.MTA{Modules: []*mta.Module{
 			{
 				Name: "m1",
 				BuildParams: map[string]interface{}{
@@ -260,18 +414,24 @@ makefile_version: 0.0.0
 					},
 				},
 			},
-		}}}
+		}})
 		_, err := data.IsNoSource("m2")
 		Ω(err).Should(HaveOccurred())
 	})
 
 	It("IsNoSource fails on empty path", func() {
-		data := templateData{File: mta.MTA{Modules: []*mta.Module{
+		data := newTemplateData(mta.MTA{Modules: []*mta.Module{
 			{
 				Name: "m1",
 			},
-		}}}
+		}})
 		_, err := data.IsNoSource("m1")
 		Ω(err).Should(HaveOccurred())
 	})
 })
+
+func newTemplateData(m mta.MTA) templateData {
+	params, err := buildparams.DecodeAll(&m)
+	Ω(err).Should(Succeed())
+	return templateData{File: m, Params: params}
+}
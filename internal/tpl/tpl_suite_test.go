@@ -5,6 +5,8 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+
+	"github.com/SAP/cloud-mta-build-tool/internal/buildconfig"
 )
 
 func TestTpl(t *testing.T) {
@@ -12,3 +14,8 @@ func TestTpl(t *testing.T) {
 	RunSpecs(t, "Tpl Suite")
 
 }
+
+// the build configuration set by the specs is not kept for the next specs
+var _ = AfterEach(func() {
+	buildconfig.Reset()
+})